@@ -0,0 +1,185 @@
+// cmd/careerscraper/bootstrap.go
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+	"github.com/fuzztobread/job-scheduler/internal/core/services"
+)
+
+// runBootstrap seeds the configured sources the repository doesn't track yet
+// from a published dataset, so a new instance starts from real baselines
+func runBootstrap(args []string) int {
+	flags := flag.NewFlagSet("bootstrap", flag.ContinueOnError)
+	dataset := flags.String("dataset", "", "URL or file of the dataset, BootstrapDataset if empty")
+	checksum := flags.String("sha256", "", "hex SHA-256 the dataset must match, BootstrapSHA256 if empty")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+
+	if *dataset != "" {
+		cfg.BootstrapDataset = *dataset
+	}
+	if *checksum != "" {
+		cfg.BootstrapSHA256 = strings.ToLower(*checksum)
+	}
+	if cfg.BootstrapDataset == "" {
+		fmt.Fprintln(os.Stderr, "--dataset or BootstrapDataset is required")
+		return 2
+	}
+	client, err := buildHTTPClient(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create HTTP client: %v\n", err)
+		return 1
+	}
+
+	if err := bootstrapRepository(context.Background(), cfg, client, repo); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to bootstrap: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// bootstrapRepository seeds the configured sources without data from the
+// dataset. The dataset is only fetched if there are such sources.
+func bootstrapRepository(ctx context.Context, cfg *config.Config, client *http.Client, repo ports.JobRepository) error {
+	backups := services.NewBackupService(repo, nil)
+	untracked, err := backups.UntrackedSources(ctx, cfg.URLs)
+	if err != nil {
+		return err
+	}
+	if len(untracked) == 0 {
+		log.Printf("Every source already has data, not bootstrapping from %s", cfg.BootstrapDataset)
+		return nil
+	}
+
+	log.Printf("Bootstrapping %d sources from %s", len(untracked), cfg.BootstrapDataset)
+	dataset, err := openDataset(ctx, client, cfg.BootstrapDataset, cfg.BootstrapSHA256)
+	if err != nil {
+		return err
+	}
+	defer dataset.Close()
+
+	summary, err := backups.Seed(ctx, dataset, untracked)
+	if err != nil {
+		return fmt.Errorf("failed to seed from dataset: %w", err)
+	}
+	log.Printf("Bootstrapped %d of %d sources with %d snapshots and %d diffs",
+		summary.Sources, len(untracked), summary.Snapshots, summary.Diffs)
+	return nil
+}
+
+// openDataset returns a reader of a dataset: a backup written by export,
+// optionally gzipped, read from a file or an http(s) URL. Downloads are
+// spooled to a temporary file so the checksum, if any, is verified before
+// anything is seeded.
+func openDataset(ctx context.Context, client *http.Client, location, checksum string) (io.ReadCloser, error) {
+	var file *os.File
+	var err error
+	downloaded := strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+	if downloaded {
+		file, err = downloadDataset(ctx, client, location)
+	} else {
+		file, err = os.Open(location)
+	}
+	if err != nil {
+		return nil, err
+	}
+	dataset := &datasetReader{file: file, downloaded: downloaded}
+
+	if checksum != "" {
+		hash := sha256.New()
+		if _, err := io.Copy(hash, file); err != nil {
+			dataset.Close()
+			return nil, fmt.Errorf("failed to read dataset: %w", err)
+		}
+		if sum := hex.EncodeToString(hash.Sum(nil)); sum != checksum {
+			dataset.Close()
+			return nil, fmt.Errorf("dataset checksum mismatch: got %s, want %s", sum, checksum)
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			dataset.Close()
+			return nil, fmt.Errorf("failed to read dataset: %w", err)
+		}
+	}
+
+	buffered := bufio.NewReader(file)
+	if magic, err := buffered.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			dataset.Close()
+			return nil, fmt.Errorf("failed to decompress dataset: %w", err)
+		}
+		dataset.Reader = gz
+		return dataset, nil
+	}
+	dataset.Reader = buffered
+	return dataset, nil
+}
+
+// downloadDataset downloads a dataset to a temporary file
+func downloadDataset(ctx context.Context, client *http.Client, location string) (*os.File, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download dataset: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download dataset: status %d", resp.StatusCode)
+	}
+
+	file, err := os.CreateTemp("", "careerscraper-dataset-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	_, err = io.Copy(file, resp.Body)
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, fmt.Errorf("failed to download dataset: %w", err)
+	}
+	return file, nil
+}
+
+// datasetReader reads a dataset from its file
+type datasetReader struct {
+	io.Reader
+	file       *os.File
+	downloaded bool // the file is a temporary download
+}
+
+// Close closes the dataset file, removing it if it was downloaded
+func (r *datasetReader) Close() error {
+	err := r.file.Close()
+	if r.downloaded {
+		os.Remove(r.file.Name())
+	}
+	return err
+}
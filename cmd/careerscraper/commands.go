@@ -0,0 +1,980 @@
+// cmd/careerscraper/commands.go
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/repository"
+	"github.com/fuzztobread/job-scheduler/internal/adapters/scraper"
+	"github.com/fuzztobread/job-scheduler/internal/adapters/trace"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+	"github.com/fuzztobread/job-scheduler/internal/core/services"
+)
+
+// runCommand runs a CLI subcommand and returns the process exit code
+func runCommand(name string, args []string) int {
+	switch name {
+	case "why-missing":
+		return runWhyMissing(args)
+	case "audit":
+		return runAudit(args)
+	case "notifications":
+		return runNotifications(args)
+	case "delete-source":
+		return runDeleteSource(args)
+	case "reparse":
+		return runReparse(args)
+	case "stale-sources":
+		return runStaleSources(args)
+	case "snapshots":
+		return runSnapshots(args)
+	case "restore":
+		return runRestore(args)
+	case "job-history":
+		return runJobHistory(args)
+	case "diffs":
+		return runDiffs(args)
+	case "job-lifetimes":
+		return runJobLifetimes(args)
+	case "raw-pages":
+		return runRawPages(args)
+	case "config":
+		return runConfig(args)
+	case "export":
+		return runExport(args)
+	case "import":
+		return runImport(args)
+	case "bulk-import":
+		return runBulkImport(args)
+	case "logs":
+		return runLogs(args)
+	case "events":
+		return runEvents(args)
+	case "snooze":
+		return runSnooze(args)
+	case "bootstrap":
+		return runBootstrap(args)
+	case "test-selectors":
+		return runTestSelectors(args)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", name)
+		fmt.Fprintln(os.Stderr, "Available commands: why-missing, audit, notifications, delete-source, reparse, stale-sources, snapshots, restore, job-history, diffs, job-lifetimes, raw-pages, config, export, import, bulk-import, logs, events, snooze, bootstrap, test-selectors")
+		return 2
+	}
+}
+
+// runWhyMissing prints the filter rules that rejected jobs matching a title
+func runWhyMissing(args []string) int {
+	flags := flag.NewFlagSet("why-missing", flag.ContinueOnError)
+	url := flags.String("url", "", "career page URL (all sources if empty)")
+	title := flags.String("title", "", "part of the job title to look for")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *title == "" {
+		fmt.Fprintln(os.Stderr, "--title is required")
+		return 2
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	if cfg.FilterTraceFile == "" {
+		fmt.Fprintln(os.Stderr, "Filter tracing is disabled, set FilterTraceFile to enable it")
+		return 1
+	}
+
+	rejections, err := trace.NewFileTraceStore(cfg.FilterTraceFile).FindRejections(context.Background(), *url, *title)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read filter trace: %v\n", err)
+		return 1
+	}
+	if len(rejections) == 0 {
+		fmt.Println("No filter rejections recorded for matching jobs")
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RUN\tSOURCE\tTITLE\tRULE\tREASON")
+	for _, r := range rejections {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.RunAt.Format(time.RFC3339), r.SourceURL, r.Title, r.Rule, r.Reason)
+	}
+	w.Flush()
+	return 0
+}
+
+// runAudit prints the audit log of administrative actions
+func runAudit(args []string) int {
+	flags := flag.NewFlagSet("audit", flag.ContinueOnError)
+	actor := flags.String("actor", "", "only actions by this actor")
+	action := flags.String("action", "", "only this action, e.g. source.delete")
+	target := flags.String("target", "", "only actions on this target")
+	since := flags.Duration("since", 0, "only actions within this duration, e.g. 72h")
+	limit := flags.Int("limit", 50, "maximum number of entries (0 for all)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	_, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+
+	auditLog, ok := ports.Optional[ports.AuditLog](repo)
+	if !ok {
+		fmt.Fprintln(os.Stderr, "The configured repository has no audit log")
+		return 1
+	}
+
+	filter := domain.AuditFilter{
+		Actor:  *actor,
+		Action: *action,
+		Target: *target,
+		Limit:  *limit,
+	}
+	if *since > 0 {
+		filter.Since = time.Now().Add(-*since)
+	}
+
+	entries, err := auditLog.QueryAudit(context.Background(), filter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to query audit log: %v\n", err)
+		return 1
+	}
+	if len(entries) == 0 {
+		fmt.Println("No matching audit entries")
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tACTOR\tACTION\tTARGET\tPREVIOUS\tCURRENT")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.At.Format(time.RFC3339), e.Actor, e.Action, e.Target, e.Previous, e.Current)
+	}
+	w.Flush()
+	return 0
+}
+
+// runNotifications prints the sent notifications and how their delivery went
+func runNotifications(args []string) int {
+	flags := flag.NewFlagSet("notifications", flag.ContinueOnError)
+	company := flags.String("company", "", "only notifications about this company")
+	url := flags.String("url", "", "only notifications about this career page URL")
+	via := flags.String("notifier", "", "only notifications sent through this notifier, e.g. discord")
+	since := flags.Duration("since", 0, "only notifications within this duration, e.g. 168h")
+	failed := flags.Bool("failed", false, "only notifications with a failed delivery")
+	limit := flags.Int("limit", 50, "maximum number of notifications (0 for all)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	_, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+
+	history, ok := ports.Optional[ports.NotificationHistory](repo)
+	if !ok {
+		fmt.Fprintln(os.Stderr, "The configured repository has no notification history")
+		return 1
+	}
+
+	filter := domain.NotificationFilter{
+		Company:   *company,
+		SourceURL: *url,
+		Notifier:  *via,
+		Failed:    *failed,
+		Limit:     *limit,
+	}
+	if *since > 0 {
+		filter.Since = time.Now().Add(-*since)
+	}
+
+	records, err := history.QueryNotifications(context.Background(), filter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to query notification history: %v\n", err)
+		return 1
+	}
+	if len(records) == 0 {
+		fmt.Println("No matching notifications")
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tSEVERITY\tNOTIFICATION\tDELIVERY")
+	for _, record := range records {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", record.CreatedAt.Format(time.RFC3339), record.Severity, record.Summary(), formatAttempts(record.Attempts))
+	}
+	w.Flush()
+	return 0
+}
+
+// formatAttempts describes the outcome of each delivery attempt
+func formatAttempts(attempts []domain.DeliveryAttempt) string {
+	if len(attempts) == 0 {
+		return "no notifier"
+	}
+	parts := make([]string, len(attempts))
+	for i, attempt := range attempts {
+		switch {
+		case attempt.Failed():
+			parts[i] = attempt.Notifier + " failed: " + attempt.Error
+		case attempt.Skipped:
+			parts[i] = attempt.Notifier + " skipped"
+		default:
+			parts[i] = attempt.Notifier + " ok"
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// runDeleteSource removes everything stored about a source URL
+func runDeleteSource(args []string) int {
+	flags := flag.NewFlagSet("delete-source", flag.ContinueOnError)
+	url := flags.String("url", "", "career page URL to forget")
+	yes := flags.Bool("yes", false, "confirm the deletion")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *url == "" {
+		fmt.Fprintln(os.Stderr, "--url is required")
+		return 2
+	}
+	if !*yes {
+		fmt.Fprintf(os.Stderr, "This permanently deletes all data about %s, rerun with --yes to confirm\n", *url)
+		return 2
+	}
+
+	cfg, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+
+	archive, err := buildRawArchive(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open raw page archive: %v\n", err)
+		return 1
+	}
+	summaries, err := buildSummaryStore(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open summary store: %v\n", err)
+		return 1
+	}
+
+	deleted, err := buildComplianceService(cfg, repo, archive, summaries).DeleteSource(context.Background(), currentActor(), *url)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to delete source: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Deleted %d records about %s\n", deleted, *url)
+
+	for _, configured := range cfg.URLs {
+		if configured == *url {
+			fmt.Println("Note: the URL is still configured and will be scraped again on the next run")
+			break
+		}
+	}
+	return 0
+}
+
+// runReparse parses the archived pages of a source again and replaces the
+// jobs stored for them, e.g. after a selector fix
+func runReparse(args []string) int {
+	flags := flag.NewFlagSet("reparse", flag.ContinueOnError)
+	url := flags.String("url", "", "career page URL to re-parse")
+	since := flags.String("since", "", "only snapshots since a date (2006-01-02), RFC 3339 time or duration (72h); all if empty")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *url == "" {
+		fmt.Fprintln(os.Stderr, "--url is required")
+		return 2
+	}
+	sinceTime, err := parseSince(*since)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --since: %v\n", err)
+		return 2
+	}
+
+	cfg, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+
+	archive, ok := ports.Optional[ports.SnapshotArchive](repo)
+	if !ok && cfg.EncryptionKey != "" {
+		fmt.Fprintln(os.Stderr, "Snapshots can't be re-parsed while they are encrypted (EncryptionKey)")
+		return 1
+	}
+	if !ok {
+		fmt.Fprintln(os.Stderr, "The configured repository does not archive raw pages")
+		return 1
+	}
+	pages, err := buildRawArchive(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open raw page archive: %v\n", err)
+		return 1
+	}
+
+	httpClient, err := buildHTTPClient(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create HTTP client: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	parserOpts, _ := buildScraperOptions(cfg, httpClient)
+	parser := buildPageScraper(cfg, scraper.NewGoRodScraper(0, nil, parserOpts...), httpClient) // parsing needs no browser
+	result, err := services.NewReparseService(archive, pages, parser, buildNormalizers(cfg)...).Reparse(ctx, *url, sinceTime)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to re-parse %s: %v\n", *url, err)
+		return 1
+	}
+
+	if auditLog, ok := ports.Optional[ports.AuditLog](repo); ok && result.Snapshots > 0 {
+		entry := domain.NewAuditEntry(currentActor(), "source.reparse", *url,
+			map[string]int{"jobs": result.JobsBefore},
+			map[string]int{"jobs": result.JobsAfter, "snapshots": result.Snapshots})
+		if err := auditLog.AppendAudit(ctx, entry); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to record audit entry: %v\n", err)
+		}
+	}
+
+	fmt.Printf("Re-parsed %d snapshots of %s: %d jobs before, %d after\n",
+		result.Snapshots, *url, result.JobsBefore, result.JobsAfter)
+	if result.Skipped > 0 {
+		fmt.Printf("Skipped %d snapshots whose raw pages were purged or not kept\n", result.Skipped)
+	}
+	return 0
+}
+
+// runStaleSources prints the configured sources that have returned no jobs or
+// the same jobs for a long time
+func runStaleSources(args []string) int {
+	flags := flag.NewFlagSet("stale-sources", flag.ContinueOnError)
+	after := flags.Duration("after", 0, "stale after this duration (StaleAfter if 0)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+
+	if *after <= 0 {
+		*after = cfg.StaleAfter
+	}
+	stale, err := services.NewStaleSourceService(repo, nil, cfg.URLs, *after).Find(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to find stale sources: %v\n", err)
+		return 1
+	}
+	if len(stale) == 0 {
+		fmt.Printf("No sources have been empty or unchanged for %s\n", *after)
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tREASON\tSINCE\tLAST SCRAPED")
+	for _, s := range stale {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.SourceURL, s.Reason, s.Since.Format("2006-01-02"), s.LastScrapedAt.Format(time.RFC3339))
+	}
+	w.Flush()
+	fmt.Println("Consider removing these sources from URLs")
+	return 0
+}
+
+// runSnapshots prints the retained snapshots of a source with the jobs added
+// and removed compared to the snapshot before
+func runSnapshots(args []string) int {
+	flags := flag.NewFlagSet("snapshots", flag.ContinueOnError)
+	url := flags.String("url", "", "career page URL")
+	limit := flags.Int("limit", 20, "maximum number of snapshots (0 for all)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *url == "" {
+		fmt.Fprintln(os.Stderr, "--url is required")
+		return 2
+	}
+
+	_, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+
+	// One more than shown, to count the changes of the oldest one
+	fetch := *limit
+	if fetch > 0 {
+		fetch++
+	}
+	snapshots, err := repo.ListSnapshots(context.Background(), *url, fetch)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list snapshots: %v\n", err)
+		return 1
+	}
+	if len(snapshots) == 0 {
+		fmt.Printf("No snapshots of %s\n", *url)
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tSCRAPED\tJOBS\tADDED\tREMOVED")
+	for i, snapshot := range snapshots {
+		if *limit > 0 && i == *limit {
+			break
+		}
+		added, removed := "-", "-"
+		if i+1 < len(snapshots) {
+			a, r := countChanges(snapshots[i+1], snapshot)
+			added, removed = fmt.Sprint(a), fmt.Sprint(r)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\n", i, snapshot.ScrapedAt.Format(time.RFC3339), len(snapshot.Jobs), added, removed)
+	}
+	w.Flush()
+	return 0
+}
+
+// runJobHistory prints the timeline of a job: when it was first seen, how its
+// fields changed and when it was removed
+func runJobHistory(args []string) int {
+	flags := flag.NewFlagSet("job-history", flag.ContinueOnError)
+	url := flags.String("url", "", "career page URL")
+	id := flags.String("id", "", "job ID")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *url == "" || *id == "" {
+		fmt.Fprintln(os.Stderr, "--url and --id are required")
+		return 2
+	}
+
+	_, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+
+	history, err := repo.GetJobHistory(context.Background(), *url, *id)
+	if errors.Is(err, domain.ErrNotFound) {
+		fmt.Printf("Job %s was not found in any snapshot of %s\n", *id, *url)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get job history: %v\n", err)
+		return 1
+	}
+
+	removed := "still listed"
+	if !history.Open() {
+		removed = history.RemovedAt.Format(time.RFC3339)
+	}
+	fmt.Printf("First seen: %s\n", history.FirstSeenAt.Format(time.RFC3339))
+	fmt.Printf("Last seen:  %s\n", history.LastSeenAt.Format(time.RFC3339))
+	fmt.Printf("Removed:    %s\n", removed)
+	fmt.Printf("Open for:   %s\n\n", formatDays(history.OpenFor(time.Now())))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SEEN\tTITLE\tLOCATION\tDEPARTMENT")
+	for _, version := range history.Versions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", version.SeenAt.Format(time.RFC3339),
+			version.Job.Title, version.Job.Location, version.Job.Department)
+	}
+	w.Flush()
+	return 0
+}
+
+// runDiffs prints the diffs recorded for a source, oldest first
+func runDiffs(args []string) int {
+	flags := flag.NewFlagSet("diffs", flag.ContinueOnError)
+	url := flags.String("url", "", "career page URL")
+	since := flags.Duration("since", 7*24*time.Hour, "how far back to list diffs, 0 lists all")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *url == "" {
+		fmt.Fprintln(os.Stderr, "--url is required")
+		return 2
+	}
+
+	_, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+
+	var from time.Time
+	if *since > 0 {
+		from = time.Now().Add(-*since)
+	}
+	records, err := repo.GetDiffHistory(context.Background(), *url, from)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get diff history: %v\n", err)
+		return 1
+	}
+	if len(records) == 0 {
+		fmt.Printf("No diffs recorded for %s\n", *url)
+		return 0
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMPUTED\tRUN\tNEW\tUPDATED\tREMOVED\tSEVERITY")
+	for _, record := range records {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\n", record.ComputedAt.Format(time.RFC3339), record.RunID,
+			len(record.Diff.NewJobs), len(record.Diff.UpdatedJobs), len(record.Diff.RemovedJobs), record.Diff.Severity)
+	}
+	w.Flush()
+	return 0
+}
+
+// runJobLifetimes prints how long jobs typically stay listed per source
+func runJobLifetimes(args []string) int {
+	flags := flag.NewFlagSet("job-lifetimes", flag.ContinueOnError)
+	url := flags.String("url", "", "career page URL (all sources if empty)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+
+	urls := cfg.URLs
+	if *url != "" {
+		urls = []string{*url}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SOURCE\tCOMPANY\tJOBS\tCLOSED\tMEDIAN OPEN")
+	for _, u := range urls {
+		snapshots, err := repo.ListSnapshots(context.Background(), u, 0)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list snapshots of %s: %v\n", u, err)
+			return 1
+		}
+		if len(snapshots) == 0 {
+			continue
+		}
+
+		histories := domain.BuildJobHistories(snapshots)
+		median, closed := domain.MedianOpenFor(histories)
+		open := "-"
+		if closed > 0 {
+			open = formatDays(median)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\n", u, snapshots[0].CompanyName, len(histories), closed, open)
+	}
+	w.Flush()
+	return 0
+}
+
+// runRawPages lists the archived raw pages of a source, or prints the one of
+// the scrape at the given time
+func runRawPages(args []string) int {
+	flags := flag.NewFlagSet("raw-pages", flag.ContinueOnError)
+	url := flags.String("url", "", "career page URL")
+	at := flags.String("at", "", "scrape time as listed, to print its page")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *url == "" {
+		fmt.Fprintln(os.Stderr, "--url is required")
+		return 2
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	if !cfg.RawArchive {
+		fmt.Fprintln(os.Stderr, "The raw page archive is disabled, set RawArchive to enable it")
+		return 1
+	}
+	archive, err := buildRawArchive(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open raw page archive: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	if *at != "" {
+		scrapedAt, err := time.Parse(time.RFC3339Nano, *at)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --at: %v\n", err)
+			return 2
+		}
+		content, err := archive.GetRawContent(ctx, *url, scrapedAt)
+		if errors.Is(err, domain.ErrNotFound) {
+			fmt.Fprintf(os.Stderr, "No raw page of %s archived at %s\n", *url, *at)
+			return 1
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read raw page: %v\n", err)
+			return 1
+		}
+		fmt.Print(content)
+		return 0
+	}
+
+	times, err := archive.ListRawContent(ctx, *url)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list raw pages: %v\n", err)
+		return 1
+	}
+	if len(times) == 0 {
+		fmt.Printf("No raw pages of %s archived\n", *url)
+		return 0
+	}
+	for _, t := range times {
+		fmt.Println(t.Format(time.RFC3339Nano))
+	}
+	return 0
+}
+
+// formatDays formats a duration as a number of days
+func formatDays(d time.Duration) string {
+	return fmt.Sprintf("%.1f days", d.Hours()/24)
+}
+
+// runRestore makes an older snapshot the latest collection of a source again,
+// e.g. after a bad scrape wiped the baseline
+func runRestore(args []string) int {
+	flags := flag.NewFlagSet("restore", flag.ContinueOnError)
+	url := flags.String("url", "", "career page URL")
+	index := flags.Int("index", 1, "snapshot to restore as numbered by the snapshots command")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *url == "" {
+		fmt.Fprintln(os.Stderr, "--url is required")
+		return 2
+	}
+	if *index < 0 {
+		fmt.Fprintln(os.Stderr, "--index must not be negative")
+		return 2
+	}
+
+	_, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+
+	ctx := context.Background()
+	snapshots, err := repo.ListSnapshots(ctx, *url, *index+1)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to list snapshots: %v\n", err)
+		return 1
+	}
+	if *index >= len(snapshots) {
+		fmt.Fprintf(os.Stderr, "%s has only %d snapshots\n", *url, len(snapshots))
+		return 1
+	}
+
+	snapshot := snapshots[*index]
+	if err := repo.SaveJobCollection(ctx, snapshot); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to restore snapshot: %v\n", err)
+		return 1
+	}
+
+	if auditLog, ok := ports.Optional[ports.AuditLog](repo); ok {
+		entry := domain.NewAuditEntry(currentActor(), "source.restore", *url,
+			map[string]interface{}{"scraped_at": snapshots[0].ScrapedAt, "jobs": len(snapshots[0].Jobs)},
+			map[string]interface{}{"scraped_at": snapshot.ScrapedAt, "jobs": len(snapshot.Jobs)})
+		if err := auditLog.AppendAudit(ctx, entry); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to record audit entry: %v\n", err)
+		}
+	}
+
+	fmt.Printf("Restored the snapshot of %s scraped at %s with %d jobs\n", *url, snapshot.ScrapedAt.Format(time.RFC3339), len(snapshot.Jobs))
+	return 0
+}
+
+// runExport writes a backup of the repository
+func runExport(args []string) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	out := flags.String("out", "", "backup file to write (stdout if empty)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+	snoozes, err := buildSnoozeStore(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open snoozes: %v\n", err)
+		return 1
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create backup file: %v\n", err)
+			return 1
+		}
+		defer file.Close()
+		w = file
+	}
+
+	summary, err := services.NewBackupService(repo, snoozes).Export(context.Background(), w)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to export backup: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d sources with %d snapshots, %d diffs, %d scrape failures, %d audit entries, %d captured and %d sent notifications and %d snoozes\n",
+		summary.Sources, summary.Snapshots, summary.Diffs, summary.Failures, summary.AuditEntries, summary.Captures, summary.Notifications, summary.Snoozes)
+	return 0
+}
+
+// runImport restores a backup into the configured repository
+func runImport(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	in := flags.String("in", "", "backup file to read")
+	force := flags.Bool("force", false, "import even if the repository already has data")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *in == "" {
+		fmt.Fprintln(os.Stderr, "--in is required")
+		return 2
+	}
+
+	cfg, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+	snoozes, err := buildSnoozeStore(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open snoozes: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	if !*force {
+		existing, err := repo.ListTrackedSources(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list sources: %v\n", err)
+			return 1
+		}
+		if len(existing) > 0 {
+			fmt.Fprintf(os.Stderr, "The repository already tracks %d sources, importing would merge the backup into their history; rerun with --force to import anyway\n", len(existing))
+			return 2
+		}
+	}
+
+	file, err := os.Open(*in)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open backup file: %v\n", err)
+		return 1
+	}
+	defer file.Close()
+
+	summary, err := services.NewBackupService(repo, snoozes).Import(ctx, file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to import backup: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Imported %d sources with %d snapshots, %d diffs, %d scrape failures, %d audit entries, %d captured and %d sent notifications and %d snoozes, skipping %d records already present\n",
+		summary.Sources, summary.Snapshots, summary.Diffs, summary.Failures, summary.AuditEntries, summary.Captures, summary.Notifications, summary.Snoozes, summary.Skipped)
+	return 0
+}
+
+// countChanges returns how many job IDs newer added and removed compared to older
+func countChanges(older, newer domain.JobCollection) (added, removed int) {
+	before := make(map[string]bool, len(older.Jobs))
+	for _, job := range older.Jobs {
+		before[job.ID] = true
+	}
+	after := make(map[string]bool, len(newer.Jobs))
+	for _, job := range newer.Jobs {
+		after[job.ID] = true
+		if !before[job.ID] {
+			added++
+		}
+	}
+	for id := range before {
+		if !after[id] {
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// parseSince parses a --since flag given as a date, an RFC 3339 time or a
+// duration before now. Empty means the beginning of time.
+func parseSince(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q is neither a date, a time nor a duration", value)
+}
+
+// runBulkImport onboards a large board by archiving the detail pages of its
+// jobs at a pace the board tolerates, resuming an earlier interrupted run
+func runBulkImport(args []string) int {
+	flags := flag.NewFlagSet("bulk-import", flag.ContinueOnError)
+	url := flags.String("url", "", "career page URL to import")
+	interval := flags.Duration("interval", 30*time.Second, "wait between two detail pages")
+	batch := flags.Int("batch", 50, "detail pages fetched before pausing, 0 never pauses")
+	pause := flags.Duration("pause", 10*time.Minute, "extra wait after every batch")
+	progressDir := flags.String("progress-dir", "", "directory keeping import progress (DataDir/imports if empty)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *url == "" {
+		fmt.Fprintln(os.Stderr, "--url is required")
+		return 2
+	}
+	if *interval < 0 || *batch < 0 || *pause < 0 {
+		fmt.Fprintln(os.Stderr, "--interval, --batch and --pause must not be negative")
+		return 2
+	}
+
+	cfg, repo, closeRepo, err := openRepository()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer closeRepo()
+
+	if !cfg.RawArchive {
+		fmt.Fprintln(os.Stderr, "Bulk imports keep detail pages in the raw page archive, set RawArchive to enable it")
+		return 1
+	}
+	archive, err := buildRawArchive(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open raw page archive: %v\n", err)
+		return 1
+	}
+	if *progressDir == "" {
+		*progressDir = filepath.Join(cfg.DataDir, "imports")
+	}
+	progressStore, err := repository.NewFileImportProgressStore(*progressDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open import progress: %v\n", err)
+		return 1
+	}
+	httpClient, err := buildHTTPClient(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create HTTP client: %v\n", err)
+		return 1
+	}
+
+	// Only the baseline of a source never scraped before needs the browser
+	scraperClient := buildBrowserClient(cfg, httpClient)
+	scraperOpts, _ := buildScraperOptions(cfg, httpClient)
+	pageScraper := buildRetryScraper(cfg, buildProxyScraper(cfg, buildPageScraper(cfg, scraper.NewGoRodScraper(30*time.Second, scraperClient, scraperOpts...), httpClient)))
+
+	// Stop between two pages on Ctrl-C; progress is saved after every page
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pace := services.BulkImportPace{Interval: *interval, BatchSize: *batch, BatchPause: *pause}
+	service := services.NewBulkImportService(pageScraper, scraper.NewHTTPFetcher(httpClient), repo, archive, progressStore, pace, buildNormalizers(cfg)...)
+	progress, err := service.Import(ctx, *url)
+	if errors.Is(err, context.Canceled) {
+		archived, _, _ := progress.Counts()
+		fmt.Printf("Interrupted after archiving %d of %d jobs of %s, run again to resume\n", archived, len(progress.Items), *url)
+		return 1
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to import %s: %v\n", *url, err)
+		return 1
+	}
+
+	archived, failed, _ := progress.Counts()
+	fmt.Printf("Archived the detail pages of %d of %d jobs of %s\n", archived, len(progress.Items), *url)
+	if failed > 0 {
+		fmt.Printf("%d failed, run again to retry them\n", failed)
+		return 1
+	}
+	return 0
+}
+
+// openRepository loads the configuration and opens its repository for a
+// command. The in-memory repository is rejected since a separate process
+// can't see the daemon's state.
+func openRepository() (*config.Config, ports.JobRepository, func(), error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("Failed to load configuration: %w", err)
+	}
+	if cfg.RepositoryType == "memory" {
+		return nil, nil, nil, fmt.Errorf("This command requires a persistent repository, set RepositoryType")
+	}
+
+	repo, err := buildRepository(cfg)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("Failed to open repository: %w", err)
+	}
+
+	closeRepo := func() {
+		if closer, ok := repo.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+	return cfg, repo, closeRepo, nil
+}
+
+// currentActor identifies who runs a command, for the audit log
+func currentActor() string {
+	if actor := os.Getenv("CAREERSCRAPER_ACTOR"); actor != "" {
+		return actor
+	}
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return "unknown"
+}
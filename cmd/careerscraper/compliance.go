@@ -0,0 +1,51 @@
+// cmd/careerscraper/compliance.go
+package main
+
+import (
+	"github.com/fuzztobread/job-scheduler/internal/adapters/trace"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+	"github.com/fuzztobread/job-scheduler/internal/core/services"
+)
+
+// buildComplianceService creates the compliance service over every store that
+// holds data about sources. archive is the raw page archive and summaries the
+// store of hosted summaries, nil if disabled.
+func buildComplianceService(cfg *config.Config, repo ports.JobRepository, archive ports.RawContentStore, summaries ports.SummaryStore) *services.ComplianceService {
+	audit, _ := ports.Optional[ports.AuditLog](repo)
+
+	var purgers []ports.PersonalDataPurger
+	if purger, ok := ports.Optional[ports.PersonalDataPurger](repo); ok {
+		purgers = append(purgers, purger)
+	}
+
+	var deleters []ports.SourceDeleter
+	if deleter, ok := ports.Optional[ports.SourceDeleter](repo); ok {
+		deleters = append(deleters, deleter)
+	}
+	if archive != nil {
+		purgers = append(purgers, archive)
+		deleters = append(deleters, archive)
+	}
+	if summaries != nil {
+		purgers = append(purgers, summaries)
+		deleters = append(deleters, summaries)
+	}
+	if cfg.FilterTraceFile != "" {
+		deleters = append(deleters, trace.NewFileTraceStore(cfg.FilterTraceFile))
+	}
+
+	return services.NewComplianceService(cfg.ComplianceMaxAge, audit, purgers, deleters)
+}
+
+// buildRetentionService creates the retention service, or returns nil if the
+// repository can't prune by age. archive is the raw page archive and
+// summaries the store of hosted summaries, nil if disabled.
+func buildRetentionService(cfg *config.Config, repo ports.JobRepository, archive ports.RawContentStore, summaries ports.SummaryStore) *services.RetentionService {
+	pruner, ok := ports.Optional[ports.RetentionPruner](repo)
+	if !ok {
+		return nil
+	}
+	audit, _ := ports.Optional[ports.AuditLog](repo)
+	return services.NewRetentionService(cfg.SnapshotMaxAge, cfg.JobMaxAge, audit, []ports.RetentionPruner{pruner}, archive, summaries)
+}
@@ -0,0 +1,75 @@
+// cmd/careerscraper/filters.go
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/rates"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/currency"
+	"github.com/fuzztobread/job-scheduler/internal/core/filter"
+	"github.com/fuzztobread/job-scheduler/internal/core/query"
+)
+
+// buildFilters creates the job filter and watch rules from the configuration
+func buildFilters(cfg *config.Config, client *http.Client) (*filter.Filter, []*filter.Watch) {
+	converter := buildCurrencyConverter(cfg, client)
+
+	var rules []filter.Rule
+	if cfg.FilterQuery != "" {
+		rules = append(rules, filter.NewQueryRule(mustParseQuery(cfg.FilterQuery)))
+	}
+	if len(cfg.FilterLanguages) > 0 {
+		rules = append(rules, filter.NewLanguageRule(cfg.FilterLanguages))
+	}
+	if cfg.FilterSalaryMin > 0 || cfg.FilterSalaryMax > 0 || cfg.FilterSalaryRequired {
+		rules = append(rules, filter.NewSalaryRule(cfg.SalaryCurrency,
+			cfg.FilterSalaryMin, cfg.FilterSalaryMax, cfg.FilterSalaryRequired, converter))
+	}
+
+	var watches []*filter.Watch
+	for _, w := range cfg.Watches {
+		var watchRules []filter.Rule
+		if w.Query != "" {
+			watchRules = append(watchRules, filter.NewQueryRule(mustParseQuery(w.Query)))
+		}
+		if w.SalaryMin > 0 || w.SalaryMax > 0 {
+			// A salary watch only fires for jobs that actually advertise one
+			watchRules = append(watchRules, filter.NewSalaryRule(cfg.SalaryCurrency,
+				w.SalaryMin, w.SalaryMax, true, converter))
+		}
+		watches = append(watches, filter.NewWatch(w.Name, w.Owner, watchRules...))
+	}
+
+	return filter.New(rules...), watches
+}
+
+// mustParseQuery parses a query already validated when loading the configuration
+func mustParseQuery(src string) *query.Query {
+	q, err := query.Parse(src)
+	if err != nil {
+		log.Fatalf("Invalid query %q: %v", src, err)
+	}
+	return q
+}
+
+// buildCurrencyConverter creates a converter from the configured rates source,
+// or returns nil when no rates are configured
+func buildCurrencyConverter(cfg *config.Config, client *http.Client) *currency.Converter {
+	var converter *currency.Converter
+	switch {
+	case cfg.SalaryRatesURL != "":
+		converter = currency.NewConverter(rates.NewHTTPRateSource(cfg.SalaryRatesURL, client), cfg.SalaryRatesTTL)
+	case len(cfg.SalaryRates) > 0:
+		converter = currency.NewConverter(rates.NewStaticRateSource(cfg.SalaryCurrency, cfg.SalaryRates), 0)
+	default:
+		return nil
+	}
+
+	if err := converter.Refresh(context.Background()); err != nil {
+		log.Printf("Failed to load exchange rates, salaries in other currencies won't be compared: %v", err)
+	}
+	return converter
+}
@@ -0,0 +1,60 @@
+// cmd/careerscraper/httpclient.go
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/httpclient"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+)
+
+// buildHTTPClient creates the outbound HTTP client shared by notifiers and
+// other HTTP-based adapters
+func buildHTTPClient(cfg *config.Config) (*http.Client, error) {
+	opts := httpclient.DefaultOptions()
+	opts.Timeout = cfg.HTTPTimeout
+	opts.ProxyURL = cfg.HTTPProxy
+	opts.ProxyURLs = cfg.HTTPProxies
+	opts.MaxRetries = cfg.HTTPMaxRetries
+	opts.RetryBackoff = cfg.HTTPRetryBackoff
+	opts.MaxIdleConns = cfg.HTTPMaxIdleConns
+	opts.UserAgent = cfg.HTTPUserAgent
+
+	tlsOpts := tlsOptions(cfg)
+	if tlsOpts.InsecureSkipVerify {
+		log.Println("WARNING: TLS certificate verification is disabled")
+	}
+	tlsConfig, err := httpclient.NewTLSConfig(tlsOpts)
+	if err != nil {
+		return nil, err
+	}
+	opts.TLSConfig = tlsConfig
+
+	return httpclient.New(opts)
+}
+
+// buildBrowserClient returns the client browser requests are routed through,
+// nil to let the browser send them itself. Custom TLS settings and proxies
+// require routing them through client.
+func buildBrowserClient(cfg *config.Config, client *http.Client) *http.Client {
+	if tlsOptions(cfg).Custom() || cfg.HTTPProxy != "" || len(cfg.HTTPProxies) > 0 {
+		return client
+	}
+	for _, source := range cfg.Sources {
+		if source.Proxy != "" {
+			return client
+		}
+	}
+	return nil
+}
+
+// tlsOptions returns the configured TLS settings
+func tlsOptions(cfg *config.Config) httpclient.TLSOptions {
+	return httpclient.TLSOptions{
+		CAFile:             cfg.TLSCAFile,
+		CertFile:           cfg.TLSCertFile,
+		KeyFile:            cfg.TLSKeyFile,
+		InsecureSkipVerify: cfg.TLSInsecureSkipVerify,
+	}
+}
@@ -0,0 +1,74 @@
+// cmd/careerscraper/leader.go
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/lease"
+	"github.com/fuzztobread/job-scheduler/internal/adapters/repository"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+	"github.com/fuzztobread/job-scheduler/internal/core/services"
+)
+
+// errNotLeader is returned by scrapes only the leader may run when this
+// instance stands by
+var errNotLeader = errors.New("this instance is standing by, the leader runs the scrapes")
+
+// leading bounds scrape by the leadership of election: it doesn't start while
+// this instance stands by and is canceled once it stops leading. Without an
+// election scrape is returned as it is.
+func leading(election *services.LeaderElection, scrape func(context.Context) error) func(context.Context) error {
+	if election == nil {
+		return scrape
+	}
+	return func(ctx context.Context) error {
+		ctx, cancel, leads := election.Lead(ctx)
+		defer cancel()
+		if !leads {
+			return errNotLeader
+		}
+		return scrape(ctx)
+	}
+}
+
+// buildLeaderElection creates the configured election of the instance that
+// runs the scheduled jobs, or returns nil if every instance runs them
+func buildLeaderElection(cfg *config.Config) (*services.LeaderElection, error) {
+	var locker ports.Locker
+	switch cfg.LeaderElection {
+	case config.LeaderElectionOff:
+		return nil, nil
+
+	case config.LeaderElectionRedis:
+		// The lock lives on the Redis server whatever the repository type
+		redisLocker, err := repository.NewRedisRepository(cfg.RedisURL, cfg.RedisKeyPrefix, 0, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		locker = redisLocker
+
+	case config.LeaderElectionKubernetes:
+		kubernetesLocker, err := lease.NewKubernetesLease(cfg.LeaderNamespace)
+		if err != nil {
+			return nil, err
+		}
+		locker = kubernetesLocker
+
+	default:
+		return nil, fmt.Errorf("unknown leader election: %s", cfg.LeaderElection)
+	}
+
+	owner := cfg.LeaderID
+	if owner == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine leader identity: %w", err)
+		}
+		owner = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	return services.NewLeaderElection(locker, cfg.LeaderLease, owner, cfg.LeaderLeaseTTL), nil
+}
@@ -0,0 +1,235 @@
+// cmd/careerscraper/lint.go
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/scheduler"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/query"
+)
+
+// lintIssue is a configuration problem found by config lint
+type lintIssue struct {
+	Check   string
+	Problem string
+}
+
+// runConfig runs a configuration subcommand
+func runConfig(args []string) int {
+	if len(args) == 0 || args[0] != "lint" {
+		fmt.Fprintln(os.Stderr, "Usage: config lint")
+		return 2
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+
+	issues := lintConfig(cfg)
+	if len(issues) == 0 {
+		fmt.Println("No problems found")
+		return 0
+	}
+	for _, issue := range issues {
+		fmt.Printf("%s: %s\n", issue.Check, issue.Problem)
+	}
+	return 1
+}
+
+// lintConfig returns the problems of a configuration that loads but likely
+// does not do what was intended
+func lintConfig(cfg *config.Config) []lintIssue {
+	var issues []lintIssue
+	issues = append(issues, lintDuplicateSources(cfg)...)
+	issues = append(issues, lintFilters(cfg)...)
+	issues = append(issues, lintSpecs(cfg)...)
+	issues = append(issues, lintSchedule(cfg)...)
+	issues = append(issues, lintJitter(cfg)...)
+	return issues
+}
+
+// lintDuplicateSources reports sources configured under several spellings of
+// the same URL, which are scraped and notified about twice
+func lintDuplicateSources(cfg *config.Config) []lintIssue {
+	var issues []lintIssue
+
+	spellings := make(map[string][]string)
+	var order []string
+	for _, raw := range cfg.URLs {
+		key := sourceKey(raw)
+		if _, seen := spellings[key]; !seen {
+			order = append(order, key)
+		}
+		spellings[key] = append(spellings[key], raw)
+	}
+	for _, key := range order {
+		if urls := spellings[key]; len(urls) > 1 {
+			issues = append(issues, lintIssue{"duplicate-source", fmt.Sprintf("%s are the same source", strings.Join(urls, ", "))})
+		}
+	}
+
+	first := make(map[string]int)
+	for i, source := range cfg.Sources {
+		key := sourceKey(source.URL)
+		if j, seen := first[key]; seen {
+			issues = append(issues, lintIssue{"duplicate-source", fmt.Sprintf("Sources[%d] and Sources[%d] both configure %s", j, i, source.URL)})
+			continue
+		}
+		first[key] = i
+	}
+
+	return issues
+}
+
+// sourceKey normalizes a URL so that spellings of the same page compare equal
+func sourceKey(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return raw
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	if port := u.Port(); port != "" && !(scheme == "http" && port == "80") && !(scheme == "https" && port == "443") {
+		host += ":" + port
+	}
+	key := scheme + "://" + host + strings.TrimRight(u.EscapedPath(), "/")
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
+
+// lintFilters reports filters and watches that can never match, and watches
+// that can never fire because the filter drops every job they match
+func lintFilters(cfg *config.Config) []lintIssue {
+	var issues []lintIssue
+
+	var filterQuery *query.Query
+	if cfg.FilterQuery != "" {
+		filterQuery, _ = query.Parse(cfg.FilterQuery)
+		if a, b, ok := query.Conflict(filterQuery); ok {
+			issues = append(issues, lintIssue{"filter-never-matches", fmt.Sprintf("FilterQuery requires both %s and %s, so every job is dropped", a, b)})
+			filterQuery = nil
+		}
+	}
+	if cfg.FilterSalaryMax > 0 && cfg.FilterSalaryMin > cfg.FilterSalaryMax {
+		issues = append(issues, lintIssue{"filter-salary-range", fmt.Sprintf("FilterSalaryMin %g is above FilterSalaryMax %g", cfg.FilterSalaryMin, cfg.FilterSalaryMax)})
+	}
+
+	for _, w := range cfg.Watches {
+		if w.SalaryMax > 0 && w.SalaryMin > w.SalaryMax {
+			issues = append(issues, lintIssue{"watch-salary-range", fmt.Sprintf("watch %q: SalaryMin %g is above SalaryMax %g", w.Name, w.SalaryMin, w.SalaryMax)})
+		}
+		if w.Query == "" {
+			continue
+		}
+
+		watchQuery, _ := query.Parse(w.Query)
+		if a, b, ok := query.Conflict(watchQuery); ok {
+			issues = append(issues, lintIssue{"watch-never-matches", fmt.Sprintf("watch %q requires both %s and %s", w.Name, a, b)})
+			continue
+		}
+		if filterQuery == nil {
+			continue
+		}
+		if filterTerm, watchTerm, ok := query.ConflictBetween(filterQuery, watchQuery); ok {
+			issues = append(issues, lintIssue{"watch-shadowed", fmt.Sprintf("watch %q requires %s but FilterQuery only keeps jobs with %s", w.Name, watchTerm, filterTerm)})
+		}
+	}
+
+	return issues
+}
+
+// lintSpecs reports schedules the scheduler would refuse at startup
+func lintSpecs(cfg *config.Config) []lintIssue {
+	specs := []struct{ key, spec string }{
+		{"ScrapeInterval", cfg.ScrapeInterval},
+		{"SelectorRefresh", cfg.SelectorRefresh},
+		{"ComplianceSchedule", cfg.ComplianceSchedule},
+		{"RetentionSchedule", cfg.RetentionSchedule},
+		{"StaleReportSchedule", cfg.StaleReportSchedule},
+	}
+	var issues []lintIssue
+	for _, s := range specs {
+		if s.spec == "" {
+			continue
+		}
+		if _, err := scheduler.ParseSpec(s.spec); err != nil {
+			issues = append(issues, lintIssue{"invalid-schedule", fmt.Sprintf("%s: %v", s.key, err)})
+		}
+	}
+	return issues
+}
+
+// lintSchedule reports hosts that the scrape schedule sends more requests
+// than HostRateLimit allows. Every run requests each source once, plus its
+// precheck URL when configured.
+func lintSchedule(cfg *config.Config) []lintIssue {
+	if cfg.HostRateLimit == 0 {
+		return nil
+	}
+
+	interval, err := scheduler.MinInterval(cfg.ScrapeInterval)
+	if err != nil || interval <= 0 {
+		return []lintIssue{{"schedule", fmt.Sprintf("can't determine how often ScrapeInterval %q runs", cfg.ScrapeInterval)}}
+	}
+
+	requests := make(map[string]int) // host -> requests per run
+	for _, raw := range cfg.URLs {
+		requests[hostOf(raw)]++
+	}
+	for _, source := range cfg.Sources {
+		if source.Precheck != "" && source.PrecheckURL != "" {
+			requests[hostOf(source.PrecheckURL)]++
+		}
+	}
+
+	var hosts []string
+	for host := range requests {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	var issues []lintIssue
+	for _, host := range hosts {
+		perMinute := float64(requests[host]) / interval.Minutes()
+		if perMinute > float64(cfg.HostRateLimit) {
+			issues = append(issues, lintIssue{"schedule-too-dense", fmt.Sprintf(
+				"%s gets %d requests every %s (%.1f/min), above HostRateLimit %d/min",
+				host, requests[host], interval, perMinute, cfg.HostRateLimit)})
+		}
+	}
+	return issues
+}
+
+// lintJitter reports a ScheduleJitter as long as the scrape interval, which
+// lets a delayed run start after the next tick and overlap its run
+func lintJitter(cfg *config.Config) []lintIssue {
+	if cfg.ScheduleJitter == 0 {
+		return nil
+	}
+	interval, err := scheduler.MinInterval(cfg.ScrapeInterval)
+	if err != nil || interval <= 0 || cfg.ScheduleJitter < interval {
+		return nil
+	}
+	return []lintIssue{{"jitter-too-long", fmt.Sprintf(
+		"ScheduleJitter %s is not shorter than the %s between scrapes, so runs can overlap",
+		cfg.ScheduleJitter, interval)}}
+}
+
+// hostOf returns the lowercased host of a URL
+func hostOf(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return raw
+	}
+	return strings.ToLower(u.Hostname())
+}
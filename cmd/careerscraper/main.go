@@ -3,65 +3,255 @@ package main
 
 import (
 	"context"
+	"io"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // timezones for Timezone on systems without a zoneinfo database
 	
+	"github.com/fuzztobread/job-scheduler/internal/adapters/httpapi"
 	"github.com/fuzztobread/job-scheduler/internal/adapters/notifier"
 	"github.com/fuzztobread/job-scheduler/internal/adapters/repository"
 	"github.com/fuzztobread/job-scheduler/internal/adapters/scheduler"
 	"github.com/fuzztobread/job-scheduler/internal/adapters/scraper"
+	"github.com/fuzztobread/job-scheduler/internal/adapters/stream"
+	"github.com/fuzztobread/job-scheduler/internal/adapters/trace"
 	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
 	"github.com/fuzztobread/job-scheduler/internal/core/ports"
 	"github.com/fuzztobread/job-scheduler/internal/core/services"
 )
 
+// streamBacklog is the number of recent log lines and job events kept for
+// clients connecting to the streams
+const streamBacklog = 500
+
 func main() {
+	// Run a CLI command instead of the daemon if one is given
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1], os.Args[2:]))
+	}
+	
+	startedAt := time.Now()
+	
 	// Load configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 	
-	// Create scraper
-	scraper := scraper.NewGoRodScraper(30 * time.Second)
+	// Stream log lines and job events to API clients
+	var logStream, eventStream *stream.Hub
+	if cfg.ServerAddr != "" {
+		logStream, eventStream = stream.NewHub(streamBacklog), stream.NewHub(streamBacklog)
+		log.SetOutput(io.MultiWriter(os.Stderr, logStream.LogWriter()))
+	}
+	
+	// Create the HTTP client shared by all outbound requests
+	httpClient, err := buildHTTPClient(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create HTTP client: %v", err)
+	}
+	
+	// Create scraper; custom TLS settings and proxies require routing the browser through our client.
+	// Job boards with an API are queried directly instead of in the browser.
+	scraperClient := buildBrowserClient(cfg, httpClient)
+	scraperOpts, registry := buildScraperOptions(cfg, httpClient)
+	if cfg.BrowserPages > 0 {
+		pages, err := scraper.NewPagePool(cfg.BrowserPages, cfg.BrowserPageRecycle, cfg.BrowserURL, scraperClient)
+		if err != nil {
+			log.Fatalf("Failed to create browser pool: %v", err)
+		}
+		defer pages.Close()
+		scraperOpts = append(scraperOpts, scraper.WithPagePool(pages))
+	}
+	// Retries pick the next proxy of a rotation, so they wrap the proxy scraper
+	scraper := buildRetryScraper(cfg, buildProxyScraper(cfg, buildPageScraper(cfg, scraper.NewGoRodScraper(30 * time.Second, scraperClient, scraperOpts...), httpClient)))
 	
 	// Create repository
-	repo := repository.NewMemoryRepository()
+	repo, err := buildRepository(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create repository: %v", err)
+	}
+	if closer, ok := repo.(io.Closer); ok {
+		defer closer.Close()
+	}
 	
-	// Create notifier
-	var notifierInstance ports.Notifier
-	switch cfg.NotifierType {
-	case "discord":
-		if cfg.DiscordWebhookURL == "" {
-			log.Fatalf("Discord webhook URL is required for Discord notifier")
+	// Seed new sources from a published dataset so the first scrape has a baseline
+	if cfg.BootstrapDataset != "" {
+		if err := bootstrapRepository(context.Background(), cfg, httpClient, repo); err != nil {
+			log.Printf("Failed to bootstrap from %s, starting without it: %v", cfg.BootstrapDataset, err)
 		}
-		notifierInstance = notifier.NewDiscordNotifier(cfg.DiscordWebhookURL)
+	}
 	
-	default:
-		log.Fatalf("Unknown notifier type: %s", cfg.NotifierType)
+	// Create the archive keeping raw pages out of the repository
+	rawArchive, err := buildRawArchive(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create raw page archive: %v", err)
+	}
+	summaries, err := buildSummaryStore(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create summary store: %v", err)
 	}
 	
+	// Create notifier
+	notifierInstance, err := buildNotifiers(cfg, httpClient, repo)
+	if err != nil {
+		log.Fatalf("Failed to create notifier: %v", err)
+	}
+	// Count deliveries for the exit report
+	deliveries := notifier.NewCountingNotifier(notifierInstance)
+	notifierInstance = deliveries
+	
+	// Verify notifier configuration before doing any work
+	if cfg.NotifierSelfTest {
+		log.Println("Running notifier self-test...")
+		if err := probeNotifier(notifierInstance); err != nil {
+			log.Fatalf("Notifier self-test failed: %v", err)
+		}
+		log.Println("Notifier self-test passed")
+	}
+	
+	// Create filter and watch rules
+	jobFilter, watches := buildFilters(cfg, httpClient)
+	
 	// Create service
-	service := services.NewCareerScraperService(scraper, notifierInstance, repo, cfg.URLs)
+	opts := []services.Option{
+		services.WithNormalizers(buildNormalizers(cfg)...),
+		services.WithJobIdentity(buildJobIdentity(cfg)),
+		services.WithFilter(jobFilter),
+		services.WithWatches(watches...),
+		services.WithErrorNotifications(cfg.NotifyErrors),
+		services.WithConcurrency(cfg.ScrapeConcurrency, cfg.HostConcurrency),
+		services.WithHostRateLimit(cfg.HostRateLimit),
+	}
+	if cfg.FailureBackoffLimit > 0 {
+		opts = append(opts, services.WithFailureBackoff(cfg.FailureBackoffLimit))
+	}
+	owners, escalations, err := buildSourceOwners(cfg, httpClient, repo)
+	if err != nil {
+		log.Fatalf("Failed to create source owners: %v", err)
+	}
+	if len(owners) > 0 {
+		opts = append(opts, services.WithSourceOwners(owners, escalations))
+	}
+	if cfg.DigestMode {
+		opts = append(opts, services.WithDigest(cfg.DigestSimilarity))
+	}
+	if cfg.FilterTraceFile != "" {
+		opts = append(opts, services.WithFilterTrace(trace.NewFileTraceStore(cfg.FilterTraceFile), cfg.FilterTraceLimit))
+	}
+	if rawArchive != nil {
+		opts = append(opts, services.WithRawArchive(rawArchive))
+	}
+	if precheck := buildPrecheck(cfg, httpClient); precheck != nil {
+		opts = append(opts, services.WithPrecheck(precheck))
+	}
+	if eventStream != nil {
+		opts = append(opts, services.WithJobEvents(eventStream))
+	}
+	if cfg.EnrichDetails {
+		opts = append(opts, services.WithEnrichment(buildEnricher(httpClient), cfg.EnrichWorkers, cfg.EnrichMaxJobs))
+	}
+	if translator := buildTranslator(cfg, httpClient); translator != nil {
+		opts = append(opts, services.WithTranslation(translator, cfg.TranslateTo))
+	}
+	// The scrape path reads the latest collections every run; cache them if configured
+	serviceRepo := repo
+	if cfg.RepositoryCache {
+		serviceRepo = repository.NewCachedRepository(repo, cfg.RepositoryCacheTTL)
+	}
+	// Keep scraping through short repository outages if configured
+	var resilient *repository.ResilientRepository
+	if cfg.RepositoryFallback {
+		resilient = repository.NewResilientRepository(serviceRepo, cfg.RepositoryRetryAfter, cfg.RepositoryQueueLimit)
+		serviceRepo = resilient
+	}
+	service := services.NewCareerScraperService(scraper, notifierInstance, serviceRepo, cfg.URLs, opts...)
+	
+	// Create scheduler, reading schedules in the configured timezone
+	location, err := cfg.ScheduleLocation()
+	if err != nil {
+		log.Fatalf("Failed to load timezone: %v", err)
+	}
+	schedulerOpts := []scheduler.CronOption{scheduler.WithJitter(cfg.ScheduleJitter), scheduler.WithTimeout(cfg.JobTimeout), scheduler.WithLocation(location)}
 	
-	// Create scheduler
-	scheduler := scheduler.NewCronScheduler()
+	// Elect the instance running the scheduled jobs when several share them
+	election, err := buildLeaderElection(cfg)
+	if err != nil {
+		log.Fatalf("Failed to set up leader election: %v", err)
+	}
+	// Keep campaigning from now on, so the lease holds through the initial scrape
+	electionCtx, stopElection := context.WithCancel(context.Background())
+	defer stopElection()
+	electionDone := make(chan struct{})
+	if election != nil {
+		if !election.Campaign(electionCtx) {
+			log.Printf("Another instance leads %s, standing by", cfg.LeaderLease)
+		}
+		schedulerOpts = append(schedulerOpts, scheduler.WithLeadership(election))
+		go func() {
+			defer close(electionDone)
+			election.Run(electionCtx)
+		}()
+	} else {
+		close(electionDone)
+	}
+	scheduler := scheduler.NewCronScheduler(schedulerOpts...)
 	
-	// For testing - run the job immediately once
-	log.Println("Running initial scrape job...")
-	if err := service.ScrapeAndNotify(context.Background()); err != nil {
-		log.Printf("Initial scrape job failed: %v", err)
+	// Run the job immediately once, unless configured otherwise or standing by
+	if election != nil && !election.IsLeader() {
+		log.Println("Leaving the initial scrape to the leader")
+	} else if shouldRunAtStartup(cfg, service) {
+		log.Println("Running initial scrape job...")
+		runInitialScrape(cfg, service, election)
 	}
 	
 	// Schedule the scraping job
-	log.Printf("Scheduling job with cron expression: %s", cfg.ScrapeInterval)
+	log.Printf("Scheduling job with schedule: %s (%s)", cfg.ScrapeInterval, location)
 	if err := scheduler.Schedule(cfg.ScrapeInterval, service.ScrapeAndNotify); err != nil {
 		log.Fatalf("Failed to schedule job: %v", err)
 	}
 	
+	// Keep the selector profiles of the registry up to date
+	if registry != nil {
+		if err := scheduler.Schedule(cfg.SelectorRefresh, registry.Refresh); err != nil {
+			log.Fatalf("Failed to schedule selector registry updates: %v", err)
+		}
+	}
+	
+	// Schedule purging of personal data for data retention compliance
+	if cfg.ComplianceMode {
+		compliance := buildComplianceService(cfg, repo, rawArchive, summaries)
+		log.Printf("Compliance mode enabled, purging personal data older than %s (%s)", cfg.ComplianceMaxAge, cfg.ComplianceSchedule)
+		if err := scheduler.Schedule(cfg.ComplianceSchedule, compliance.Purge); err != nil {
+			log.Fatalf("Failed to schedule compliance purge: %v", err)
+		}
+	}
+	
+	// Schedule pruning of data older than the retention policy allows
+	if cfg.SnapshotMaxAge > 0 || cfg.JobMaxAge > 0 {
+		if retention := buildRetentionService(cfg, repo, rawArchive, summaries); retention != nil {
+			log.Printf("Retention pruning enabled (%s)", cfg.RetentionSchedule)
+			if err := scheduler.Schedule(cfg.RetentionSchedule, retention.Prune); err != nil {
+				log.Fatalf("Failed to schedule retention pruning: %v", err)
+			}
+		} else {
+			log.Printf("Repository %s does not support retention pruning, ignoring SnapshotMaxAge and JobMaxAge", cfg.RepositoryType)
+		}
+	}
+	
+	// Schedule the report of sources that look abandoned
+	if cfg.StaleReport {
+		stale := services.NewStaleSourceService(repo, notifierInstance, cfg.URLs, cfg.StaleAfter)
+		if err := scheduler.Schedule(cfg.StaleReportSchedule, stale.Report); err != nil {
+			log.Fatalf("Failed to schedule stale source report: %v", err)
+		}
+	}
+	
 	// Handle graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -73,6 +263,43 @@ func main() {
 		}
 	}()
 	
+	// Run a scrape on demand, on SIGUSR1 or over the HTTP API
+	trigger := newScrapeTrigger(cfg, service, election)
+	go trigger.listen(ctx)
+	
+	// Start the dashboard and HTTP API
+	var server *http.Server
+	if cfg.ServerAddr != "" {
+		serverOpts := []httpapi.ServerOption{httpapi.WithStreams(logStream, eventStream)}
+		if cfg.OverflowSummaries && cfg.SummaryStoreType == "file" {
+			serverOpts = append(serverOpts, httpapi.WithSummaries(cfg.SummaryDir))
+		}
+		if snoozes, err := buildSnoozeStore(cfg); err != nil {
+			log.Printf("Not serving snoozes: %v", err)
+		} else if snoozes != nil {
+			serverOpts = append(serverOpts, httpapi.WithSnoozes(snoozes))
+		}
+		if cfg.BatchAPIToken != "" {
+			serverOpts = append(serverOpts, httpapi.WithJobBatches(service, cfg.BatchAPIToken))
+		}
+		if cfg.TriggerAPIToken != "" {
+			serverOpts = append(serverOpts, httpapi.WithScrapeTrigger(trigger.Trigger, cfg.TriggerAPIToken))
+		}
+		if len(cfg.AdminAPITokens) > 0 {
+			serverOpts = append(serverOpts, httpapi.WithAdministrators(cfg.AdminAPITokens))
+		}
+		if cfg.FailureBackoffLimit > 0 {
+			serverOpts = append(serverOpts, httpapi.WithBackoffs(service.Backoffs))
+		}
+		server = &http.Server{Addr: cfg.ServerAddr, Handler: httpapi.NewServer(repo, cfg.URLs, serverOpts...)}
+		go func() {
+			log.Printf("Dashboard listening on %s", cfg.ServerAddr)
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Printf("HTTP server stopped with error: %v", err)
+			}
+		}()
+	}
+	
 	log.Printf("Career scraper started, monitoring %d URLs every %s", len(cfg.URLs), cfg.ScrapeInterval)
 	
 	// Set up signal handling
@@ -83,11 +310,41 @@ func main() {
 	<-sigCh
 	log.Println("Shutting down...")
 	
+	// Stop the HTTP server
+	if server != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Error stopping HTTP server: %v", err)
+		}
+		shutdownCancel()
+	}
+	
 	// Stop the scheduler
 	cancel()
 	if err := scheduler.Stop(); err != nil {
 		log.Printf("Error stopping scheduler: %v", err)
 	}
 	
+	// Hand the leadership over to a standby
+	stopElection()
+	<-electionDone
+	
+	// Report whether stopping lost any work
+	report := domain.ExitReport{StartedAt: startedAt, StoppedAt: time.Now()}
+	report.RunsCompleted, report.RunsInProgress = service.RunCounts()
+	report.NotificationsSent, report.NotificationsFailed = deliveries.Counts()
+	if resilient != nil {
+		report.PendingWrites = resilient.Pending()
+	}
+	var reportNotifier ports.Notifier
+	if cfg.ExitReportNotify {
+		reportNotifier = notifierInstance
+	}
+	reportCtx, reportCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := services.NewExitReportService(repo, reportNotifier, cfg.URLs).Report(reportCtx, report); err != nil {
+		log.Printf("Error reporting on exit: %v", err)
+	}
+	reportCancel()
+	
 	log.Println("Shutdown complete")
 }
\ No newline at end of file
@@ -0,0 +1,62 @@
+// cmd/careerscraper/normalizers.go
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/translator"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/normalize"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// buildNormalizers creates the normalizers applied to every scraped job,
+// cleaning up its text before its language, dates and salary are read and its
+// location and category are mapped, and identifying it last
+func buildNormalizers(cfg *config.Config) []normalize.Normalizer {
+	locationAliases := make(map[string][]string, len(cfg.LocationAliases))
+	for _, alias := range cfg.LocationAliases {
+		locationAliases[alias.Canonical] = append(locationAliases[alias.Canonical], alias.Aliases...)
+	}
+
+	categoryAliases := make(map[string][]string, len(cfg.CategoryMappings))
+	for _, mapping := range cfg.CategoryMappings {
+		categoryAliases[mapping.Category] = append(categoryAliases[mapping.Category], mapping.Aliases...)
+	}
+
+	normalizers := []normalize.Normalizer{
+		normalize.NewTextNormalizer(),
+		normalize.NewLanguageDetector(),
+		normalize.NewDateExtractor(),
+	}
+	if cfg.ExtractSalary {
+		normalizers = append(normalizers, normalize.NewSalaryExtractor())
+	}
+	return append(normalizers,
+		normalize.NewLocationNormalizer(locationAliases),
+		normalize.NewCategoryNormalizer(categoryAliases, cfg.CategorySimilarity),
+		normalize.NewIdentityNormalizer(buildJobIdentity(cfg)),
+	)
+}
+
+// buildJobIdentity creates the strategy jobs are identified by across scrapes
+func buildJobIdentity(cfg *config.Config) normalize.JobIdentity {
+	identity, err := normalize.ParseJobIdentity(cfg.JobIdentity)
+	if err != nil {
+		log.Fatalf("Invalid JobIdentity: %v", err)
+	}
+	return identity
+}
+
+// buildTranslator creates the translator of job titles, or returns nil when
+// translation is disabled
+func buildTranslator(cfg *config.Config, client *http.Client) ports.Translator {
+	switch cfg.Translator {
+	case config.TranslatorDeepL:
+		return translator.NewDeepLTranslator(cfg.TranslatorURL, cfg.TranslatorAPIKey, client)
+	case config.TranslatorLibreTranslate:
+		return translator.NewLibreTranslateTranslator(cfg.TranslatorURL, cfg.TranslatorAPIKey, client)
+	}
+	return nil
+}
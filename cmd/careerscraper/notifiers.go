@@ -0,0 +1,194 @@
+// cmd/careerscraper/notifiers.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/notifier"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// notifierProbeTimeout bounds the startup self-test of all notifiers
+const notifierProbeTimeout = 30 * time.Second
+
+// buildNotifiers creates every configured notifier, each filtered by its
+// minimum severity and its snoozes and linking to a hosted summary of changes
+// it can't show if enabled, and combines them into one, recording what is sent if the
+// notification history is enabled
+func buildNotifiers(cfg *config.Config, client *http.Client, repo ports.JobRepository) (ports.Notifier, error) {
+	if len(cfg.NotifierTypes) == 0 {
+		return nil, fmt.Errorf("no notifier configured")
+	}
+
+	summaries, err := buildSummaryStore(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create summary store: %w", err)
+	}
+	snoozes, err := buildSnoozeStore(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snoozes: %w", err)
+	}
+
+	var notifiers []ports.Notifier
+	var named []notifier.NamedNotifier
+	for _, name := range cfg.NotifierTypes {
+		n, err := buildNotifier(cfg, name, client, repo)
+		if err != nil {
+			return nil, err
+		}
+
+		if limits, ok := n.(ports.SizeLimited); ok && summaries != nil {
+			n = notifier.NewOverflowNotifier(n, limits, summaries, cfg.SummaryFormat == config.SummaryFormatMarkdown)
+		}
+		if snoozes != nil {
+			n = notifier.NewSnoozeNotifier(n, name, snoozes)
+		}
+
+		if minSeverity, ok := cfg.NotifierMinSeverity[name]; ok {
+			severity, err := domain.ParseSeverity(minSeverity)
+			if err != nil {
+				return nil, err
+			}
+			n = notifier.NewSeverityNotifier(n, severity)
+		}
+		notifiers = append(notifiers, n)
+		named = append(named, notifier.NamedNotifier{Name: name, Notifier: n})
+	}
+
+	if cfg.NotificationHistory {
+		history, ok := ports.Optional[ports.NotificationHistory](repo)
+		if !ok {
+			return nil, fmt.Errorf("repository %s does not keep a notification history", cfg.RepositoryType)
+		}
+		return notifier.NewHistoryNotifier(history, named...), nil
+	}
+	if len(notifiers) == 1 {
+		return notifiers[0], nil
+	}
+	return notifier.NewMultiNotifier(notifiers...), nil
+}
+
+// buildSourceOwners returns the owners of the configured sources and the
+// notifiers their failure alerts escalate to, both by source URL. Owners
+// escalating to the same notifier and target share one notifier.
+func buildSourceOwners(cfg *config.Config, client *http.Client, repo ports.JobRepository) (map[string]domain.SourceOwner, map[string]ports.Notifier, error) {
+	snoozes, err := buildSnoozeStore(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open snoozes: %w", err)
+	}
+
+	owners := make(map[string]domain.SourceOwner)
+	escalations := make(map[string]ports.Notifier)
+	shared := make(map[config.OwnerConfig]ports.Notifier)
+	for _, source := range cfg.Sources {
+		if source.Owner == nil {
+			continue
+		}
+		owner := *source.Owner
+		owners[source.URL] = domain.SourceOwner{Name: owner.Name, Contact: owner.Contact, Notifier: owner.Notifier}
+		if owner.Notifier == "" {
+			continue
+		}
+
+		key := config.OwnerConfig{Notifier: owner.Notifier, Target: owner.Target}
+		n, ok := shared[key]
+		if !ok {
+			n, err = buildEscalationNotifier(cfg, owner, client, repo)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create escalation notifier of %s: %w", source.URL, err)
+			}
+			if snoozes != nil {
+				n = notifier.NewSnoozeNotifier(n, owner.Notifier, snoozes)
+			}
+			shared[key] = n
+		}
+		escalations[source.URL] = n
+	}
+	return owners, escalations, nil
+}
+
+// buildEscalationNotifier creates the notifier of an owner, delivering to
+// its target instead of the configured destination if one is set
+func buildEscalationNotifier(cfg *config.Config, owner config.OwnerConfig, client *http.Client, repo ports.JobRepository) (ports.Notifier, error) {
+	if owner.Target == "" {
+		return buildNotifier(cfg, owner.Notifier, client, repo)
+	}
+
+	targeted := *cfg
+	switch owner.Notifier {
+	case "discord":
+		targeted.DiscordWebhookURL = owner.Target
+	case "webhook":
+		targeted.WebhookURL = owner.Target
+	case "apprise":
+		targeted.AppriseTag = owner.Target
+	case "console":
+		targeted.ConsoleFile = owner.Target
+	default:
+		return nil, fmt.Errorf("notifier %s has no target to escalate to", owner.Notifier)
+	}
+	return buildNotifier(&targeted, owner.Notifier, client, repo)
+}
+
+// buildNotifier creates a single notifier by type name
+func buildNotifier(cfg *config.Config, name string, client *http.Client, repo ports.JobRepository) (ports.Notifier, error) {
+	switch name {
+	case "discord":
+		if cfg.DiscordWebhookURL == "" {
+			return nil, fmt.Errorf("Discord webhook URL is required for Discord notifier")
+		}
+		var mentions []notifier.DiscordMention
+		for _, m := range cfg.DiscordMentions {
+			mentions = append(mentions, notifier.DiscordMention{Keywords: m.Keywords, RoleID: m.RoleID, UserID: m.UserID})
+		}
+		return notifier.NewDiscordNotifier(cfg.DiscordWebhookURL, cfg.DiscordSigningSecret, mentions, cfg.DiscordScreenshots, client), nil
+
+	case "webhook":
+		if cfg.WebhookURL == "" {
+			return nil, fmt.Errorf("Webhook URL is required for webhook notifier")
+		}
+		if cfg.WebhookMode == config.WebhookModePerJob {
+			return notifier.NewJobEventWebhookNotifier(cfg.WebhookURL, cfg.WebhookSigningSecret, client), nil
+		}
+		return notifier.NewWebhookNotifier(cfg.WebhookURL, cfg.WebhookSigningSecret, client), nil
+
+	case "desktop":
+		return notifier.NewDesktopNotifier(), nil
+
+	case "console":
+		return notifier.NewConsoleNotifier(cfg.ConsoleFile), nil
+
+	case "apprise":
+		if cfg.AppriseURL == "" {
+			return nil, fmt.Errorf("Apprise URL is required for Apprise notifier")
+		}
+		return notifier.NewAppriseNotifier(cfg.AppriseURL, cfg.AppriseTag, client), nil
+
+	case "capture":
+		store, ok := ports.Optional[ports.CaptureStore](repo)
+		if !ok {
+			return nil, fmt.Errorf("Repository %s can't store captured notifications for the capture notifier", cfg.RepositoryType)
+		}
+		return notifier.NewCaptureNotifier(store), nil
+
+	default:
+		return nil, fmt.Errorf("unknown notifier type: %s", name)
+	}
+}
+
+// probeNotifier runs the startup self-test of the notifier, if it supports one
+func probeNotifier(n ports.Notifier) error {
+	prober, ok := n.(ports.Prober)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), notifierProbeTimeout)
+	defer cancel()
+	return prober.Probe(ctx)
+}
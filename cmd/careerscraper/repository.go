@@ -0,0 +1,139 @@
+// cmd/careerscraper/repository.go
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/repository"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// buildRepository creates the configured job repository, writing to a
+// secondary backend as well while migrating and encrypting stored collections
+// if a key is configured
+func buildRepository(cfg *config.Config) (ports.JobRepository, error) {
+	key, err := cfg.RepositoryKey()
+	if err != nil {
+		return nil, err
+	}
+	backend, err := buildBackend(cfg, cfg.RepositoryType)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.RepositorySecondary != "" {
+		secondary, err := buildBackend(cfg, cfg.RepositorySecondary)
+		if err != nil {
+			if closer, ok := backend.(io.Closer); ok {
+				closer.Close()
+			}
+			return nil, fmt.Errorf("failed to create secondary repository: %w", err)
+		}
+		backend = repository.DualWrite(backend, secondary)
+	}
+	if key == nil {
+		return backend, nil
+	}
+
+	repo, err := repository.EncryptRepository(backend, key)
+	if err != nil {
+		if closer, ok := backend.(io.Closer); ok {
+			closer.Close()
+		}
+		return nil, err
+	}
+	return repo, nil
+}
+
+// buildBackend creates a store of job collections of the given type
+func buildBackend(cfg *config.Config, kind string) (ports.JobRepository, error) {
+	switch kind {
+	case "memory":
+		opts := []repository.MemoryOption{repository.WithMaxSources(cfg.MemoryMaxSources)}
+		if cfg.MemoryDumpFile != "" {
+			opts = append(opts, repository.WithDumpFile(cfg.MemoryDumpFile, cfg.MemoryDumpInterval))
+		}
+		return repository.NewMemoryRepository(cfg.SnapshotRetention, cfg.RawContentRetention, opts...), nil
+
+	case "file":
+		return repository.NewFileRepository(cfg.DataDir, cfg.SnapshotRetention, cfg.RawContentRetention)
+
+	case "sqlite":
+		return repository.NewSQLiteRepository(cfg.SQLitePath, cfg.SnapshotRetention, cfg.RawContentRetention)
+
+	case "bolt":
+		return repository.NewBoltRepository(cfg.BoltPath, cfg.SnapshotRetention, cfg.RawContentRetention)
+
+	case "redis":
+		return repository.NewRedisRepository(cfg.RedisURL, cfg.RedisKeyPrefix, cfg.RedisTTL, cfg.SnapshotRetention, cfg.RawContentRetention)
+
+	case "s3":
+		return repository.NewS3Repository(s3Options(cfg))
+
+	default:
+		return nil, fmt.Errorf("unknown repository type: %s", kind)
+	}
+}
+
+// buildRawArchive creates the configured raw page archive, or returns nil if
+// raw pages are kept with the collections
+func buildRawArchive(cfg *config.Config) (ports.RawContentStore, error) {
+	if !cfg.RawArchive {
+		return nil, nil
+	}
+
+	switch cfg.RawArchiveType {
+	case "file":
+		return repository.NewFileRawArchive(cfg.RawArchiveDir)
+
+	case "s3":
+		return repository.NewS3RawArchive(s3Options(cfg))
+
+	default:
+		return nil, fmt.Errorf("unknown raw archive type: %s", cfg.RawArchiveType)
+	}
+}
+
+// buildSnoozeStore opens the snoozes silencing notifications, or returns nil
+// if snoozing is disabled
+func buildSnoozeStore(cfg *config.Config) (ports.SnoozeStore, error) {
+	if cfg.SnoozeFile == "" {
+		return nil, nil
+	}
+	return repository.NewFileSnoozeStore(cfg.SnoozeFile)
+}
+
+// buildSummaryStore creates the store hosting summaries of changes too large
+// for a notification, or returns nil if overflow summaries are disabled
+func buildSummaryStore(cfg *config.Config) (ports.SummaryStore, error) {
+	if !cfg.OverflowSummaries {
+		return nil, nil
+	}
+
+	switch cfg.SummaryStoreType {
+	case "file":
+		return repository.NewFileSummaryStore(cfg.SummaryDir, cfg.SummaryBaseURL)
+
+	case "s3":
+		return repository.NewS3SummaryStore(s3Options(cfg), cfg.SummaryBaseURL)
+
+	default:
+		return nil, fmt.Errorf("unknown summary store type: %s", cfg.SummaryStoreType)
+	}
+}
+
+// s3Options returns the S3 settings shared by the repository and raw archive
+func s3Options(cfg *config.Config) repository.S3Options {
+	return repository.S3Options{
+		Endpoint:     cfg.S3Endpoint,
+		Bucket:       cfg.S3Bucket,
+		Prefix:       cfg.S3Prefix,
+		Region:       cfg.S3Region,
+		AccessKey:    cfg.S3AccessKey,
+		SecretKey:    cfg.S3SecretKey,
+		UseSSL:       cfg.S3UseSSL,
+		Retention:    cfg.SnapshotRetention,
+		RawRetention: cfg.RawContentRetention,
+	}
+}
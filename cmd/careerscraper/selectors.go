@@ -0,0 +1,176 @@
+// cmd/careerscraper/selectors.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/scraper"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/normalize"
+)
+
+// runTestSelectors scrapes a career page, or reads a saved one, and reports
+// how the configured selectors match it and a sample of the parsed jobs,
+// without saving or notifying anything
+func runTestSelectors(args []string) int {
+	flags := flag.NewFlagSet("test-selectors", flag.ContinueOnError)
+	url := flags.String("url", "", "career page URL the selectors are configured for")
+	file := flags.String("file", "", "saved HTML of the page to read instead of scraping it")
+	limit := flags.Int("limit", 5, "maximum number of parsed jobs shown (0 for all)")
+	asJSON := flags.Bool("json", false, "print the report as JSON")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *url == "" {
+		fmt.Fprintln(os.Stderr, "--url is required")
+		return 2
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	httpClient, err := buildHTTPClient(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create HTTP client: %v\n", err)
+		return 1
+	}
+	scraperOpts, _ := buildScraperOptions(cfg, httpClient)
+	browser := scraper.NewGoRodScraper(30*time.Second, buildBrowserClient(cfg, httpClient), scraperOpts...)
+
+	html, err := readTestPage(cfg, browser, httpClient, *url, *file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read the page: %v\n", err)
+		if html == "" {
+			return 1
+		}
+	}
+
+	report, err := browser.DiagnoseSelectors(html, *url)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse the page: %v\n", err)
+		return 1
+	}
+	report.Jobs = normalize.Apply(domain.JobCollection{Jobs: report.Jobs}, buildNormalizers(cfg)...).Jobs
+	total := len(report.Jobs)
+	if *limit > 0 && total > *limit {
+		report.Jobs = report.Jobs[:*limit]
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode report: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	printSelectorReport(report, total)
+	return 0
+}
+
+// readTestPage returns the HTML of the page at url as the scraper sees it,
+// rendered in the browser or downloaded as configured, or the content of
+// file if given. The page is returned with the error of a scrape failing
+// after the page was read, e.g. because it is a bot check.
+func readTestPage(cfg *config.Config, browser *scraper.GoRodScraper, client *http.Client, url, file string) (string, error) {
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	}
+
+	collection, err := buildRenderScraper(cfg, browser, client).Scrape(context.Background(), url)
+	if collection.RawContent == "" && err == nil {
+		err = errors.New("the page has no content")
+	}
+	return collection.RawContent, err
+}
+
+// printSelectorReport prints a selector report, total being the number of
+// jobs parsed of which the report shows a sample
+func printSelectorReport(report scraper.SelectorReport, total int) {
+	fmt.Printf("Source:  %s\n", report.SourceURL)
+	switch {
+	case report.Rule == nil:
+		fmt.Println("Parser:  generic (no selector rule matches the URL)")
+	case report.Parser == scraper.ParserProfile:
+		fmt.Printf("Parser:  selector profile %s, container %q matched %d listings\n", report.Rule.Name, report.Rule.Container, report.Containers)
+	default:
+		fmt.Printf("Parser:  selector rule, container %q matched %d listings\n", report.Rule.Container, report.Containers)
+	}
+	fmt.Printf("JSON-LD: %d JobPosting objects\n", report.JSONLD)
+
+	if len(report.Selectors) > 0 {
+		fmt.Println()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "FIELD\tSELECTOR\tMATCHES\tLISTINGS\t")
+		for _, match := range report.Selectors {
+			listings := "-"
+			if report.Rule != nil && match.Field != "wait_for" {
+				listings = fmt.Sprintf("%d/%d", match.Listings, report.Containers)
+			}
+			warning := ""
+			if match.Matches == 0 {
+				warning = "no match"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", match.Field, match.Selector, match.Matches, listings, warning)
+		}
+		w.Flush()
+	}
+
+	fmt.Println()
+	if total == 0 {
+		fmt.Println("No jobs parsed")
+		return
+	}
+	fmt.Printf("Parsed %d jobs", total)
+	if len(report.Jobs) < total {
+		fmt.Printf(", showing the first %d", len(report.Jobs))
+	}
+	fmt.Println(":")
+	for i, job := range report.Jobs {
+		fmt.Printf("\n[%d] %s\n", i+1, job.Title)
+		printJobField("id", job.ID)
+		printJobField("location", job.Location)
+		printJobField("department", job.Department)
+		printJobField("url", job.URL)
+		printJobField("apply", job.DirectApplyURL())
+		if !job.PostedDate.IsZero() {
+			printJobField("posted", job.PostedDate.Format("2006-01-02"))
+		}
+		if !job.Deadline.IsZero() {
+			printJobField("deadline", job.Deadline.Format("2006-01-02"))
+		}
+		for _, key := range job.MetadataKeys() {
+			printJobField(key, job.Metadata[key])
+		}
+		printJobField("description", job.Description)
+	}
+}
+
+// printJobField prints a field of a sample job, cut to one short line
+func printJobField(name, value string) {
+	if value == "" {
+		return
+	}
+	value = strings.Join(strings.Fields(value), " ")
+	if runes := []rune(value); len(runes) > 100 {
+		value = string(runes[:100]) + "…"
+	}
+	fmt.Printf("    %-12s %s\n", name+":", value)
+}
@@ -0,0 +1,111 @@
+// cmd/careerscraper/snooze.go
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// runSnooze silences notifications about a source or one of its jobs for a
+// number of days, or lists or removes snoozes
+func runSnooze(args []string) int {
+	flags := flag.NewFlagSet("snooze", flag.ContinueOnError)
+	url := flags.String("url", "", "career page URL to snooze")
+	job := flags.String("job", "", "only snooze this job ID of the career page")
+	via := flags.String("notifier", "", "only snooze notifications sent through this notifier, e.g. discord")
+	days := flags.Float64("days", 7, "number of days to snooze for")
+	reason := flags.String("reason", "", "why the snooze was added, e.g. already reviewed")
+	list := flags.Bool("list", false, "list the active snoozes instead")
+	remove := flags.String("remove", "", "remove the snooze with this ID instead")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if !*list && *remove == "" && *url == "" {
+		fmt.Fprintln(os.Stderr, "--url, --list or --remove is required")
+		return 2
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		return 1
+	}
+	store, err := buildSnoozeStore(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open snoozes: %v\n", err)
+		return 1
+	}
+	if store == nil {
+		fmt.Fprintln(os.Stderr, "Snoozing is disabled, set SnoozeFile")
+		return 1
+	}
+	ctx := context.Background()
+
+	switch {
+	case *list:
+		snoozes, err := store.ListSnoozes(ctx, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list snoozes: %v\n", err)
+			return 1
+		}
+		if len(snoozes) == 0 {
+			fmt.Println("No active snoozes")
+			return 0
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tUNTIL\tNOTIFIER\tSOURCE\tJOB\tREASON")
+		for _, snooze := range snoozes {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", snooze.ID, snooze.Until.Format(time.RFC3339),
+				orAll(snooze.Notifier), snooze.SourceURL, orAll(snooze.JobID), snooze.Reason)
+		}
+		w.Flush()
+
+	case *remove != "":
+		err := store.DeleteSnooze(ctx, *remove)
+		if errors.Is(err, domain.ErrNotFound) {
+			fmt.Fprintf(os.Stderr, "No active snooze %s\n", *remove)
+			return 1
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to remove snooze: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Removed snooze %s\n", *remove)
+
+	default:
+		if *days <= 0 {
+			fmt.Fprintln(os.Stderr, "--days must be positive")
+			return 2
+		}
+		snooze, err := store.SaveSnooze(ctx, domain.Snooze{
+			Notifier:  *via,
+			SourceURL: *url,
+			JobID:     *job,
+			Until:     time.Now().Add(time.Duration(*days * float64(24*time.Hour))),
+			Reason:    *reason,
+			CreatedBy: currentActor(),
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to snooze: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Snoozed until %s (ID %s)\n", snooze.Until.Format(time.RFC1123), snooze.ID)
+	}
+	return 0
+}
+
+// orAll shows an empty snooze scope as applying to all
+func orAll(value string) string {
+	if value == "" {
+		return "(all)"
+	}
+	return value
+}
@@ -0,0 +1,332 @@
+// cmd/careerscraper/sources.go
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/httpclient"
+	"github.com/fuzztobread/job-scheduler/internal/adapters/scraper"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// buildPrecheck creates the change detector for sources with a precheck, or
+// nil if no source has one
+func buildPrecheck(cfg *config.Config, client *http.Client) ports.ChangeDetector {
+	sources := make(map[string]scraper.PrecheckSource)
+	for _, source := range cfg.Sources {
+		if source.Precheck == "" {
+			continue
+		}
+		sources[source.URL] = scraper.PrecheckSource{
+			Method: source.Precheck,
+			URL:    source.PrecheckURL,
+		}
+	}
+	if len(sources) == 0 {
+		return nil
+	}
+	return scraper.NewHTTPPrecheck(client, sources)
+}
+
+// buildFields returns the custom fields extracted from the job listings of
+// each source
+func buildFields(cfg *config.Config) map[string][]scraper.FieldSelector {
+	fields := make(map[string][]scraper.FieldSelector)
+	for _, source := range cfg.Sources {
+		for _, field := range source.Fields {
+			fields[source.URL] = append(fields[source.URL], scraper.FieldSelector{
+				Name:     field.Name,
+				Selector: field.Selector,
+				Attr:     field.Attr,
+				JSONPath: field.JSONPath,
+			})
+		}
+	}
+	return fields
+}
+
+// buildAuth returns how the browser signs in to each gated source, with the
+// ${NAME} references of cookie and login values replaced by the environment
+func buildAuth(cfg *config.Config) map[string]scraper.SourceAuth {
+	auth := make(map[string]scraper.SourceAuth)
+	for _, source := range cfg.Sources {
+		if len(source.Cookies) == 0 && source.Login == nil {
+			continue
+		}
+		var sourceAuth scraper.SourceAuth
+		for _, cookie := range source.Cookies {
+			sourceAuth.Cookies = append(sourceAuth.Cookies, scraper.Cookie{
+				Name:   cookie.Name,
+				Value:  expandEnvRefs(cookie.Value),
+				Domain: cookie.Domain,
+				Path:   cookie.Path,
+			})
+		}
+		if source.Login != nil {
+			sourceAuth.LoginURL = source.Login.URL
+			for _, step := range source.Login.Steps {
+				sourceAuth.Steps = append(sourceAuth.Steps, scraper.LoginStep{
+					Action:   step.Action,
+					Selector: step.Selector,
+					Value:    expandEnvRefs(step.Value),
+				})
+			}
+		}
+		auth[source.URL] = sourceAuth
+	}
+	return auth
+}
+
+// envRef matches a ${NAME} reference to an environment variable
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnvRefs replaces the ${NAME} references in value by the environment
+// variables they name, empty if unset. Unlike os.ExpandEnv it leaves a bare
+// $ alone, which literal passwords and cookies may contain.
+func expandEnvRefs(value string) string {
+	return envRef.ReplaceAllStringFunc(value, func(ref string) string {
+		return os.Getenv(envRef.FindStringSubmatch(ref)[1])
+	})
+}
+
+// buildInterception returns the rules reading the jobs of sources from the
+// API responses of their pages
+func buildInterception(cfg *config.Config) map[string]scraper.InterceptRule {
+	rules := make(map[string]scraper.InterceptRule)
+	for _, source := range cfg.Sources {
+		intercept := source.Intercept
+		if intercept == nil {
+			continue
+		}
+		rules[source.URL] = scraper.InterceptRule{
+			URLPattern:  regexp.MustCompile(intercept.URLPattern), // checked by config validation
+			Jobs:        intercept.Jobs,
+			ID:          intercept.ID,
+			Title:       intercept.Title,
+			Location:    intercept.Location,
+			Department:  intercept.Department,
+			URL:         intercept.URL,
+			Description: intercept.Description,
+			PostedDate:  intercept.PostedDate,
+		}
+	}
+	return rules
+}
+
+// buildSelectors returns the configured selector rules of sites
+func buildSelectors(cfg *config.Config) []scraper.SelectorRule {
+	rules := make([]scraper.SelectorRule, 0, len(cfg.Selectors))
+	for _, rule := range cfg.Selectors {
+		rules = append(rules, scraper.SelectorRule{
+			URL:         rule.URL,
+			Domain:      rule.Domain,
+			Container:   rule.Container,
+			Title:       rule.Title,
+			Location:    rule.Location,
+			Department:  rule.Department,
+			Description: rule.Description,
+			Link:        rule.Link,
+			ApplyLink:   rule.ApplyLink,
+			IDAttr:      rule.IDAttr,
+			WaitFor:     rule.WaitFor,
+			WaitIdle:    rule.WaitIdle,
+			WaitTimeout: rule.WaitTimeout,
+		})
+	}
+	return rules
+}
+
+// buildBoardScrapers returns the scrapers of the job boards queried through
+// their API instead of the browser, after the scrapers of the sources
+// scraped by plugins and discovered through their sitemap
+func buildBoardScrapers(cfg *config.Config, client *http.Client) []scraper.BoardScraper {
+	return []scraper.BoardScraper{
+		buildPluginScraper(cfg, client),
+		buildSitemapScraper(cfg, client),
+		buildSearchScraper(cfg, client),
+		buildPDFScraper(cfg, client),
+		scraper.NewGreenhouseScraper(client),
+		scraper.NewLeverScraper(client),
+		scraper.NewWorkdayScraper(client),
+		scraper.NewAshbyScraper(client),
+		scraper.NewSmartRecruitersScraper(client),
+		scraper.NewRecruiteeScraper(client),
+		scraper.NewTeamtailorScraper(client),
+		scraper.NewFeedScraper(client),
+	}
+}
+
+// buildPageScraper creates the scraper of career pages: job boards through
+// their API, other pages with browser or over plain HTTP as their render mode
+// says
+func buildPageScraper(cfg *config.Config, browser *scraper.GoRodScraper, client *http.Client) *scraper.RouterScraper {
+	return scraper.NewRouterScraper(buildRenderScraper(cfg, browser, client), buildBoardScrapers(cfg, client)...)
+}
+
+// buildRenderScraper creates the scraper of career pages, rendering each in
+// the browser or downloading it as its source or RenderMode says
+func buildRenderScraper(cfg *config.Config, browser *scraper.GoRodScraper, client *http.Client) *scraper.RenderScraper {
+	modes := make(map[string]string)
+	for _, source := range cfg.Sources {
+		if source.Render != "" {
+			modes[source.URL] = source.Render
+		}
+	}
+	return scraper.NewRenderScraper(browser, scraper.NewHTTPScraper(scraper.NewHTTPFetcher(client), browser), modes, cfg.RenderMode)
+}
+
+// buildProxyScraper pins the proxy of each scrape of next, the source's proxy
+// or else the next of HTTPProxies, or returns next if no proxies are
+// configured
+func buildProxyScraper(cfg *config.Config, next ports.Scraper) ports.Scraper {
+	proxies := make(map[string]*url.URL)
+	for _, source := range cfg.Sources {
+		if source.Proxy == "" {
+			continue
+		}
+		parsed, err := httpclient.ParseProxies([]string{source.Proxy})
+		if err != nil {
+			log.Printf("Not using the proxy of %s: %v", source.URL, err)
+			continue
+		}
+		proxies[source.URL] = parsed[0]
+	}
+	rotation, err := httpclient.ParseProxies(cfg.HTTPProxies)
+	if err != nil {
+		log.Printf("Not rotating proxies: %v", err)
+		rotation = nil
+	}
+	if len(proxies) == 0 && len(rotation) == 0 {
+		return next
+	}
+	return scraper.NewProxyScraper(next, proxies, rotation)
+}
+
+// buildRetryScraper repeats the transiently failing scrapes of next, or
+// returns next if ScrapeRetries is 0
+func buildRetryScraper(cfg *config.Config, next ports.Scraper) ports.Scraper {
+	if cfg.ScrapeRetries <= 0 {
+		return next
+	}
+	return scraper.NewRetryScraper(next, scraper.RetryPolicy{
+		MaxRetries: cfg.ScrapeRetries,
+		Backoff:    cfg.ScrapeRetryBackoff,
+		MaxBackoff: cfg.ScrapeRetryMaxBackoff,
+	})
+}
+
+// buildEnricher creates the enricher reading the detail pages of new jobs
+func buildEnricher(client *http.Client) ports.JobEnricher {
+	return scraper.NewDetailEnricher(scraper.NewHTTPFetcher(client))
+}
+
+// buildSitemapScraper creates the scraper of the sources with a sitemap
+func buildSitemapScraper(cfg *config.Config, client *http.Client) *scraper.SitemapScraper {
+	sources := make(map[string]scraper.SitemapSource)
+	for _, source := range cfg.Sources {
+		if source.Sitemap == "" {
+			continue
+		}
+		sitemap := scraper.SitemapSource{Sitemap: source.Sitemap, MaxPages: source.SitemapMaxPages}
+		if source.SitemapPattern != "" {
+			sitemap.Pattern = regexp.MustCompile(source.SitemapPattern) // checked by config validation
+		}
+		sources[source.URL] = sitemap
+	}
+	return scraper.NewSitemapScraper(client, sources)
+}
+
+// buildSearchScraper creates the scraper of the saved job board searches
+func buildSearchScraper(cfg *config.Config, client *http.Client) *scraper.SearchScraper {
+	maxPages := make(map[string]int)
+	for _, search := range cfg.Searches {
+		if search.MaxPages > 0 {
+			maxPages[search.URL()] = search.MaxPages
+		}
+	}
+	return scraper.NewSearchScraper(client, maxPages)
+}
+
+// buildPDFScraper creates the scraper of PDF listings, with the listing
+// rules of the sources configuring them
+func buildPDFScraper(cfg *config.Config, client *http.Client) *scraper.PDFScraper {
+	sources := make(map[string]scraper.PDFSource)
+	for _, source := range cfg.Sources {
+		var pdf scraper.PDFSource
+		if source.PDFPattern != "" {
+			pdf.Pattern = regexp.MustCompile(source.PDFPattern) // checked by config validation
+		}
+		if source.PDFSeparator != "" {
+			pdf.Separator = regexp.MustCompile(source.PDFSeparator)
+		}
+		if pdf.Pattern != nil || pdf.Separator != nil {
+			sources[source.URL] = pdf
+		}
+	}
+	return scraper.NewPDFScraper(client, cfg.PDFTextCommand, sources)
+}
+
+// buildPluginScraper creates the scraper of the sources configured with a
+// plugin
+func buildPluginScraper(cfg *config.Config, client *http.Client) *scraper.PluginScraper {
+	plugins := make(map[string]scraper.Plugin, len(cfg.Plugins))
+	for _, plugin := range cfg.Plugins {
+		plugins[plugin.Name] = scraper.Plugin{
+			Name:     plugin.Name,
+			Command:  plugin.Command,
+			Endpoint: plugin.Endpoint,
+			Timeout:  plugin.Timeout,
+		}
+	}
+	sources := make(map[string]scraper.Plugin)
+	for _, source := range cfg.Sources {
+		if source.Plugin != "" {
+			sources[source.URL] = plugins[source.Plugin] // checked by config validation
+		}
+	}
+	return scraper.NewPluginScraper(client, sources)
+}
+
+// buildScraperOptions returns the options of the scraper: custom fields,
+// configured selectors, scrolling, screenshots, sign-ins, the remote browser
+// and the profiles of the selector registry, if one is configured. The registry is returned too so it
+// can be refreshed.
+func buildScraperOptions(cfg *config.Config, client *http.Client) ([]scraper.GoRodOption, *scraper.SelectorRegistry) {
+	opts := []scraper.GoRodOption{
+		scraper.WithFields(buildFields(cfg)),
+		scraper.WithSelectors(buildSelectors(cfg)),
+		scraper.WithScrolling(cfg.ScrollMax, cfg.ScrollIdle),
+	}
+	if cfg.Screenshots {
+		opts = append(opts, scraper.WithScreenshots())
+	}
+	if auth := buildAuth(cfg); len(auth) > 0 {
+		opts = append(opts, scraper.WithAuth(auth))
+	}
+	if rules := buildInterception(cfg); len(rules) > 0 {
+		opts = append(opts, scraper.WithInterception(rules))
+	}
+	if cfg.BrowserURL != "" {
+		opts = append(opts, scraper.WithBrowserURL(cfg.BrowserURL))
+	}
+	if cfg.SelectorRegistryURL == "" {
+		return opts, nil
+	}
+
+	key, err := cfg.SelectorRegistryPublicKey()
+	if err != nil {
+		log.Printf("Not using the selector registry: %v", err)
+		return opts, nil
+	}
+	registry := scraper.NewSelectorRegistry(client, cfg.SelectorRegistryURL, key, cfg.SelectorRegistryPin, cfg.SelectorCacheFile)
+	if err := registry.Load(context.Background()); err != nil {
+		log.Printf("No selector profiles available yet: %v", err)
+	}
+	return append(opts, scraper.WithSelectorProfiles(registry)), registry
+}
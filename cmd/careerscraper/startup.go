@@ -0,0 +1,78 @@
+// cmd/careerscraper/startup.go
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/scheduler"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/services"
+)
+
+// shouldRunAtStartup decides whether to scrape right away according to
+// StartupRun: "always", "off", or "if-stale" when any URL's last scrape is
+// older than StartupStaleAfter (one scrape interval by default)
+func shouldRunAtStartup(cfg *config.Config, service *services.CareerScraperService) bool {
+	switch cfg.StartupRun {
+	case config.StartupRunOff:
+		log.Println("Startup run disabled, waiting for the first scheduled run")
+		return false
+
+	case config.StartupRunIfStale:
+		staleAfter := cfg.StartupStaleAfter
+		if staleAfter <= 0 {
+			interval, err := scheduler.Interval(cfg.ScrapeInterval)
+			if err != nil {
+				log.Printf("Cannot determine scrape interval, running startup scrape: %v", err)
+				return true
+			}
+			staleAfter = interval
+		}
+
+		oldest, err := service.OldestScrapeAt(context.Background())
+		if err != nil {
+			log.Printf("Cannot determine last scrape time, running startup scrape: %v", err)
+			return true
+		}
+		if oldest.IsZero() {
+			log.Println("Some URLs were never scraped, running startup scrape")
+			return true
+		}
+		if age := time.Since(oldest); age < staleAfter {
+			log.Printf("Last scrape was %s ago (stale after %s), skipping startup run", age.Round(time.Second), staleAfter)
+			return false
+		}
+		log.Printf("Last scrape is older than %s, running startup scrape", staleAfter)
+		return true
+
+	default:
+		return true
+	}
+}
+
+// runInitialScrape runs the scrape at startup, bounded by JobTimeout and the
+// leadership of election, if any, like the scheduled runs
+func runInitialScrape(cfg *config.Config, service *services.CareerScraperService, election *services.LeaderElection) {
+	runScrape(cfg, leading(election, service.ScrapeAndNotify), "Initial")
+}
+
+// runScrape runs a scrape outside of the schedule, bounded by JobTimeout;
+// kind names the run in the log, e.g. "Initial"
+func runScrape(cfg *config.Config, scrape func(context.Context) error, kind string) {
+	ctx := context.Background()
+	if cfg.JobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.JobTimeout)
+		defer cancel()
+	}
+	err := scrape(ctx)
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		log.Printf("%s scrape job timed out after %s", kind, cfg.JobTimeout)
+	case err != nil:
+		log.Printf("%s scrape job failed: %v", kind, err)
+	}
+}
@@ -0,0 +1,228 @@
+// cmd/careerscraper/tail.go
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/stream"
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// reconnectDelay is how long a followed stream waits before reconnecting
+const reconnectDelay = 2 * time.Second
+
+// streamEvent is an event as received from a stream, its data left raw
+type streamEvent struct {
+	ID   int64           `json:"id"`
+	Type string          `json:"type"`
+	Time time.Time       `json:"time"`
+	Data json.RawMessage `json:"data"`
+}
+
+// runLogs prints the recent log lines of the running daemon, following new
+// ones with -f
+func runLogs(args []string) int {
+	return runTail("logs", "/api/logs/stream", args, printLogEvent)
+}
+
+// runEvents prints the recent job events of the running daemon, following
+// new ones with -f
+func runEvents(args []string) int {
+	return runTail("events", "/api/events/stream", args, printJobEvent)
+}
+
+// runTail reads a stream of the daemon's API and prints its events
+func runTail(name, path string, args []string, print func(streamEvent) error) int {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	follow := flags.Bool("f", false, "keep streaming new entries")
+	server := flags.String("server", "", "base URL of the daemon's API (from ServerAddr if empty)")
+	types := flags.String("type", "", "comma separated event types to show, e.g. job.created")
+	asJSON := flags.Bool("json", false, "print entries as JSON lines")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	base := *server
+	if base == "" {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+			return 1
+		}
+		if cfg.ServerAddr == "" {
+			fmt.Fprintln(os.Stderr, "The daemon serves no API, set ServerAddr or pass --server")
+			return 1
+		}
+		base = serverBaseURL(cfg.ServerAddr)
+	}
+
+	query := url.Values{"follow": {strconv.FormatBool(*follow)}}
+	if *types != "" {
+		query.Set("type", *types)
+	}
+	target := strings.TrimSuffix(base, "/") + path + "?" + query.Encode()
+	if *asJSON {
+		print = printRawEvent
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var lastID int64
+	connected := false
+	for {
+		err := readStream(ctx, target, lastID, &connected, func(event streamEvent) error {
+			lastID = event.ID
+			return print(event)
+		})
+		switch {
+		case ctx.Err() != nil:
+			return 0
+		case !*follow && err == nil:
+			return 0
+		case !*follow || !connected:
+			// Give up if the daemon can't be reached at all
+			fmt.Fprintf(os.Stderr, "Failed to read %s: %v\n", name, err)
+			return 1
+		}
+
+		fmt.Fprintf(os.Stderr, "Connection to the daemon lost, reconnecting: %v\n", err)
+		select {
+		case <-ctx.Done():
+			return 0
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// errStreamClosed is returned when the daemon ends a followed stream
+var errStreamClosed = errors.New("stream closed by the daemon")
+
+// readStream reads server-sent events from target, resuming after lastID.
+// connected is set once the daemon accepted the stream.
+func readStream(ctx context.Context, target string, lastID int64, connected *bool, handle func(streamEvent) error) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if lastID > 0 {
+		req.Header.Set("Last-Event-ID", strconv.FormatInt(lastID, 10))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var body struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return fmt.Errorf("daemon returned status %d: %s", resp.StatusCode, body.Error)
+	}
+	*connected = true
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case line == "" && data.Len() > 0:
+			var event streamEvent
+			if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+				return fmt.Errorf("malformed event: %w", err)
+			}
+			data.Reset()
+			if err := handle(event); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if strings.Contains(target, "follow=true") {
+		return errStreamClosed
+	}
+	return nil
+}
+
+// serverBaseURL turns a listen address such as :8080 into a URL to reach it
+func serverBaseURL(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "http://" + addr
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
+
+// printLogEvent prints a log line
+func printLogEvent(event streamEvent) error {
+	var entry stream.LogEntry
+	if err := json.Unmarshal(event.Data, &entry); err != nil {
+		return fmt.Errorf("malformed log entry: %w", err)
+	}
+	fmt.Printf("%s %s\n", entry.Time.Format("2006-01-02 15:04:05"), entry.Message)
+	return nil
+}
+
+// printJobEvent prints a job event on one line
+func printJobEvent(event streamEvent) error {
+	var jobEvent domain.JobEvent
+	if err := json.Unmarshal(event.Data, &jobEvent); err != nil {
+		return fmt.Errorf("malformed job event: %w", err)
+	}
+	job := jobEvent.After
+	if job == nil {
+		job = jobEvent.Before
+	}
+	if job == nil {
+		job = &domain.Job{ID: jobEvent.JobID}
+	}
+
+	line := fmt.Sprintf("%s %-11s %s  %s", event.Time.Format("15:04:05"), jobEvent.Type, jobEvent.CompanyName, job.Title)
+	if job.Location != "" {
+		line += " (" + job.Location + ")"
+	}
+	if len(jobEvent.Watches) > 0 {
+		line += " [" + strings.Join(jobEvent.Watches, ", ") + "]"
+	}
+	if job.URL != "" {
+		line += "  " + job.URL
+	}
+	fmt.Println(line)
+	return nil
+}
+
+// printRawEvent prints an event as a JSON line
+func printRawEvent(event streamEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
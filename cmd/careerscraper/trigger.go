@@ -0,0 +1,78 @@
+// cmd/careerscraper/trigger.go
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"os/signal"
+	"sync/atomic"
+
+	"github.com/fuzztobread/job-scheduler/internal/config"
+	"github.com/fuzztobread/job-scheduler/internal/core/services"
+)
+
+// scrapeTrigger runs a scrape of all URLs on demand, between the ticks of
+// the schedule, e.g. to try a configuration change or catch up after downtime.
+// It scrapes the URLs the schedule backs off from too.
+type scrapeTrigger struct {
+	cfg      *config.Config
+	service  *services.CareerScraperService
+	election *services.LeaderElection // nil if every instance runs scrapes
+	running  atomic.Bool
+}
+
+// errScrapeRunning is returned by Trigger while a scrape is running
+var errScrapeRunning = errors.New("a scrape is already running")
+
+// newScrapeTrigger creates a trigger of the scrapes of service, which only
+// start while this instance leads election, if there is one
+func newScrapeTrigger(cfg *config.Config, service *services.CareerScraperService, election *services.LeaderElection) *scrapeTrigger {
+	return &scrapeTrigger{cfg: cfg, service: service, election: election}
+}
+
+// Trigger starts a scrape in the background, bounded by JobTimeout and the
+// leadership of the instance like the scheduled runs. It returns an error
+// without starting one while a scrape is already running, so repeated
+// triggers don't pile up, or while this instance stands by.
+func (t *scrapeTrigger) Trigger() error {
+	if t.election != nil && !t.election.IsLeader() {
+		return errNotLeader
+	}
+	if !t.running.CompareAndSwap(false, true) {
+		return errScrapeRunning
+	}
+	if _, active := t.service.RunCounts(); active > 0 {
+		t.running.Store(false)
+		return errScrapeRunning
+	}
+	go func() {
+		defer t.running.Store(false)
+		runScrape(t.cfg, leading(t.election, t.service.ScrapeAllNow), "Triggered")
+	}()
+	return nil
+}
+
+// listen triggers a scrape on each of the trigger signals, SIGUSR1 where
+// the platform has it, until ctx is done
+func (t *scrapeTrigger) listen(ctx context.Context) {
+	if len(triggerSignals) == 0 {
+		return
+	}
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, triggerSignals...)
+	defer signal.Stop(sigCh)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sig := <-sigCh:
+			if err := t.Trigger(); err != nil {
+				log.Printf("Received %s, but %v", sig, err)
+			} else {
+				log.Printf("Received %s, starting a scrape", sig)
+			}
+		}
+	}
+}
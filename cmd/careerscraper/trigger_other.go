@@ -0,0 +1,11 @@
+// cmd/careerscraper/trigger_other.go
+
+//go:build !unix
+
+package main
+
+import "os"
+
+// triggerSignals is empty on platforms without SIGUSR1; scrapes are
+// triggered over the HTTP API only
+var triggerSignals []os.Signal
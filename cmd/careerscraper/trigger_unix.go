@@ -0,0 +1,13 @@
+// cmd/careerscraper/trigger_unix.go
+
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// triggerSignals trigger a scrape, see scrapeTrigger
+var triggerSignals = []os.Signal{syscall.SIGUSR1}
@@ -0,0 +1,98 @@
+// internal/adapters/httpapi/audit.go
+package httpapi
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// WithAdministrators lets the administrators in tokens, name -> bearer
+// token, read the audit trail with GET /api/audit and change snoozes. Their
+// names identify them in the audit log and on the snoozes they create.
+func WithAdministrators(tokens map[string]string) ServerOption {
+	return func(s *Server) {
+		s.admins = tokens
+	}
+}
+
+// handleAudit returns the audit trail, newest first. The actor, action,
+// target, since and limit parameters narrow the result, e.g.
+// ?action=source.delete&since=168h for the sources deleted in the last week.
+func (s *Server) handleAudit(w http.ResponseWriter, r *http.Request) {
+	if s.audit == nil || len(s.admins) == 0 {
+		writeError(w, http.StatusNotFound, "the audit trail is not served")
+		return
+	}
+	if _, ok := s.administrator(r); !ok {
+		writeError(w, http.StatusUnauthorized, "invalid or missing bearer token")
+		return
+	}
+	query := r.URL.Query()
+	filter := domain.AuditFilter{
+		Actor:  query.Get("actor"),
+		Action: query.Get("action"),
+		Target: query.Get("target"),
+	}
+	var err error
+	if filter.Limit, err = parseLimit(query.Get("limit")); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.Since, err = parseSince(query.Get("since")); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entries, err := s.audit.QueryAudit(r.Context(), filter)
+	if err != nil {
+		log.Printf("Failed to query audit log: %v", err)
+		writeError(w, http.StatusInternalServerError, "failed to query audit log")
+		return
+	}
+	if entries == nil {
+		entries = []domain.AuditEntry{}
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// administrator returns the name of the administrator whose token the
+// request carries as a bearer token
+func (s *Server) administrator(r *http.Request) (string, bool) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return "", false
+	}
+	for name, key := range s.admins {
+		if key != "" && subtle.ConstantTimeCompare([]byte(token), []byte(key)) == 1 {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// appendAudit records a change made through the API in the audit log, if the
+// repository keeps one. A failure is logged, the change stands.
+func (s *Server) appendAudit(ctx context.Context, entry domain.AuditEntry) {
+	if s.audit == nil {
+		return
+	}
+	if err := s.audit.AppendAudit(ctx, entry); err != nil {
+		log.Printf("Failed to record audit entry of %s: %v", entry.Action, err)
+	}
+}
+
+// clientActor identifies the client holding a shared token, e.g. the batch
+// token, for the audit log by the token and the address it called from
+func clientActor(token string, r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return token + "@" + host
+}
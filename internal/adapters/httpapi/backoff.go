@@ -0,0 +1,27 @@
+// internal/adapters/httpapi/backoff.go
+package httpapi
+
+import (
+	"net/http"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// WithBackoffs serves the URLs the scheduled runs back off from after they
+// failed repeatedly, as returned by backoffs, with GET /api/backoff
+func WithBackoffs(backoffs func() []domain.SourceBackoff) ServerOption {
+	return func(s *Server) {
+		s.backoffs = backoffs
+	}
+}
+
+// handleBackoffs lists the URLs backed off from, with their consecutive
+// failures and the scheduled runs they still sit out. A URL not listed is
+// scraped on every run.
+func (s *Server) handleBackoffs(w http.ResponseWriter, r *http.Request) {
+	if s.backoffs == nil {
+		writeError(w, http.StatusNotFound, "failing URLs are not backed off")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.backoffs())
+}
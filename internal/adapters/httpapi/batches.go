@@ -0,0 +1,133 @@
+// internal/adapters/httpapi/batches.go
+package httpapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+const (
+	// maxBatchRequest bounds the body of a batch of submitted collections
+	maxBatchRequest = 32 << 20
+
+	// maxBatchCollections bounds the number of collections of a batch
+	maxBatchCollections = 100
+)
+
+// WithJobBatches accepts job collections from trusted external collectors,
+// e.g. a scraper running elsewhere, and runs them through processor. Requests
+// must carry token as a bearer token.
+func WithJobBatches(processor ports.CollectionProcessor, token string) ServerOption {
+	return func(s *Server) {
+		s.batches = processor
+		s.batchKey = token
+	}
+}
+
+// batchRequest is the body of a batch submission
+type batchRequest struct {
+	Collections []batchCollection `json:"collections"`
+}
+
+// batchCollection is a submitted job collection. ScrapedAt defaults to the
+// time the batch is received.
+type batchCollection struct {
+	CompanyName string       `json:"company_name"`
+	SourceURL   string       `json:"source_url"`
+	ScrapedAt   time.Time    `json:"scraped_at"`
+	Jobs        []domain.Job `json:"jobs"`
+}
+
+// handleJobBatch runs the submitted collections through the diff and notify
+// pipeline and returns the outcome of each
+func (s *Server) handleJobBatch(w http.ResponseWriter, r *http.Request) {
+	if s.batches == nil || s.batchKey == "" {
+		writeError(w, http.StatusNotFound, "job batches are not enabled")
+		return
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.batchKey)) != 1 {
+		writeError(w, http.StatusUnauthorized, "invalid or missing bearer token")
+		return
+	}
+
+	var req batchRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchRequest)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid batch request: "+err.Error())
+		return
+	}
+	if len(req.Collections) == 0 {
+		writeError(w, http.StatusBadRequest, "collections are required")
+		return
+	}
+	if len(req.Collections) > maxBatchCollections {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("at most %d collections can be submitted at once", maxBatchCollections))
+		return
+	}
+
+	now := time.Now()
+	collections := make([]domain.JobCollection, len(req.Collections))
+	for i, submitted := range req.Collections {
+		collection, err := submitted.collection(now)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("collection %d: %v", i, err))
+			return
+		}
+		collections[i] = collection
+	}
+
+	results := s.batches.ProcessCollections(r.Context(), collections)
+	actor := clientActor("batch", r)
+	for i, result := range results {
+		if result.Error != "" || i >= len(collections) {
+			continue
+		}
+		s.appendAudit(r.Context(), domain.NewAuditEntry(actor, "jobs.batch", result.SourceURL, nil,
+			map[string]interface{}{"scraped_at": collections[i].ScrapedAt, "jobs": len(collections[i].Jobs),
+				"new": result.NewJobs, "updated": result.UpdatedJobs, "removed": result.RemovedJobs}))
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+// collection validates a submitted collection and converts it
+func (c batchCollection) collection(receivedAt time.Time) (domain.JobCollection, error) {
+	u, err := url.Parse(c.SourceURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return domain.JobCollection{}, fmt.Errorf("source_url must be an absolute http(s) URL")
+	}
+
+	scrapedAt := c.ScrapedAt
+	if scrapedAt.IsZero() {
+		scrapedAt = receivedAt
+	}
+	ids := make(map[string]bool, len(c.Jobs))
+	jobs := make([]domain.Job, len(c.Jobs))
+	for i, job := range c.Jobs {
+		if job.ID == "" {
+			return domain.JobCollection{}, fmt.Errorf("job %d has no id", i)
+		}
+		if ids[job.ID] {
+			return domain.JobCollection{}, fmt.Errorf("job id %q is not unique", job.ID)
+		}
+		ids[job.ID] = true
+		if job.ScrapedAt.IsZero() {
+			job.ScrapedAt = scrapedAt
+		}
+		jobs[i] = job
+	}
+
+	return domain.JobCollection{
+		CompanyName: c.CompanyName,
+		SourceURL:   c.SourceURL,
+		ScrapedAt:   scrapedAt,
+		Jobs:        jobs,
+	}, nil
+}
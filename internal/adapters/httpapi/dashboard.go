@@ -0,0 +1,124 @@
+// internal/adapters/httpapi/dashboard.go
+package httpapi
+
+import (
+	"embed"
+	"html/template"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+//go:embed templates/*.html
+var templates embed.FS
+
+// dashboardTemplate renders the dashboard page
+var dashboardTemplate = template.Must(template.ParseFS(templates, "templates/dashboard.html"))
+
+// capturesTemplate renders the page of captured notifications
+var capturesTemplate = template.Must(template.ParseFS(templates, "templates/captures.html"))
+
+// dashboardPage is the data of the dashboard template
+type dashboardPage struct {
+	URLs          []string
+	URL           string
+	Date          string // selected day
+	Oldest        string // first day with a snapshot or a job sighting
+	Today         string
+	Days          int // days between Oldest and Today, the slider range
+	Day           int // slider position of Date
+	Found         bool
+	Snapshot      domain.JobCollection
+	Reconstructed bool // Snapshot was reconstructed from job sightings, see domain.ListedAt
+	Error         string
+	Captures      bool // link to the captured notifications
+}
+
+// handleDashboard renders the job list of a source on a selected day. A date
+// slider moves through the days covered by the source's snapshots and, before
+// the oldest one retained, by the sightings of its jobs.
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	page := dashboardPage{
+		URLs:     s.urls,
+		URL:      r.URL.Query().Get("url"),
+		Date:     r.URL.Query().Get("at"),
+		Today:    today.Format(dateLayout),
+		Captures: s.captures != nil,
+	}
+	if page.URL == "" && len(s.urls) > 0 {
+		page.URL = s.urls[0]
+	}
+	if page.Date == "" {
+		page.Date = page.Today
+	}
+
+	if page.URL != "" {
+		s.loadDashboardJobs(r, &page, today)
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := dashboardTemplate.Execute(w, page); err != nil {
+		log.Printf("Failed to render dashboard: %v", err)
+	}
+}
+
+// loadDashboardJobs fills the page with the jobs of its source on its day
+func (s *Server) loadDashboardJobs(r *http.Request, page *dashboardPage, today time.Time) {
+	day, err := time.Parse(dateLayout, page.Date)
+	if err != nil {
+		page.Error = "Invalid date " + page.Date
+		return
+	}
+
+	snapshots, err := s.repo.ListSnapshots(r.Context(), page.URL, 0)
+	if err != nil {
+		log.Printf("Failed to list snapshots of %s: %v", page.URL, err)
+		page.Error = "Failed to load the history of " + page.URL
+		return
+	}
+	if len(snapshots) == 0 {
+		page.Error = "No snapshots of " + page.URL + " yet"
+		return
+	}
+
+	oldest := snapshots[len(snapshots)-1].ScrapedAt
+	if first := snapshots[0].FirstSighting(); first.Before(oldest) {
+		oldest = first
+	}
+	oldest = oldest.UTC().Truncate(24 * time.Hour)
+	page.Oldest = oldest.Format(dateLayout)
+	page.Days = int(today.Sub(oldest).Hours() / 24)
+	page.Day = min(max(int(day.Sub(oldest).Hours()/24), 0), page.Days)
+
+	page.Snapshot, page.Reconstructed, page.Found = collectionAt(snapshots, day.Add(24*time.Hour-time.Nanosecond))
+}
+
+// capturesPage is the data of the captures template
+type capturesPage struct {
+	Captures []domain.CapturedNotification
+	Error    string
+}
+
+// handleCapturesPage renders the latest notifications captured instead of sent
+func (s *Server) handleCapturesPage(w http.ResponseWriter, r *http.Request) {
+	if s.captures == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var page capturesPage
+	captures, err := s.captures.ListCaptures(r.Context(), defaultCaptureLimit)
+	if err != nil {
+		log.Printf("Failed to list captured notifications: %v", err)
+		page.Error = "Failed to load the captured notifications"
+	}
+	page.Captures = captures
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := capturesTemplate.Execute(w, page); err != nil {
+		log.Printf("Failed to render captured notifications: %v", err)
+	}
+}
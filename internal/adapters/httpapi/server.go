@@ -0,0 +1,289 @@
+// internal/adapters/httpapi/server.go
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/stream"
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+	"github.com/fuzztobread/job-scheduler/internal/core/query"
+)
+
+// dateLayout is the day format accepted and shown by the dashboard
+const dateLayout = "2006-01-02"
+
+// defaultCaptureLimit is the number of captured notifications listed by default
+const defaultCaptureLimit = 50
+
+// Server serves the dashboard and the JSON API over the job repository
+type Server struct {
+	repo       ports.JobRepository
+	captures   ports.CaptureStore            // nil if the repository doesn't keep captured notifications
+	history    ports.NotificationHistory     // nil if the repository doesn't keep sent notifications
+	audit      ports.AuditLog                // nil if the repository doesn't keep an audit log
+	admins     map[string]string             // administrator name -> bearer token
+	logs       *stream.Hub                   // nil if log lines are not streamed
+	events     *stream.Hub                   // nil if job events are not streamed
+	summaries  string                        // directory of summary pages, empty if they are not served
+	snoozes    ports.SnoozeStore             // nil if snoozes are not served
+	batches    ports.CollectionProcessor     // nil if collections can't be submitted
+	batchKey   string                        // bearer token required to submit collections
+	trigger    func() error                  // nil if scrapes can't be triggered
+	triggerKey string                        // bearer token required to trigger a scrape
+	backoffs   func() []domain.SourceBackoff // nil if failing URLs are not backed off
+	urls       []string
+	mux        *http.ServeMux
+}
+
+// NewServer creates a server for the given repository and configured source URLs
+func NewServer(repo ports.JobRepository, urls []string, opts ...ServerOption) *Server {
+	s := &Server{
+		repo: repo,
+		urls: urls,
+		mux:  http.NewServeMux(),
+	}
+	s.captures, _ = ports.Optional[ports.CaptureStore](repo)
+	s.history, _ = ports.Optional[ports.NotificationHistory](repo)
+	s.audit, _ = ports.Optional[ports.AuditLog](repo)
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.mux.HandleFunc("GET /{$}", s.handleDashboard)
+	s.mux.HandleFunc("GET /notifications", s.handleCapturesPage)
+	s.mux.HandleFunc("GET /api/jobs", s.handleJobs)
+	s.mux.HandleFunc("GET /api/notifications", s.handleCaptures)
+	s.mux.HandleFunc("GET /api/notification-history", s.handleNotificationHistory)
+	s.mux.HandleFunc("GET /api/logs/stream", s.handleLogStream)
+	s.mux.HandleFunc("GET /api/events/stream", s.handleEventStream)
+	s.mux.HandleFunc("GET /summaries/{name}", s.handleSummary)
+	s.mux.HandleFunc("GET /api/snoozes", s.handleSnoozes)
+	s.mux.HandleFunc("POST /api/snoozes", s.handleCreateSnooze)
+	s.mux.HandleFunc("DELETE /api/snoozes/{id}", s.handleDeleteSnooze)
+	s.mux.HandleFunc("POST /api/jobs/batch", s.handleJobBatch)
+	s.mux.HandleFunc("POST /api/scrape", s.handleTriggerScrape)
+	s.mux.HandleFunc("GET /api/backoff", s.handleBackoffs)
+	s.mux.HandleFunc("GET /api/audit", s.handleAudit)
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// jobsResponse is the job list of a source at a point in time
+type jobsResponse struct {
+	SourceURL     string       `json:"source_url"`
+	CompanyName   string       `json:"company_name"`
+	At            time.Time    `json:"at"`
+	ScrapedAt     time.Time    `json:"scraped_at"`              // snapshot the list was taken from
+	Reconstructed bool         `json:"reconstructed,omitempty"` // reconstructed from the sightings of the snapshot, see domain.ListedAt
+	Query         string       `json:"query,omitempty"`         // the jobs are those matching it
+	Jobs          []domain.Job `json:"jobs"`
+}
+
+// handleJobs returns the jobs of a source as listed at the time given by the
+// at parameter, now if empty. Before the oldest snapshot retained they are
+// reconstructed from job sightings. The q parameter narrows them to the jobs
+// matching a query in the syntax of FilterQuery and watch rules, e.g.
+// ?q=title ~ "go|golang" AND location = "Remote".
+func (s *Server) handleJobs(w http.ResponseWriter, r *http.Request) {
+	url := r.URL.Query().Get("url")
+	if url == "" {
+		writeError(w, http.StatusBadRequest, "url is required")
+		return
+	}
+	at, err := parseAt(r.URL.Query().Get("at"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var q *query.Query
+	if src := r.URL.Query().Get("q"); src != "" {
+		if q, err = query.Parse(src); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	snapshots, err := s.repo.ListSnapshots(r.Context(), url, 0)
+	if err != nil {
+		log.Printf("Failed to list snapshots of %s: %v", url, err)
+		writeError(w, http.StatusInternalServerError, "failed to list snapshots")
+		return
+	}
+	collection, reconstructed, ok := collectionAt(snapshots, at)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no jobs of %s known at or before %s", url, at.Format(time.RFC3339)))
+		return
+	}
+
+	jobs := collection.Jobs
+	if q != nil {
+		jobs = []domain.Job{}
+		for _, job := range collection.Jobs {
+			if q.Match(job) {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, jobsResponse{
+		SourceURL:     url,
+		CompanyName:   collection.CompanyName,
+		At:            at,
+		ScrapedAt:     collection.ScrapedAt,
+		Reconstructed: reconstructed,
+		Query:         r.URL.Query().Get("q"),
+		Jobs:          jobs,
+	})
+}
+
+// collectionAt returns the jobs of a source listed at a point in time from
+// its snapshots, newest first, reconstructing them from the sightings of the
+// newest if every snapshot is newer
+func collectionAt(snapshots []domain.JobCollection, at time.Time) (domain.JobCollection, bool, bool) {
+	if collection, ok := domain.CollectionAt(snapshots, at); ok {
+		return collection, false, true
+	}
+	if len(snapshots) == 0 {
+		return domain.JobCollection{}, false, false
+	}
+	collection, ok := domain.ListedAt(snapshots[0], at)
+	return collection, true, ok
+}
+
+// handleCaptures returns the notifications captured instead of sent, newest
+// first, up to the limit parameter
+func (s *Server) handleCaptures(w http.ResponseWriter, r *http.Request) {
+	if s.captures == nil {
+		writeError(w, http.StatusNotFound, "the repository does not keep captured notifications")
+		return
+	}
+	limit, err := parseLimit(r.URL.Query().Get("limit"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	captures, err := s.captures.ListCaptures(r.Context(), limit)
+	if err != nil {
+		log.Printf("Failed to list captured notifications: %v", err)
+		writeError(w, http.StatusInternalServerError, "failed to list captured notifications")
+		return
+	}
+	if captures == nil {
+		captures = []domain.CapturedNotification{}
+	}
+	writeJSON(w, http.StatusOK, captures)
+}
+
+// handleNotificationHistory returns the sent notifications with their
+// delivery attempts, newest first. The company, source, notifier, since,
+// failed and limit parameters narrow the result, e.g.
+// ?company=Acme&since=168h for those about Acme in the last week.
+func (s *Server) handleNotificationHistory(w http.ResponseWriter, r *http.Request) {
+	if s.history == nil {
+		writeError(w, http.StatusNotFound, "the repository does not keep a notification history")
+		return
+	}
+	query := r.URL.Query()
+	filter := domain.NotificationFilter{
+		Company:   query.Get("company"),
+		SourceURL: query.Get("source"),
+		Notifier:  query.Get("notifier"),
+	}
+	var err error
+	if filter.Limit, err = parseLimit(query.Get("limit")); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if filter.Since, err = parseSince(query.Get("since")); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if value := query.Get("failed"); value != "" {
+		if filter.Failed, err = strconv.ParseBool(value); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("failed must be true or false, got %q", value))
+			return
+		}
+	}
+
+	records, err := s.history.QueryNotifications(r.Context(), filter)
+	if err != nil {
+		log.Printf("Failed to query notification history: %v", err)
+		writeError(w, http.StatusInternalServerError, "failed to query notification history")
+		return
+	}
+	if records == nil {
+		records = []domain.NotificationRecord{}
+	}
+	writeJSON(w, http.StatusOK, records)
+}
+
+// parseLimit parses a positive result limit, defaultCaptureLimit if empty
+func parseLimit(value string) (int, error) {
+	if value == "" {
+		return defaultCaptureLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("limit must be a positive number, got %q", value)
+	}
+	return limit, nil
+}
+
+// parseAt parses a point in time given as a day, meaning its end, or an RFC
+// 3339 time. Empty means now.
+func parseAt(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	if day, err := time.Parse(dateLayout, value); err == nil {
+		return day.Add(24*time.Hour - time.Nanosecond), nil
+	}
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("at must be a date (%s) or an RFC 3339 time, got %q", dateLayout, value)
+	}
+	return at, nil
+}
+
+// parseSince parses the start of a time range given as a duration back from
+// now, a day, meaning its start, or an RFC 3339 time. Empty means no start.
+func parseSince(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return time.Now().Add(-d), nil
+	}
+	if day, err := time.Parse(dateLayout, value); err == nil {
+		return day, nil
+	}
+	since, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("since must be a duration, a date (%s) or an RFC 3339 time, got %q", dateLayout, value)
+	}
+	return since, nil
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
+
+// writeError writes a JSON error response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
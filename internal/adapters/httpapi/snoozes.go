@@ -0,0 +1,137 @@
+// internal/adapters/httpapi/snoozes.go
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// maxSnoozeRequest bounds the body of a snooze request
+const maxSnoozeRequest = 64 << 10
+
+// WithSnoozes serves the snoozes of store, which chat integrations and
+// scripts holding an administrator token (see WithAdministrators) can add and
+// remove to silence sources or jobs
+func WithSnoozes(store ports.SnoozeStore) ServerOption {
+	return func(s *Server) {
+		s.snoozes = store
+	}
+}
+
+// snoozeRequest is the body of a snooze request. The snooze lasts for Days,
+// or until Until if it is set. It is created by the administrator whose
+// token the request carries.
+type snoozeRequest struct {
+	Notifier  string    `json:"notifier"`
+	SourceURL string    `json:"source_url"`
+	JobID     string    `json:"job_id"`
+	Days      float64   `json:"days"`
+	Until     time.Time `json:"until"`
+	Reason    string    `json:"reason"`
+}
+
+// handleSnoozes returns the active snoozes, ending soonest first
+func (s *Server) handleSnoozes(w http.ResponseWriter, r *http.Request) {
+	if s.snoozes == nil {
+		writeError(w, http.StatusNotFound, "snoozes are not enabled")
+		return
+	}
+
+	snoozes, err := s.snoozes.ListSnoozes(r.Context(), time.Now())
+	if err != nil {
+		log.Printf("Failed to list snoozes: %v", err)
+		writeError(w, http.StatusInternalServerError, "failed to list snoozes")
+		return
+	}
+	if snoozes == nil {
+		snoozes = []domain.Snooze{}
+	}
+	writeJSON(w, http.StatusOK, snoozes)
+}
+
+// handleCreateSnooze adds a snooze
+func (s *Server) handleCreateSnooze(w http.ResponseWriter, r *http.Request) {
+	admin, ok := s.authorizeSnoozes(w, r)
+	if !ok {
+		return
+	}
+
+	var req snoozeRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSnoozeRequest)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid snooze request: "+err.Error())
+		return
+	}
+	if req.SourceURL == "" {
+		writeError(w, http.StatusBadRequest, "source_url is required")
+		return
+	}
+	now := time.Now()
+	until := req.Until
+	if until.IsZero() {
+		until = now.Add(time.Duration(req.Days * float64(24*time.Hour)))
+	}
+	if !until.After(now) {
+		writeError(w, http.StatusBadRequest, "days must be positive or until in the future")
+		return
+	}
+
+	snooze, err := s.snoozes.SaveSnooze(r.Context(), domain.Snooze{
+		Notifier:  req.Notifier,
+		SourceURL: req.SourceURL,
+		JobID:     req.JobID,
+		Until:     until,
+		Reason:    req.Reason,
+		CreatedBy: admin,
+		CreatedAt: now,
+	})
+	if err != nil {
+		log.Printf("Failed to save snooze: %v", err)
+		writeError(w, http.StatusInternalServerError, "failed to save snooze")
+		return
+	}
+	s.appendAudit(r.Context(), domain.NewAuditEntry(admin, "snooze.create", snooze.SourceURL, nil, snooze))
+	writeJSON(w, http.StatusCreated, snooze)
+}
+
+// handleDeleteSnooze removes a snooze by ID
+func (s *Server) handleDeleteSnooze(w http.ResponseWriter, r *http.Request) {
+	admin, ok := s.authorizeSnoozes(w, r)
+	if !ok {
+		return
+	}
+
+	id := r.PathValue("id")
+	err := s.snoozes.DeleteSnooze(r.Context(), id)
+	if errors.Is(err, domain.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "no such snooze")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to delete snooze: %v", err)
+		writeError(w, http.StatusInternalServerError, "failed to delete snooze")
+		return
+	}
+	s.appendAudit(r.Context(), domain.NewAuditEntry(admin, "snooze.delete", id, nil, nil))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorizeSnoozes checks that snoozes can be changed by the request,
+// returning the administrator making it, or writes the error response
+func (s *Server) authorizeSnoozes(w http.ResponseWriter, r *http.Request) (string, bool) {
+	if s.snoozes == nil || len(s.admins) == 0 {
+		writeError(w, http.StatusNotFound, "snoozes can't be changed over the API")
+		return "", false
+	}
+	admin, ok := s.administrator(r)
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "invalid or missing bearer token")
+		return "", false
+	}
+	return admin, true
+}
@@ -0,0 +1,139 @@
+// internal/adapters/httpapi/stream.go
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/stream"
+)
+
+// heartbeatInterval is how often an idle stream sends a comment, so proxies
+// and clients don't time the connection out
+const heartbeatInterval = 15 * time.Second
+
+// ServerOption configures optional endpoints of the Server
+type ServerOption func(*Server)
+
+// WithStreams serves the daemon's log lines and job events as server-sent
+// events. Either hub may be nil to leave its stream out.
+func WithStreams(logs, events *stream.Hub) ServerOption {
+	return func(s *Server) {
+		s.logs = logs
+		s.events = events
+	}
+}
+
+// handleLogStream streams the daemon's log lines
+func (s *Server) handleLogStream(w http.ResponseWriter, r *http.Request) {
+	if s.logs == nil {
+		writeError(w, http.StatusNotFound, "the log stream is not enabled")
+		return
+	}
+	s.serveStream(w, r, s.logs)
+}
+
+// handleEventStream streams the job events of every run
+func (s *Server) handleEventStream(w http.ResponseWriter, r *http.Request) {
+	if s.events == nil {
+		writeError(w, http.StatusNotFound, "the event stream is not enabled")
+		return
+	}
+	s.serveStream(w, r, s.events)
+}
+
+// serveStream writes the recent events of a hub as server-sent events, then
+// keeps streaming new ones unless the follow parameter is false. Clients
+// resume with the Last-Event-ID header or the after parameter, and the type
+// parameter keeps only events of the comma separated types.
+func (s *Server) serveStream(w http.ResponseWriter, r *http.Request, hub *stream.Hub) {
+	query := r.URL.Query()
+	follow := true
+	if value := query.Get("follow"); value != "" {
+		var err error
+		if follow, err = strconv.ParseBool(value); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("follow must be true or false, got %q", value))
+			return
+		}
+	}
+	after := r.Header.Get("Last-Event-ID")
+	if value := query.Get("after"); value != "" {
+		after = value
+	}
+	var afterID int64
+	if after != "" {
+		var err error
+		if afterID, err = strconv.ParseInt(after, 10, 64); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("event ID must be a number, got %q", after))
+			return
+		}
+	}
+	types := make(map[string]bool)
+	for _, t := range strings.Split(query.Get("type"), ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types[t] = true
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	backlog, events, cancel := hub.Subscribe(afterID)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	send := func(event stream.Event) error {
+		if len(types) > 0 && !types[event.Type] {
+			return nil
+		}
+		return writeEvent(w, event)
+	}
+	for _, event := range backlog {
+		if err := send(event); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+	if !follow {
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event := <-events:
+			if err := send(event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeEvent writes an event in the server-sent events format
+func writeEvent(w http.ResponseWriter, event stream.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
@@ -0,0 +1,31 @@
+// internal/adapters/httpapi/summaries.go
+package httpapi
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// WithSummaries serves the summary pages of changes too large for a
+// notification, as written by a FileSummaryStore to dir
+func WithSummaries(dir string) ServerOption {
+	return func(s *Server) {
+		s.summaries = dir
+	}
+}
+
+// handleSummary serves a summary page by name. Directories and hidden files,
+// such as the sources of the pages, are not served.
+func (s *Server) handleSummary(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	if s.summaries == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		http.NotFound(w, r)
+		return
+	}
+
+	if filepath.Ext(name) == ".md" {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+	}
+	http.ServeFile(w, r, filepath.Join(s.summaries, name))
+}
@@ -0,0 +1,50 @@
+// internal/adapters/httpapi/trigger.go
+package httpapi
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// WithScrapeTrigger lets clients start a scrape of all URLs right away with
+// POST /api/scrape. trigger starts the scrape in the background, or returns
+// why it can't, e.g. because one is already running or another instance
+// leads. Requests must carry token as a bearer token.
+func WithScrapeTrigger(trigger func() error, token string) ServerOption {
+	return func(s *Server) {
+		s.trigger = trigger
+		s.triggerKey = token
+	}
+}
+
+// triggerResponse is the outcome of a triggered scrape
+type triggerResponse struct {
+	Started bool   `json:"started"`
+	Message string `json:"message"`
+}
+
+// handleTriggerScrape starts a scrape, 202 if it started and 409 if it can't
+// start now
+func (s *Server) handleTriggerScrape(w http.ResponseWriter, r *http.Request) {
+	if s.trigger == nil || s.triggerKey == "" {
+		writeError(w, http.StatusNotFound, "triggered scrapes are not enabled")
+		return
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.triggerKey)) != 1 {
+		writeError(w, http.StatusUnauthorized, "invalid or missing bearer token")
+		return
+	}
+
+	if err := s.trigger(); err != nil {
+		log.Printf("Refused triggered scrape from %s: %v", r.RemoteAddr, err)
+		writeJSON(w, http.StatusConflict, triggerResponse{Message: err.Error()})
+		return
+	}
+	s.appendAudit(r.Context(), domain.NewAuditEntry(clientActor("trigger", r), "scrape.trigger", "all sources", nil, nil))
+	writeJSON(w, http.StatusAccepted, triggerResponse{Started: true, Message: "scrape started"})
+}
@@ -0,0 +1,114 @@
+// internal/adapters/httpclient/client.go
+package httpclient
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Options configures the shared outbound HTTP client
+type Options struct {
+	Timeout             time.Duration // overall deadline of a request, retries included
+	ProxyURL            string        // empty uses the HTTP_PROXY/HTTPS_PROXY environment
+	ProxyURLs           []string      // proxies taking turns with ProxyURL, see WithProxy for per-request proxies
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	MaxRetries          int           // retries after the first attempt, 0 disables retrying
+	RetryBackoff        time.Duration // delay before the first retry, doubled for each further one
+	UserAgent           string        // sent when a request doesn't set its own
+	TLSConfig           *tls.Config
+}
+
+// DefaultOptions returns the options used when nothing is configured
+func DefaultOptions() Options {
+	return Options{
+		Timeout:             10 * time.Second,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+		MaxRetries:          2,
+		RetryBackoff:        500 * time.Millisecond,
+		UserAgent:           "career-scraper",
+	}
+}
+
+// New creates an HTTP client with pooled connections, TLS defaults, optional
+// proxies and retries of transient failures
+func New(opts Options) (*http.Client, error) {
+	var rawProxies []string
+	if opts.ProxyURL != "" {
+		rawProxies = append(rawProxies, opts.ProxyURL)
+	}
+	proxies, err := ParseProxies(append(rawProxies, opts.ProxyURLs...))
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := opts.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	if tlsConfig.MinVersion == 0 {
+		tlsConfig.MinVersion = tls.VersionTLS12
+	}
+
+	var transport http.RoundTripper = &http.Transport{
+		Proxy: proxyFunc(proxies),
+		DialContext: (&net.Dialer{
+			Timeout:   5 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: opts.Timeout,
+		ExpectContinueTimeout: time.Second,
+		MaxIdleConns:          opts.MaxIdleConns,
+		MaxIdleConnsPerHost:   opts.MaxIdleConnsPerHost,
+		IdleConnTimeout:       opts.IdleConnTimeout,
+		ForceAttemptHTTP2:     true,
+	}
+
+	if opts.MaxRetries > 0 {
+		transport = &retryTransport{
+			next:       transport,
+			maxRetries: opts.MaxRetries,
+			backoff:    opts.RetryBackoff,
+		}
+	}
+	if opts.UserAgent != "" {
+		transport = &userAgentTransport{
+			next:      transport,
+			userAgent: opts.UserAgent,
+		}
+	}
+
+	return &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: transport,
+	}, nil
+}
+
+// Default returns a client built from DefaultOptions
+func Default() *http.Client {
+	client, _ := New(DefaultOptions()) // only a bad proxy URL can fail
+	return client
+}
+
+// userAgentTransport sets the User-Agent header on requests that have none
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") != "" {
+		return t.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,262 @@
+// internal/adapters/lease/kubernetes_lease.go
+package lease
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// serviceAccountDir holds the credentials Kubernetes mounts into pods
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// microTime is the timestamp format of the times of a Lease
+const microTime = "2006-01-02T15:04:05.000000Z07:00"
+
+// errNotFound is returned by get for a Lease that doesn't exist yet
+var errNotFound = errors.New("lease not found")
+
+// KubernetesLease implements the Locker interface with Lease objects of the
+// coordination.k8s.io API, taking a lock by writing this instance as the
+// holder of the Lease named after the key. Writes carry the resource version
+// read, so of two instances taking a lock at once only one succeeds. It runs
+// inside the cluster, authenticating as the service account of the pod, which
+// must be allowed to get, create and update leases in the namespace.
+type KubernetesLease struct {
+	client    *http.Client
+	server    string
+	namespace string
+	tokenFile string
+}
+
+// NewKubernetesLease creates a locker of the Leases of namespace, the
+// namespace of the pod if empty
+func NewKubernetesLease(namespace string) (*KubernetesLease, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("not running in a Kubernetes cluster: KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are not set")
+	}
+	if namespace == "" {
+		data, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read namespace of the pod: %w", err)
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("invalid cluster CA certificate")
+	}
+
+	return &KubernetesLease{
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		},
+		server:    "https://" + net.JoinHostPort(host, port),
+		namespace: namespace,
+		tokenFile: filepath.Join(serviceAccountDir, "token"),
+	}, nil
+}
+
+// lease is a Lease object. Its metadata is kept as read, so an update keeps
+// the labels of the Lease and carries its resource version.
+type lease struct {
+	APIVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Metadata   json.RawMessage `json:"metadata"`
+	Spec       leaseSpec       `json:"spec"`
+}
+
+// leaseSpec is the holder of a Lease
+type leaseSpec struct {
+	HolderIdentity       string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int    `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string `json:"acquireTime,omitempty"`
+	RenewTime            string `json:"renewTime,omitempty"`
+	LeaseTransitions     int    `json:"leaseTransitions,omitempty"`
+}
+
+// expired reports whether the holder of a Lease failed to renew it in time
+func (s leaseSpec) expired(now time.Time) bool {
+	renewed, err := time.Parse(time.RFC3339Nano, s.RenewTime)
+	if err != nil {
+		return true
+	}
+	return now.After(renewed.Add(time.Duration(s.LeaseDurationSeconds) * time.Second))
+}
+
+// TryLock makes owner the holder of the Lease key if it is free or expired,
+// or renews it if owner holds it already
+func (l *KubernetesLease) TryLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	spec := leaseSpec{
+		HolderIdentity:       owner,
+		LeaseDurationSeconds: max(int((ttl+time.Second-1)/time.Second), 1),
+		AcquireTime:          now.UTC().Format(microTime),
+		RenewTime:            now.UTC().Format(microTime),
+	}
+
+	current, err := l.get(ctx, key)
+	if errors.Is(err, errNotFound) {
+		metadata, _ := json.Marshal(map[string]string{"name": key})
+		return l.write(ctx, http.MethodPost, l.leasesURL(), lease{
+			APIVersion: "coordination.k8s.io/v1",
+			Kind:       "Lease",
+			Metadata:   metadata,
+			Spec:       spec,
+		})
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+
+	held := current.Spec
+	switch {
+	case held.HolderIdentity == owner:
+		spec.AcquireTime = held.AcquireTime
+		spec.LeaseTransitions = held.LeaseTransitions
+	case held.HolderIdentity != "" && !held.expired(now):
+		return false, nil
+	default:
+		spec.LeaseTransitions = held.LeaseTransitions + 1
+	}
+	current.Spec = spec
+	acquired, err := l.write(ctx, http.MethodPut, l.leaseURL(key), current)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	return acquired, nil
+}
+
+// Unlock clears the holder of the Lease key if owner holds it
+func (l *KubernetesLease) Unlock(ctx context.Context, key, owner string) error {
+	current, err := l.get(ctx, key)
+	if errors.Is(err, errNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", key, err)
+	}
+	if current.Spec.HolderIdentity != owner {
+		return nil
+	}
+	current.Spec.HolderIdentity = ""
+	current.Spec.RenewTime = ""
+	if _, err := l.write(ctx, http.MethodPut, l.leaseURL(key), current); err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", key, err)
+	}
+	return nil
+}
+
+// get reads the Lease name
+func (l *KubernetesLease) get(ctx context.Context, name string) (lease, error) {
+	var current lease
+	resp, err := l.do(ctx, http.MethodGet, l.leaseURL(name), nil)
+	if err != nil {
+		return current, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return current, errNotFound
+	case resp.StatusCode != http.StatusOK:
+		return current, statusError(resp)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&current); err != nil {
+		return current, fmt.Errorf("failed to decode lease: %w", err)
+	}
+	return current, nil
+}
+
+// write creates or updates a Lease, false if another instance wrote it first
+func (l *KubernetesLease) write(ctx context.Context, method, target string, object lease) (bool, error) {
+	body, err := json.Marshal(object)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode lease: %w", err)
+	}
+	resp, err := l.do(ctx, method, target, body)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return true, nil
+	case http.StatusConflict:
+		return false, nil
+	}
+	return false, statusError(resp)
+}
+
+// do sends an authenticated request to the API server. The token is read on
+// every request, since Kubernetes rotates it.
+func (l *KubernetesLease) do(ctx context.Context, method, target string, body []byte) (*http.Response, error) {
+	token, err := os.ReadFile(l.tokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account token: %w", err)
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the Kubernetes API: %w", err)
+	}
+	return resp, nil
+}
+
+// leasesURL returns the URL of the Leases of the namespace
+func (l *KubernetesLease) leasesURL() string {
+	return l.server + "/apis/coordination.k8s.io/v1/namespaces/" + url.PathEscape(l.namespace) + "/leases"
+}
+
+// leaseURL returns the URL of the Lease name
+func (l *KubernetesLease) leaseURL(name string) string {
+	return l.leasesURL() + "/" + url.PathEscape(name)
+}
+
+// statusError describes a failed API request by the message of its Status
+func statusError(resp *http.Response) error {
+	var status struct {
+		Message string `json:"message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if json.Unmarshal(data, &status) == nil && status.Message != "" {
+		return fmt.Errorf("Kubernetes API returned %s: %s", resp.Status, status.Message)
+	}
+	return fmt.Errorf("Kubernetes API returned %s", resp.Status)
+}
+
+var (
+	_ ports.Locker = (*KubernetesLease)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,192 @@
+// internal/adapters/notifier/apprise_notifier.go
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// appriseMaxJobs bounds the number of jobs listed per section of a message
+const appriseMaxJobs = 20
+
+// appriseTypes maps severities to Apprise notification types
+var appriseTypes = map[domain.Severity]string{
+	domain.SeverityInfo:     "info",
+	domain.SeverityHigh:     "success",
+	domain.SeverityCritical: "failure",
+}
+
+// AppriseNotifier implements the Notifier interface for an Apprise API server,
+// which fans notifications out to any of the services Apprise supports
+type AppriseNotifier struct {
+	url    string // e.g. http://apprise:8000/notify/mykey
+	tag    string
+	client *http.Client
+}
+
+// AppriseNotifyRequest represents the body of an Apprise API notify call
+type AppriseNotifyRequest struct {
+	Title  string `json:"title,omitempty"`
+	Body   string `json:"body"`
+	Type   string `json:"type,omitempty"`
+	Format string `json:"format,omitempty"`
+	Tag    string `json:"tag,omitempty"`
+}
+
+// NewAppriseNotifier creates a new AppriseNotifier instance. tag selects which of
+// the URLs stored under the Apprise key are notified; empty notifies all of them.
+func NewAppriseNotifier(url, tag string, client *http.Client) *AppriseNotifier {
+	return &AppriseNotifier{
+		url:    url,
+		tag:    tag,
+		client: client,
+	}
+}
+
+// NotifyNewJobs sends the diff to Apprise as a markdown message
+func (n *AppriseNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffResult) error {
+	if !diff.HasChanges() {
+		return nil
+	}
+
+	return n.send(ctx, AppriseNotifyRequest{
+		Title: fmt.Sprintf("Job updates for %s", diff.CompanyName),
+		Body:  appriseDiffBody(diff),
+		Type:  appriseTypes[diff.Severity],
+	})
+}
+
+// NotifyDigest sends all changes of a run to Apprise as one message
+func (n *AppriseNotifier) NotifyDigest(ctx context.Context, digest domain.Digest) error {
+	var sections []string
+	for _, diff := range digest.Diffs {
+		sections = append(sections, fmt.Sprintf("## [%s](%s)\n%s", diff.CompanyName, diff.SourceURL, appriseDiffBody(diff)))
+	}
+
+	return n.send(ctx, AppriseNotifyRequest{
+		Title: fmt.Sprintf("Job digest: %d new jobs across %d career pages", digest.NewJobCount(), len(digest.Diffs)),
+		Body:  strings.Join(sections, "\n\n"),
+		Type:  appriseTypes[digest.Severity()],
+	})
+}
+
+// Notify sends a standalone notification, such as a scraping error, to Apprise
+func (n *AppriseNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	body := notification.Message
+	if notification.SourceURL != "" {
+		body += fmt.Sprintf("\n\nSource: %s", notification.SourceURL)
+	}
+
+	return n.send(ctx, AppriseNotifyRequest{
+		Title: notification.Title,
+		Body:  body,
+		Type:  appriseTypes[notification.Severity],
+	})
+}
+
+// FitsDiff reports whether a message lists every job of the diff
+func (n *AppriseNotifier) FitsDiff(diff domain.DiffResult) bool {
+	return len(diff.NewJobs) <= appriseMaxJobs && len(diff.UpdatedJobs) <= appriseMaxJobs && len(diff.RemovedJobs) <= appriseMaxJobs
+}
+
+// FitsDigest reports whether a message lists every job of the digest
+func (n *AppriseNotifier) FitsDigest(digest domain.Digest) bool {
+	for _, diff := range digest.Diffs {
+		if !n.FitsDiff(diff) {
+			return false
+		}
+	}
+	return true
+}
+
+// send posts a notify request to the Apprise API
+func (n *AppriseNotifier) send(ctx context.Context, request AppriseNotifyRequest) error {
+	request.Format = "markdown"
+	request.Tag = n.tag
+	if request.Type == "" {
+		request.Type = "info"
+	}
+
+	jsonPayload, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Apprise payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create Apprise request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Apprise notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Apprise returned non-success status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// appriseDiffBody renders a diff as markdown
+func appriseDiffBody(diff domain.DiffResult) string {
+	var b strings.Builder
+	writeJobs := func(heading string, jobs []domain.Job) {
+		if len(jobs) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "**%s (%d)**\n", heading, len(jobs))
+		for i, job := range jobs {
+			if i == appriseMaxJobs {
+				fmt.Fprintf(&b, "- ...and %d more\n", len(jobs)-i)
+				break
+			}
+			if job.URL != "" {
+				fmt.Fprintf(&b, "- [%s](%s)", job.DisplayTitle(), job.URL)
+			} else {
+				fmt.Fprintf(&b, "- %s", job.DisplayTitle())
+			}
+			if job.Location != "" {
+				fmt.Fprintf(&b, " (%s)", job.Location)
+			}
+			if job.Salary != nil {
+				fmt.Fprintf(&b, ", %s", formatSalary(*job.Salary))
+			}
+			if dates := formatDates(job, time.Now()); dates != "" {
+				fmt.Fprintf(&b, ", %s", dates)
+			}
+			if seen := formatListedFor(job, heading == "New Jobs"); seen != "" {
+				fmt.Fprintf(&b, ", %s", seen)
+			}
+			if apply := job.DirectApplyURL(); apply != "" {
+				fmt.Fprintf(&b, " · [Apply](%s)", apply)
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+	}
+
+	writeJobs("New Jobs", diff.NewJobs)
+	writeJobs("Updated Jobs", diff.UpdatedJobs)
+	writeJobs("Removed Jobs", diff.RemovedJobs)
+
+	return strings.TrimSpace(b.String())
+}
+
+var (
+	_ ports.Notifier        = (*AppriseNotifier)(nil) // Ensure interface compliance
+	_ ports.DigestNotifier  = (*AppriseNotifier)(nil)
+	_ ports.MessageNotifier = (*AppriseNotifier)(nil)
+	_ ports.SizeLimited     = (*AppriseNotifier)(nil)
+)
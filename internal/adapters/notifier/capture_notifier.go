@@ -0,0 +1,57 @@
+// internal/adapters/notifier/capture_notifier.go
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// CaptureNotifier implements the Notifier interface by storing would-be
+// notifications instead of sending them, so a staging instance can scrape the
+// production sources without messaging anyone. Captured notifications are
+// shown by the dashboard and the HTTP API.
+type CaptureNotifier struct {
+	store ports.CaptureStore
+}
+
+// NewCaptureNotifier creates a new CaptureNotifier storing into store
+func NewCaptureNotifier(store ports.CaptureStore) *CaptureNotifier {
+	return &CaptureNotifier{store: store}
+}
+
+// NotifyNewJobs captures the diff if it has changes
+func (n *CaptureNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffResult) error {
+	if !diff.HasChanges() {
+		return nil
+	}
+	return n.capture(ctx, domain.CapturedNotification{Kind: domain.CaptureKindJobs, Diff: &diff})
+}
+
+// NotifyDigest captures the digest of a run
+func (n *CaptureNotifier) NotifyDigest(ctx context.Context, digest domain.Digest) error {
+	return n.capture(ctx, domain.CapturedNotification{Kind: domain.CaptureKindDigest, Digest: &digest})
+}
+
+// Notify captures a standalone notification
+func (n *CaptureNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	return n.capture(ctx, domain.CapturedNotification{Kind: domain.CaptureKindMessage, Notification: &notification})
+}
+
+// capture stamps and stores a notification
+func (n *CaptureNotifier) capture(ctx context.Context, capture domain.CapturedNotification) error {
+	capture.CapturedAt = time.Now()
+	if err := n.store.SaveCapture(ctx, capture); err != nil {
+		return fmt.Errorf("failed to capture notification: %w", err)
+	}
+	return nil
+}
+
+var (
+	_ ports.Notifier        = (*CaptureNotifier)(nil) // Ensure interface compliance
+	_ ports.DigestNotifier  = (*CaptureNotifier)(nil)
+	_ ports.MessageNotifier = (*CaptureNotifier)(nil)
+)
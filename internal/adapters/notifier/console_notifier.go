@@ -0,0 +1,179 @@
+// internal/adapters/notifier/console_notifier.go
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// ConsoleNotifier implements the Notifier interface without contacting any
+// external service: it pretty-prints to stdout, or appends JSON lines in the
+// webhook payload format to a file. Meant for developing scrapers and rules.
+type ConsoleNotifier struct {
+	path string // empty prints to stdout
+	out  io.Writer
+	mu   sync.Mutex
+}
+
+// NewConsoleNotifier creates a new ConsoleNotifier. If path is empty or "-"
+// notifications are printed to stdout, otherwise appended to path as JSON lines.
+func NewConsoleNotifier(path string) *ConsoleNotifier {
+	if path == "-" {
+		path = ""
+	}
+	return &ConsoleNotifier{
+		path: path,
+		out:  os.Stdout,
+	}
+}
+
+// NotifyNewJobs prints the diff
+func (n *ConsoleNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffResult) error {
+	if !diff.HasChanges() {
+		return nil
+	}
+
+	if n.path != "" {
+		return n.appendJSON(WebhookPayload{
+			Event:      "jobs_changed",
+			SentAt:     time.Now(),
+			DiffResult: diff,
+		})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== %s (%s) [%s] ===\n", diff.CompanyName, diff.SourceURL, diff.Severity)
+	writeConsoleDiff(&b, diff)
+	return n.print(b.String())
+}
+
+// NotifyDigest prints all changes of a run
+func (n *ConsoleNotifier) NotifyDigest(ctx context.Context, digest domain.Digest) error {
+	if n.path != "" {
+		return n.appendJSON(WebhookDigestPayload{
+			Event:  "digest",
+			SentAt: time.Now(),
+			Digest: digest,
+		})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "=== Digest: %d new jobs across %d career pages [%s] ===\n",
+		digest.NewJobCount(), len(digest.Diffs), digest.Severity())
+	for _, cluster := range digest.Clusters {
+		if len(cluster.Entries) > 1 {
+			fmt.Fprintf(&b, "  %s ×%d\n", cluster.Title, len(cluster.Entries))
+		}
+	}
+	for _, diff := range digest.Diffs {
+		fmt.Fprintf(&b, "--- %s (%s)\n", diff.CompanyName, diff.SourceURL)
+		writeConsoleDiff(&b, diff)
+	}
+	return n.print(b.String())
+}
+
+// Notify prints a standalone notification such as a scraping error
+func (n *ConsoleNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	if n.path != "" {
+		return n.appendJSON(WebhookNotificationPayload{
+			Event:        "notification",
+			SentAt:       time.Now(),
+			Notification: notification,
+		})
+	}
+
+	text := fmt.Sprintf("=== %s [%s] ===\n%s\n", notification.Title, notification.Severity, notification.Message)
+	if notification.SourceURL != "" {
+		text += fmt.Sprintf("Source: %s\n", notification.SourceURL)
+	}
+	return n.print(text)
+}
+
+// print writes text to stdout followed by a blank line
+func (n *ConsoleNotifier) print(text string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if _, err := fmt.Fprintln(n.out, text); err != nil {
+		return fmt.Errorf("failed to print notification: %w", err)
+	}
+	return nil
+}
+
+// appendJSON appends payload to the output file as a single JSON line
+func (n *ConsoleNotifier) appendJSON(payload interface{}) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open console notifier file: %w", err)
+	}
+	defer file.Close()
+
+	if err := json.NewEncoder(file).Encode(payload); err != nil {
+		return fmt.Errorf("failed to write notification: %w", err)
+	}
+	return nil
+}
+
+// writeConsoleDiff renders the jobs of a diff as indented text
+func writeConsoleDiff(b *strings.Builder, diff domain.DiffResult) {
+	for _, alert := range diff.Alerts {
+		fmt.Fprintf(b, "  ! [%s] %s\n", alert.WatchNames(), alert.Job.DisplayTitle())
+	}
+
+	writeJobs := func(marker string, jobs []domain.Job) {
+		for _, job := range jobs {
+			fmt.Fprintf(b, "  %s %s", marker, job.DisplayTitle())
+			var details []string
+			if job.Department != "" {
+				details = append(details, job.Department)
+			}
+			if job.Location != "" {
+				details = append(details, job.Location)
+			}
+			if job.Salary != nil {
+				details = append(details, formatSalary(*job.Salary))
+			}
+			if dates := formatDates(job, time.Now()); dates != "" {
+				details = append(details, dates)
+			}
+			if seen := formatListedFor(job, marker == "+"); seen != "" {
+				details = append(details, seen)
+			}
+			for _, key := range job.MetadataKeys() {
+				details = append(details, key+": "+job.Metadata[key])
+			}
+			if len(details) > 0 {
+				fmt.Fprintf(b, " (%s)", strings.Join(details, " | "))
+			}
+			if job.URL != "" {
+				fmt.Fprintf(b, "\n      %s", job.URL)
+			}
+			if apply := job.DirectApplyURL(); apply != "" {
+				fmt.Fprintf(b, "\n      Apply: %s", apply)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	writeJobs("+", diff.NewJobs)
+	writeJobs("~", diff.UpdatedJobs)
+	writeJobs("-", diff.RemovedJobs)
+}
+
+var (
+	_ ports.Notifier        = (*ConsoleNotifier)(nil) // Ensure interface compliance
+	_ ports.DigestNotifier  = (*ConsoleNotifier)(nil)
+	_ ports.MessageNotifier = (*ConsoleNotifier)(nil)
+)
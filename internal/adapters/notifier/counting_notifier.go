@@ -0,0 +1,78 @@
+// internal/adapters/notifier/counting_notifier.go
+package notifier
+
+import (
+	"context"
+	"sync"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// CountingNotifier wraps a notifier and counts the notifications it delivered
+// and failed to deliver, for the exit report of a session. A digest counts as
+// one notification.
+type CountingNotifier struct {
+	inner  ports.Notifier
+	sent   int
+	failed int
+	mu     sync.Mutex
+}
+
+// NewCountingNotifier creates a new CountingNotifier instance
+func NewCountingNotifier(inner ports.Notifier) *CountingNotifier {
+	return &CountingNotifier{inner: inner}
+}
+
+// NotifyNewJobs delivers the diff and counts it
+func (n *CountingNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffResult) error {
+	if !diff.HasChanges() {
+		return nil
+	}
+	return n.count(n.inner.NotifyNewJobs(ctx, diff))
+}
+
+// NotifyDigest delivers the digest and counts it
+func (n *CountingNotifier) NotifyDigest(ctx context.Context, digest domain.Digest) error {
+	return n.count(notifyDigest(ctx, n.inner, digest))
+}
+
+// Notify delivers a standalone notification and counts it, unless the
+// wrapped notifier doesn't support them
+func (n *CountingNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	if _, ok := n.inner.(ports.MessageNotifier); !ok {
+		return nil
+	}
+	return n.count(notifyMessage(ctx, n.inner, notification))
+}
+
+// Probe probes the wrapped notifier; probes are not counted
+func (n *CountingNotifier) Probe(ctx context.Context) error {
+	return probe(ctx, n.inner)
+}
+
+// Counts returns the number of notifications delivered and failed so far
+func (n *CountingNotifier) Counts() (sent, failed int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.sent, n.failed
+}
+
+// count records the outcome of a delivery
+func (n *CountingNotifier) count(err error) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err != nil {
+		n.failed++
+	} else {
+		n.sent++
+	}
+	return err
+}
+
+var (
+	_ ports.Notifier        = (*CountingNotifier)(nil) // Ensure interface compliance
+	_ ports.DigestNotifier  = (*CountingNotifier)(nil)
+	_ ports.MessageNotifier = (*CountingNotifier)(nil)
+	_ ports.Prober          = (*CountingNotifier)(nil)
+)
@@ -0,0 +1,151 @@
+// internal/adapters/notifier/desktop_notifier.go
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// desktopMaxTitles is the number of job titles listed in a desktop notification
+const desktopMaxTitles = 3
+
+// windowsToastScript shows a toast notification using the WinRT API available
+// in every Windows PowerShell. Title and body are passed via the environment to
+// avoid quoting issues.
+const windowsToastScript = `
+[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName("text")
+$text.Item(0).AppendChild($template.CreateTextNode($env:CAREERSCRAPER_TOAST_TITLE)) > $null
+$text.Item(1).AppendChild($template.CreateTextNode($env:CAREERSCRAPER_TOAST_BODY)) > $null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier("Career Scraper").Show($toast)
+`
+
+// DesktopNotifier implements the Notifier interface with native desktop
+// notifications (notify-send on Linux, osascript on macOS, toasts on Windows)
+type DesktopNotifier struct {
+	goos string
+}
+
+// NewDesktopNotifier creates a new DesktopNotifier for the current platform
+func NewDesktopNotifier() *DesktopNotifier {
+	return &DesktopNotifier{
+		goos: runtime.GOOS,
+	}
+}
+
+// NotifyNewJobs pops a notification when the diff contains new jobs
+func (n *DesktopNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffResult) error {
+	if len(diff.NewJobs) == 0 {
+		return nil
+	}
+
+	title := fmt.Sprintf("%d new jobs at %s", len(diff.NewJobs), diff.CompanyName)
+	if len(diff.NewJobs) == 1 {
+		title = fmt.Sprintf("New job at %s", diff.CompanyName)
+	}
+
+	return n.show(ctx, title, jobTitlesSummary(diff.NewJobs))
+}
+
+// NotifyDigest pops a single notification for all new jobs of a run
+func (n *DesktopNotifier) NotifyDigest(ctx context.Context, digest domain.Digest) error {
+	count := digest.NewJobCount()
+	if count == 0 {
+		return nil
+	}
+
+	var jobs []domain.Job
+	for _, diff := range digest.Diffs {
+		jobs = append(jobs, diff.NewJobs...)
+	}
+
+	title := fmt.Sprintf("%d new jobs on %d career pages", count, len(digest.Diffs))
+	return n.show(ctx, title, jobTitlesSummary(jobs))
+}
+
+// Notify pops a notification for a standalone message such as a scraping error
+func (n *DesktopNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	title := notification.Title
+	if notification.CompanyName != "" {
+		title += " - " + notification.CompanyName
+	}
+	return n.show(ctx, title, notification.Message)
+}
+
+// Probe checks that the platform's notification tool is installed
+func (n *DesktopNotifier) Probe(ctx context.Context) error {
+	tools := map[string]string{
+		"linux":   "notify-send",
+		"freebsd": "notify-send",
+		"openbsd": "notify-send",
+		"netbsd":  "notify-send",
+		"darwin":  "osascript",
+		"windows": "powershell",
+	}
+
+	tool, ok := tools[n.goos]
+	if !ok {
+		return fmt.Errorf("desktop notifications are not supported on %s", n.goos)
+	}
+	if _, err := exec.LookPath(tool); err != nil {
+		return fmt.Errorf("desktop notifier requires %s: %w", tool, err)
+	}
+	return nil
+}
+
+// show displays a notification using the platform's native mechanism
+func (n *DesktopNotifier) show(ctx context.Context, title, body string) error {
+	var cmd *exec.Cmd
+	switch n.goos {
+	case "linux", "freebsd", "openbsd", "netbsd":
+		cmd = exec.CommandContext(ctx, "notify-send", "--app-name=Career Scraper", title, body)
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			title, body)
+	case "windows":
+		cmd = exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", windowsToastScript)
+		cmd.Env = append(os.Environ(),
+			"CAREERSCRAPER_TOAST_TITLE="+title,
+			"CAREERSCRAPER_TOAST_BODY="+body)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", n.goos)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to show desktop notification: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// jobTitlesSummary lists the first few job titles
+func jobTitlesSummary(jobs []domain.Job) string {
+	var titles []string
+	for i, job := range jobs {
+		if i == desktopMaxTitles {
+			titles = append(titles, fmt.Sprintf("and %d more", len(jobs)-i))
+			break
+		}
+		titles = append(titles, job.DisplayTitle())
+	}
+	return strings.Join(titles, "\n")
+}
+
+var (
+	_ ports.Notifier        = (*DesktopNotifier)(nil) // Ensure interface compliance
+	_ ports.DigestNotifier  = (*DesktopNotifier)(nil)
+	_ ports.MessageNotifier = (*DesktopNotifier)(nil)
+	_ ports.Prober          = (*DesktopNotifier)(nil)
+)
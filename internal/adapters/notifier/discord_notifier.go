@@ -6,17 +6,32 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
+	"mime/multipart"
 	"net/http"
 	"strings"
 	"time"
+	"unicode/utf8"
 	
 	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/textutil"
 )
 
 // DiscordNotifier implements the Notifier interface for Discord webhooks
 type DiscordNotifier struct {
-	webhookURL string
-	client     *http.Client
+	webhookURL    string
+	signingSecret string
+	mentions      []DiscordMention
+	screenshots   bool // attach the screenshot of the scrape to change notifications
+	client        *http.Client
+}
+
+// DiscordMention pings a role and/or user when a new job title contains any of
+// the keywords (case-insensitive, whole words)
+type DiscordMention struct {
+	Keywords []string
+	RoleID   string
+	UserID   string
 }
 
 // DiscordEmbed represents a Discord embed object
@@ -51,21 +66,33 @@ type DiscordEmbedFooter struct {
 	IconURL string `json:"icon_url,omitempty"`
 }
 
+// DiscordAllowedMentions restricts which mentions in the content ping
+type DiscordAllowedMentions struct {
+	Parse []string `json:"parse"`
+	Roles []string `json:"roles,omitempty"`
+	Users []string `json:"users,omitempty"`
+}
+
 // DiscordWebhookPayload represents a Discord webhook payload
 type DiscordWebhookPayload struct {
-	Username  string         `json:"username,omitempty"`
-	AvatarURL string         `json:"avatar_url,omitempty"`
-	Content   string         `json:"content,omitempty"`
-	Embeds    []DiscordEmbed `json:"embeds,omitempty"`
+	Username        string                  `json:"username,omitempty"`
+	AvatarURL       string                  `json:"avatar_url,omitempty"`
+	Content         string                  `json:"content,omitempty"`
+	Embeds          []DiscordEmbed          `json:"embeds,omitempty"`
+	AllowedMentions *DiscordAllowedMentions `json:"allowed_mentions,omitempty"`
 }
 
-// NewDiscordNotifier creates a new DiscordNotifier instance
-func NewDiscordNotifier(webhookURL string) *DiscordNotifier {
+// NewDiscordNotifier creates a new DiscordNotifier instance.
+// Discord ignores the X-Signature header, but signing lets relays in front of
+// the webhook verify the message came from the scraper. With screenshots,
+// change notifications carry the screenshot of the scrape, if one was taken.
+func NewDiscordNotifier(webhookURL, signingSecret string, mentions []DiscordMention, screenshots bool, client *http.Client) *DiscordNotifier {
 	return &DiscordNotifier{
-		webhookURL: webhookURL,
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-		},
+		webhookURL:    webhookURL,
+		signingSecret: signingSecret,
+		mentions:      mentions,
+		screenshots:   screenshots,
+		client:        client,
 	}
 }
 
@@ -76,6 +103,23 @@ func (n *DiscordNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffRes
 		return nil
 	}
 	
+	// Send the webhook, with the screenshot of the page if wanted
+	if n.screenshots && len(diff.Screenshot) > 0 {
+		if len(diff.Screenshot) <= discordMaxAttachment {
+			return n.sendWebhookWithFile(ctx, n.diffPayload(diff), "screenshot.png", diff.Screenshot)
+		}
+		log.Printf("Not attaching the screenshot of %s, its %d bytes exceed the Discord limit", diff.SourceURL, len(diff.Screenshot))
+	}
+	return n.sendWebhook(ctx, n.diffPayload(diff))
+}
+
+// FitsDiff reports whether Discord can show every change of a diff
+func (n *DiscordNotifier) FitsDiff(diff domain.DiffResult) bool {
+	return len(diff.Alerts) <= discordMaxFields && discordFits(n.diffPayload(diff))
+}
+
+// diffPayload renders a diff as a webhook payload
+func (n *DiscordNotifier) diffPayload(diff domain.DiffResult) DiscordWebhookPayload {
 	// Create the webhook payload
 	payload := DiscordWebhookPayload{
 		Username:  "Career Scraper",
@@ -83,6 +127,7 @@ func (n *DiscordNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffRes
 		Content:   fmt.Sprintf("Job updates for **%s**", diff.CompanyName),
 		Embeds:    []DiscordEmbed{},
 	}
+	n.addMentions(&payload, diff.NewJobs)
 	
 	// Add source URL embed
 	sourceEmbed := DiscordEmbed{
@@ -96,6 +141,11 @@ func (n *DiscordNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffRes
 	}
 	payload.Embeds = append(payload.Embeds, sourceEmbed)
 	
+	// Add watch alerts
+	if len(diff.Alerts) > 0 {
+		payload.Embeds = append(payload.Embeds, discordAlertsEmbed(diff.Alerts))
+	}
+	
 	// Add new jobs
 	if len(diff.NewJobs) > 0 {
 		newJobsEmbed := DiscordEmbed{
@@ -114,6 +164,18 @@ func (n *DiscordNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffRes
 			if job.Location != "" {
 				details = append(details, fmt.Sprintf("Location: %s", job.Location))
 			}
+			if job.Salary != nil {
+				details = append(details, fmt.Sprintf("Salary: %s", formatSalary(*job.Salary)))
+			}
+			if dates := formatDates(job, time.Now()); dates != "" {
+				details = append(details, dates)
+			}
+			if seen := formatListedFor(job, true); seen != "" {
+				details = append(details, seen)
+			}
+			for _, key := range job.MetadataKeys() {
+				details = append(details, fmt.Sprintf("%s: %s", key, job.Metadata[key]))
+			}
 			
 			detailsStr := "No additional details"
 			if len(details) > 0 {
@@ -122,8 +184,8 @@ func (n *DiscordNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffRes
 			
 			// Add job field
 			field := DiscordEmbedField{
-				Name:   job.Title,
-				Value:  fmt.Sprintf("[View Job](%s)\n%s", job.URL, detailsStr),
+				Name:   job.DisplayTitle(),
+				Value:  fmt.Sprintf("%s\n%s", discordJobLinks(job), detailsStr),
 				Inline: false,
 			}
 			newJobsEmbed.Fields = append(newJobsEmbed.Fields, field)
@@ -158,8 +220,8 @@ func (n *DiscordNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffRes
 		
 		for _, job := range diff.UpdatedJobs {
 			field := DiscordEmbedField{
-				Name:   job.Title,
-				Value:  fmt.Sprintf("[View Job](%s)", job.URL),
+				Name:   job.DisplayTitle(),
+				Value:  discordJobLinks(job),
 				Inline: false,
 			}
 			updatedJobsEmbed.Fields = append(updatedJobsEmbed.Fields, field)
@@ -179,7 +241,7 @@ func (n *DiscordNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffRes
 		
 		for _, job := range diff.RemovedJobs {
 			field := DiscordEmbedField{
-				Name:   job.Title,
+				Name:   job.DisplayTitle(),
 				Value:  job.Department + (func() string { if job.Location != "" { return " | " + job.Location }; return "" })(),
 				Inline: false,
 			}
@@ -189,8 +251,7 @@ func (n *DiscordNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffRes
 		payload.Embeds = append(payload.Embeds, removedJobsEmbed)
 	}
 	
-	// Send the webhook
-	return n.sendWebhook(ctx, payload)
+	return payload
 }
 
 // sendWebhook sends a payload to the Discord webhook
@@ -201,14 +262,48 @@ func (n *DiscordNotifier) sendWebhook(ctx context.Context, payload DiscordWebhoo
 		return fmt.Errorf("failed to marshal Discord webhook payload: %w", err)
 	}
 	
+	return n.post(ctx, jsonPayload, "application/json")
+}
+
+// sendWebhookWithFile sends a payload with an attached file to the Discord
+// webhook
+func (n *DiscordNotifier) sendWebhookWithFile(ctx context.Context, payload DiscordWebhookPayload, name string, file []byte) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal Discord webhook payload: %w", err)
+	}
+	
+	// Discord takes files as multipart form parts next to the payload
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if err := form.WriteField("payload_json", string(jsonPayload)); err != nil {
+		return fmt.Errorf("failed to encode Discord webhook payload: %w", err)
+	}
+	part, err := form.CreateFormFile("files[0]", name)
+	if err != nil {
+		return fmt.Errorf("failed to encode Discord webhook file: %w", err)
+	}
+	if _, err := part.Write(file); err != nil {
+		return fmt.Errorf("failed to encode Discord webhook file: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return fmt.Errorf("failed to encode Discord webhook file: %w", err)
+	}
+	
+	return n.post(ctx, body.Bytes(), form.FormDataContentType())
+}
+
+// post sends an encoded payload to the Discord webhook
+func (n *DiscordNotifier) post(ctx context.Context, body []byte, contentType string) error {
 	// Create request
-	req, err := http.NewRequestWithContext(ctx, "POST", n.webhookURL, bytes.NewBuffer(jsonPayload))
+	req, err := http.NewRequestWithContext(ctx, "POST", n.webhookURL, bytes.NewBuffer(body))
 	if err != nil {
 		return fmt.Errorf("failed to create Discord webhook request: %w", err)
 	}
 	
 	// Set headers
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
+	setSignature(req, n.signingSecret, body)
 	
 	// Send request
 	resp, err := n.client.Do(req)
@@ -224,3 +319,309 @@ func (n *DiscordNotifier) sendWebhook(ctx context.Context, payload DiscordWebhoo
 	
 	return nil
 }
+
+// Discord limits on embeds and fields
+const (
+	discordMaxEmbeds      = 10
+	discordMaxEmbedText   = 6000 // total of the texts of all embeds of a message
+	discordMaxDescription = 4096
+	discordMaxFields      = 25
+	discordMaxFieldName   = 256
+	discordMaxFieldValue  = 1024
+	discordMaxAttachment  = 8 << 20 // bytes of the files of a message
+)
+
+// discordFits reports whether a payload is within the Discord limits
+func discordFits(payload DiscordWebhookPayload) bool {
+	if len(payload.Embeds) > discordMaxEmbeds {
+		return false
+	}
+	
+	total := 0
+	for _, embed := range payload.Embeds {
+		if len(embed.Fields) > discordMaxFields || utf8.RuneCountInString(embed.Description) > discordMaxDescription {
+			return false
+		}
+		total += utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+		if embed.Author != nil {
+			total += utf8.RuneCountInString(embed.Author.Name)
+		}
+		if embed.Footer != nil {
+			total += utf8.RuneCountInString(embed.Footer.Text)
+		}
+		for _, field := range embed.Fields {
+			name, value := utf8.RuneCountInString(field.Name), utf8.RuneCountInString(field.Value)
+			if name > discordMaxFieldName || value > discordMaxFieldValue {
+				return false
+			}
+			total += name + value
+		}
+	}
+	return total <= discordMaxEmbedText
+}
+
+// NotifyDigest sends all changes of a run to Discord as a single digest, with
+// near-identical titles across companies collapsed into one field
+func (n *DiscordNotifier) NotifyDigest(ctx context.Context, digest domain.Digest) error {
+	payload := n.digestPayload(digest)
+	if len(payload.Embeds) == 0 {
+		return nil
+	}
+	return n.sendWebhook(ctx, payload)
+}
+
+// FitsDigest reports whether Discord can show every change of a digest
+func (n *DiscordNotifier) FitsDigest(digest domain.Digest) bool {
+	alerts := 0
+	for _, diff := range digest.Diffs {
+		alerts += len(diff.Alerts)
+	}
+	return len(digest.Clusters) <= discordMaxFields && alerts <= discordMaxFields && discordFits(n.digestPayload(digest))
+}
+
+// digestPayload renders a digest as a webhook payload, without embeds if
+// there is nothing to send
+func (n *DiscordNotifier) digestPayload(digest domain.Digest) DiscordWebhookPayload {
+	payload := DiscordWebhookPayload{
+		Username:  "Career Scraper",
+		AvatarURL: "https://cdn-icons-png.flaticon.com/512/4365/4365271.png", // Job search icon
+		Content:   fmt.Sprintf("Job digest: **%d** new jobs across **%d** career pages", digest.NewJobCount(), len(digest.Diffs)),
+		Embeds:    []DiscordEmbed{},
+	}
+	
+	var newJobs []domain.Job
+	for _, diff := range digest.Diffs {
+		newJobs = append(newJobs, diff.NewJobs...)
+	}
+	n.addMentions(&payload, newJobs)
+	
+	// Add watch alerts
+	var alerts []domain.WatchAlert
+	for _, diff := range digest.Diffs {
+		alerts = append(alerts, diff.Alerts...)
+	}
+	if len(alerts) > 0 {
+		payload.Embeds = append(payload.Embeds, discordAlertsEmbed(alerts))
+	}
+	
+	// Add clustered new jobs
+	if len(digest.Clusters) > 0 {
+		newJobsEmbed := DiscordEmbed{
+			Title:       fmt.Sprintf("New Jobs (%d)", digest.NewJobCount()),
+			Description: "Similar titles are grouped, reveal a group to see every listing:",
+			Color:       5763719, // Green color
+			Fields:      []DiscordEmbedField{},
+		}
+		
+		for i, cluster := range digest.Clusters {
+			if i == discordMaxFields-1 && len(digest.Clusters) > discordMaxFields {
+				newJobsEmbed.Fields = append(newJobsEmbed.Fields, DiscordEmbedField{
+					Name:  "More",
+					Value: fmt.Sprintf("...and %d more groups", len(digest.Clusters)-i),
+				})
+				break
+			}
+			newJobsEmbed.Fields = append(newJobsEmbed.Fields, discordClusterField(cluster))
+		}
+		
+		payload.Embeds = append(payload.Embeds, newJobsEmbed)
+	}
+	
+	// Summarize updated and removed jobs per company
+	var summary []string
+	for _, diff := range digest.Diffs {
+		if len(diff.UpdatedJobs) == 0 && len(diff.RemovedJobs) == 0 {
+			continue
+		}
+		summary = append(summary, fmt.Sprintf("[%s](%s): %d updated, %d removed",
+			diff.CompanyName, diff.SourceURL, len(diff.UpdatedJobs), len(diff.RemovedJobs)))
+	}
+	if len(summary) > 0 {
+		payload.Embeds = append(payload.Embeds, DiscordEmbed{
+			Title:       "Other Changes",
+			Description: strings.Join(summary, "\n"),
+			Color:       16776960, // Yellow color
+		})
+	}
+	
+	if len(payload.Embeds) == 0 {
+		return payload
+	}
+	payload.Embeds[len(payload.Embeds)-1].Footer = &DiscordEmbedFooter{
+		Text: fmt.Sprintf("Last updated: %s", digest.CreatedAt.Format(time.RFC1123)),
+	}
+	
+	return payload
+}
+
+// discordClusterField renders a title cluster. Clusters with more than one job
+// list their companies and hide the individual links behind a spoiler.
+func discordClusterField(cluster domain.TitleCluster) DiscordEmbedField {
+	if len(cluster.Entries) == 1 {
+		entry := cluster.Entries[0]
+		return DiscordEmbedField{
+			Name:  cluster.Title,
+			Value: fmt.Sprintf("%s at %s", discordJobLinks(entry.Job), entry.CompanyName),
+		}
+	}
+	
+	var companies, links []string
+	seen := make(map[string]bool)
+	for _, entry := range cluster.Entries {
+		if !seen[entry.CompanyName] {
+			seen[entry.CompanyName] = true
+			companies = append(companies, entry.CompanyName)
+		}
+		links = append(links, fmt.Sprintf("[%s - %s](%s)", entry.CompanyName, entry.Job.Title, entry.Job.URL))
+	}
+	
+	value := strings.Join(companies, ", ")
+	if len(cluster.Locations) > 0 {
+		value += " (" + strings.Join(cluster.Locations, ", ") + ")"
+	}
+	value += "\n||" + strings.Join(links, "\n") + "||"
+	if len(value) > discordMaxFieldValue {
+		value = value[:discordMaxFieldValue-5] + "...||"
+	}
+	
+	return DiscordEmbedField{
+		Name:  fmt.Sprintf("%s ×%d", cluster.Title, len(cluster.Entries)),
+		Value: value,
+	}
+}
+
+// discordJobLinks renders the listing link of a job, followed by its
+// application link if it has a separate one
+func discordJobLinks(job domain.Job) string {
+	links := fmt.Sprintf("[View Job](%s)", job.URL)
+	if apply := job.DirectApplyURL(); apply != "" {
+		links += fmt.Sprintf(" · [Apply](%s)", apply)
+	}
+	return links
+}
+
+// discordAlertsEmbed renders the jobs that matched watch rules
+func discordAlertsEmbed(alerts []domain.WatchAlert) DiscordEmbed {
+	embed := DiscordEmbed{
+		Title:       fmt.Sprintf("Watch Alerts (%d)", len(alerts)),
+		Description: "The following jobs match your watch rules:",
+		Color:       15105570, // Orange color
+		Fields:      []DiscordEmbedField{},
+	}
+	
+	for i, alert := range alerts {
+		if i == discordMaxFields {
+			break
+		}
+		value := fmt.Sprintf("%s\nWatch: %s", discordJobLinks(alert.Job), alert.WatchNames())
+		if alert.Owner != "" {
+			value += fmt.Sprintf(" (for %s)", alert.Owner)
+		}
+		if alert.Job.Salary != nil {
+			value += fmt.Sprintf(" | Salary: %s", formatSalary(*alert.Job.Salary))
+		}
+		embed.Fields = append(embed.Fields, DiscordEmbedField{
+			Name:  alert.Job.DisplayTitle(),
+			Value: value,
+		})
+	}
+	
+	return embed
+}
+
+// addMentions prepends pings for the mention rules matched by the new jobs and
+// restricts allowed mentions to exactly those roles and users
+func (n *DiscordNotifier) addMentions(payload *DiscordWebhookPayload, jobs []domain.Job) {
+	allowed := &DiscordAllowedMentions{Parse: []string{}}
+	var pings []string
+	seen := make(map[string]bool)
+	
+	for _, mention := range n.mentions {
+		if !mentionMatches(mention, jobs) {
+			continue
+		}
+		if mention.RoleID != "" && !seen["role:"+mention.RoleID] {
+			seen["role:"+mention.RoleID] = true
+			allowed.Roles = append(allowed.Roles, mention.RoleID)
+			pings = append(pings, fmt.Sprintf("<@&%s>", mention.RoleID))
+		}
+		if mention.UserID != "" && !seen["user:"+mention.UserID] {
+			seen["user:"+mention.UserID] = true
+			allowed.Users = append(allowed.Users, mention.UserID)
+			pings = append(pings, fmt.Sprintf("<@%s>", mention.UserID))
+		}
+	}
+	
+	// Never ping anyone not explicitly configured, e.g. @everyone in a job title
+	payload.AllowedMentions = allowed
+	if len(pings) > 0 {
+		payload.Content = strings.Join(pings, " ") + " " + payload.Content
+	}
+}
+
+// mentionMatches reports whether any job title contains one of the mention keywords
+func mentionMatches(mention DiscordMention, jobs []domain.Job) bool {
+	for _, job := range jobs {
+		title := " " + textutil.NormalizeKey(job.Title) + " "
+		for _, keyword := range mention.Keywords {
+			keyword = textutil.NormalizeKey(keyword)
+			if keyword != "" && strings.Contains(title, " "+keyword+" ") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// discordSeverityColors maps severities to embed colors
+var discordSeverityColors = map[domain.Severity]int{
+	domain.SeverityInfo:     3447003,  // Blue color
+	domain.SeverityHigh:     5763719,  // Green color
+	domain.SeverityCritical: 15158332, // Red color
+}
+
+// Notify sends a standalone notification, such as a scraping error, to Discord
+func (n *DiscordNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	embed := DiscordEmbed{
+		Title:       notification.Title,
+		URL:         notification.SourceURL,
+		Description: notification.Message,
+		Color:       discordSeverityColors[notification.Severity],
+		Footer: &DiscordEmbedFooter{
+			Text: fmt.Sprintf("Severity: %s | %s", notification.Severity, notification.CreatedAt.Format(time.RFC1123)),
+		},
+	}
+	if notification.CompanyName != "" {
+		embed.Author = &DiscordEmbedAuthor{Name: notification.CompanyName}
+	}
+	
+	payload := DiscordWebhookPayload{
+		Username:        "Career Scraper",
+		AvatarURL:       "https://cdn-icons-png.flaticon.com/512/4365/4365271.png", // Job search icon
+		Embeds:          []DiscordEmbed{embed},
+		AllowedMentions: &DiscordAllowedMentions{Parse: []string{}},
+	}
+	
+	return n.sendWebhook(ctx, payload)
+}
+
+// Probe validates the webhook URL. A GET on a Discord webhook returns its
+// metadata without posting anything to the channel.
+func (n *DiscordNotifier) Probe(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", n.webhookURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create Discord webhook probe: %w", err)
+	}
+	
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach Discord webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("Discord webhook probe returned non-success status: %d", resp.StatusCode)
+	}
+	
+	return nil
+}
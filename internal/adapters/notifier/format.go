@@ -0,0 +1,82 @@
+// internal/adapters/notifier/format.go
+package notifier
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// formatSalary renders a salary as e.g. "USD 120,000-150,000/year"
+func formatSalary(salary domain.Salary) string {
+	var amount string
+	switch {
+	case salary.Min > 0 && salary.Max > 0 && salary.Min != salary.Max:
+		amount = formatAmount(salary.Min) + "-" + formatAmount(salary.Max)
+	case salary.Min > 0:
+		amount = formatAmount(salary.Min)
+	default:
+		amount = formatAmount(salary.Max)
+	}
+
+	text := fmt.Sprintf("%s %s", salary.Currency, amount)
+	if salary.Period != "" {
+		text += "/" + string(salary.Period)
+	}
+	return text
+}
+
+// formatAmount renders a number with thousands separators
+func formatAmount(value float64) string {
+	digits := strconv.FormatInt(int64(value), 10)
+	var out []byte
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 && digits[i-1] != '-' {
+			out = append(out, ',')
+		}
+		out = append(out, digits[i])
+	}
+	return string(out)
+}
+
+// formatDates notes when a job was posted and until when it takes
+// applications, e.g. "posted Apr 12, apply by Apr 20", or returns "" if
+// neither is known. The year is left out for dates in the current one.
+func formatDates(job domain.Job, now time.Time) string {
+	day := func(t time.Time) string {
+		if t.Year() == now.Year() {
+			return t.Format("Jan 2")
+		}
+		return t.Format("Jan 2, 2006")
+	}
+	var dates []string
+	if !job.PostedDate.IsZero() {
+		dates = append(dates, "posted "+day(job.PostedDate))
+	}
+	if !job.Deadline.IsZero() {
+		dates = append(dates, "apply by "+day(job.Deadline))
+	}
+	return strings.Join(dates, ", ")
+}
+
+// formatListedFor notes how long a job has been listed, e.g. "first seen 12
+// days ago", or returns "" for jobs listed for less than a day. Jobs among the
+// new ones were re-posted.
+func formatListedFor(job domain.Job, new bool) string {
+	days := int(job.ListedFor() / (24 * time.Hour))
+	if days < 1 {
+		return ""
+	}
+
+	text := fmt.Sprintf("first seen %d days ago", days)
+	if days == 1 {
+		text = "first seen 1 day ago"
+	}
+	if new {
+		text = "re-posted, " + text
+	}
+	return text
+}
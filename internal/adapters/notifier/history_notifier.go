@@ -0,0 +1,125 @@
+// internal/adapters/notifier/history_notifier.go
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// NamedNotifier is a notifier with the name it is configured under
+type NamedNotifier struct {
+	Name     string
+	Notifier ports.Notifier
+}
+
+// HistoryNotifier fans notifications out to several notifiers like
+// MultiNotifier and records every notification with the outcome of each
+// delivery attempt in a notification history. Failing to record is logged
+// and doesn't fail the delivery.
+type HistoryNotifier struct {
+	history   ports.NotificationHistory
+	notifiers []NamedNotifier
+}
+
+// NewHistoryNotifier creates a new HistoryNotifier recording into history
+func NewHistoryNotifier(history ports.NotificationHistory, notifiers ...NamedNotifier) *HistoryNotifier {
+	return &HistoryNotifier{
+		history:   history,
+		notifiers: notifiers,
+	}
+}
+
+// severityGate is implemented by notifiers that drop notifications below a
+// minimum severity, so skipped deliveries are recorded as such
+type severityGate interface {
+	Accepts(severity domain.Severity) bool
+}
+
+// NotifyNewJobs sends the diff to every notifier and records it
+func (n *HistoryNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffResult) error {
+	if !diff.HasChanges() {
+		return nil
+	}
+	record := domain.NotificationRecord{Kind: domain.CaptureKindJobs, Severity: diff.Severity, Diff: &diff}
+	return n.deliver(ctx, record, func(notifier ports.Notifier) error {
+		return notifier.NotifyNewJobs(ctx, diff)
+	})
+}
+
+// NotifyDigest sends the digest to every notifier and records it
+func (n *HistoryNotifier) NotifyDigest(ctx context.Context, digest domain.Digest) error {
+	record := domain.NotificationRecord{Kind: domain.CaptureKindDigest, Severity: digest.Severity(), Digest: &digest}
+	return n.deliver(ctx, record, func(notifier ports.Notifier) error {
+		return notifyDigest(ctx, notifier, digest)
+	})
+}
+
+// Notify sends the notification to every notifier that supports standalone
+// messages and records it
+func (n *HistoryNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	record := domain.NotificationRecord{Kind: domain.CaptureKindMessage, Severity: notification.Severity, Notification: &notification}
+	return n.deliver(ctx, record, func(notifier ports.Notifier) error {
+		if _, ok := notifier.(ports.MessageNotifier); !ok {
+			return errSkipped
+		}
+		return notifyMessage(ctx, notifier, notification)
+	})
+}
+
+// Probe probes every notifier that supports it; probes are not recorded
+func (n *HistoryNotifier) Probe(ctx context.Context) error {
+	var errs []error
+	for _, named := range n.notifiers {
+		if err := probe(ctx, named.Notifier); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// errSkipped marks a notifier that can't send a kind of notification
+var errSkipped = errors.New("skipped")
+
+// deliver sends a notification through every notifier, then records it with
+// the attempts made
+func (n *HistoryNotifier) deliver(ctx context.Context, record domain.NotificationRecord, send func(ports.Notifier) error) error {
+	record.CreatedAt = time.Now()
+
+	var errs []error
+	for _, named := range n.notifiers {
+		if gate, ok := named.Notifier.(severityGate); ok && !gate.Accepts(record.Severity) {
+			record.Attempts = append(record.Attempts, domain.DeliveryAttempt{Notifier: named.Name, At: time.Now(), Skipped: true})
+			continue
+		}
+
+		attempt := domain.DeliveryAttempt{Notifier: named.Name, At: time.Now()}
+		err := send(named.Notifier)
+		if errors.Is(err, errSkipped) {
+			continue
+		}
+		attempt.Duration = time.Since(attempt.At)
+		if err != nil {
+			attempt.Error = err.Error()
+			errs = append(errs, fmt.Errorf("%s: %w", named.Name, err))
+		}
+		record.Attempts = append(record.Attempts, attempt)
+	}
+
+	if err := n.history.RecordNotification(ctx, record); err != nil {
+		log.Printf("Failed to record notification %q: %v", record.Summary(), err)
+	}
+	return errors.Join(errs...)
+}
+
+var (
+	_ ports.Notifier        = (*HistoryNotifier)(nil) // Ensure interface compliance
+	_ ports.DigestNotifier  = (*HistoryNotifier)(nil)
+	_ ports.MessageNotifier = (*HistoryNotifier)(nil)
+	_ ports.Prober          = (*HistoryNotifier)(nil)
+)
@@ -0,0 +1,107 @@
+// internal/adapters/notifier/multi_notifier.go
+package notifier
+
+import (
+	"context"
+	"errors"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// MultiNotifier fans notifications out to several notifiers. A failing notifier
+// does not prevent delivery to the others; all errors are returned joined.
+type MultiNotifier struct {
+	notifiers []ports.Notifier
+}
+
+// NewMultiNotifier creates a new MultiNotifier instance
+func NewMultiNotifier(notifiers ...ports.Notifier) *MultiNotifier {
+	return &MultiNotifier{
+		notifiers: notifiers,
+	}
+}
+
+// NotifyNewJobs sends the diff to every notifier
+func (m *MultiNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffResult) error {
+	var errs []error
+	for _, n := range m.notifiers {
+		if err := n.NotifyNewJobs(ctx, diff); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NotifyDigest sends the digest to every notifier, one message per diff for
+// notifiers without digest support
+func (m *MultiNotifier) NotifyDigest(ctx context.Context, digest domain.Digest) error {
+	var errs []error
+	for _, n := range m.notifiers {
+		if err := notifyDigest(ctx, n, digest); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Notify sends the notification to every notifier that supports standalone messages
+func (m *MultiNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	var errs []error
+	for _, n := range m.notifiers {
+		if err := notifyMessage(ctx, n, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Probe probes every notifier that supports it
+func (m *MultiNotifier) Probe(ctx context.Context) error {
+	var errs []error
+	for _, n := range m.notifiers {
+		if err := probe(ctx, n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// notifyDigest delivers a digest through n, falling back to one NotifyNewJobs
+// call per diff when n cannot send digests
+func notifyDigest(ctx context.Context, n ports.Notifier, digest domain.Digest) error {
+	if dn, ok := n.(ports.DigestNotifier); ok {
+		return dn.NotifyDigest(ctx, digest)
+	}
+
+	var errs []error
+	for _, diff := range digest.Diffs {
+		if err := n.NotifyNewJobs(ctx, diff); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// notifyMessage delivers a standalone notification through n if it supports them
+func notifyMessage(ctx context.Context, n ports.Notifier, notification domain.Notification) error {
+	if mn, ok := n.(ports.MessageNotifier); ok {
+		return mn.Notify(ctx, notification)
+	}
+	return nil
+}
+
+// probe probes n if it supports probing
+func probe(ctx context.Context, n ports.Notifier) error {
+	if p, ok := n.(ports.Prober); ok {
+		return p.Probe(ctx)
+	}
+	return nil
+}
+
+var (
+	_ ports.Notifier        = (*MultiNotifier)(nil) // Ensure interface compliance
+	_ ports.DigestNotifier  = (*MultiNotifier)(nil)
+	_ ports.MessageNotifier = (*MultiNotifier)(nil)
+	_ ports.Prober          = (*MultiNotifier)(nil)
+)
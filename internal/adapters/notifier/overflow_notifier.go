@@ -0,0 +1,132 @@
+// internal/adapters/notifier/overflow_notifier.go
+package notifier
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// OverflowNotifier wraps a notifier whose platform caps the size of a message.
+// Changes the platform can't show in full are published as a summary page and
+// announced with a short message linking to it instead of being truncated.
+// If publishing fails the changes are delivered as the wrapped notifier would.
+type OverflowNotifier struct {
+	inner    ports.Notifier
+	limits   ports.SizeLimited
+	store    ports.SummaryStore
+	markdown bool // publish Markdown documents instead of HTML pages
+}
+
+// NewOverflowNotifier creates a new OverflowNotifier instance
+func NewOverflowNotifier(inner ports.Notifier, limits ports.SizeLimited, store ports.SummaryStore, markdown bool) *OverflowNotifier {
+	return &OverflowNotifier{
+		inner:    inner,
+		limits:   limits,
+		store:    store,
+		markdown: markdown,
+	}
+}
+
+// NotifyNewJobs delivers the diff, or a link to its summary if it doesn't fit
+func (n *OverflowNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffResult) error {
+	if !diff.HasChanges() || n.limits.FitsDiff(diff) {
+		return n.inner.NotifyNewJobs(ctx, diff)
+	}
+
+	title := fmt.Sprintf("Job updates for %s", diff.CompanyName)
+	digest := domain.Digest{CreatedAt: time.Now(), Diffs: []domain.DiffResult{diff}}
+	link, err := n.publish(ctx, title, digest)
+	if err != nil {
+		log.Printf("Failed to publish summary of %s, sending it truncated: %v", diff.SourceURL, err)
+		return n.inner.NotifyNewJobs(ctx, diff)
+	}
+
+	return notifyMessage(ctx, n.inner, domain.Notification{
+		Type:        domain.NotificationTypeSummary,
+		Severity:    diff.Severity,
+		CompanyName: diff.CompanyName,
+		SourceURL:   diff.SourceURL,
+		Title:       title,
+		Message:     fmt.Sprintf("%s, too many to show here.\nFull summary: %s", changeCounts(digest), link),
+		CreatedAt:   digest.CreatedAt,
+	})
+}
+
+// NotifyDigest delivers the digest, or a link to its summary if it doesn't fit
+func (n *OverflowNotifier) NotifyDigest(ctx context.Context, digest domain.Digest) error {
+	if n.limits.FitsDigest(digest) {
+		return notifyDigest(ctx, n.inner, digest)
+	}
+
+	title := fmt.Sprintf("Job digest: %d new jobs across %d career pages", digest.NewJobCount(), len(digest.Diffs))
+	link, err := n.publish(ctx, title, digest)
+	if err != nil {
+		log.Printf("Failed to publish summary of digest, sending it truncated: %v", err)
+		return notifyDigest(ctx, n.inner, digest)
+	}
+
+	return notifyMessage(ctx, n.inner, domain.Notification{
+		Type:      domain.NotificationTypeSummary,
+		Severity:  digest.Severity(),
+		Title:     title,
+		Message:   fmt.Sprintf("%s, too many to show here.\nFull summary: %s", changeCounts(digest), link),
+		CreatedAt: digest.CreatedAt,
+	})
+}
+
+// Notify delivers a standalone notification through the wrapped notifier
+func (n *OverflowNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	return notifyMessage(ctx, n.inner, notification)
+}
+
+// Probe probes the wrapped notifier
+func (n *OverflowNotifier) Probe(ctx context.Context) error {
+	return probe(ctx, n.inner)
+}
+
+// publish renders the summary page of a digest and stores it, returning its
+// URL. Pages are named by their time and content so links are hard to guess.
+func (n *OverflowNotifier) publish(ctx context.Context, title string, digest domain.Digest) (string, error) {
+	content, contentType, ext := renderSummaryMarkdown(title, digest), "text/markdown; charset=utf-8", ".md"
+	if !n.markdown {
+		var err error
+		if content, err = renderSummaryHTML(title, digest); err != nil {
+			return "", err
+		}
+		contentType, ext = "text/html; charset=utf-8", ".html"
+	}
+
+	sources := make([]string, 0, len(digest.Diffs))
+	for _, diff := range digest.Diffs {
+		sources = append(sources, diff.SourceURL)
+	}
+
+	sum := sha256.Sum256(content)
+	name := digest.CreatedAt.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(sum[:8]) + ext
+	return n.store.PutSummary(ctx, name, contentType, content, sources)
+}
+
+// changeCounts describes the number of changes in a digest, e.g. "42 new,
+// 3 updated and 7 removed jobs"
+func changeCounts(digest domain.Digest) string {
+	var updated, removed int
+	for _, diff := range digest.Diffs {
+		updated += len(diff.UpdatedJobs)
+		removed += len(diff.RemovedJobs)
+	}
+	return fmt.Sprintf("%d new, %d updated and %d removed jobs", digest.NewJobCount(), updated, removed)
+}
+
+var (
+	_ ports.Notifier        = (*OverflowNotifier)(nil) // Ensure interface compliance
+	_ ports.DigestNotifier  = (*OverflowNotifier)(nil)
+	_ ports.MessageNotifier = (*OverflowNotifier)(nil)
+	_ ports.Prober          = (*OverflowNotifier)(nil)
+)
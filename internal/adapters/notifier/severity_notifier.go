@@ -0,0 +1,78 @@
+// internal/adapters/notifier/severity_notifier.go
+package notifier
+
+import (
+	"context"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// SeverityNotifier wraps a notifier and only delivers notifications of at least
+// a minimum severity
+type SeverityNotifier struct {
+	inner ports.Notifier
+	min   domain.Severity
+}
+
+// NewSeverityNotifier creates a new SeverityNotifier instance
+func NewSeverityNotifier(inner ports.Notifier, min domain.Severity) *SeverityNotifier {
+	return &SeverityNotifier{
+		inner: inner,
+		min:   min,
+	}
+}
+
+// Accepts reports whether notifications of the severity are delivered. A
+// digest is delivered if any of its diffs is accepted.
+func (n *SeverityNotifier) Accepts(severity domain.Severity) bool {
+	return severity.AtLeast(n.min)
+}
+
+// NotifyNewJobs delivers the diff if its severity is high enough
+func (n *SeverityNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffResult) error {
+	if !n.Accepts(diff.Severity) {
+		return nil
+	}
+	return n.inner.NotifyNewJobs(ctx, diff)
+}
+
+// NotifyDigest delivers the diffs of the digest whose severity is high enough
+func (n *SeverityNotifier) NotifyDigest(ctx context.Context, digest domain.Digest) error {
+	var diffs []domain.DiffResult
+	for _, diff := range digest.Diffs {
+		if n.Accepts(diff.Severity) {
+			diffs = append(diffs, diff)
+		}
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	filtered := digest
+	filtered.Diffs = diffs
+	if len(diffs) != len(digest.Diffs) {
+		filtered.Clusters = domain.ClusterNewJobs(diffs, digest.Similarity)
+	}
+	return notifyDigest(ctx, n.inner, filtered)
+}
+
+// Notify delivers the notification if its severity is high enough
+func (n *SeverityNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	if !n.Accepts(notification.Severity) {
+		return nil
+	}
+	return notifyMessage(ctx, n.inner, notification)
+}
+
+// Probe probes the wrapped notifier
+func (n *SeverityNotifier) Probe(ctx context.Context) error {
+	return probe(ctx, n.inner)
+}
+
+var (
+	_ ports.Notifier        = (*SeverityNotifier)(nil) // Ensure interface compliance
+	_ ports.DigestNotifier  = (*SeverityNotifier)(nil)
+	_ ports.MessageNotifier = (*SeverityNotifier)(nil)
+	_ ports.Prober          = (*SeverityNotifier)(nil)
+)
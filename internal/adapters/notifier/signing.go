@@ -0,0 +1,28 @@
+// internal/adapters/notifier/signing.go
+package notifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+)
+
+// SignatureHeader is the header carrying the HMAC-SHA256 signature of the request body
+const SignatureHeader = "X-Signature"
+
+// signPayload returns the hex encoded HMAC-SHA256 of body keyed with secret,
+// prefixed with the algorithm name (e.g. "sha256=3f2a...")
+func signPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// setSignature signs body and sets the signature header when a secret is configured
+func setSignature(req *http.Request, secret string, body []byte) {
+	if secret == "" {
+		return
+	}
+	req.Header.Set(SignatureHeader, signPayload(secret, body))
+}
@@ -0,0 +1,98 @@
+// internal/adapters/notifier/snooze_notifier.go
+package notifier
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// SnoozeNotifier wraps the notifier configured under a name and drops the
+// changes silenced by the snoozes applying to it. If the snoozes can't be
+// read everything is delivered.
+type SnoozeNotifier struct {
+	inner ports.Notifier
+	name  string
+	store ports.SnoozeStore
+}
+
+// NewSnoozeNotifier creates a new SnoozeNotifier instance
+func NewSnoozeNotifier(inner ports.Notifier, name string, store ports.SnoozeStore) *SnoozeNotifier {
+	return &SnoozeNotifier{
+		inner: inner,
+		name:  name,
+		store: store,
+	}
+}
+
+// NotifyNewJobs delivers the changes of the diff that are not snoozed
+func (n *SnoozeNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffResult) error {
+	now := time.Now()
+	diff = domain.SnoozeDiff(diff, n.snoozes(ctx, now), n.name, now)
+	if !diff.HasChanges() {
+		return nil
+	}
+	return n.inner.NotifyNewJobs(ctx, diff)
+}
+
+// NotifyDigest delivers the changes of the digest that are not snoozed
+func (n *SnoozeNotifier) NotifyDigest(ctx context.Context, digest domain.Digest) error {
+	now := time.Now()
+	snoozes := n.snoozes(ctx, now)
+	if len(snoozes) == 0 {
+		return notifyDigest(ctx, n.inner, digest)
+	}
+
+	var diffs []domain.DiffResult
+	for _, diff := range digest.Diffs {
+		if diff = domain.SnoozeDiff(diff, snoozes, n.name, now); diff.HasChanges() {
+			diffs = append(diffs, diff)
+		}
+	}
+	if len(diffs) == 0 {
+		return nil
+	}
+
+	filtered := digest
+	filtered.Diffs = diffs
+	filtered.Clusters = domain.ClusterNewJobs(diffs, digest.Similarity)
+	return notifyDigest(ctx, n.inner, filtered)
+}
+
+// Notify delivers a standalone notification unless its source is snoozed
+func (n *SnoozeNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	if notification.SourceURL != "" {
+		now := time.Now()
+		for _, snooze := range n.snoozes(ctx, now) {
+			if snooze.SourceURL == notification.SourceURL && snooze.JobID == "" && snooze.AppliesTo(n.name) {
+				return nil
+			}
+		}
+	}
+	return notifyMessage(ctx, n.inner, notification)
+}
+
+// Probe probes the wrapped notifier
+func (n *SnoozeNotifier) Probe(ctx context.Context) error {
+	return probe(ctx, n.inner)
+}
+
+// snoozes returns the snoozes active at a time
+func (n *SnoozeNotifier) snoozes(ctx context.Context, at time.Time) []domain.Snooze {
+	snoozes, err := n.store.ListSnoozes(ctx, at)
+	if err != nil {
+		log.Printf("Failed to read snoozes, notifying %s of everything: %v", n.name, err)
+		return nil
+	}
+	return snoozes
+}
+
+var (
+	_ ports.Notifier        = (*SnoozeNotifier)(nil) // Ensure interface compliance
+	_ ports.DigestNotifier  = (*SnoozeNotifier)(nil)
+	_ ports.MessageNotifier = (*SnoozeNotifier)(nil)
+	_ ports.Prober          = (*SnoozeNotifier)(nil)
+)
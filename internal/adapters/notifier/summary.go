@@ -0,0 +1,125 @@
+// internal/adapters/notifier/summary.go
+package notifier
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// summaryTemplate renders the changes of a digest as a standalone HTML page
+var summaryTemplate = template.Must(template.New("summary").Funcs(template.FuncMap{
+	"details": summaryJobDetails,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 60rem; margin: 2rem auto; padding: 0 1rem; color: #222; }
+h2 { border-bottom: 1px solid #ddd; padding-bottom: .25rem; }
+li { margin: .25rem 0; }
+.details { color: #666; font-size: .9em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Generated {{.CreatedAt.Format "Mon, 02 Jan 2006 15:04:05 MST"}}</p>
+{{range .Diffs}}
+<h2><a href="{{.SourceURL}}">{{.CompanyName}}</a></h2>
+{{if .Alerts}}<h3>Watch Alerts ({{len .Alerts}})</h3>
+<ul>{{range .Alerts}}<li><a href="{{.Job.URL}}">{{.Job.DisplayTitle}}</a> <span class="details">Watch: {{.WatchNames}}{{if .Owner}} (for {{.Owner}}){{end}}</span></li>{{end}}</ul>{{end}}
+{{if .NewJobs}}<h3>New Jobs ({{len .NewJobs}})</h3>
+<ul>{{range .NewJobs}}<li><a href="{{.URL}}">{{.DisplayTitle}}</a> <span class="details">{{details . true}}</span></li>{{end}}</ul>{{end}}
+{{if .UpdatedJobs}}<h3>Updated Jobs ({{len .UpdatedJobs}})</h3>
+<ul>{{range .UpdatedJobs}}<li><a href="{{.URL}}">{{.DisplayTitle}}</a> <span class="details">{{details . false}}</span></li>{{end}}</ul>{{end}}
+{{if .RemovedJobs}}<h3>Removed Jobs ({{len .RemovedJobs}})</h3>
+<ul>{{range .RemovedJobs}}<li>{{.DisplayTitle}} <span class="details">{{details . false}}</span></li>{{end}}</ul>{{end}}
+{{end}}
+</body>
+</html>
+`))
+
+// summaryPage is the data of a summary page
+type summaryPage struct {
+	domain.Digest
+	Title string
+}
+
+// renderSummaryHTML renders every change of a digest as an HTML page
+func renderSummaryHTML(title string, digest domain.Digest) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := summaryTemplate.Execute(&buf, summaryPage{Digest: digest, Title: title}); err != nil {
+		return nil, fmt.Errorf("failed to render summary: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderSummaryMarkdown renders every change of a digest as a Markdown document
+func renderSummaryMarkdown(title string, digest domain.Digest) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\nGenerated %s\n", title, digest.CreatedAt.Format("Mon, 02 Jan 2006 15:04:05 MST"))
+
+	writeJobs := func(heading string, jobs []domain.Job, new, linked bool) {
+		if len(jobs) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n### %s (%d)\n\n", heading, len(jobs))
+		for _, job := range jobs {
+			if linked && job.URL != "" {
+				fmt.Fprintf(&b, "- [%s](%s)", job.DisplayTitle(), job.URL)
+			} else {
+				fmt.Fprintf(&b, "- %s", job.DisplayTitle())
+			}
+			if details := summaryJobDetails(job, new); details != "" {
+				fmt.Fprintf(&b, " — %s", details)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	for _, diff := range digest.Diffs {
+		fmt.Fprintf(&b, "\n## [%s](%s)\n", diff.CompanyName, diff.SourceURL)
+		if len(diff.Alerts) > 0 {
+			fmt.Fprintf(&b, "\n### Watch Alerts (%d)\n\n", len(diff.Alerts))
+			for _, alert := range diff.Alerts {
+				fmt.Fprintf(&b, "- [%s](%s) — Watch: %s", alert.Job.DisplayTitle(), alert.Job.URL, alert.WatchNames())
+				if alert.Owner != "" {
+					fmt.Fprintf(&b, " (for %s)", alert.Owner)
+				}
+				b.WriteString("\n")
+			}
+		}
+		writeJobs("New Jobs", diff.NewJobs, true, true)
+		writeJobs("Updated Jobs", diff.UpdatedJobs, false, true)
+		writeJobs("Removed Jobs", diff.RemovedJobs, false, false)
+	}
+	return []byte(b.String())
+}
+
+// summaryJobDetails lists the department, location, salary, dates and
+// listing age of a job
+func summaryJobDetails(job domain.Job, new bool) string {
+	var details []string
+	if job.Department != "" {
+		details = append(details, job.Department)
+	}
+	if job.Location != "" {
+		details = append(details, job.Location)
+	}
+	if job.Salary != nil {
+		details = append(details, formatSalary(*job.Salary))
+	}
+	if dates := formatDates(job, time.Now()); dates != "" {
+		details = append(details, dates)
+	}
+	if seen := formatListedFor(job, new); seen != "" {
+		details = append(details, seen)
+	}
+	return strings.Join(details, " | ")
+}
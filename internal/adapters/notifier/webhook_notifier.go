@@ -0,0 +1,182 @@
+// internal/adapters/notifier/webhook_notifier.go
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// WebhookNotifier implements the Notifier interface for generic JSON webhooks
+type WebhookNotifier struct {
+	webhookURL    string
+	signingSecret string
+	jobEvents     bool // post one event per changed job instead of one per diff
+	client        *http.Client
+}
+
+// WebhookPayload represents the JSON document posted to a generic webhook
+type WebhookPayload struct {
+	Event  string    `json:"event"`
+	SentAt time.Time `json:"sent_at"`
+	domain.DiffResult
+}
+
+// NewWebhookNotifier creates a new WebhookNotifier instance.
+// If signingSecret is not empty every request carries an X-Signature header.
+func NewWebhookNotifier(webhookURL, signingSecret string, client *http.Client) *WebhookNotifier {
+	return &WebhookNotifier{
+		webhookURL:    webhookURL,
+		signingSecret: signingSecret,
+		client:        client,
+	}
+}
+
+// WebhookJobEventPayload represents the change of a single job posted to a
+// generic webhook, with the event being its JobEventType
+type WebhookJobEventPayload struct {
+	SentAt time.Time `json:"sent_at"`
+	domain.JobEvent
+}
+
+// NewJobEventWebhookNotifier creates a WebhookNotifier posting one event per
+// created, updated or removed job, carrying the job before and after the
+// change, instead of one event per diff. Digests are posted as the events of
+// all their diffs.
+func NewJobEventWebhookNotifier(webhookURL, signingSecret string, client *http.Client) *WebhookNotifier {
+	n := NewWebhookNotifier(webhookURL, signingSecret, client)
+	n.jobEvents = true
+	return n
+}
+
+// NotifyNewJobs posts the diff to the configured webhook
+func (n *WebhookNotifier) NotifyNewJobs(ctx context.Context, diff domain.DiffResult) error {
+	// Skip if there are no changes
+	if len(diff.NewJobs) == 0 && len(diff.UpdatedJobs) == 0 && len(diff.RemovedJobs) == 0 {
+		return nil
+	}
+	if n.jobEvents {
+		return n.sendJobEvents(ctx, diff)
+	}
+
+	payload := WebhookPayload{
+		Event:      "jobs_changed",
+		SentAt:     time.Now(),
+		DiffResult: diff,
+	}
+
+	return n.send(ctx, payload)
+}
+
+// sendJobEvents posts an event for every job changed in the diff. A failed
+// event doesn't stop the others from being sent.
+func (n *WebhookNotifier) sendJobEvents(ctx context.Context, diff domain.DiffResult) error {
+	var errs []error
+	for _, event := range diff.JobEvents() {
+		payload := WebhookJobEventPayload{SentAt: time.Now(), JobEvent: event}
+		if err := n.send(ctx, payload); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", event.Type, event.JobID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// send posts a payload to the webhook
+func (n *WebhookNotifier) send(ctx context.Context, payload interface{}) error {
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", n.webhookURL, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	setSignature(req, n.signingSecret, jsonPayload)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned non-success status: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// WebhookDigestPayload represents a digest posted to a generic webhook
+type WebhookDigestPayload struct {
+	Event  string    `json:"event"`
+	SentAt time.Time `json:"sent_at"`
+	domain.Digest
+}
+
+// NotifyDigest posts all changes of a run to the webhook as a single digest
+func (n *WebhookNotifier) NotifyDigest(ctx context.Context, digest domain.Digest) error {
+	if n.jobEvents {
+		var errs []error
+		for _, diff := range digest.Diffs {
+			errs = append(errs, n.sendJobEvents(ctx, diff))
+		}
+		return errors.Join(errs...)
+	}
+
+	payload := WebhookDigestPayload{
+		Event:  "digest",
+		SentAt: time.Now(),
+		Digest: digest,
+	}
+
+	return n.send(ctx, payload)
+}
+
+// WebhookNotificationPayload represents a standalone notification posted to a generic webhook
+type WebhookNotificationPayload struct {
+	Event        string              `json:"event"`
+	SentAt       time.Time           `json:"sent_at"`
+	Notification domain.Notification `json:"notification"`
+}
+
+// Notify posts a standalone notification, such as a scraping error, to the webhook
+func (n *WebhookNotifier) Notify(ctx context.Context, notification domain.Notification) error {
+	payload := WebhookNotificationPayload{
+		Event:        "notification",
+		SentAt:       time.Now(),
+		Notification: notification,
+	}
+
+	return n.send(ctx, payload)
+}
+
+// Probe posts a "test" event so the receiver can be verified at startup.
+// Receivers should acknowledge it with a 2xx status and otherwise ignore it.
+func (n *WebhookNotifier) Probe(ctx context.Context) error {
+	payload := WebhookPayload{
+		Event:  "test",
+		SentAt: time.Now(),
+	}
+
+	if err := n.send(ctx, payload); err != nil {
+		return fmt.Errorf("webhook probe failed: %w", err)
+	}
+	return nil
+}
+
+var (
+	_ ports.Notifier        = (*WebhookNotifier)(nil) // Ensure interface compliance
+	_ ports.DigestNotifier  = (*WebhookNotifier)(nil)
+	_ ports.MessageNotifier = (*WebhookNotifier)(nil)
+	_ ports.Prober          = (*WebhookNotifier)(nil)
+)
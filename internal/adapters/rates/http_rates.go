@@ -0,0 +1,69 @@
+// internal/adapters/rates/http_rates.go
+package rates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// HTTPRateSource implements the RateSource interface by fetching a JSON document
+// of the form {"base": "USD", "rates": {"EUR": 0.92, ...}} from a URL.
+// The "base_code" key used by some public APIs is accepted as well.
+type HTTPRateSource struct {
+	url    string
+	client *http.Client
+}
+
+// ratesResponse represents the JSON rates document
+type ratesResponse struct {
+	Base     string             `json:"base"`
+	BaseCode string             `json:"base_code"`
+	Rates    map[string]float64 `json:"rates"`
+}
+
+// NewHTTPRateSource creates a new HTTPRateSource instance
+func NewHTTPRateSource(url string, client *http.Client) *HTTPRateSource {
+	return &HTTPRateSource{
+		url:    url,
+		client: client,
+	}
+}
+
+// Rates fetches the rates document
+func (s *HTTPRateSource) Rates(ctx context.Context) (string, map[string]float64, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", s.url, nil)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create rates request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to fetch rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", nil, fmt.Errorf("rates source returned non-success status: %d", resp.StatusCode)
+	}
+
+	var body ratesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", nil, fmt.Errorf("failed to decode rates: %w", err)
+	}
+
+	base := body.Base
+	if base == "" {
+		base = body.BaseCode
+	}
+	if base == "" || len(body.Rates) == 0 {
+		return "", nil, fmt.Errorf("rates document has no base currency or rates")
+	}
+
+	return base, body.Rates, nil
+}
+
+var _ ports.RateSource = (*HTTPRateSource)(nil) // Ensure interface compliance
@@ -0,0 +1,29 @@
+// internal/adapters/rates/static_rates.go
+package rates
+
+import (
+	"context"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// StaticRateSource implements the RateSource interface with fixed rates from configuration
+type StaticRateSource struct {
+	base  string
+	rates map[string]float64
+}
+
+// NewStaticRateSource creates a new StaticRateSource instance
+func NewStaticRateSource(base string, rates map[string]float64) *StaticRateSource {
+	return &StaticRateSource{
+		base:  base,
+		rates: rates,
+	}
+}
+
+// Rates returns the configured rates
+func (s *StaticRateSource) Rates(ctx context.Context) (string, map[string]float64, error) {
+	return s.base, s.rates, nil
+}
+
+var _ ports.RateSource = (*StaticRateSource)(nil) // Ensure interface compliance
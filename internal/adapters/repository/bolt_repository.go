@@ -0,0 +1,1130 @@
+// internal/adapters/repository/bolt_repository.go
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// Bucket layout:
+//
+//	sources/<url>/latest            -> version of the latest snapshot
+//	sources/<url>/failure           -> ScrapeFailure JSON of the last failed scrape
+//	sources/<url>/snapshots/<ver>   -> boltSnapshot JSON, versions are big-endian uint64
+//	sources/<url>/diffs/<seq>       -> DiffRecord JSON, oldest first
+//	audit/<id>                      -> AuditEntry JSON
+//	captures/<id>                   -> CapturedNotification JSON
+//	notifications/<id>              -> NotificationRecord JSON
+var (
+	boltSourcesBucket       = []byte("sources")
+	boltSnapshotsBucket     = []byte("snapshots")
+	boltDiffsBucket         = []byte("diffs")
+	boltAuditBucket         = []byte("audit")
+	boltCapturesBucket      = []byte("captures")
+	boltNotificationsBucket = []byte("notifications")
+	boltLatestKey           = []byte("latest")
+	boltFailureKey          = []byte("failure")
+)
+
+// boltSnapshot is a stored version of a job collection
+type boltSnapshot struct {
+	SchemaVersion int                  `json:"schema_version"`
+	Collection    domain.JobCollection `json:"collection"`
+	SavedAt       time.Time            `json:"saved_at"`
+	PurgedAt      *time.Time           `json:"purged_at,omitempty"`
+}
+
+// marshalBoltSnapshot encodes a snapshot with the current schema version
+func marshalBoltSnapshot(snapshot boltSnapshot) ([]byte, error) {
+	snapshot.SchemaVersion = storageSchemaVersion
+	return json.Marshal(snapshot)
+}
+
+// unmarshalBoltSnapshot decodes a snapshot, upgrading its collection to the
+// current schema
+func unmarshalBoltSnapshot(data []byte, snapshot *boltSnapshot) error {
+	var doc struct {
+		SchemaVersion int             `json:"schema_version"`
+		Collection    json.RawMessage `json:"collection"`
+		SavedAt       time.Time       `json:"saved_at"`
+		PurgedAt      *time.Time      `json:"purged_at,omitempty"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	collection, err := migrateCollection(doc.Collection, doc.SchemaVersion)
+	if err != nil {
+		return err
+	}
+	*snapshot = boltSnapshot{
+		SchemaVersion: storageSchemaVersion,
+		Collection:    collection,
+		SavedAt:       doc.SavedAt,
+		PurgedAt:      doc.PurgedAt,
+	}
+	return nil
+}
+
+// BoltRepository implements the JobRepository interface using an embedded
+// bbolt database, for single-binary deployments without external services
+type BoltRepository struct {
+	db           *bolt.DB
+	retention    int // snapshots kept per source, 0 keeps all
+	rawRetention int // snapshots per source keeping their raw page, 0 keeps it in all
+}
+
+// NewBoltRepository opens (or creates) the database file at path. Up to
+// retention snapshots are kept per source, 0 keeps all. Only the newest
+// rawRetention snapshots keep their raw page, 0 keeps it in all.
+//
+// bbolt locks the file for the process that opened it, even to readers, so
+// while the daemon runs the commands reading or changing its database fail
+// with an error saying so.
+func NewBoltRepository(path string, retention, rawRetention int) (*BoltRepository, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if errors.Is(err, bolt.ErrTimeout) {
+		return nil, fmt.Errorf("failed to open bbolt database %s: it is locked by another process, e.g. a running daemon; stop it first, since bbolt allows a single process per database", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bbolt database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{boltSourcesBucket, boltAuditBucket, boltCapturesBucket, boltNotificationsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create bbolt buckets: %w", err)
+	}
+
+	return &BoltRepository{
+		db:           db,
+		retention:    retention,
+		rawRetention: rawRetention,
+	}, nil
+}
+
+// Close closes the database
+func (r *BoltRepository) Close() error {
+	return r.db.Close()
+}
+
+// SaveJobCollection stores the collection as a new snapshot version of its
+// source and makes it the latest one
+func (r *BoltRepository) SaveJobCollection(
+	ctx context.Context,
+	collection domain.JobCollection,
+) error {
+	return r.saveSnapshot(collection, true)
+}
+
+// SaveSnapshot stores the collection as a new snapshot version of its source
+// without making it the latest one
+func (r *BoltRepository) SaveSnapshot(ctx context.Context, collection domain.JobCollection) error {
+	return r.saveSnapshot(collection, false)
+}
+
+// saveSnapshot stores a snapshot version and prunes versions beyond the retention limit
+func (r *BoltRepository) saveSnapshot(collection domain.JobCollection, latest bool) error {
+	snapshot := boltSnapshot{
+		Collection: collection.WithChecksum(),
+		SavedAt:    time.Now(),
+	}
+	data, err := marshalBoltSnapshot(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job collection: %w", err)
+	}
+
+	err = r.db.Update(func(tx *bolt.Tx) error {
+		source, err := tx.Bucket(boltSourcesBucket).CreateBucketIfNotExists([]byte(collection.SourceURL))
+		if err != nil {
+			return err
+		}
+		snapshots, err := source.CreateBucketIfNotExists(boltSnapshotsBucket)
+		if err != nil {
+			return err
+		}
+
+		version, err := snapshots.NextSequence()
+		if err != nil {
+			return err
+		}
+		key := boltKey(version)
+		if err := snapshots.Put(key, data); err != nil {
+			return err
+		}
+		if latest {
+			if err := source.Put(boltLatestKey, key); err != nil {
+				return err
+			}
+		}
+		return r.prune(source, snapshots)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save job collection: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatestJobCollection retrieves the latest snapshot of a URL
+func (r *BoltRepository) GetLatestJobCollection(
+	ctx context.Context,
+	url string,
+) (domain.JobCollection, error) {
+	var snapshot boltSnapshot
+	err := r.db.View(func(tx *bolt.Tx) error {
+		source := tx.Bucket(boltSourcesBucket).Bucket([]byte(url))
+		if source == nil {
+			return domain.ErrNotFound
+		}
+		latest := source.Get(boltLatestKey)
+		snapshots := source.Bucket(boltSnapshotsBucket)
+		if latest == nil || snapshots == nil {
+			return domain.ErrNotFound
+		}
+		data := snapshots.Get(latest)
+		if data == nil {
+			return &domain.CorruptSnapshotError{SourceURL: url, Reason: "latest snapshot is missing"}
+		}
+		if err := unmarshalBoltSnapshot(data, &snapshot); err != nil {
+			return &domain.CorruptSnapshotError{SourceURL: url, Reason: err.Error()}
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.JobCollection{}, err
+	}
+
+	if err := snapshot.Collection.VerifyChecksum(); err != nil {
+		return domain.JobCollection{}, err
+	}
+
+	return snapshot.Collection, nil
+}
+
+// prune deletes the oldest snapshots of a source beyond the retention limit
+// and drops the raw pages of those beyond the raw retention limit, never
+// touching the latest one
+func (r *BoltRepository) prune(source, snapshots *bolt.Bucket) error {
+	if r.retention <= 0 && r.rawRetention <= 0 {
+		return nil
+	}
+
+	type version struct {
+		key       []byte
+		scrapedAt time.Time
+		raw       bool // has a raw page that can be dropped
+	}
+	var versions []version
+	err := snapshots.ForEach(func(key, data []byte) error {
+		var snapshot boltSnapshot
+		json.Unmarshal(data, &snapshot) // only needs the scrape time and raw page; corrupt snapshots sort as oldest
+		_, raw := withoutRawContent(snapshot.Collection)
+		versions = append(versions, version{append([]byte(nil), key...), snapshot.Collection.ScrapedAt, raw})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].scrapedAt.After(versions[j].scrapedAt)
+	})
+
+	latest := source.Get(boltLatestKey)
+	for i, v := range versions {
+		if bytes.Equal(v.key, latest) {
+			continue
+		}
+		switch {
+		case r.retention > 0 && i >= r.retention:
+			if err := snapshots.Delete(v.key); err != nil {
+				return err
+			}
+		case r.rawRetention > 0 && i >= r.rawRetention && v.raw:
+			if err := dropBoltRawContent(snapshots, v.key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dropBoltRawContent rewrites the snapshot version key without its raw page.
+// Unreadable snapshots are left as they are.
+func dropBoltRawContent(snapshots *bolt.Bucket, key []byte) error {
+	var snapshot boltSnapshot
+	if err := unmarshalBoltSnapshot(snapshots.Get(key), &snapshot); err != nil {
+		return nil
+	}
+	collection, ok := withoutRawContent(snapshot.Collection)
+	if !ok {
+		return nil
+	}
+	snapshot.Collection = collection
+	data, err := marshalBoltSnapshot(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	return snapshots.Put(key, data)
+}
+
+// ListSnapshots returns up to limit snapshots of a URL, newest first
+func (r *BoltRepository) ListSnapshots(ctx context.Context, url string, limit int) ([]domain.JobCollection, error) {
+	var snapshots []domain.JobCollection
+	err := r.db.View(func(tx *bolt.Tx) error {
+		bucket := boltSnapshots(tx, url)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(key, data []byte) error {
+			var snapshot boltSnapshot
+			if err := unmarshalBoltSnapshot(data, &snapshot); err != nil {
+				return nil // corrupt snapshots are reported when read as latest
+			}
+			if snapshot.Collection.VerifyChecksum() != nil {
+				return nil
+			}
+			snapshots = append(snapshots, snapshot.Collection)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	sortSnapshots(snapshots)
+	return limitSnapshots(snapshots, limit), nil
+}
+
+// GetJobHistory returns the timeline of a job ID on a URL from its retained snapshots
+func (r *BoltRepository) GetJobHistory(ctx context.Context, url, jobID string) (domain.JobHistory, error) {
+	return jobHistory(ctx, r, url, jobID)
+}
+
+// SaveScrapeFailure records the last failed scrape of a source
+func (r *BoltRepository) SaveScrapeFailure(ctx context.Context, failure domain.ScrapeFailure) error {
+	data, err := json.Marshal(failure)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scrape failure: %w", err)
+	}
+
+	err = r.db.Update(func(tx *bolt.Tx) error {
+		source, err := tx.Bucket(boltSourcesBucket).CreateBucketIfNotExists([]byte(failure.SourceURL))
+		if err != nil {
+			return err
+		}
+		return source.Put(boltFailureKey, data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save scrape failure: %w", err)
+	}
+	return nil
+}
+
+// SaveDiff records a diff computed for a source
+func (r *BoltRepository) SaveDiff(ctx context.Context, record domain.DiffRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal diff: %w", err)
+	}
+
+	err = r.db.Update(func(tx *bolt.Tx) error {
+		source, err := tx.Bucket(boltSourcesBucket).CreateBucketIfNotExists([]byte(record.Diff.SourceURL))
+		if err != nil {
+			return err
+		}
+		diffs, err := source.CreateBucketIfNotExists(boltDiffsBucket)
+		if err != nil {
+			return err
+		}
+		seq, err := diffs.NextSequence()
+		if err != nil {
+			return err
+		}
+		return diffs.Put(boltKey(seq), data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save diff: %w", err)
+	}
+	return nil
+}
+
+// GetDiffHistory returns the diffs recorded for a source since the given time, oldest first
+func (r *BoltRepository) GetDiffHistory(ctx context.Context, url string, since time.Time) ([]domain.DiffRecord, error) {
+	var records []domain.DiffRecord
+	err := r.db.View(func(tx *bolt.Tx) error {
+		source := tx.Bucket(boltSourcesBucket).Bucket([]byte(url))
+		if source == nil {
+			return nil
+		}
+		diffs := source.Bucket(boltDiffsBucket)
+		if diffs == nil {
+			return nil
+		}
+		return diffs.ForEach(func(key, data []byte) error {
+			var record domain.DiffRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				return err
+			}
+			records = append(records, record)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read diff history: %w", err)
+	}
+
+	return domain.FilterDiffRecords(records, since), nil
+}
+
+// ListTrackedSources returns the state of every source in the database
+func (r *BoltRepository) ListTrackedSources(ctx context.Context) ([]domain.TrackedSource, error) {
+	var sources []domain.TrackedSource
+	var failures []domain.ScrapeFailure
+	err := r.db.View(func(tx *bolt.Tx) error {
+		buckets := tx.Bucket(boltSourcesBucket)
+		return buckets.ForEachBucket(func(url []byte) error {
+			source := buckets.Bucket(url)
+			if data := source.Get(boltFailureKey); data != nil {
+				var failure domain.ScrapeFailure
+				if err := json.Unmarshal(data, &failure); err == nil {
+					failures = append(failures, failure)
+				}
+			}
+
+			latest := source.Get(boltLatestKey)
+			snapshots := source.Bucket(boltSnapshotsBucket)
+			if latest == nil || snapshots == nil {
+				return nil
+			}
+			var snapshot boltSnapshot
+			if err := unmarshalBoltSnapshot(snapshots.Get(latest), &snapshot); err != nil {
+				return nil // corrupt snapshots are reported when read
+			}
+			sources = append(sources, domain.TrackCollection(snapshot.Collection))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sources: %w", err)
+	}
+
+	return domain.MergeScrapeFailures(sources, failures), nil
+}
+
+// ListRawSnapshots returns the snapshots of url since the given time, oldest
+// first, with their raw content unless it was purged or archived
+func (r *BoltRepository) ListRawSnapshots(ctx context.Context, url string, since time.Time) ([]domain.RawSnapshot, error) {
+	var snapshots []domain.RawSnapshot
+	err := r.db.View(func(tx *bolt.Tx) error {
+		bucket := boltSnapshots(tx, url)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(key, data []byte) error {
+			var snapshot boltSnapshot
+			if err := unmarshalBoltSnapshot(data, &snapshot); err != nil {
+				return nil // corrupt snapshots are reported when read
+			}
+			collection := snapshot.Collection
+			if collection.ScrapedAt.Before(since) {
+				return nil
+			}
+			snapshots = append(snapshots, domain.RawSnapshot{
+				SourceURL:  url,
+				Version:    int64(binary.BigEndian.Uint64(key)),
+				ScrapedAt:  collection.ScrapedAt,
+				JobCount:   len(collection.Jobs),
+				RawContent: collection.RawContent,
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list raw snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// ReplaceSnapshotJobs overwrites the jobs of a snapshot and updates its checksum
+func (r *BoltRepository) ReplaceSnapshotJobs(ctx context.Context, url string, version int64, jobs []domain.Job) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		bucket := boltSnapshots(tx, url)
+		if bucket == nil {
+			return domain.ErrNotFound
+		}
+		key := boltKey(uint64(version))
+		data := bucket.Get(key)
+		if data == nil {
+			return domain.ErrNotFound
+		}
+
+		var snapshot boltSnapshot
+		if err := unmarshalBoltSnapshot(data, &snapshot); err != nil {
+			return &domain.CorruptSnapshotError{SourceURL: url, Reason: err.Error()}
+		}
+		snapshot.Collection.Jobs = jobs
+		snapshot.Collection = snapshot.Collection.WithChecksum()
+
+		updated, err := marshalBoltSnapshot(snapshot)
+		if err != nil {
+			return fmt.Errorf("failed to marshal snapshot: %w", err)
+		}
+		return bucket.Put(key, updated)
+	})
+}
+
+// PurgePersonalData strips personal data from snapshots scraped, diffs
+// computed and notifications captured or sent before cutoff
+func (r *BoltRepository) PurgePersonalData(ctx context.Context, cutoff time.Time) (int, error) {
+	purged := 0
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		captures, err := purgeBoltCaptures(tx.Bucket(boltCapturesBucket), cutoff)
+		purged += captures
+		if err != nil {
+			return err
+		}
+		sent, err := purgeBoltNotifications(tx.Bucket(boltNotificationsBucket), cutoff)
+		purged += sent
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		return tx.Bucket(boltSourcesBucket).ForEachBucket(func(url []byte) error {
+			diffs, err := purgeBoltDiffs(tx.Bucket(boltSourcesBucket).Bucket(url).Bucket(boltDiffsBucket), cutoff)
+			purged += diffs
+			if err != nil {
+				return err
+			}
+
+			snapshots := boltSnapshots(tx, string(url))
+			if snapshots == nil {
+				return nil
+			}
+
+			cursor := snapshots.Cursor()
+			for key, data := cursor.First(); key != nil; key, data = cursor.Next() {
+				var snapshot boltSnapshot
+				if err := unmarshalBoltSnapshot(data, &snapshot); err != nil {
+					continue // corrupt snapshots are reported when read
+				}
+				if snapshot.PurgedAt != nil || !snapshot.Collection.ScrapedAt.Before(cutoff) {
+					continue
+				}
+
+				snapshot.Collection = snapshot.Collection.StripPersonalData()
+				snapshot.PurgedAt = &now
+				updated, err := marshalBoltSnapshot(snapshot)
+				if err != nil {
+					return err
+				}
+				// Overwriting the current key is allowed while iterating
+				if err := snapshots.Put(key, updated); err != nil {
+					return err
+				}
+				purged++
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge personal data: %w", err)
+	}
+
+	return purged, nil
+}
+
+// purgeBoltDiffs strips job descriptions from the diffs in a diffs bucket,
+// which may be nil, computed before cutoff
+func purgeBoltDiffs(diffs *bolt.Bucket, cutoff time.Time) (int, error) {
+	if diffs == nil {
+		return 0, nil
+	}
+
+	purged := 0
+	cursor := diffs.Cursor()
+	for key, data := cursor.First(); key != nil; key, data = cursor.Next() {
+		var record domain.DiffRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			continue // unreadable diffs are reported when read
+		}
+		if !record.ComputedAt.Before(cutoff) || !record.HasPersonalData() {
+			continue
+		}
+
+		updated, err := json.Marshal(record.StripPersonalData())
+		if err != nil {
+			return purged, err
+		}
+		// Overwriting the current key is allowed while iterating
+		if err := diffs.Put(key, updated); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// DeleteSource removes all snapshots and the captured and sent notifications
+// of a source URL
+func (r *BoltRepository) DeleteSource(ctx context.Context, url string) (int, error) {
+	deleted := 0
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		captures, err := deleteBoltCaptures(tx.Bucket(boltCapturesBucket), url)
+		deleted += captures
+		if err != nil {
+			return err
+		}
+		sent, err := deleteBoltNotifications(tx.Bucket(boltNotificationsBucket), url)
+		deleted += sent
+		if err != nil {
+			return err
+		}
+
+		sources := tx.Bucket(boltSourcesBucket)
+		source := sources.Bucket([]byte(url))
+		if source == nil {
+			return nil
+		}
+		if snapshots := source.Bucket(boltSnapshotsBucket); snapshots != nil {
+			deleted += snapshots.Stats().KeyN
+		}
+		return sources.DeleteBucket([]byte(url))
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete source %s: %w", url, err)
+	}
+
+	return deleted, nil
+}
+
+// PruneSnapshots deletes snapshots scraped before cutoff except the latest of
+// each source, and the diffs and captured and sent notifications from before
+// cutoff
+func (r *BoltRepository) PruneSnapshots(ctx context.Context, cutoff time.Time) (int, error) {
+	pruned := 0
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		if err := pruneBoltCaptures(tx.Bucket(boltCapturesBucket), cutoff); err != nil {
+			return err
+		}
+		if err := pruneBoltNotifications(tx.Bucket(boltNotificationsBucket), cutoff); err != nil {
+			return err
+		}
+
+		sources := tx.Bucket(boltSourcesBucket)
+		return sources.ForEachBucket(func(url []byte) error {
+			source := sources.Bucket(url)
+			if err := pruneBoltDiffs(source, cutoff); err != nil {
+				return err
+			}
+			snapshots := source.Bucket(boltSnapshotsBucket)
+			if snapshots == nil {
+				return nil
+			}
+
+			// Keys are deleted after iterating, which a cursor doesn't survive
+			latest := source.Get(boltLatestKey)
+			var old [][]byte
+			err := snapshots.ForEach(func(key, data []byte) error {
+				var snapshot boltSnapshot
+				if err := unmarshalBoltSnapshot(data, &snapshot); err != nil {
+					return nil // corrupt snapshots are reported when read
+				}
+				if snapshot.Collection.ScrapedAt.Before(cutoff) && !bytes.Equal(key, latest) {
+					old = append(old, append([]byte(nil), key...))
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, key := range old {
+				if err := snapshots.Delete(key); err != nil {
+					return err
+				}
+			}
+			pruned += len(old)
+			return nil
+		})
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune snapshots: %w", err)
+	}
+
+	return pruned, nil
+}
+
+// PruneSources deletes the sources whose latest snapshot was scraped before cutoff
+func (r *BoltRepository) PruneSources(ctx context.Context, cutoff time.Time) ([]string, error) {
+	var pruned []string
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		sources := tx.Bucket(boltSourcesBucket)
+		err := sources.ForEachBucket(func(url []byte) error {
+			source := sources.Bucket(url)
+			latest := source.Get(boltLatestKey)
+			snapshots := source.Bucket(boltSnapshotsBucket)
+			if latest == nil || snapshots == nil {
+				return nil
+			}
+			var snapshot boltSnapshot
+			if err := unmarshalBoltSnapshot(snapshots.Get(latest), &snapshot); err != nil {
+				return nil // corrupt snapshots are reported when read
+			}
+			if snapshot.Collection.ScrapedAt.Before(cutoff) {
+				pruned = append(pruned, string(url))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, url := range pruned {
+			if err := sources.DeleteBucket([]byte(url)); err != nil {
+				return err
+			}
+			if _, err := deleteBoltCaptures(tx.Bucket(boltCapturesBucket), url); err != nil {
+				return err
+			}
+			if _, err := deleteBoltNotifications(tx.Bucket(boltNotificationsBucket), url); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune sources: %w", err)
+	}
+
+	return pruned, nil
+}
+
+// AppendAudit appends an entry to the audit log
+func (r *BoltRepository) AppendAudit(ctx context.Context, entry domain.AuditEntry) error {
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltAuditBucket)
+		id, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		entry.ID = int64(id)
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(boltKey(id), data)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+
+	return nil
+}
+
+// QueryAudit returns the matching audit entries, newest first
+func (r *BoltRepository) QueryAudit(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	var entries []domain.AuditEntry
+	err := r.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(boltAuditBucket).Cursor()
+		for key, data := cursor.Last(); key != nil; key, data = cursor.Prev() {
+			if filter.Limit > 0 && len(entries) == filter.Limit {
+				break
+			}
+			var entry domain.AuditEntry
+			if err := json.Unmarshal(data, &entry); err != nil {
+				return err
+			}
+			if filter.Matches(entry) {
+				entries = append(entries, entry)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// SaveCapture stores a captured notification, deleting the oldest beyond
+// maxCaptures
+func (r *BoltRepository) SaveCapture(ctx context.Context, capture domain.CapturedNotification) error {
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltCapturesBucket)
+		id, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		capture.ID = int64(id)
+
+		data, err := json.Marshal(capture)
+		if err != nil {
+			return err
+		}
+		if err := bucket.Put(boltKey(id), data); err != nil {
+			return err
+		}
+		if id <= maxCaptures {
+			return nil
+		}
+		oldest := boltKey(id - maxCaptures)
+		cursor := bucket.Cursor()
+		for key, _ := cursor.First(); key != nil && bytes.Compare(key, oldest) <= 0; key, _ = cursor.First() {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save captured notification: %w", err)
+	}
+
+	return nil
+}
+
+// purgeBoltCaptures strips personal data from the notifications in the
+// captures bucket captured before cutoff
+func purgeBoltCaptures(captures *bolt.Bucket, cutoff time.Time) (int, error) {
+	purged := 0
+	cursor := captures.Cursor()
+	for key, data := cursor.First(); key != nil; key, data = cursor.Next() {
+		var capture domain.CapturedNotification
+		if err := json.Unmarshal(data, &capture); err != nil {
+			continue // unreadable captures are reported when read
+		}
+		if !capture.CapturedAt.Before(cutoff) || !capture.HasPersonalData() {
+			continue
+		}
+
+		updated, err := json.Marshal(capture.StripPersonalData())
+		if err != nil {
+			return purged, err
+		}
+		// Overwriting the current key is allowed while iterating
+		if err := captures.Put(key, updated); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// deleteBoltCaptures removes what the notifications in the captures bucket
+// say about a source URL, deleting those left empty, and returns how many
+// were deleted
+func deleteBoltCaptures(captures *bolt.Bucket, url string) (int, error) {
+	// Keys are written after iterating, which ForEach doesn't allow
+	var emptied [][]byte
+	kept := make(map[string][]byte)
+	err := captures.ForEach(func(key, data []byte) error {
+		var capture domain.CapturedNotification
+		if err := json.Unmarshal(data, &capture); err != nil || !capture.Concerns(url) {
+			return nil // unreadable captures are reported when read
+		}
+		capture, left := capture.WithoutSource(url)
+		if !left {
+			emptied = append(emptied, append([]byte(nil), key...))
+			return nil
+		}
+		updated, err := json.Marshal(capture)
+		if err != nil {
+			return err
+		}
+		kept[string(key)] = updated
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for key, data := range kept {
+		if err := captures.Put([]byte(key), data); err != nil {
+			return 0, err
+		}
+	}
+	for _, key := range emptied {
+		if err := captures.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+	return len(emptied), nil
+}
+
+// pruneBoltCaptures deletes the notifications in the captures bucket
+// captured before cutoff
+func pruneBoltCaptures(captures *bolt.Bucket, cutoff time.Time) error {
+	var old [][]byte
+	err := captures.ForEach(func(key, data []byte) error {
+		var capture domain.CapturedNotification
+		if err := json.Unmarshal(data, &capture); err != nil {
+			return nil // unreadable captures are reported when read
+		}
+		if capture.CapturedAt.Before(cutoff) {
+			old = append(old, append([]byte(nil), key...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range old {
+		if err := captures.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListCaptures returns up to limit captured notifications, newest first
+func (r *BoltRepository) ListCaptures(ctx context.Context, limit int) ([]domain.CapturedNotification, error) {
+	var captures []domain.CapturedNotification
+	err := r.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(boltCapturesBucket).Cursor()
+		for key, data := cursor.Last(); key != nil; key, data = cursor.Prev() {
+			if limit > 0 && len(captures) == limit {
+				break
+			}
+			var capture domain.CapturedNotification
+			if err := json.Unmarshal(data, &capture); err != nil {
+				return err
+			}
+			captures = append(captures, capture)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list captured notifications: %w", err)
+	}
+
+	return captures, nil
+}
+
+// RecordNotification stores a sent notification with its delivery attempts,
+// deleting the oldest beyond maxNotificationRecords
+func (r *BoltRepository) RecordNotification(ctx context.Context, record domain.NotificationRecord) error {
+	err := r.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(boltNotificationsBucket)
+		id, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		record.ID = int64(id)
+
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if err := bucket.Put(boltKey(id), data); err != nil {
+			return err
+		}
+		if id <= maxNotificationRecords {
+			return nil
+		}
+		oldest := boltKey(id - maxNotificationRecords)
+		cursor := bucket.Cursor()
+		for key, _ := cursor.First(); key != nil && bytes.Compare(key, oldest) <= 0; key, _ = cursor.First() {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record notification: %w", err)
+	}
+
+	return nil
+}
+
+// QueryNotifications returns the matching sent notifications, newest first
+func (r *BoltRepository) QueryNotifications(ctx context.Context, filter domain.NotificationFilter) ([]domain.NotificationRecord, error) {
+	var records []domain.NotificationRecord
+	err := r.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(boltNotificationsBucket).Cursor()
+		for key, data := cursor.Last(); key != nil; key, data = cursor.Prev() {
+			if filter.Limit > 0 && len(records) == filter.Limit {
+				break
+			}
+			var record domain.NotificationRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				return err
+			}
+			if !filter.Since.IsZero() && record.CreatedAt.Before(filter.Since) {
+				break // records are stored in the order they were created
+			}
+			if filter.Matches(record) {
+				records = append(records, record)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+
+	return records, nil
+}
+
+// purgeBoltNotifications strips personal data from the notifications in the
+// notifications bucket sent before cutoff
+func purgeBoltNotifications(notifications *bolt.Bucket, cutoff time.Time) (int, error) {
+	purged := 0
+	cursor := notifications.Cursor()
+	for key, data := cursor.First(); key != nil; key, data = cursor.Next() {
+		var record domain.NotificationRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			continue // unreadable notifications are reported when read
+		}
+		if !record.CreatedAt.Before(cutoff) || !record.HasPersonalData() {
+			continue
+		}
+
+		updated, err := json.Marshal(record.StripPersonalData())
+		if err != nil {
+			return purged, err
+		}
+		// Overwriting the current key is allowed while iterating
+		if err := notifications.Put(key, updated); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// deleteBoltNotifications removes what the notifications in the
+// notifications bucket say about a source URL, deleting those left empty,
+// and returns how many were deleted
+func deleteBoltNotifications(notifications *bolt.Bucket, url string) (int, error) {
+	// Keys are written after iterating, which ForEach doesn't allow
+	var emptied [][]byte
+	kept := make(map[string][]byte)
+	err := notifications.ForEach(func(key, data []byte) error {
+		var record domain.NotificationRecord
+		if err := json.Unmarshal(data, &record); err != nil || !record.Concerns(url) {
+			return nil // unreadable notifications are reported when read
+		}
+		record, left := record.WithoutSource(url)
+		if !left {
+			emptied = append(emptied, append([]byte(nil), key...))
+			return nil
+		}
+		updated, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		kept[string(key)] = updated
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for key, data := range kept {
+		if err := notifications.Put([]byte(key), data); err != nil {
+			return 0, err
+		}
+	}
+	for _, key := range emptied {
+		if err := notifications.Delete(key); err != nil {
+			return 0, err
+		}
+	}
+	return len(emptied), nil
+}
+
+// pruneBoltNotifications deletes the notifications in the notifications
+// bucket sent before cutoff
+func pruneBoltNotifications(notifications *bolt.Bucket, cutoff time.Time) error {
+	var old [][]byte
+	err := notifications.ForEach(func(key, data []byte) error {
+		var record domain.NotificationRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil // unreadable notifications are reported when read
+		}
+		if record.CreatedAt.Before(cutoff) {
+			old = append(old, append([]byte(nil), key...))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range old {
+		if err := notifications.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneBoltDiffs deletes the diffs of a source computed before cutoff
+func pruneBoltDiffs(source *bolt.Bucket, cutoff time.Time) error {
+	diffs := source.Bucket(boltDiffsBucket)
+	if diffs == nil {
+		return nil
+	}
+
+	// Diffs are stored oldest first, so stop at the first one that is kept
+	var old [][]byte
+	cursor := diffs.Cursor()
+	for key, data := cursor.First(); key != nil; key, data = cursor.Next() {
+		var record domain.DiffRecord
+		if err := json.Unmarshal(data, &record); err == nil && !record.ComputedAt.Before(cutoff) {
+			break
+		}
+		old = append(old, append([]byte(nil), key...))
+	}
+	for _, key := range old {
+		if err := diffs.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// boltSnapshots returns the snapshots bucket of a source, or nil if it has none
+func boltSnapshots(tx *bolt.Tx, url string) *bolt.Bucket {
+	source := tx.Bucket(boltSourcesBucket).Bucket([]byte(url))
+	if source == nil {
+		return nil
+	}
+	return source.Bucket(boltSnapshotsBucket)
+}
+
+// boltKey encodes a sequence number so keys sort in numeric order
+func boltKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
+
+var (
+	_ ports.JobRepository       = (*BoltRepository)(nil) // Ensure interface compliance
+	_ ports.AuditLog            = (*BoltRepository)(nil)
+	_ ports.CaptureStore        = (*BoltRepository)(nil)
+	_ ports.NotificationHistory = (*BoltRepository)(nil)
+	_ ports.PersonalDataPurger  = (*BoltRepository)(nil)
+	_ ports.SourceDeleter       = (*BoltRepository)(nil)
+	_ ports.SnapshotArchive     = (*BoltRepository)(nil)
+	_ ports.RetentionPruner     = (*BoltRepository)(nil)
+)
@@ -0,0 +1,127 @@
+// internal/adapters/repository/cached_repository.go
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// cachedCollection is a latest collection held by the cache
+type cachedCollection struct {
+	collection domain.JobCollection
+	cachedAt   time.Time
+}
+
+// CachedRepository is a JobRepository decorator keeping the latest collection
+// of every URL in memory in front of a slow backend, so the diff of each run
+// doesn't read it back from the database. Saves go to the backend first and
+// then replace the cached collection.
+//
+// Only the JobRepository methods are decorated; optional interfaces such as
+// SourceDeleter must be used on the backend. Changes made there, or by other
+// instances sharing it, show up once the cached entry expires.
+type CachedRepository struct {
+	backend ports.JobRepository
+	ttl     time.Duration // 0 keeps entries until the next save
+	entries map[string]cachedCollection
+	mu      sync.RWMutex
+}
+
+// NewCachedRepository wraps backend with a cache whose entries expire after
+// ttl, 0 never expires them
+func NewCachedRepository(backend ports.JobRepository, ttl time.Duration) *CachedRepository {
+	return &CachedRepository{
+		backend: backend,
+		ttl:     ttl,
+		entries: make(map[string]cachedCollection),
+	}
+}
+
+// SaveJobCollection saves the collection to the backend and caches it
+func (r *CachedRepository) SaveJobCollection(ctx context.Context, collection domain.JobCollection) error {
+	if err := r.backend.SaveJobCollection(ctx, collection); err != nil {
+		// The backend may or may not have stored it
+		r.Invalidate(collection.SourceURL)
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[collection.SourceURL] = cachedCollection{
+		collection: collection.WithChecksum(),
+		cachedAt:   time.Now(),
+	}
+	return nil
+}
+
+// GetLatestJobCollection returns the cached collection of a URL, reading it
+// from the backend on a miss. Errors, including ErrNotFound, are not cached.
+func (r *CachedRepository) GetLatestJobCollection(ctx context.Context, url string) (domain.JobCollection, error) {
+	r.mu.RLock()
+	entry, exists := r.entries[url]
+	r.mu.RUnlock()
+	if exists && (r.ttl <= 0 || time.Since(entry.cachedAt) < r.ttl) {
+		return entry.collection, nil
+	}
+
+	collection, err := r.backend.GetLatestJobCollection(ctx, url)
+	if err != nil {
+		r.Invalidate(url)
+		return domain.JobCollection{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[url] = cachedCollection{collection: collection, cachedAt: time.Now()}
+	return collection, nil
+}
+
+// SaveSnapshot saves a snapshot to the backend; the latest collection is unchanged
+func (r *CachedRepository) SaveSnapshot(ctx context.Context, collection domain.JobCollection) error {
+	return r.backend.SaveSnapshot(ctx, collection)
+}
+
+// ListSnapshots returns the snapshots of a URL from the backend
+func (r *CachedRepository) ListSnapshots(ctx context.Context, url string, limit int) ([]domain.JobCollection, error) {
+	return r.backend.ListSnapshots(ctx, url, limit)
+}
+
+// GetJobHistory returns the timeline of a job ID from the backend
+func (r *CachedRepository) GetJobHistory(ctx context.Context, url, jobID string) (domain.JobHistory, error) {
+	return r.backend.GetJobHistory(ctx, url, jobID)
+}
+
+// SaveScrapeFailure records a failed scrape in the backend
+func (r *CachedRepository) SaveScrapeFailure(ctx context.Context, failure domain.ScrapeFailure) error {
+	return r.backend.SaveScrapeFailure(ctx, failure)
+}
+
+// ListTrackedSources returns the state of every URL from the backend
+func (r *CachedRepository) ListTrackedSources(ctx context.Context) ([]domain.TrackedSource, error) {
+	return r.backend.ListTrackedSources(ctx)
+}
+
+// SaveDiff records a diff in the backend
+func (r *CachedRepository) SaveDiff(ctx context.Context, record domain.DiffRecord) error {
+	return r.backend.SaveDiff(ctx, record)
+}
+
+// GetDiffHistory returns the diffs recorded for a URL from the backend
+func (r *CachedRepository) GetDiffHistory(ctx context.Context, url string, since time.Time) ([]domain.DiffRecord, error) {
+	return r.backend.GetDiffHistory(ctx, url, since)
+}
+
+// Invalidate drops the cached collection of a URL
+func (r *CachedRepository) Invalidate(url string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, url)
+}
+
+var (
+	_ ports.JobRepository = (*CachedRepository)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,51 @@
+// internal/adapters/repository/captures.go
+package repository
+
+import (
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// maxCaptures is the number of captured notifications kept, the oldest are
+// deleted beyond it. Captures are for trying out a configuration without
+// sending anything, not an archive of notifications.
+const maxCaptures = 10000
+
+// purgeCaptures strips personal data from the captures made before cutoff
+// and returns how many were purged
+func purgeCaptures(captures []domain.CapturedNotification, cutoff time.Time) int {
+	purged := 0
+	for i, capture := range captures {
+		if capture.CapturedAt.Before(cutoff) && capture.HasPersonalData() {
+			captures[i] = capture.StripPersonalData()
+			purged++
+		}
+	}
+	return purged
+}
+
+// capturesWithoutSource removes what the captures say about the source url,
+// deleting those left empty, and returns the captures kept and the number
+// deleted
+func capturesWithoutSource(captures []domain.CapturedNotification, url string) ([]domain.CapturedNotification, int) {
+	kept := captures[:0]
+	for _, capture := range captures {
+		capture, left := capture.WithoutSource(url)
+		if left {
+			kept = append(kept, capture)
+		}
+	}
+	return kept, len(captures) - len(kept)
+}
+
+// pruneCaptures deletes the captures made before cutoff
+func pruneCaptures(captures []domain.CapturedNotification, cutoff time.Time) []domain.CapturedNotification {
+	kept := captures[:0]
+	for _, capture := range captures {
+		if !capture.CapturedAt.Before(cutoff) {
+			kept = append(kept, capture)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,321 @@
+// internal/adapters/repository/dual_write_repository.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// DualWriteRepository is a JobRepository decorator for migrating between
+// storage backends without downtime. Everything is read from the primary and
+// written to both backends, the primary first. Only a failed write to the
+// primary fails the call; failed writes to the secondary are logged, so it
+// can't take the scraper down while it is being brought up.
+//
+// The secondary starts from the next save of each source, which is enough to
+// keep the diff baseline when switching over; older snapshots can be copied
+// with the export and import commands. The optional ports are served if the
+// primary serves them. Writes through them, such as source deletion,
+// retention pruning, purging personal data, audit entries, captured
+// notifications and notification history, are mirrored to the secondary if
+// it serves the port too. Locks are taken on the primary only. Re-parsed
+// jobs are written to the primary only, since the versions of snapshots
+// differ between backends, so the secondary keeps the jobs parsed first.
+type DualWriteRepository struct {
+	primary   ports.JobRepository
+	secondary ports.JobRepository
+}
+
+// NewDualWriteRepository creates a repository reading from primary and
+// writing to both primary and secondary
+func NewDualWriteRepository(primary, secondary ports.JobRepository) *DualWriteRepository {
+	return &DualWriteRepository{primary: primary, secondary: secondary}
+}
+
+// DualWrite wraps primary and secondary in a DualWriteRepository
+func DualWrite(primary, secondary ports.JobRepository) ports.JobRepository {
+	return NewDualWriteRepository(primary, secondary)
+}
+
+// SaveJobCollection saves the collection to both backends
+func (r *DualWriteRepository) SaveJobCollection(ctx context.Context, collection domain.JobCollection) error {
+	if err := r.primary.SaveJobCollection(ctx, collection); err != nil {
+		return err
+	}
+	r.mirror("save job collection of "+collection.SourceURL, r.secondary.SaveJobCollection(ctx, collection))
+	return nil
+}
+
+// GetLatestJobCollection reads the latest collection of a URL from the primary
+func (r *DualWriteRepository) GetLatestJobCollection(ctx context.Context, url string) (domain.JobCollection, error) {
+	return r.primary.GetLatestJobCollection(ctx, url)
+}
+
+// SaveSnapshot saves the snapshot to both backends
+func (r *DualWriteRepository) SaveSnapshot(ctx context.Context, collection domain.JobCollection) error {
+	if err := r.primary.SaveSnapshot(ctx, collection); err != nil {
+		return err
+	}
+	r.mirror("save snapshot of "+collection.SourceURL, r.secondary.SaveSnapshot(ctx, collection))
+	return nil
+}
+
+// ListSnapshots reads the snapshots of a URL from the primary
+func (r *DualWriteRepository) ListSnapshots(ctx context.Context, url string, limit int) ([]domain.JobCollection, error) {
+	return r.primary.ListSnapshots(ctx, url, limit)
+}
+
+// GetJobHistory reads the timeline of a job ID from the primary
+func (r *DualWriteRepository) GetJobHistory(ctx context.Context, url, jobID string) (domain.JobHistory, error) {
+	return r.primary.GetJobHistory(ctx, url, jobID)
+}
+
+// SaveScrapeFailure records a failed scrape in both backends
+func (r *DualWriteRepository) SaveScrapeFailure(ctx context.Context, failure domain.ScrapeFailure) error {
+	if err := r.primary.SaveScrapeFailure(ctx, failure); err != nil {
+		return err
+	}
+	r.mirror("save scrape failure of "+failure.SourceURL, r.secondary.SaveScrapeFailure(ctx, failure))
+	return nil
+}
+
+// ListTrackedSources reads the state of every URL from the primary
+func (r *DualWriteRepository) ListTrackedSources(ctx context.Context) ([]domain.TrackedSource, error) {
+	return r.primary.ListTrackedSources(ctx)
+}
+
+// SaveDiff records a diff in both backends
+func (r *DualWriteRepository) SaveDiff(ctx context.Context, record domain.DiffRecord) error {
+	if err := r.primary.SaveDiff(ctx, record); err != nil {
+		return err
+	}
+	r.mirror("save diff of "+record.Diff.SourceURL, r.secondary.SaveDiff(ctx, record))
+	return nil
+}
+
+// GetDiffHistory reads the diffs recorded for a URL from the primary
+func (r *DualWriteRepository) GetDiffHistory(ctx context.Context, url string, since time.Time) ([]domain.DiffRecord, error) {
+	return r.primary.GetDiffHistory(ctx, url, since)
+}
+
+// DeleteSource removes all data about a URL from both backends, returning
+// the number of stored collections removed from the primary
+func (r *DualWriteRepository) DeleteSource(ctx context.Context, url string) (int, error) {
+	deleter, ok := ports.Optional[ports.SourceDeleter](r.primary)
+	if !ok {
+		return 0, errors.New("primary repository does not support deleting sources")
+	}
+	deleted, err := deleter.DeleteSource(ctx, url)
+	if err != nil {
+		return deleted, err
+	}
+	if deleter, ok := ports.Optional[ports.SourceDeleter](r.secondary); ok {
+		_, err := deleter.DeleteSource(ctx, url)
+		r.mirror("delete source "+url, err)
+	}
+	return deleted, nil
+}
+
+// PruneSnapshots removes snapshots scraped before cutoff from both backends,
+// returning the number pruned from the primary
+func (r *DualWriteRepository) PruneSnapshots(ctx context.Context, cutoff time.Time) (int, error) {
+	pruner, ok := ports.Optional[ports.RetentionPruner](r.primary)
+	if !ok {
+		return 0, errors.New("primary repository does not support retention pruning")
+	}
+	pruned, err := pruner.PruneSnapshots(ctx, cutoff)
+	if err != nil {
+		return pruned, err
+	}
+	if pruner, ok := ports.Optional[ports.RetentionPruner](r.secondary); ok {
+		_, err := pruner.PruneSnapshots(ctx, cutoff)
+		r.mirror("prune snapshots", err)
+	}
+	return pruned, nil
+}
+
+// PruneSources removes sources last scraped before cutoff from both
+// backends, returning those pruned from the primary
+func (r *DualWriteRepository) PruneSources(ctx context.Context, cutoff time.Time) ([]string, error) {
+	pruner, ok := ports.Optional[ports.RetentionPruner](r.primary)
+	if !ok {
+		return nil, errors.New("primary repository does not support retention pruning")
+	}
+	pruned, err := pruner.PruneSources(ctx, cutoff)
+	if err != nil {
+		return pruned, err
+	}
+	if pruner, ok := ports.Optional[ports.RetentionPruner](r.secondary); ok {
+		_, err := pruner.PruneSources(ctx, cutoff)
+		r.mirror("prune sources", err)
+	}
+	return pruned, nil
+}
+
+// PurgePersonalData strips personal data from snapshots scraped before
+// cutoff in both backends, returning the number purged in the primary
+func (r *DualWriteRepository) PurgePersonalData(ctx context.Context, cutoff time.Time) (int, error) {
+	purger, ok := ports.Optional[ports.PersonalDataPurger](r.primary)
+	if !ok {
+		return 0, errors.New("primary repository does not support purging personal data")
+	}
+	purged, err := purger.PurgePersonalData(ctx, cutoff)
+	if err != nil {
+		return purged, err
+	}
+	if purger, ok := ports.Optional[ports.PersonalDataPurger](r.secondary); ok {
+		_, err := purger.PurgePersonalData(ctx, cutoff)
+		r.mirror("purge personal data", err)
+	}
+	return purged, nil
+}
+
+// ListRawSnapshots reads the raw snapshots of a URL from the primary
+func (r *DualWriteRepository) ListRawSnapshots(ctx context.Context, url string, since time.Time) ([]domain.RawSnapshot, error) {
+	archive, ok := ports.Optional[ports.SnapshotArchive](r.primary)
+	if !ok {
+		return nil, errors.New("primary repository does not archive raw pages")
+	}
+	return archive.ListRawSnapshots(ctx, url, since)
+}
+
+// ReplaceSnapshotJobs overwrites the jobs of a snapshot of the primary
+func (r *DualWriteRepository) ReplaceSnapshotJobs(ctx context.Context, url string, version int64, jobs []domain.Job) error {
+	archive, ok := ports.Optional[ports.SnapshotArchive](r.primary)
+	if !ok {
+		return errors.New("primary repository does not archive raw pages")
+	}
+	return archive.ReplaceSnapshotJobs(ctx, url, version, jobs)
+}
+
+// TryLock acquires a lock of the primary
+func (r *DualWriteRepository) TryLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	locker, ok := ports.Optional[ports.Locker](r.primary)
+	if !ok {
+		return false, errors.New("primary repository does not support locks")
+	}
+	return locker.TryLock(ctx, key, owner, ttl)
+}
+
+// Unlock releases a lock of the primary
+func (r *DualWriteRepository) Unlock(ctx context.Context, key, owner string) error {
+	locker, ok := ports.Optional[ports.Locker](r.primary)
+	if !ok {
+		return errors.New("primary repository does not support locks")
+	}
+	return locker.Unlock(ctx, key, owner)
+}
+
+// SupportsPort reports whether the primary serves an optional port
+func (r *DualWriteRepository) SupportsPort(port any) bool {
+	return servesPort(r.primary, port)
+}
+
+// Close closes both backends if they hold resources
+func (r *DualWriteRepository) Close() error {
+	var errs []error
+	for _, backend := range []ports.JobRepository{r.primary, r.secondary} {
+		if closer, ok := backend.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// mirror logs a failed write to the secondary
+func (r *DualWriteRepository) mirror(action string, err error) {
+	if err != nil {
+		log.Printf("Secondary repository failed to %s: %v", action, err)
+	}
+}
+
+// AppendAudit appends an entry to the audit log of both backends
+func (r *DualWriteRepository) AppendAudit(ctx context.Context, entry domain.AuditEntry) error {
+	audit, ok := ports.Optional[ports.AuditLog](r.primary)
+	if !ok {
+		return errors.New("primary repository does not keep an audit log")
+	}
+	if err := audit.AppendAudit(ctx, entry); err != nil {
+		return err
+	}
+	if audit, ok := ports.Optional[ports.AuditLog](r.secondary); ok {
+		r.mirror("append audit entry", audit.AppendAudit(ctx, entry))
+	}
+	return nil
+}
+
+// QueryAudit reads the matching audit entries from the primary
+func (r *DualWriteRepository) QueryAudit(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	audit, ok := ports.Optional[ports.AuditLog](r.primary)
+	if !ok {
+		return nil, errors.New("primary repository does not keep an audit log")
+	}
+	return audit.QueryAudit(ctx, filter)
+}
+
+// SaveCapture stores a captured notification in both backends
+func (r *DualWriteRepository) SaveCapture(ctx context.Context, capture domain.CapturedNotification) error {
+	captures, ok := ports.Optional[ports.CaptureStore](r.primary)
+	if !ok {
+		return errors.New("primary repository does not store captured notifications")
+	}
+	if err := captures.SaveCapture(ctx, capture); err != nil {
+		return err
+	}
+	if captures, ok := ports.Optional[ports.CaptureStore](r.secondary); ok {
+		r.mirror("save captured notification", captures.SaveCapture(ctx, capture))
+	}
+	return nil
+}
+
+// ListCaptures reads captured notifications from the primary
+func (r *DualWriteRepository) ListCaptures(ctx context.Context, limit int) ([]domain.CapturedNotification, error) {
+	captures, ok := ports.Optional[ports.CaptureStore](r.primary)
+	if !ok {
+		return nil, errors.New("primary repository does not store captured notifications")
+	}
+	return captures.ListCaptures(ctx, limit)
+}
+
+// RecordNotification stores a sent notification in both backends
+func (r *DualWriteRepository) RecordNotification(ctx context.Context, record domain.NotificationRecord) error {
+	history, ok := ports.Optional[ports.NotificationHistory](r.primary)
+	if !ok {
+		return errors.New("primary repository does not keep a notification history")
+	}
+	if err := history.RecordNotification(ctx, record); err != nil {
+		return err
+	}
+	if history, ok := ports.Optional[ports.NotificationHistory](r.secondary); ok {
+		r.mirror("record notification", history.RecordNotification(ctx, record))
+	}
+	return nil
+}
+
+// QueryNotifications reads the matching sent notifications from the primary
+func (r *DualWriteRepository) QueryNotifications(ctx context.Context, filter domain.NotificationFilter) ([]domain.NotificationRecord, error) {
+	history, ok := ports.Optional[ports.NotificationHistory](r.primary)
+	if !ok {
+		return nil, errors.New("primary repository does not keep a notification history")
+	}
+	return history.QueryNotifications(ctx, filter)
+}
+
+var (
+	_ ports.JobRepository       = (*DualWriteRepository)(nil) // Ensure interface compliance
+	_ ports.SourceDeleter       = (*DualWriteRepository)(nil) // Ensure interface compliance
+	_ ports.RetentionPruner     = (*DualWriteRepository)(nil) // Ensure interface compliance
+	_ ports.PersonalDataPurger  = (*DualWriteRepository)(nil) // Ensure interface compliance
+	_ ports.SnapshotArchive     = (*DualWriteRepository)(nil) // Ensure interface compliance
+	_ ports.AuditLog            = (*DualWriteRepository)(nil) // Ensure interface compliance
+	_ ports.CaptureStore        = (*DualWriteRepository)(nil) // Ensure interface compliance
+	_ ports.NotificationHistory = (*DualWriteRepository)(nil) // Ensure interface compliance
+	_ ports.Locker              = (*DualWriteRepository)(nil) // Ensure interface compliance
+	_ ports.PortSupporter       = (*DualWriteRepository)(nil) // Ensure interface compliance
+)
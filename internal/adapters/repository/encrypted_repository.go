@@ -0,0 +1,368 @@
+// internal/adapters/repository/encrypted_repository.go
+package repository
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// sealedPrefix marks a collection whose contents are held encrypted in its
+// RawContent, followed by the base64 nonce and AES-GCM ciphertext
+const sealedPrefix = "aesgcm:v1:"
+
+// EncryptedRepository is a JobRepository decorator encrypting collections at
+// rest with AES-GCM, for backends such as shared object storage. The backend
+// stores a sealed collection keeping only the source URL and scrape times in
+// the clear, which it needs for lookups, ordering and retention; the company,
+// jobs and raw content travel in the ciphertext, bound to the source URL.
+// Collections saved before encryption was enabled are read as they are.
+// Recorded diffs are sealed the same way, keeping only the source URL, run ID
+// and time they were computed in the clear.
+//
+// Besides the JobRepository methods, the audit log, captured notifications,
+// notification history, locks, source deletion and retention pruning are
+// passed to the backend, each if the backend serves it; they are not
+// encrypted. Purging personal data and re-parsing raw pages are not supported
+// since the backend can't see inside sealed collections. Raw pages sent to a
+// separate archive are not encrypted.
+type EncryptedRepository struct {
+	backend ports.JobRepository
+	aead    cipher.AEAD
+}
+
+// NewEncryptedRepository wraps backend, encrypting with key, which must be
+// 16, 24 or 32 bytes to select AES-128, AES-192 or AES-256
+func NewEncryptedRepository(backend ports.JobRepository, key []byte) (*EncryptedRepository, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return &EncryptedRepository{backend: backend, aead: aead}, nil
+}
+
+// EncryptRepository wraps backend in an EncryptedRepository
+func EncryptRepository(backend ports.JobRepository, key []byte) (ports.JobRepository, error) {
+	return NewEncryptedRepository(backend, key)
+}
+
+// SaveJobCollection seals the collection and saves it as the latest one
+func (r *EncryptedRepository) SaveJobCollection(ctx context.Context, collection domain.JobCollection) error {
+	sealed, err := r.seal(collection)
+	if err != nil {
+		return err
+	}
+	return r.backend.SaveJobCollection(ctx, sealed)
+}
+
+// GetLatestJobCollection reads and opens the latest collection of a URL
+func (r *EncryptedRepository) GetLatestJobCollection(ctx context.Context, url string) (domain.JobCollection, error) {
+	sealed, err := r.backend.GetLatestJobCollection(ctx, url)
+	if err != nil {
+		return domain.JobCollection{}, err
+	}
+	return r.open(sealed)
+}
+
+// SaveSnapshot seals the collection and saves it as a snapshot
+func (r *EncryptedRepository) SaveSnapshot(ctx context.Context, collection domain.JobCollection) error {
+	sealed, err := r.seal(collection)
+	if err != nil {
+		return err
+	}
+	return r.backend.SaveSnapshot(ctx, sealed)
+}
+
+// ListSnapshots reads and opens the snapshots of a URL, newest first
+func (r *EncryptedRepository) ListSnapshots(ctx context.Context, url string, limit int) ([]domain.JobCollection, error) {
+	sealed, err := r.backend.ListSnapshots(ctx, url, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]domain.JobCollection, 0, len(sealed))
+	for _, snapshot := range sealed {
+		collection, err := r.open(snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open snapshot of %s scraped at %s: %w", url, snapshot.ScrapedAt.Format(time.RFC3339), err)
+		}
+		snapshots = append(snapshots, collection)
+	}
+	return snapshots, nil
+}
+
+// GetJobHistory returns the timeline of a job ID on a URL from its snapshots
+func (r *EncryptedRepository) GetJobHistory(ctx context.Context, url, jobID string) (domain.JobHistory, error) {
+	return jobHistory(ctx, r, url, jobID)
+}
+
+// SaveScrapeFailure records a failed scrape in the backend
+func (r *EncryptedRepository) SaveScrapeFailure(ctx context.Context, failure domain.ScrapeFailure) error {
+	return r.backend.SaveScrapeFailure(ctx, failure)
+}
+
+// SaveDiff seals the diff and records it in the backend
+func (r *EncryptedRepository) SaveDiff(ctx context.Context, record domain.DiffRecord) error {
+	plaintext, err := json.Marshal(record.Diff)
+	if err != nil {
+		return fmt.Errorf("failed to marshal diff: %w", err)
+	}
+	sealed, err := r.encrypt(plaintext, record.Diff.SourceURL)
+	if err != nil {
+		return err
+	}
+
+	record.Diff = domain.DiffResult{SourceURL: record.Diff.SourceURL, CompanyName: sealed}
+	return r.backend.SaveDiff(ctx, record)
+}
+
+// GetDiffHistory reads and opens the diffs recorded for a URL, oldest first
+func (r *EncryptedRepository) GetDiffHistory(ctx context.Context, url string, since time.Time) ([]domain.DiffRecord, error) {
+	records, err := r.backend.GetDiffHistory(ctx, url, since)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, record := range records {
+		if !strings.HasPrefix(record.Diff.CompanyName, sealedPrefix) {
+			continue // recorded before encryption was enabled
+		}
+		plaintext, err := r.decrypt(record.Diff.CompanyName, url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt diff of run %s: %w", record.RunID, err)
+		}
+		if err := json.Unmarshal(plaintext, &records[i].Diff); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal diff: %w", err)
+		}
+	}
+	return records, nil
+}
+
+// ListTrackedSources returns the state of every URL. The backend can't see
+// company names and job counts, so they are read from the latest collections.
+func (r *EncryptedRepository) ListTrackedSources(ctx context.Context) ([]domain.TrackedSource, error) {
+	sources, err := r.backend.ListTrackedSources(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, source := range sources {
+		if source.LastScrapedAt.IsZero() {
+			continue // only failed so far
+		}
+		collection, err := r.GetLatestJobCollection(ctx, source.SourceURL)
+		if err != nil {
+			continue
+		}
+		sources[i].CompanyName = collection.CompanyName
+		sources[i].JobCount = len(collection.Jobs)
+	}
+	return sources, nil
+}
+
+// DeleteSource removes all data about a URL from the backend
+func (r *EncryptedRepository) DeleteSource(ctx context.Context, url string) (int, error) {
+	deleter, ok := ports.Optional[ports.SourceDeleter](r.backend)
+	if !ok {
+		return 0, errors.New("repository backend does not support deleting sources")
+	}
+	return deleter.DeleteSource(ctx, url)
+}
+
+// PruneSnapshots removes snapshots scraped before cutoff from the backend
+func (r *EncryptedRepository) PruneSnapshots(ctx context.Context, cutoff time.Time) (int, error) {
+	pruner, ok := ports.Optional[ports.RetentionPruner](r.backend)
+	if !ok {
+		return 0, errors.New("repository backend does not support retention pruning")
+	}
+	return pruner.PruneSnapshots(ctx, cutoff)
+}
+
+// PruneSources removes sources last scraped before cutoff from the backend
+func (r *EncryptedRepository) PruneSources(ctx context.Context, cutoff time.Time) ([]string, error) {
+	pruner, ok := ports.Optional[ports.RetentionPruner](r.backend)
+	if !ok {
+		return nil, errors.New("repository backend does not support retention pruning")
+	}
+	return pruner.PruneSources(ctx, cutoff)
+}
+
+// AppendAudit appends an entry to the audit log of the backend
+func (r *EncryptedRepository) AppendAudit(ctx context.Context, entry domain.AuditEntry) error {
+	audit, ok := ports.Optional[ports.AuditLog](r.backend)
+	if !ok {
+		return errors.New("repository backend does not keep an audit log")
+	}
+	return audit.AppendAudit(ctx, entry)
+}
+
+// QueryAudit reads the matching audit entries from the backend
+func (r *EncryptedRepository) QueryAudit(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	audit, ok := ports.Optional[ports.AuditLog](r.backend)
+	if !ok {
+		return nil, errors.New("repository backend does not keep an audit log")
+	}
+	return audit.QueryAudit(ctx, filter)
+}
+
+// SaveCapture stores a captured notification in the backend
+func (r *EncryptedRepository) SaveCapture(ctx context.Context, capture domain.CapturedNotification) error {
+	captures, ok := ports.Optional[ports.CaptureStore](r.backend)
+	if !ok {
+		return errors.New("repository backend does not store captured notifications")
+	}
+	return captures.SaveCapture(ctx, capture)
+}
+
+// ListCaptures reads captured notifications from the backend
+func (r *EncryptedRepository) ListCaptures(ctx context.Context, limit int) ([]domain.CapturedNotification, error) {
+	captures, ok := ports.Optional[ports.CaptureStore](r.backend)
+	if !ok {
+		return nil, errors.New("repository backend does not store captured notifications")
+	}
+	return captures.ListCaptures(ctx, limit)
+}
+
+// RecordNotification stores a sent notification in the backend
+func (r *EncryptedRepository) RecordNotification(ctx context.Context, record domain.NotificationRecord) error {
+	history, ok := ports.Optional[ports.NotificationHistory](r.backend)
+	if !ok {
+		return errors.New("repository backend does not keep a notification history")
+	}
+	return history.RecordNotification(ctx, record)
+}
+
+// QueryNotifications reads the matching sent notifications from the backend
+func (r *EncryptedRepository) QueryNotifications(ctx context.Context, filter domain.NotificationFilter) ([]domain.NotificationRecord, error) {
+	history, ok := ports.Optional[ports.NotificationHistory](r.backend)
+	if !ok {
+		return nil, errors.New("repository backend does not keep a notification history")
+	}
+	return history.QueryNotifications(ctx, filter)
+}
+
+// TryLock acquires a lock of the backend
+func (r *EncryptedRepository) TryLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	locker, ok := ports.Optional[ports.Locker](r.backend)
+	if !ok {
+		return false, errors.New("repository backend does not support locks")
+	}
+	return locker.TryLock(ctx, key, owner, ttl)
+}
+
+// Unlock releases a lock of the backend
+func (r *EncryptedRepository) Unlock(ctx context.Context, key, owner string) error {
+	locker, ok := ports.Optional[ports.Locker](r.backend)
+	if !ok {
+		return errors.New("repository backend does not support locks")
+	}
+	return locker.Unlock(ctx, key, owner)
+}
+
+// SupportsPort reports whether the backend serves an optional port. Purging
+// personal data and re-parsing are never served, whatever the backend.
+func (r *EncryptedRepository) SupportsPort(port any) bool {
+	return servesPort(r.backend, port)
+}
+
+// Close closes the backend if it holds resources
+func (r *EncryptedRepository) Close() error {
+	if closer, ok := r.backend.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// seal encrypts a collection into one the backend can store
+func (r *EncryptedRepository) seal(collection domain.JobCollection) (domain.JobCollection, error) {
+	plaintext, err := json.Marshal(newVersionedCollection(collection.WithChecksum()))
+	if err != nil {
+		return domain.JobCollection{}, fmt.Errorf("failed to marshal job collection: %w", err)
+	}
+
+	sealed, err := r.encrypt(plaintext, collection.SourceURL)
+	if err != nil {
+		return domain.JobCollection{}, err
+	}
+
+	return domain.JobCollection{
+		SourceURL:  collection.SourceURL,
+		ScrapedAt:  collection.ScrapedAt,
+		ChangedAt:  collection.ChangedAt,
+		EmptySince: collection.EmptySince,
+		RawContent: sealed,
+	}, nil
+}
+
+// open decrypts a sealed collection, passing through unsealed ones
+func (r *EncryptedRepository) open(sealed domain.JobCollection) (domain.JobCollection, error) {
+	if !strings.HasPrefix(sealed.RawContent, sealedPrefix) {
+		return sealed, nil
+	}
+
+	plaintext, err := r.decrypt(sealed.RawContent, sealed.SourceURL)
+	if err != nil {
+		return domain.JobCollection{}, &domain.CorruptSnapshotError{SourceURL: sealed.SourceURL, Reason: err.Error()}
+	}
+
+	collection, err := decodeCollection(plaintext)
+	if err != nil {
+		return domain.JobCollection{}, fmt.Errorf("failed to unmarshal job collection: %w", err)
+	}
+	if err := collection.VerifyChecksum(); err != nil {
+		return domain.JobCollection{}, err
+	}
+	return collection, nil
+}
+
+// encrypt seals plaintext bound to a source URL, returning sealedPrefix
+// followed by the base64 nonce and ciphertext
+func (r *EncryptedRepository) encrypt(plaintext []byte, url string) (string, error) {
+	nonce := make([]byte, r.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	ciphertext := r.aead.Seal(nonce, nonce, plaintext, []byte(url))
+	return sealedPrefix + base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// decrypt opens a value sealed by encrypt for the same source URL
+func (r *EncryptedRepository) decrypt(sealed, url string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(sealed, sealedPrefix))
+	if err != nil || len(data) < r.aead.NonceSize() {
+		return nil, errors.New("malformed ciphertext")
+	}
+	nonce, ciphertext := data[:r.aead.NonceSize()], data[r.aead.NonceSize():]
+	plaintext, err := r.aead.Open(nil, nonce, ciphertext, []byte(url))
+	if err != nil {
+		// A wrong key looks the same as tampered data
+		return nil, errors.New("decryption failed, check the encryption key")
+	}
+	return plaintext, nil
+}
+
+var (
+	_ ports.JobRepository       = (*EncryptedRepository)(nil) // Ensure interface compliance
+	_ ports.SourceDeleter       = (*EncryptedRepository)(nil) // Ensure interface compliance
+	_ ports.RetentionPruner     = (*EncryptedRepository)(nil) // Ensure interface compliance
+	_ ports.AuditLog            = (*EncryptedRepository)(nil) // Ensure interface compliance
+	_ ports.CaptureStore        = (*EncryptedRepository)(nil) // Ensure interface compliance
+	_ ports.NotificationHistory = (*EncryptedRepository)(nil) // Ensure interface compliance
+	_ ports.Locker              = (*EncryptedRepository)(nil) // Ensure interface compliance
+	_ ports.PortSupporter       = (*EncryptedRepository)(nil) // Ensure interface compliance
+)
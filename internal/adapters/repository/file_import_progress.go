@@ -0,0 +1,67 @@
+// internal/adapters/repository/file_import_progress.go
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// Directory layout:
+//
+//	<dir>/<slug>.json -> progress of the bulk import of a source
+
+// FileImportProgressStore implements the ImportProgressStore interface with
+// one JSON file per source in a directory
+type FileImportProgressStore struct {
+	dir string
+}
+
+// NewFileImportProgressStore creates the progress directory if needed
+func NewFileImportProgressStore(dir string) (*FileImportProgressStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create import progress directory: %w", err)
+	}
+	return &FileImportProgressStore{dir: dir}, nil
+}
+
+// LoadImportProgress reads the progress of the import of a URL
+func (s *FileImportProgressStore) LoadImportProgress(ctx context.Context, url string) (domain.ImportProgress, error) {
+	data, err := os.ReadFile(s.path(url))
+	if errors.Is(err, os.ErrNotExist) {
+		return domain.ImportProgress{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.ImportProgress{}, fmt.Errorf("failed to read import progress: %w", err)
+	}
+
+	var progress domain.ImportProgress
+	if err := json.Unmarshal(data, &progress); err != nil {
+		return domain.ImportProgress{}, fmt.Errorf("failed to unmarshal import progress: %w", err)
+	}
+	return progress, nil
+}
+
+// SaveImportProgress replaces the progress file of the source atomically
+func (s *FileImportProgressStore) SaveImportProgress(ctx context.Context, progress domain.ImportProgress) error {
+	data, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal import progress: %w", err)
+	}
+	return writeAtomic(s.path(progress.SourceURL), data)
+}
+
+// path returns the progress file of a URL
+func (s *FileImportProgressStore) path(url string) string {
+	return filepath.Join(s.dir, sourceSlug(url)+".json")
+}
+
+var (
+	_ ports.ImportProgressStore = (*FileImportProgressStore)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,217 @@
+// internal/adapters/repository/file_raw_archive.go
+package repository
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// rawPageSuffix ends the names of archived raw pages
+const rawPageSuffix = ".html.gz"
+
+// screenshotSuffix ends the names of archived screenshots
+const screenshotSuffix = ".png"
+
+// Directory layout:
+//
+//	<dir>/<slug>/<time>.html.gz -> gzipped raw page of a scrape
+//	<dir>/<slug>/<time>.png     -> screenshot of the rendered page, if taken
+
+// FileRawArchive implements the RawContentStore interface with gzipped files
+// in a directory
+type FileRawArchive struct {
+	dir string
+}
+
+// NewFileRawArchive creates the archive directory if needed
+func NewFileRawArchive(dir string) (*FileRawArchive, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create raw archive directory: %w", err)
+	}
+	return &FileRawArchive{dir: dir}, nil
+}
+
+// PutRawContent compresses a raw page into the archive
+func (a *FileRawArchive) PutRawContent(ctx context.Context, url string, scrapedAt time.Time, content string) error {
+	path := a.path(url, scrapedAt)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create raw archive directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	zw := gzip.NewWriter(tmp)
+	if _, err := io.WriteString(zw, content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write raw page: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to compress raw page: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close raw page file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store raw page: %w", err)
+	}
+	return nil
+}
+
+// PutScreenshot stores the screenshot of a scrape next to its raw page
+func (a *FileRawArchive) PutScreenshot(ctx context.Context, url string, scrapedAt time.Time, png []byte) error {
+	path := a.screenshotPath(url, scrapedAt)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create raw archive directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	if _, err := tmp.Write(png); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write screenshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close screenshot file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store screenshot: %w", err)
+	}
+	return nil
+}
+
+// GetRawContent reads an archived raw page
+func (a *FileRawArchive) GetRawContent(ctx context.Context, url string, scrapedAt time.Time) (string, error) {
+	f, err := os.Open(a.path(url, scrapedAt))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", domain.ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to open raw page: %w", err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress raw page: %w", err)
+	}
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read raw page: %w", err)
+	}
+	return string(data), nil
+}
+
+// ListRawContent returns the scrape times of the archived pages of url, newest first
+func (a *FileRawArchive) ListRawContent(ctx context.Context, url string) ([]time.Time, error) {
+	entries, err := os.ReadDir(filepath.Join(a.dir, sourceSlug(url)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list raw pages: %w", err)
+	}
+
+	var times []time.Time
+	for _, entry := range entries {
+		scrapedAt, err := time.Parse(snapshotTimeLayout, strings.TrimSuffix(entry.Name(), rawPageSuffix))
+		if err != nil || !strings.HasSuffix(entry.Name(), rawPageSuffix) {
+			continue
+		}
+		times = append(times, scrapedAt)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].After(times[j]) })
+	return times, nil
+}
+
+// PruneRawContent removes the pages and screenshots of scrapes before cutoff
+func (a *FileRawArchive) PruneRawContent(ctx context.Context, cutoff time.Time) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(a.dir, "*", "*"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list raw pages: %w", err)
+	}
+
+	removed := 0
+	for _, path := range paths {
+		scrapedAt, ok := archivedAt(filepath.Base(path))
+		if !ok || !scrapedAt.Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return removed, fmt.Errorf("failed to remove raw page: %w", err)
+		}
+		os.Remove(filepath.Dir(path)) // only succeeds once the source has no pages left
+		removed++
+	}
+	return removed, nil
+}
+
+// PurgePersonalData removes the pages and screenshots of scrapes before
+// cutoff, which are all personal data
+func (a *FileRawArchive) PurgePersonalData(ctx context.Context, cutoff time.Time) (int, error) {
+	return a.PruneRawContent(ctx, cutoff)
+}
+
+// DeleteSource removes the pages and screenshots of a URL
+func (a *FileRawArchive) DeleteSource(ctx context.Context, url string) (int, error) {
+	dir := filepath.Join(a.dir, sourceSlug(url))
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to list raw pages: %w", err)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return 0, fmt.Errorf("failed to delete raw pages of %s: %w", url, err)
+	}
+	return len(entries), nil
+}
+
+// path returns the file of a raw page
+func (a *FileRawArchive) path(url string, scrapedAt time.Time) string {
+	return filepath.Join(a.dir, sourceSlug(url), scrapedAt.UTC().Format(snapshotTimeLayout)+rawPageSuffix)
+}
+
+// screenshotPath returns the file of a screenshot
+func (a *FileRawArchive) screenshotPath(url string, scrapedAt time.Time) string {
+	return filepath.Join(a.dir, sourceSlug(url), scrapedAt.UTC().Format(snapshotTimeLayout)+screenshotSuffix)
+}
+
+// archivedAt returns the scrape time of the raw page or screenshot stored
+// under name
+func archivedAt(name string) (time.Time, bool) {
+	for _, suffix := range []string{rawPageSuffix, screenshotSuffix} {
+		if stamp, ok := strings.CutSuffix(name, suffix); ok {
+			scrapedAt, err := time.Parse(snapshotTimeLayout, stamp)
+			return scrapedAt, err == nil
+		}
+	}
+	return time.Time{}, false
+}
+
+var (
+	_ ports.RawContentStore = (*FileRawArchive)(nil) // Ensure interface compliance
+	_ ports.ScreenshotStore = (*FileRawArchive)(nil)
+)
@@ -0,0 +1,649 @@
+// internal/adapters/repository/file_repository.go
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// unsafeFileChars matches characters replaced in file names derived from URLs
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Directory layout:
+//
+//	<dir>/<slug>.json                   -> latest collection of a URL
+//	<dir>/snapshots/<slug>/<time>.json  -> snapshots of a URL
+//	<dir>/failures/<slug>.json          -> last failed scrape of a URL
+//	<dir>/diffs/<slug>.jsonl            -> DiffRecord lines, oldest first
+
+// FileRepository implements the JobRepository interface by persisting the
+// latest collection of every URL as a JSON file in a data directory. Files
+// are read whenever they are needed rather than cached, so changes another
+// process makes to the directory, e.g. the delete-source or restore commands
+// run alongside the daemon, take effect on its next read.
+type FileRepository struct {
+	dir          string
+	retention    int // snapshots kept per URL, 0 keeps all
+	rawRetention int // snapshots per URL keeping their raw page, 0 keeps it in all
+	mu           sync.RWMutex
+}
+
+// NewFileRepository creates the data directory if needed and checks the
+// collections stored in it. Up to retention snapshots are kept per URL, 0
+// keeps all. Only the newest rawRetention snapshots keep their raw page, 0
+// keeps it in all.
+func NewFileRepository(dir string, retention, rawRetention int) (*FileRepository, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	r := &FileRepository{
+		dir:          dir,
+		retention:    retention,
+		rawRetention: rawRetention,
+	}
+
+	collections, err := r.latest(true)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Found %d job collections in %s", len(collections), dir)
+
+	return r, nil
+}
+
+// latest reads the latest collection of every URL, by URL. Unreadable files
+// are skipped, and logged if report is set; their sources are re-baselined
+// on their next scrape.
+func (r *FileRepository) latest(report bool) (map[string]domain.JobCollection, error) {
+	paths, err := filepath.Glob(filepath.Join(r.dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data directory: %w", err)
+	}
+	collections := make(map[string]domain.JobCollection, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue // deleted since listed
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		collection, err := decodeCollection(data)
+		if err != nil {
+			if report {
+				log.Printf("Skipping unreadable job collection file %s: %v", path, err)
+			}
+			continue
+		}
+		collections[collection.SourceURL] = collection
+	}
+	return collections, nil
+}
+
+// SaveJobCollection writes the collection to its file atomically
+func (r *FileRepository) SaveJobCollection(
+	ctx context.Context,
+	collection domain.JobCollection,
+) error {
+	collection = collection.WithChecksum()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.write(r.path(collection.SourceURL), collection); err != nil {
+		return err
+	}
+	return r.addSnapshot(collection)
+}
+
+// SaveSnapshot writes a collection to the history of its URL
+func (r *FileRepository) SaveSnapshot(ctx context.Context, collection domain.JobCollection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.addSnapshot(collection.WithChecksum())
+}
+
+// addSnapshot writes a snapshot file and removes the oldest beyond the
+// retention limit. The snapshot the new one pushes beyond the raw retention
+// limit loses its raw page.
+func (r *FileRepository) addSnapshot(collection domain.JobCollection) error {
+	dir := r.snapshotDir(collection.SourceURL)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	name := collection.ScrapedAt.UTC().Format(snapshotTimeLayout) + ".json"
+	if err := r.write(filepath.Join(dir, name), collection); err != nil {
+		return err
+	}
+
+	if r.retention <= 0 && r.rawRetention <= 0 {
+		return nil
+	}
+	names, err := snapshotFiles(dir)
+	if err != nil {
+		return err
+	}
+	if r.retention > 0 {
+		for _, old := range names[min(r.retention, len(names)):] {
+			if err := os.Remove(filepath.Join(dir, old)); err != nil {
+				return fmt.Errorf("failed to remove old snapshot: %w", err)
+			}
+		}
+		names = names[:min(r.retention, len(names))]
+	}
+	if r.rawRetention > 0 && len(names) > r.rawRetention {
+		return r.dropRawContent(filepath.Join(dir, names[r.rawRetention]))
+	}
+	return nil
+}
+
+// dropRawContent rewrites the snapshot file at path without its raw page
+func (r *FileRepository) dropRawContent(path string) error {
+	snapshot, err := readCollection(path)
+	if err != nil {
+		log.Printf("Failed to read snapshot %s to drop its raw page: %v", path, err)
+		return nil
+	}
+	if snapshot, ok := withoutRawContent(snapshot); ok {
+		return r.write(path, snapshot)
+	}
+	return nil
+}
+
+// GetLatestJobCollection reads the latest job collection for a URL from its
+// file
+func (r *FileRepository) GetLatestJobCollection(
+	ctx context.Context,
+	url string,
+) (domain.JobCollection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	path := r.path(url)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return domain.JobCollection{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.JobCollection{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	collection, err := decodeCollection(data)
+	if err != nil {
+		// The source is re-baselined, which rewrites the file
+		log.Printf("Skipping unreadable job collection file %s: %v", path, err)
+		return domain.JobCollection{}, domain.ErrNotFound
+	}
+
+	if err := collection.VerifyChecksum(); err != nil {
+		return domain.JobCollection{}, err
+	}
+
+	return collection, nil
+}
+
+// ListSnapshots returns up to limit snapshots of a URL, newest first
+func (r *FileRepository) ListSnapshots(ctx context.Context, url string, limit int) ([]domain.JobCollection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dir := r.snapshotDir(url)
+	names, err := snapshotFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(names) > limit {
+		names = names[:limit]
+	}
+
+	snapshots := make([]domain.JobCollection, 0, len(names))
+	for _, name := range names {
+		collection, err := readCollection(filepath.Join(dir, name))
+		if err != nil || collection.VerifyChecksum() != nil {
+			continue // corrupt snapshots are reported when read as latest
+		}
+		snapshots = append(snapshots, collection)
+	}
+	return snapshots, nil
+}
+
+// GetJobHistory returns the timeline of a job ID on a URL from its retained snapshots
+func (r *FileRepository) GetJobHistory(ctx context.Context, url, jobID string) (domain.JobHistory, error) {
+	return jobHistory(ctx, r, url, jobID)
+}
+
+// SaveScrapeFailure writes the last failed scrape of a URL to its file
+func (r *FileRepository) SaveScrapeFailure(ctx context.Context, failure domain.ScrapeFailure) error {
+	data, err := json.MarshalIndent(failure, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal scrape failure: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Join(r.dir, "failures"), 0o755); err != nil {
+		return fmt.Errorf("failed to create failures directory: %w", err)
+	}
+	return writeAtomic(r.failurePath(failure.SourceURL), data)
+}
+
+// SaveDiff appends a diff computed for a URL to its diff file
+func (r *FileRepository) SaveDiff(ctx context.Context, record domain.DiffRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal diff: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Join(r.dir, "diffs"), 0o755); err != nil {
+		return fmt.Errorf("failed to create diffs directory: %w", err)
+	}
+	file, err := os.OpenFile(r.diffPath(record.Diff.SourceURL), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open diff file: %w", err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to save diff: %w", err)
+	}
+	return file.Close()
+}
+
+// GetDiffHistory returns the diffs recorded for a URL since the given time, oldest first
+func (r *FileRepository) GetDiffHistory(ctx context.Context, url string, since time.Time) ([]domain.DiffRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	records, err := readDiffs(r.diffPath(url))
+	if err != nil {
+		return nil, err
+	}
+	return domain.FilterDiffRecords(records, since), nil
+}
+
+// ListTrackedSources returns the state of every URL in the data directory
+func (r *FileRepository) ListTrackedSources(ctx context.Context) ([]domain.TrackedSource, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	collections, err := r.latest(false)
+	if err != nil {
+		return nil, err
+	}
+	sources := make([]domain.TrackedSource, 0, len(collections))
+	for _, collection := range collections {
+		sources = append(sources, domain.TrackCollection(collection))
+	}
+
+	paths, err := filepath.Glob(filepath.Join(r.dir, "failures", "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scrape failures: %w", err)
+	}
+	var failures []domain.ScrapeFailure
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		var failure domain.ScrapeFailure
+		if err := json.Unmarshal(data, &failure); err == nil {
+			failures = append(failures, failure)
+		}
+	}
+
+	return domain.MergeScrapeFailures(sources, failures), nil
+}
+
+// PurgePersonalData strips personal data from collections scraped and diffs
+// computed before cutoff
+func (r *FileRepository) PurgePersonalData(ctx context.Context, cutoff time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	collections, err := r.latest(false)
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for url, collection := range collections {
+		if !collection.ScrapedAt.Before(cutoff) || !hasPersonalData(collection) {
+			continue
+		}
+		if err := r.write(r.path(url), collection.StripPersonalData()); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	paths, err := filepath.Glob(filepath.Join(r.dir, "snapshots", "*", "*.json"))
+	if err != nil {
+		return purged, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	for _, path := range paths {
+		scrapedAt, err := time.Parse(snapshotTimeLayout, strings.TrimSuffix(filepath.Base(path), ".json"))
+		if err != nil || !scrapedAt.Before(cutoff) {
+			continue
+		}
+		collection, err := readCollection(path)
+		if err != nil || !hasPersonalData(collection) {
+			continue
+		}
+		if err := r.write(path, collection.StripPersonalData()); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	diffs, err := r.purgeDiffs(cutoff)
+	return purged + diffs, err
+}
+
+// DeleteSource removes the files of a source URL
+func (r *FileRepository) DeleteSource(ctx context.Context, url string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names, err := snapshotFiles(r.snapshotDir(url))
+	if err != nil {
+		return 0, err
+	}
+	if err := os.RemoveAll(r.snapshotDir(url)); err != nil {
+		return 0, fmt.Errorf("failed to delete snapshots of %s: %w", url, err)
+	}
+	deleted := len(names)
+
+	err = os.Remove(r.path(url))
+	if err == nil {
+		deleted++
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return deleted, fmt.Errorf("failed to delete source %s: %w", url, err)
+	}
+
+	if err := os.Remove(r.failurePath(url)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return deleted, fmt.Errorf("failed to delete scrape failure of %s: %w", url, err)
+	}
+	if err := os.Remove(r.diffPath(url)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return deleted, fmt.Errorf("failed to delete diffs of %s: %w", url, err)
+	}
+	return deleted, nil
+}
+
+// PruneSnapshots deletes snapshot files scraped before cutoff except the
+// latest of each URL
+func (r *FileRepository) PruneSnapshots(ctx context.Context, cutoff time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	collections, err := r.latest(false)
+	if err != nil {
+		return 0, err
+	}
+	latest := make(map[string]bool, len(collections))
+	for url, collection := range collections {
+		latest[filepath.Join(r.snapshotDir(url), collection.ScrapedAt.UTC().Format(snapshotTimeLayout)+".json")] = true
+	}
+
+	paths, err := filepath.Glob(filepath.Join(r.dir, "snapshots", "*", "*.json"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	pruned := 0
+	for _, path := range paths {
+		scrapedAt, err := time.Parse(snapshotTimeLayout, strings.TrimSuffix(filepath.Base(path), ".json"))
+		if err != nil || !scrapedAt.Before(cutoff) || latest[path] {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return pruned, fmt.Errorf("failed to remove old snapshot: %w", err)
+		}
+		pruned++
+	}
+
+	if err := r.pruneDiffs(cutoff); err != nil {
+		return pruned, err
+	}
+	return pruned, nil
+}
+
+// PruneSources deletes the files of URLs last scraped before cutoff
+func (r *FileRepository) PruneSources(ctx context.Context, cutoff time.Time) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	collections, err := r.latest(false)
+	if err != nil {
+		return nil, err
+	}
+	var pruned []string
+	for url, collection := range collections {
+		if !collection.ScrapedAt.Before(cutoff) {
+			continue
+		}
+		if err := os.RemoveAll(r.snapshotDir(url)); err != nil {
+			return pruned, fmt.Errorf("failed to delete snapshots of %s: %w", url, err)
+		}
+		if err := os.Remove(r.path(url)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return pruned, fmt.Errorf("failed to delete source %s: %w", url, err)
+		}
+		if err := os.Remove(r.failurePath(url)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return pruned, fmt.Errorf("failed to delete scrape failure of %s: %w", url, err)
+		}
+		if err := os.Remove(r.diffPath(url)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return pruned, fmt.Errorf("failed to delete diffs of %s: %w", url, err)
+		}
+		pruned = append(pruned, url)
+	}
+	return pruned, nil
+}
+
+// pruneDiffs rewrites the diff files without the diffs computed before cutoff
+func (r *FileRepository) pruneDiffs(cutoff time.Time) error {
+	paths, err := filepath.Glob(filepath.Join(r.dir, "diffs", "*.jsonl"))
+	if err != nil {
+		return fmt.Errorf("failed to list diff files: %w", err)
+	}
+	for _, path := range paths {
+		records, err := readDiffs(path)
+		if err != nil {
+			return err
+		}
+		kept := domain.FilterDiffRecords(records, cutoff)
+		if len(kept) == len(records) {
+			continue
+		}
+		if err := writeDiffs(path, kept); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// purgeDiffs rewrites the diff files with the job descriptions stripped from
+// the diffs computed before cutoff
+func (r *FileRepository) purgeDiffs(cutoff time.Time) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(r.dir, "diffs", "*.jsonl"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list diff files: %w", err)
+	}
+
+	purged := 0
+	for _, path := range paths {
+		records, err := readDiffs(path)
+		if err != nil {
+			return purged, err
+		}
+		stripped := 0
+		for i, record := range records {
+			if record.ComputedAt.Before(cutoff) && record.HasPersonalData() {
+				records[i] = record.StripPersonalData()
+				stripped++
+			}
+		}
+		if stripped == 0 {
+			continue
+		}
+		if err := writeDiffs(path, records); err != nil {
+			return purged, err
+		}
+		purged += stripped
+	}
+	return purged, nil
+}
+
+// writeDiffs replaces a diff file with records
+func writeDiffs(path string, records []domain.DiffRecord) error {
+	var data []byte
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to marshal diff: %w", err)
+		}
+		data = append(append(data, line...), '\n')
+	}
+	return writeAtomic(path, data)
+}
+
+// write stores a collection at path via a temporary file and rename, so a
+// crash never leaves a half-written file behind
+func (r *FileRepository) write(path string, collection domain.JobCollection) error {
+	data, err := json.MarshalIndent(newVersionedCollection(collection), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal job collection: %w", err)
+	}
+	return writeAtomic(path, data)
+}
+
+// writeAtomic writes data to path via a temporary file and rename
+func writeAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync %s: %w", filepath.Base(path), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", filepath.Base(path), err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// path returns the file of a URL
+func (r *FileRepository) path(url string) string {
+	return filepath.Join(r.dir, sourceSlug(url)+".json")
+}
+
+// failurePath returns the file holding the last failed scrape of a URL
+func (r *FileRepository) failurePath(url string) string {
+	return filepath.Join(r.dir, "failures", sourceSlug(url)+".json")
+}
+
+// diffPath returns the file holding the diffs computed for a URL
+func (r *FileRepository) diffPath(url string) string {
+	return filepath.Join(r.dir, "diffs", sourceSlug(url)+".jsonl")
+}
+
+// snapshotDir returns the directory holding the snapshots of a URL
+func (r *FileRepository) snapshotDir(url string) string {
+	return filepath.Join(r.dir, "snapshots", sourceSlug(url))
+}
+
+// snapshotFiles returns the snapshot file names in dir, newest first
+func snapshotFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	return names, nil
+}
+
+// readDiffs reads a diff file, skipping unreadable lines such as one cut
+// short by a crash. A missing file holds no diffs.
+func readDiffs(path string) ([]domain.DiffRecord, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var records []domain.DiffRecord
+	for _, line := range strings.Split(string(data), "\n") {
+		var record domain.DiffRecord
+		if line == "" || json.Unmarshal([]byte(line), &record) != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// readCollection reads a collection file
+func readCollection(path string) (domain.JobCollection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return domain.JobCollection{}, err
+	}
+	return decodeCollection(data)
+}
+
+// sourceSlug turns a URL into a name usable as file name or object key: a
+// readable slug plus a hash to keep names unique
+func sourceSlug(url string) string {
+	slug := strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	slug = strings.Trim(unsafeFileChars.ReplaceAllString(slug, "_"), "_")
+	if len(slug) > 80 {
+		slug = slug[:80]
+	}
+	sum := sha256.Sum256([]byte(url))
+	return slug + "-" + hex.EncodeToString(sum[:4])
+}
+
+var (
+	_ ports.JobRepository      = (*FileRepository)(nil) // Ensure interface compliance
+	_ ports.PersonalDataPurger = (*FileRepository)(nil)
+	_ ports.SourceDeleter      = (*FileRepository)(nil)
+	_ ports.RetentionPruner    = (*FileRepository)(nil)
+)
@@ -0,0 +1,136 @@
+// internal/adapters/repository/file_snooze_store.go
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// FileSnoozeStore implements the SnoozeStore interface with a JSON file. The
+// file is read on every call, so snoozes added by a CLI command or the API
+// of another process apply to the next notification. Expired snoozes are
+// dropped whenever the file is written.
+type FileSnoozeStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSnoozeStore creates the directory of the snooze file if needed
+func NewFileSnoozeStore(path string) (*FileSnoozeStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create snooze directory: %w", err)
+	}
+	return &FileSnoozeStore{path: path}, nil
+}
+
+// SaveSnooze adds a snooze with a new random ID
+func (s *FileSnoozeStore) SaveSnooze(ctx context.Context, snooze domain.Snooze) (domain.Snooze, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return domain.Snooze{}, fmt.Errorf("failed to generate snooze ID: %w", err)
+	}
+	snooze.ID = hex.EncodeToString(id)
+	if snooze.CreatedAt.IsZero() {
+		snooze.CreatedAt = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snoozes, err := s.read()
+	if err != nil {
+		return domain.Snooze{}, err
+	}
+	if err := s.write(append(activeSnoozes(snoozes, time.Now()), snooze)); err != nil {
+		return domain.Snooze{}, err
+	}
+	return snooze, nil
+}
+
+// ListSnoozes returns the snoozes active at a time, ending soonest first
+func (s *FileSnoozeStore) ListSnoozes(ctx context.Context, at time.Time) ([]domain.Snooze, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snoozes, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	snoozes = activeSnoozes(snoozes, at)
+	sort.SliceStable(snoozes, func(i, j int) bool { return snoozes[i].Until.Before(snoozes[j].Until) })
+	return snoozes, nil
+}
+
+// DeleteSnooze removes a snooze by ID
+func (s *FileSnoozeStore) DeleteSnooze(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snoozes, err := s.read()
+	if err != nil {
+		return err
+	}
+	kept := activeSnoozes(snoozes, time.Now())
+	for i, snooze := range kept {
+		if snooze.ID == id {
+			return s.write(append(kept[:i], kept[i+1:]...))
+		}
+	}
+	return domain.ErrNotFound
+}
+
+// read loads every snooze of the file, none if it doesn't exist yet
+func (s *FileSnoozeStore) read() ([]domain.Snooze, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snoozes: %w", err)
+	}
+
+	var snoozes []domain.Snooze
+	if err := json.Unmarshal(data, &snoozes); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snoozes: %w", err)
+	}
+	return snoozes, nil
+}
+
+// write replaces the file atomically
+func (s *FileSnoozeStore) write(snoozes []domain.Snooze) error {
+	if snoozes == nil {
+		snoozes = []domain.Snooze{}
+	}
+	data, err := json.MarshalIndent(snoozes, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal snoozes: %w", err)
+	}
+	return writeAtomic(s.path, data)
+}
+
+// activeSnoozes returns the snoozes still in effect at a time
+func activeSnoozes(snoozes []domain.Snooze, at time.Time) []domain.Snooze {
+	var kept []domain.Snooze
+	for _, snooze := range snoozes {
+		if snooze.Active(at) {
+			kept = append(kept, snooze)
+		}
+	}
+	return kept
+}
+
+var (
+	_ ports.SnoozeStore = (*FileSnoozeStore)(nil) // Ensure interface compliance
+)
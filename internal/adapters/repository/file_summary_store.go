@@ -0,0 +1,130 @@
+// internal/adapters/repository/file_summary_store.go
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// summarySourcesDir holds the source URLs of every page of a FileSummaryStore,
+// hidden from the pages served
+const summarySourcesDir = ".sources"
+
+// FileSummaryStore implements the SummaryStore interface with files in a
+// directory, served under baseURL by the dashboard server or any web server.
+// The source URLs of a page are kept in a JSON file of the same name in the
+// .sources subdirectory; pages stored before that are only pruned by age.
+type FileSummaryStore struct {
+	dir     string
+	baseURL string
+}
+
+// NewFileSummaryStore creates the summary directory if needed
+func NewFileSummaryStore(dir, baseURL string) (*FileSummaryStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, summarySourcesDir), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create summary directory: %w", err)
+	}
+	return &FileSummaryStore{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+// PutSummary writes a summary page into the directory, after its sources so
+// that no page is left without them
+func (s *FileSummaryStore) PutSummary(ctx context.Context, name, contentType string, content []byte, sources []string) (string, error) {
+	name = filepath.Base(name)
+	data, err := json.Marshal(sources)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal summary sources: %w", err)
+	}
+	if err := writeAtomic(s.sourcesPath(name), data); err != nil {
+		return "", fmt.Errorf("failed to store summary sources: %w", err)
+	}
+	if err := writeAtomic(filepath.Join(s.dir, name), content); err != nil {
+		return "", fmt.Errorf("failed to store summary: %w", err)
+	}
+	return s.baseURL + "/" + url.PathEscape(name), nil
+}
+
+// PruneSummaries removes the pages written before cutoff
+func (s *FileSummaryStore) PruneSummaries(ctx context.Context, cutoff time.Time) (int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list summaries: %w", err)
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := s.remove(entry.Name()); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// PurgePersonalData removes the pages written before cutoff, which are all
+// personal data
+func (s *FileSummaryStore) PurgePersonalData(ctx context.Context, cutoff time.Time) (int, error) {
+	return s.PruneSummaries(ctx, cutoff)
+}
+
+// DeleteSource removes the pages about a URL
+func (s *FileSummaryStore) DeleteSource(ctx context.Context, sourceURL string) (int, error) {
+	paths, err := filepath.Glob(filepath.Join(s.dir, summarySourcesDir, "*.json"))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list summary sources: %w", err)
+	}
+
+	removed := 0
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return removed, fmt.Errorf("failed to read summary sources: %w", err)
+		}
+		var sources []string
+		if err := json.Unmarshal(data, &sources); err != nil || !slices.Contains(sources, sourceURL) {
+			continue
+		}
+		if err := s.remove(strings.TrimSuffix(filepath.Base(path), ".json")); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// remove deletes a page and its sources
+func (s *FileSummaryStore) remove(name string) error {
+	if err := os.Remove(filepath.Join(s.dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove summary: %w", err)
+	}
+	if err := os.Remove(s.sourcesPath(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove summary sources: %w", err)
+	}
+	return nil
+}
+
+// sourcesPath returns the file of the source URLs of a page
+func (s *FileSummaryStore) sourcesPath(name string) string {
+	return filepath.Join(s.dir, summarySourcesDir, name+".json")
+}
+
+var (
+	_ ports.SummaryStore = (*FileSummaryStore)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,238 @@
+// internal/adapters/repository/memory_dump.go
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// memoryDumper writes a MemoryRepository to a file periodically
+type memoryDumper struct {
+	path     string
+	interval time.Duration // 0 only dumps on Close
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// memoryDump is the document a MemoryRepository is dumped to. Collections are
+// versioned like those of the persistent repositories so dumps written by an
+// older release are upgraded when loaded.
+type memoryDump struct {
+	SchemaVersion int                           `json:"schema_version"`
+	DumpedAt      time.Time                     `json:"dumped_at"`
+	Sources       []memoryDumpSource            `json:"sources"` // least recently used first
+	Audit         []domain.AuditEntry           `json:"audit,omitempty"`
+	Captures      []domain.CapturedNotification `json:"captures,omitempty"`
+	Sent          []domain.NotificationRecord   `json:"sent,omitempty"`
+}
+
+// memoryDumpSource holds everything kept about one URL
+type memoryDumpSource struct {
+	URL       string                `json:"url"`
+	Latest    json.RawMessage       `json:"latest,omitempty"`
+	Snapshots []json.RawMessage     `json:"snapshots,omitempty"` // newest first
+	Failure   *domain.ScrapeFailure `json:"failure,omitempty"`
+	Diffs     []domain.DiffRecord   `json:"diffs,omitempty"`
+}
+
+// Dump writes the repository atomically to the dump file. It is a no-op
+// unless the repository was created WithDumpFile.
+func (r *MemoryRepository) Dump() error {
+	if r.dump == nil {
+		return nil
+	}
+
+	data, err := r.marshalDump()
+	if err != nil {
+		return err
+	}
+	if err := writeAtomic(r.dump.path, data); err != nil {
+		return fmt.Errorf("failed to write memory repository dump: %w", err)
+	}
+	return nil
+}
+
+// Close stops the periodic dumps and writes a final one
+func (r *MemoryRepository) Close() error {
+	if r.dump == nil {
+		return nil
+	}
+	select {
+	case <-r.dump.stop:
+		return nil // already closed
+	default:
+		close(r.dump.stop)
+	}
+	<-r.dump.done
+	return r.Dump()
+}
+
+// dumpPeriodically dumps the repository every interval until Close
+func (r *MemoryRepository) dumpPeriodically() {
+	defer close(r.dump.done)
+	if r.dump.interval <= 0 {
+		<-r.dump.stop
+		return
+	}
+
+	ticker := time.NewTicker(r.dump.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.dump.stop:
+			return
+		case <-ticker.C:
+			if err := r.Dump(); err != nil {
+				log.Printf("Failed to dump memory repository: %v", err)
+			}
+		}
+	}
+}
+
+// marshalDump encodes the repository contents
+func (r *MemoryRepository) marshalDump() ([]byte, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dump := memoryDump{
+		SchemaVersion: storageSchemaVersion,
+		DumpedAt:      time.Now(),
+		Audit:         r.audit,
+		Captures:      r.captures,
+		Sent:          r.sent,
+	}
+	for _, url := range r.dumpOrder() {
+		source := memoryDumpSource{URL: url, Diffs: r.diffs[url]}
+		if collection, ok := r.collections[url]; ok {
+			latest, err := json.Marshal(newVersionedCollection(collection))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal job collection: %w", err)
+			}
+			source.Latest = latest
+		}
+		for _, snapshot := range r.snapshots[url] {
+			data, err := json.Marshal(newVersionedCollection(snapshot))
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal snapshot: %w", err)
+			}
+			source.Snapshots = append(source.Snapshots, data)
+		}
+		if failure, ok := r.failures[url]; ok {
+			source.Failure = &failure
+		}
+		dump.Sources = append(dump.Sources, source)
+	}
+
+	data, err := json.Marshal(dump)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal memory repository dump: %w", err)
+	}
+	return data, nil
+}
+
+// dumpOrder returns every URL with data, least recently used first. URLs
+// only known from failures or diffs, or evicted, come first. The caller holds
+// mu.
+func (r *MemoryRepository) dumpOrder() []string {
+	r.lruMu.Lock()
+	defer r.lruMu.Unlock()
+
+	seen := make(map[string]bool)
+	var order []string
+	for _, m := range []map[string]bool{keys(r.collections), keys(r.failures), keys(r.diffs)} {
+		for url := range m {
+			if _, tracked := r.elements[url]; !tracked && !seen[url] {
+				seen[url] = true
+				order = append(order, url)
+			}
+		}
+	}
+	for element := r.recent.Back(); element != nil; element = element.Prev() {
+		order = append(order, element.Value.(string))
+	}
+	return order
+}
+
+// keys returns the set of keys of a map
+func keys[V any](m map[string]V) map[string]bool {
+	set := make(map[string]bool, len(m))
+	for key := range m {
+		set[key] = true
+	}
+	return set
+}
+
+// loadDump fills the repository from the dump file if there is one. A dump
+// that can't be read is moved aside rather than overwritten by the next dump.
+func (r *MemoryRepository) loadDump() {
+	data, err := os.ReadFile(r.dump.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err == nil {
+		err = r.restore(data)
+	}
+	if err != nil {
+		log.Printf("Failed to load memory repository dump %s, starting empty: %v", r.dump.path, err)
+		if err := os.Rename(r.dump.path, r.dump.path+".corrupt"); err != nil {
+			log.Printf("Failed to move aside memory repository dump: %v", err)
+		}
+		return
+	}
+	log.Printf("Loaded %d sources from memory repository dump %s", len(r.collections), r.dump.path)
+}
+
+// restore decodes a dump into the repository
+func (r *MemoryRepository) restore(data []byte) error {
+	var dump memoryDump
+	if err := json.Unmarshal(data, &dump); err != nil {
+		return fmt.Errorf("failed to unmarshal memory repository dump: %w", err)
+	}
+	if err := checkSchemaVersion(dump.SchemaVersion); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, source := range dump.Sources {
+		if source.Latest != nil {
+			collection, err := decodeCollection(source.Latest)
+			if err != nil {
+				return fmt.Errorf("failed to decode job collection of %s: %w", source.URL, err)
+			}
+			r.collections[source.URL] = collection
+		}
+		for _, data := range source.Snapshots {
+			snapshot, err := decodeCollection(data)
+			if err != nil {
+				return fmt.Errorf("failed to decode snapshot of %s: %w", source.URL, err)
+			}
+			r.snapshots[source.URL] = append(r.snapshots[source.URL], snapshot)
+		}
+		r.snapshots[source.URL] = limitSnapshots(r.snapshots[source.URL], r.retention)
+		dropRawContent(r.snapshots[source.URL], r.rawRetention)
+		if len(r.snapshots[source.URL]) == 0 {
+			delete(r.snapshots, source.URL)
+		}
+		if source.Failure != nil {
+			r.failures[source.URL] = *source.Failure
+		}
+		if len(source.Diffs) > 0 {
+			r.diffs[source.URL] = source.Diffs
+		}
+		if source.Latest != nil || len(source.Snapshots) > 0 {
+			r.touch(source.URL)
+		}
+	}
+	r.audit = dump.Audit
+	r.captures = dump.Captures
+	r.sent = dump.Sent
+	return nil
+}
@@ -2,8 +2,12 @@
 package repository
 
 import (
+	"container/list"
 	"context"
+	"log"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/fuzztobread/job-scheduler/internal/core/domain"
 	"github.com/fuzztobread/job-scheduler/internal/core/ports"
@@ -11,15 +15,67 @@ import (
 
 // MemoryRepository implements the JobRepository interface using in-memory storage
 type MemoryRepository struct {
-	collections map[string]domain.JobCollection
-	mu          sync.RWMutex
+	collections  map[string]domain.JobCollection
+	snapshots    map[string][]domain.JobCollection // newest first
+	failures     map[string]domain.ScrapeFailure   // last failed scrape per URL
+	diffs        map[string][]domain.DiffRecord    // oldest first
+	retention    int                               // snapshots and diffs kept per URL, 0 keeps all
+	rawRetention int                               // snapshots per URL keeping their raw page, 0 keeps it in all
+	maxSources   int                               // URLs whose history is kept, the least recently used lose it beyond; 0 keeps all
+	recent       *list.List                        // URLs, most recently used first
+	elements     map[string]*list.Element          // URL -> its element of recent
+	lruMu        sync.Mutex                        // guards recent and elements, taken after mu
+	dump         *memoryDumper                     // nil unless the repository is dumped to disk
+	audit        []domain.AuditEntry
+	captures     []domain.CapturedNotification
+	sent         []domain.NotificationRecord
+	mu           sync.RWMutex
 }
 
-// NewMemoryRepository creates a new MemoryRepository instance
-func NewMemoryRepository() *MemoryRepository {
-	return &MemoryRepository{
-		collections: make(map[string]domain.JobCollection),
+// MemoryOption configures a MemoryRepository
+type MemoryOption func(*MemoryRepository)
+
+// WithMaxSources keeps the snapshots and diffs of at most n URLs, evicting
+// those of the least recently saved or read one beyond that. An evicted URL
+// keeps its latest collection, so its next scrape is diffed against it rather
+// than re-baselined. 0 keeps all.
+func WithMaxSources(n int) MemoryOption {
+	return func(r *MemoryRepository) {
+		r.maxSources = n
+	}
+}
+
+// WithDumpFile loads the repository from a dump at path when created and
+// writes the dump every interval and on Close, so a restart keeps the
+// baselines instead of silently re-baselining every source
+func WithDumpFile(path string, interval time.Duration) MemoryOption {
+	return func(r *MemoryRepository) {
+		r.dump = &memoryDumper{path: path, interval: interval, stop: make(chan struct{}), done: make(chan struct{})}
+	}
+}
+
+// NewMemoryRepository creates a new MemoryRepository instance keeping up to
+// retention snapshots and diffs per URL, 0 keeps all. Only the newest
+// rawRetention snapshots keep their raw page, 0 keeps it in all.
+func NewMemoryRepository(retention, rawRetention int, opts ...MemoryOption) *MemoryRepository {
+	r := &MemoryRepository{
+		collections:  make(map[string]domain.JobCollection),
+		snapshots:    make(map[string][]domain.JobCollection),
+		failures:     make(map[string]domain.ScrapeFailure),
+		diffs:        make(map[string][]domain.DiffRecord),
+		retention:    retention,
+		rawRetention: rawRetention,
+		recent:       list.New(),
+		elements:     make(map[string]*list.Element),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.dump != nil {
+		r.loadDump()
+		go r.dumpPeriodically()
 	}
+	return r
 }
 
 // SaveJobCollection saves a job collection to the repository
@@ -30,10 +86,33 @@ func (r *MemoryRepository) SaveJobCollection(
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	collection = collection.WithChecksum()
 	r.collections[collection.SourceURL] = collection
+	r.addSnapshot(collection)
+	r.touch(collection.SourceURL)
 	return nil
 }
 
+// SaveSnapshot adds a collection to the history of its URL
+func (r *MemoryRepository) SaveSnapshot(ctx context.Context, collection domain.JobCollection) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.addSnapshot(collection.WithChecksum())
+	r.touch(collection.SourceURL)
+	return nil
+}
+
+// addSnapshot inserts a snapshot and drops the oldest beyond the retention
+// limit, and the raw pages beyond the raw retention limit
+func (r *MemoryRepository) addSnapshot(collection domain.JobCollection) {
+	snapshots := append(r.snapshots[collection.SourceURL], collection)
+	sortSnapshots(snapshots)
+	snapshots = limitSnapshots(snapshots, r.retention)
+	dropRawContent(snapshots, r.rawRetention)
+	r.snapshots[collection.SourceURL] = snapshots
+}
+
 // GetLatestJobCollection retrieves the latest job collection for a URL
 func (r *MemoryRepository) GetLatestJobCollection(
 	ctx context.Context,
@@ -44,10 +123,326 @@ func (r *MemoryRepository) GetLatestJobCollection(
 
 	collection, exists := r.collections[url]
 	if !exists {
-		return domain.JobCollection{}, nil
+		return domain.JobCollection{}, domain.ErrNotFound
 	}
 
+	if err := collection.VerifyChecksum(); err != nil {
+		return domain.JobCollection{}, err
+	}
+
+	r.lruMu.Lock()
+	defer r.lruMu.Unlock()
+	if element, ok := r.elements[url]; ok {
+		r.recent.MoveToFront(element)
+	}
 	return collection, nil
 }
 
-var _ ports.JobRepository = (*MemoryRepository)(nil) // Ensure interface compliance
+// ListSnapshots returns up to limit snapshots of a URL, newest first
+func (r *MemoryRepository) ListSnapshots(ctx context.Context, url string, limit int) ([]domain.JobCollection, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var snapshots []domain.JobCollection
+	for _, snapshot := range limitSnapshots(r.snapshots[url], limit) {
+		if snapshot.VerifyChecksum() != nil {
+			continue // corrupt snapshots are reported when read as latest
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// GetJobHistory returns the timeline of a job ID on a URL from its retained snapshots
+func (r *MemoryRepository) GetJobHistory(ctx context.Context, url, jobID string) (domain.JobHistory, error) {
+	return jobHistory(ctx, r, url, jobID)
+}
+
+// SaveScrapeFailure records the last failed scrape of a URL
+func (r *MemoryRepository) SaveScrapeFailure(ctx context.Context, failure domain.ScrapeFailure) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failures[failure.SourceURL] = failure
+	return nil
+}
+
+// SaveDiff records a diff computed for a URL
+func (r *MemoryRepository) SaveDiff(ctx context.Context, record domain.DiffRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	url := record.Diff.SourceURL
+	diffs := append(r.diffs[url], record)
+	if r.retention > 0 && len(diffs) > r.retention {
+		diffs = diffs[len(diffs)-r.retention:]
+	}
+	r.diffs[url] = diffs
+	return nil
+}
+
+// GetDiffHistory returns the diffs recorded for a URL since the given time, oldest first
+func (r *MemoryRepository) GetDiffHistory(ctx context.Context, url string, since time.Time) ([]domain.DiffRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return domain.FilterDiffRecords(r.diffs[url], since), nil
+}
+
+// ListTrackedSources returns the state of every URL in the repository
+func (r *MemoryRepository) ListTrackedSources(ctx context.Context) ([]domain.TrackedSource, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sources := make([]domain.TrackedSource, 0, len(r.collections))
+	for _, collection := range r.collections {
+		sources = append(sources, domain.TrackCollection(collection))
+	}
+	failures := make([]domain.ScrapeFailure, 0, len(r.failures))
+	for _, failure := range r.failures {
+		failures = append(failures, failure)
+	}
+	return domain.MergeScrapeFailures(sources, failures), nil
+}
+
+// PurgePersonalData strips personal data from collections scraped, diffs
+// computed and notifications captured or sent before cutoff
+func (r *MemoryRepository) PurgePersonalData(ctx context.Context, cutoff time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := 0
+	for url, collection := range r.collections {
+		if collection.ScrapedAt.Before(cutoff) && hasPersonalData(collection) {
+			r.collections[url] = collection.StripPersonalData()
+			purged++
+		}
+	}
+	for _, snapshots := range r.snapshots {
+		for i, snapshot := range snapshots {
+			if snapshot.ScrapedAt.Before(cutoff) && hasPersonalData(snapshot) {
+				snapshots[i] = snapshot.StripPersonalData()
+				purged++
+			}
+		}
+	}
+	for _, diffs := range r.diffs {
+		for i, record := range diffs {
+			if record.ComputedAt.Before(cutoff) && record.HasPersonalData() {
+				diffs[i] = record.StripPersonalData()
+				purged++
+			}
+		}
+	}
+	purged += purgeCaptures(r.captures, cutoff)
+	purged += purgeNotificationRecords(r.sent, cutoff)
+	return purged, nil
+}
+
+// DeleteSource removes the collection, snapshots and captured and sent
+// notifications of a source URL
+func (r *MemoryRepository) DeleteSource(ctx context.Context, url string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := len(r.snapshots[url])
+	if _, exists := r.collections[url]; exists {
+		deleted++
+	}
+	r.forget(url)
+	var captures, sent int
+	r.captures, captures = capturesWithoutSource(r.captures, url)
+	r.sent, sent = notificationRecordsWithoutSource(r.sent, url)
+	return deleted + captures + sent, nil
+}
+
+// PruneSnapshots deletes snapshots scraped before cutoff except the latest of
+// each URL, and the diffs and captured and sent notifications from before
+// cutoff
+func (r *MemoryRepository) PruneSnapshots(ctx context.Context, cutoff time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pruned := 0
+	for url, snapshots := range r.snapshots {
+		latest := r.collections[url].ScrapedAt
+		kept := snapshots[:0]
+		for _, snapshot := range snapshots {
+			if snapshot.ScrapedAt.Before(cutoff) && !snapshot.ScrapedAt.Equal(latest) {
+				pruned++
+				continue
+			}
+			kept = append(kept, snapshot)
+		}
+		r.snapshots[url] = kept
+	}
+	for url, records := range r.diffs {
+		r.diffs[url] = domain.FilterDiffRecords(records, cutoff)
+	}
+	r.captures = pruneCaptures(r.captures, cutoff)
+	r.sent = pruneNotificationRecords(r.sent, cutoff)
+	return pruned, nil
+}
+
+// PruneSources deletes the collections and snapshots of URLs last scraped before cutoff
+func (r *MemoryRepository) PruneSources(ctx context.Context, cutoff time.Time) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var pruned []string
+	for url, collection := range r.collections {
+		if collection.ScrapedAt.Before(cutoff) {
+			r.forget(url)
+			r.captures, _ = capturesWithoutSource(r.captures, url)
+			r.sent, _ = notificationRecordsWithoutSource(r.sent, url)
+			pruned = append(pruned, url)
+		}
+	}
+	return pruned, nil
+}
+
+// touch marks a URL as the most recently used, evicting the history of the
+// least recently used URLs beyond maxSources. The caller holds mu for writing.
+func (r *MemoryRepository) touch(url string) {
+	r.lruMu.Lock()
+	if element, ok := r.elements[url]; ok {
+		r.recent.MoveToFront(element)
+	} else {
+		r.elements[url] = r.recent.PushFront(url)
+	}
+	var evicted []string
+	for r.maxSources > 0 && r.recent.Len() > r.maxSources {
+		evicted = append(evicted, r.recent.Back().Value.(string))
+		r.removeRecent(evicted[len(evicted)-1])
+	}
+	r.lruMu.Unlock()
+
+	for _, url := range evicted {
+		log.Printf("Memory repository holds more than %d sources, evicting the history of %s", r.maxSources, url)
+		delete(r.snapshots, url)
+		delete(r.diffs, url)
+	}
+}
+
+// forget removes all data of a URL. The caller holds mu for writing.
+func (r *MemoryRepository) forget(url string) {
+	delete(r.collections, url)
+	delete(r.snapshots, url)
+	delete(r.failures, url)
+	delete(r.diffs, url)
+
+	r.lruMu.Lock()
+	defer r.lruMu.Unlock()
+	r.removeRecent(url)
+}
+
+// removeRecent drops a URL from the recency list. The caller holds lruMu.
+func (r *MemoryRepository) removeRecent(url string) {
+	if element, ok := r.elements[url]; ok {
+		r.recent.Remove(element)
+		delete(r.elements, url)
+	}
+}
+
+// AppendAudit appends an entry to the audit log
+func (r *MemoryRepository) AppendAudit(ctx context.Context, entry domain.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry.ID = int64(len(r.audit) + 1)
+	r.audit = append(r.audit, entry)
+	return nil
+}
+
+// QueryAudit returns the matching audit entries, newest first
+func (r *MemoryRepository) QueryAudit(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []domain.AuditEntry
+	for i := len(r.audit) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(entries) == filter.Limit {
+			break
+		}
+		if filter.Matches(r.audit[i]) {
+			entries = append(entries, r.audit[i])
+		}
+	}
+	return entries, nil
+}
+
+// SaveCapture stores a captured notification, deleting the oldest beyond
+// maxCaptures
+func (r *MemoryRepository) SaveCapture(ctx context.Context, capture domain.CapturedNotification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	capture.ID = 1
+	if len(r.captures) > 0 {
+		capture.ID = r.captures[len(r.captures)-1].ID + 1
+	}
+	r.captures = append(r.captures, capture)
+	if len(r.captures) > maxCaptures {
+		r.captures = slices.Delete(r.captures, 0, len(r.captures)-maxCaptures)
+	}
+	return nil
+}
+
+// ListCaptures returns up to limit captured notifications, newest first
+func (r *MemoryRepository) ListCaptures(ctx context.Context, limit int) ([]domain.CapturedNotification, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var captures []domain.CapturedNotification
+	for i := len(r.captures) - 1; i >= 0; i-- {
+		if limit > 0 && len(captures) == limit {
+			break
+		}
+		captures = append(captures, r.captures[i])
+	}
+	return captures, nil
+}
+
+// RecordNotification stores a sent notification with its delivery attempts,
+// deleting the oldest beyond maxNotificationRecords
+func (r *MemoryRepository) RecordNotification(ctx context.Context, record domain.NotificationRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record.ID = 1
+	if len(r.sent) > 0 {
+		record.ID = r.sent[len(r.sent)-1].ID + 1
+	}
+	r.sent = append(r.sent, record)
+	if len(r.sent) > maxNotificationRecords {
+		r.sent = slices.Delete(r.sent, 0, len(r.sent)-maxNotificationRecords)
+	}
+	return nil
+}
+
+// QueryNotifications returns the matching sent notifications, newest first
+func (r *MemoryRepository) QueryNotifications(ctx context.Context, filter domain.NotificationFilter) ([]domain.NotificationRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var records []domain.NotificationRecord
+	for i := len(r.sent) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(records) == filter.Limit {
+			break
+		}
+		if filter.Matches(r.sent[i]) {
+			records = append(records, r.sent[i])
+		}
+	}
+	return records, nil
+}
+
+var (
+	_ ports.JobRepository = (*MemoryRepository)(nil) // Ensure interface compliance
+	_ ports.AuditLog            = (*MemoryRepository)(nil)
+	_ ports.CaptureStore        = (*MemoryRepository)(nil)
+	_ ports.NotificationHistory = (*MemoryRepository)(nil)
+	_ ports.PersonalDataPurger  = (*MemoryRepository)(nil)
+	_ ports.SourceDeleter       = (*MemoryRepository)(nil)
+	_ ports.RetentionPruner     = (*MemoryRepository)(nil)
+)
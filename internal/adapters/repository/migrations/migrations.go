@@ -0,0 +1,179 @@
+// internal/adapters/repository/migrations/migrations.go
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed sqlite/*.sql
+var sqliteFiles embed.FS
+
+// Migration is a numbered schema change. Files are named <version>_<name>.sql,
+// e.g. 0002_activity_times.sql, and must never change once released.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// SQLite returns the migrations of the SQLite repository
+func SQLite() ([]Migration, error) {
+	return Load(sqliteFiles, "sqlite")
+}
+
+// Load reads the migrations in dir of fsys, ordered by version
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	var migrations []Migration
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		prefix, name, ok := strings.Cut(strings.TrimSuffix(entry.Name(), ".sql"), "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s is not named <version>_<name>.sql", entry.Name())
+		}
+		if other, exists := seen[version]; exists {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", other, entry.Name())
+		}
+		seen[version] = entry.Name()
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name, SQL: string(data)})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Version returns the schema version of db, 0 if no migration was applied
+func Version(ctx context.Context, db *sql.DB) (int, error) {
+	if err := ensureVersionTable(ctx, db); err != nil {
+		return 0, err
+	}
+
+	var version int
+	if err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// Apply runs the migrations newer than the schema version of db, each in its
+// own transaction, and returns how many were applied
+func Apply(ctx context.Context, db *sql.DB, migrations []Migration) (int, error) {
+	current, err := Version(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	if latest := latestVersion(migrations); current > latest {
+		return 0, fmt.Errorf("database schema version %d is newer than this release supports (%d)", current, latest)
+	}
+
+	applied := 0
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		if err := apply(ctx, db, m); err != nil {
+			return applied, err
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+// Baseline records the migrations up to version as applied without running
+// them, for databases created before migrations were tracked
+func Baseline(ctx context.Context, db *sql.DB, migrations []Migration, version int) error {
+	if err := ensureVersionTable(ctx, db); err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if m.Version > version {
+			break
+		}
+		if err := record(ctx, db, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply runs a migration and records it in the same transaction
+func apply(ctx context.Context, db *sql.DB, m Migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %d: %w", m.Version, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return fmt.Errorf("failed to apply migration %d_%s: %w", m.Version, m.Name, err)
+	}
+	if err := record(ctx, tx, m); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %d: %w", m.Version, err)
+	}
+	return nil
+}
+
+// execer is implemented by *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// record marks a migration as applied
+func record(ctx context.Context, db execer, m Migration) error {
+	_, err := db.ExecContext(ctx,
+		`INSERT OR IGNORE INTO schema_version (version, name, applied_at) VALUES (?, ?, ?)`,
+		m.Version, m.Name, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+	}
+	return nil
+}
+
+// ensureVersionTable creates the schema_version table if needed
+func ensureVersionTable(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_version (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TEXT NOT NULL
+	)`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_version table: %w", err)
+	}
+	return nil
+}
+
+// latestVersion returns the highest version of the migrations
+func latestVersion(migrations []Migration) int {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
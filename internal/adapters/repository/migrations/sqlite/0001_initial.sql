@@ -0,0 +1,48 @@
+-- Scrape runs own their jobs; collections point at the latest run per source.
+CREATE TABLE IF NOT EXISTS scrape_runs (
+	id           INTEGER PRIMARY KEY AUTOINCREMENT,
+	source_url   TEXT    NOT NULL,
+	company_name TEXT    NOT NULL,
+	scraped_at   TEXT    NOT NULL,
+	job_count    INTEGER NOT NULL,
+	checksum     TEXT    NOT NULL,
+	raw_content  TEXT    NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_scrape_runs_source ON scrape_runs (source_url, id);
+
+CREATE TABLE IF NOT EXISTS jobs (
+	run_id     INTEGER NOT NULL REFERENCES scrape_runs (id) ON DELETE CASCADE,
+	position   INTEGER NOT NULL,
+	job_id     TEXT    NOT NULL,
+	title      TEXT    NOT NULL,
+	location   TEXT    NOT NULL DEFAULT '',
+	department TEXT    NOT NULL DEFAULT '',
+	url        TEXT    NOT NULL DEFAULT '',
+	data       TEXT    NOT NULL,
+	PRIMARY KEY (run_id, position)
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_job_id ON jobs (job_id);
+
+CREATE TABLE IF NOT EXISTS collections (
+	source_url    TEXT PRIMARY KEY,
+	company_name  TEXT    NOT NULL,
+	latest_run_id INTEGER NOT NULL REFERENCES scrape_runs (id),
+	updated_at    TEXT    NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS audit_log (
+	id       INTEGER PRIMARY KEY AUTOINCREMENT,
+	at       TEXT NOT NULL,
+	actor    TEXT NOT NULL,
+	action   TEXT NOT NULL,
+	target   TEXT NOT NULL,
+	previous TEXT NOT NULL DEFAULT '',
+	current  TEXT NOT NULL DEFAULT ''
+);
+CREATE TABLE IF NOT EXISTS purges (
+	run_id    INTEGER PRIMARY KEY REFERENCES scrape_runs (id) ON DELETE CASCADE,
+	purged_at TEXT NOT NULL
+);
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN SELECT RAISE(ABORT, 'audit log is append-only'); END;
@@ -0,0 +1,3 @@
+-- Activity times used to detect stale sources, see JobCollection.TrackActivity.
+ALTER TABLE scrape_runs ADD COLUMN changed_at TEXT NOT NULL DEFAULT '';
+ALTER TABLE scrape_runs ADD COLUMN empty_since TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+-- Last failed scrape of each source, see SaveScrapeFailure.
+CREATE TABLE IF NOT EXISTS scrape_failures (
+	source_url TEXT PRIMARY KEY,
+	failed_at  TEXT NOT NULL,
+	error      TEXT NOT NULL
+);
@@ -0,0 +1,3 @@
+-- Storage schema version each job was encoded with, see storageSchemaVersion.
+-- Jobs stored before versioning are version 0.
+ALTER TABLE jobs ADD COLUMN schema_version INTEGER NOT NULL DEFAULT 0;
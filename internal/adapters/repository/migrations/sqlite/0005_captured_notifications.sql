@@ -0,0 +1,7 @@
+-- Notifications kept by the capture notifier instead of being sent, see SaveCapture.
+CREATE TABLE IF NOT EXISTS captured_notifications (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	captured_at TEXT NOT NULL,
+	kind        TEXT NOT NULL,
+	data        TEXT NOT NULL
+);
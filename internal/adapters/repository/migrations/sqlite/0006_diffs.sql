@@ -0,0 +1,10 @@
+-- Diffs computed by every scrape run, see SaveDiff.
+CREATE TABLE IF NOT EXISTS diffs (
+	id          INTEGER PRIMARY KEY AUTOINCREMENT,
+	source_url  TEXT NOT NULL,
+	run_id      TEXT NOT NULL,
+	computed_at TEXT NOT NULL,
+	data        TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_diffs_source ON diffs (source_url, computed_at);
@@ -0,0 +1,9 @@
+-- Notifications handed to the notifiers with their delivery attempts, see RecordNotification.
+CREATE TABLE IF NOT EXISTS notification_history (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TEXT NOT NULL,
+	kind       TEXT NOT NULL,
+	data       TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_history_created ON notification_history (created_at);
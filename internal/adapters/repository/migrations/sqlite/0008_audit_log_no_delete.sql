@@ -0,0 +1,3 @@
+-- The audit log is append-only: entries can't be deleted either.
+CREATE TRIGGER IF NOT EXISTS audit_log_no_delete BEFORE DELETE ON audit_log
+BEGIN SELECT RAISE(ABORT, 'audit log is append-only'); END;
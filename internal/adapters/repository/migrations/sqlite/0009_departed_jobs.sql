@@ -0,0 +1,2 @@
+-- Jobs no longer listed, encoded as JSON, see JobCollection.Departed.
+ALTER TABLE scrape_runs ADD COLUMN departed TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,50 @@
+// internal/adapters/repository/notification_history.go
+package repository
+
+import (
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// maxNotificationRecords is the number of sent notifications kept in the
+// notification history, the oldest are deleted beyond it
+const maxNotificationRecords = 10000
+
+// purgeNotificationRecords strips personal data from the notifications sent
+// before cutoff and returns how many were purged
+func purgeNotificationRecords(records []domain.NotificationRecord, cutoff time.Time) int {
+	purged := 0
+	for i, record := range records {
+		if record.CreatedAt.Before(cutoff) && record.HasPersonalData() {
+			records[i] = record.StripPersonalData()
+			purged++
+		}
+	}
+	return purged
+}
+
+// notificationRecordsWithoutSource removes what the sent notifications say
+// about the source url, deleting those left empty, and returns the records
+// kept and the number deleted
+func notificationRecordsWithoutSource(records []domain.NotificationRecord, url string) ([]domain.NotificationRecord, int) {
+	kept := records[:0]
+	for _, record := range records {
+		record, left := record.WithoutSource(url)
+		if left {
+			kept = append(kept, record)
+		}
+	}
+	return kept, len(records) - len(kept)
+}
+
+// pruneNotificationRecords deletes the notifications sent before cutoff
+func pruneNotificationRecords(records []domain.NotificationRecord, cutoff time.Time) []domain.NotificationRecord {
+	kept := records[:0]
+	for _, record := range records {
+		if !record.CreatedAt.Before(cutoff) {
+			kept = append(kept, record)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,29 @@
+// internal/adapters/repository/optional_ports.go
+package repository
+
+import "github.com/fuzztobread/job-scheduler/internal/core/ports"
+
+// servesPort reports whether backend serves the optional port given as a nil
+// pointer to it, for the SupportsPort methods of decorators
+func servesPort(backend ports.JobRepository, port any) bool {
+	var ok bool
+	switch port.(type) {
+	case *ports.AuditLog:
+		_, ok = ports.Optional[ports.AuditLog](backend)
+	case *ports.CaptureStore:
+		_, ok = ports.Optional[ports.CaptureStore](backend)
+	case *ports.NotificationHistory:
+		_, ok = ports.Optional[ports.NotificationHistory](backend)
+	case *ports.PersonalDataPurger:
+		_, ok = ports.Optional[ports.PersonalDataPurger](backend)
+	case *ports.SourceDeleter:
+		_, ok = ports.Optional[ports.SourceDeleter](backend)
+	case *ports.RetentionPruner:
+		_, ok = ports.Optional[ports.RetentionPruner](backend)
+	case *ports.SnapshotArchive:
+		_, ok = ports.Optional[ports.SnapshotArchive](backend)
+	case *ports.Locker:
+		_, ok = ports.Optional[ports.Locker](backend)
+	}
+	return ok
+}
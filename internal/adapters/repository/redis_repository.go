@@ -0,0 +1,550 @@
+// internal/adapters/repository/redis_repository.go
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// redisLockScript acquires or extends a lock held by the same owner
+var redisLockScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if current == false then
+	return redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2]) and 1 or 0
+end
+if current == ARGV[1] then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	return 1
+end
+return 0
+`)
+
+// redisUnlockScript deletes a lock only if it is held by the given owner
+var redisUnlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisRepository implements the JobRepository interface using Redis. The
+// latest collection per URL and its recent snapshots, kept in a sorted set
+// scored by scrape time, optionally expire after a TTL. Diffs are kept the
+// same way, scored by the time they were computed.
+type RedisRepository struct {
+	client       *redis.Client
+	prefix       string
+	ttl          time.Duration // 0 keeps collections forever
+	retention    int           // snapshots kept per URL, 0 keeps all
+	rawRetention int           // snapshots per URL keeping their raw page, 0 keeps it in all
+}
+
+// NewRedisRepository connects to the Redis server at url (redis://...) and
+// namespaces all keys with prefix. Up to retention snapshots are kept per
+// URL, 0 keeps all. Only the newest rawRetention snapshots keep their raw
+// page, 0 keeps it in all.
+func NewRedisRepository(url, prefix string, ttl time.Duration, retention, rawRetention int) (*RedisRepository, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Redis URL: %w", err)
+	}
+
+	client := redis.NewClient(opts)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return &RedisRepository{
+		client:       client,
+		prefix:       prefix,
+		ttl:          ttl,
+		retention:    retention,
+		rawRetention: rawRetention,
+	}, nil
+}
+
+// Close closes the connection pool
+func (r *RedisRepository) Close() error {
+	return r.client.Close()
+}
+
+// SaveJobCollection stores the collection as the latest one of its URL and
+// as a snapshot
+func (r *RedisRepository) SaveJobCollection(
+	ctx context.Context,
+	collection domain.JobCollection,
+) error {
+	return r.save(ctx, collection, true)
+}
+
+// SaveSnapshot stores the collection as a snapshot of its URL only
+func (r *RedisRepository) SaveSnapshot(ctx context.Context, collection domain.JobCollection) error {
+	return r.save(ctx, collection, false)
+}
+
+// save writes a snapshot, optionally also as the latest collection, and trims
+// the snapshots beyond the retention limit in one transaction, then drops the
+// raw page of the snapshot pushed beyond the raw retention limit
+func (r *RedisRepository) save(ctx context.Context, collection domain.JobCollection, latest bool) error {
+	data, err := json.Marshal(newVersionedCollection(collection.WithChecksum()))
+	if err != nil {
+		return fmt.Errorf("failed to marshal job collection: %w", err)
+	}
+
+	snapshotsKey := r.snapshotsKey(collection.SourceURL)
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if latest {
+			pipe.Set(ctx, r.collectionKey(collection.SourceURL), data, r.ttl)
+		}
+		pipe.ZAdd(ctx, snapshotsKey, redis.Z{Score: float64(collection.ScrapedAt.UnixMilli()), Member: data})
+		if r.retention > 0 {
+			pipe.ZRemRangeByRank(ctx, snapshotsKey, 0, int64(-r.retention-1))
+		}
+		if r.ttl > 0 {
+			pipe.Expire(ctx, snapshotsKey, r.ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save job collection: %w", err)
+	}
+	if r.rawRetention > 0 {
+		return r.dropRawContent(ctx, snapshotsKey)
+	}
+	return nil
+}
+
+// dropRawContent replaces the snapshot at the raw retention limit of the
+// sorted set key with a copy without its raw page
+func (r *RedisRepository) dropRawContent(ctx context.Context, key string) error {
+	members, err := r.client.ZRevRangeWithScores(ctx, key, int64(r.rawRetention), int64(r.rawRetention)).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	for _, member := range members {
+		data, _ := member.Member.(string)
+		snapshot, err := decodeCollection([]byte(data))
+		if err != nil {
+			continue // unreadable snapshots are left as they are
+		}
+		snapshot, ok := withoutRawContent(snapshot)
+		if !ok {
+			continue
+		}
+		updated, err := json.Marshal(newVersionedCollection(snapshot))
+		if err != nil {
+			return fmt.Errorf("failed to marshal job collection: %w", err)
+		}
+		_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.ZRem(ctx, key, data)
+			pipe.ZAdd(ctx, key, redis.Z{Score: member.Score, Member: updated})
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to drop raw page of snapshot: %w", err)
+		}
+	}
+	return nil
+}
+
+// GetLatestJobCollection retrieves the latest job collection for a URL
+func (r *RedisRepository) GetLatestJobCollection(
+	ctx context.Context,
+	url string,
+) (domain.JobCollection, error) {
+	data, err := r.client.Get(ctx, r.collectionKey(url)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return domain.JobCollection{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.JobCollection{}, fmt.Errorf("failed to get job collection: %w", err)
+	}
+
+	collection, err := decodeCollection(data)
+	if err != nil {
+		return domain.JobCollection{}, &domain.CorruptSnapshotError{SourceURL: url, Reason: err.Error()}
+	}
+	if err := collection.VerifyChecksum(); err != nil {
+		return domain.JobCollection{}, err
+	}
+
+	return collection, nil
+}
+
+// ListSnapshots returns up to limit snapshots of a URL, newest first
+func (r *RedisRepository) ListSnapshots(ctx context.Context, url string, limit int) ([]domain.JobCollection, error) {
+	members, err := r.client.ZRevRange(ctx, r.snapshotsKey(url), 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+
+	snapshots := make([]domain.JobCollection, 0, len(members))
+	for _, member := range members {
+		collection, err := decodeCollection([]byte(member))
+		if err != nil || collection.VerifyChecksum() != nil {
+			continue // corrupt snapshots are reported when read as latest
+		}
+		snapshots = append(snapshots, collection)
+	}
+	return snapshots, nil
+}
+
+// GetJobHistory returns the timeline of a job ID on a URL from its retained snapshots
+func (r *RedisRepository) GetJobHistory(ctx context.Context, url, jobID string) (domain.JobHistory, error) {
+	return jobHistory(ctx, r, url, jobID)
+}
+
+// SaveScrapeFailure records the last failed scrape of a URL, expiring like
+// its collection
+func (r *RedisRepository) SaveScrapeFailure(ctx context.Context, failure domain.ScrapeFailure) error {
+	data, err := json.Marshal(failure)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scrape failure: %w", err)
+	}
+	if err := r.client.Set(ctx, r.failureKey(failure.SourceURL), data, r.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save scrape failure: %w", err)
+	}
+	return nil
+}
+
+// SaveDiff records a diff computed for a URL, expiring like its collection
+func (r *RedisRepository) SaveDiff(ctx context.Context, record domain.DiffRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal diff: %w", err)
+	}
+
+	diffsKey := r.diffsKey(record.Diff.SourceURL)
+	_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, diffsKey, redis.Z{Score: float64(record.ComputedAt.UnixMilli()), Member: data})
+		if r.ttl > 0 {
+			pipe.Expire(ctx, diffsKey, r.ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save diff: %w", err)
+	}
+	return nil
+}
+
+// GetDiffHistory returns the diffs recorded for a URL since the given time, oldest first
+func (r *RedisRepository) GetDiffHistory(ctx context.Context, url string, since time.Time) ([]domain.DiffRecord, error) {
+	members, err := r.client.ZRangeByScore(ctx, r.diffsKey(url), &redis.ZRangeBy{
+		Min: fmt.Sprintf("%d", since.UnixMilli()),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list diffs: %w", err)
+	}
+
+	records := make([]domain.DiffRecord, 0, len(members))
+	for _, member := range members {
+		var record domain.DiffRecord
+		if err := json.Unmarshal([]byte(member), &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal diff: %w", err)
+		}
+		records = append(records, record)
+	}
+	return domain.FilterDiffRecords(records, since), nil
+}
+
+// ListTrackedSources returns the state of every URL with a collection or failure
+func (r *RedisRepository) ListTrackedSources(ctx context.Context) ([]domain.TrackedSource, error) {
+	var sources []domain.TrackedSource
+	keys := r.client.Scan(ctx, 0, r.prefix+"collection:*", 100).Iterator()
+	for keys.Next(ctx) {
+		collection, err := r.GetLatestJobCollection(ctx, strings.TrimPrefix(keys.Val(), r.prefix+"collection:"))
+		if err != nil {
+			continue // corrupt collections are reported when read
+		}
+		sources = append(sources, domain.TrackCollection(collection))
+	}
+	if err := keys.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan collections: %w", err)
+	}
+
+	var failures []domain.ScrapeFailure
+	keys = r.client.Scan(ctx, 0, r.prefix+"failure:*", 100).Iterator()
+	for keys.Next(ctx) {
+		data, err := r.client.Get(ctx, keys.Val()).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue // expired since the scan
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get scrape failure: %w", err)
+		}
+		var failure domain.ScrapeFailure
+		if err := json.Unmarshal(data, &failure); err == nil {
+			failures = append(failures, failure)
+		}
+	}
+	if err := keys.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan scrape failures: %w", err)
+	}
+
+	return domain.MergeScrapeFailures(sources, failures), nil
+}
+
+// PurgePersonalData strips personal data from collections scraped and diffs
+// computed before cutoff
+func (r *RedisRepository) PurgePersonalData(ctx context.Context, cutoff time.Time) (int, error) {
+	purged := 0
+	keys := r.client.Scan(ctx, 0, r.prefix+"snapshots:*", 100).Iterator()
+	for keys.Next(ctx) {
+		n, err := r.purgeSnapshots(ctx, strings.TrimPrefix(keys.Val(), r.prefix+"snapshots:"), cutoff)
+		purged += n
+		if err != nil {
+			return purged, err
+		}
+	}
+	if err := keys.Err(); err != nil {
+		return purged, fmt.Errorf("failed to scan snapshots: %w", err)
+	}
+
+	keys = r.client.Scan(ctx, 0, r.prefix+"diffs:*", 100).Iterator()
+	for keys.Next(ctx) {
+		n, err := r.purgeDiffs(ctx, keys.Val(), cutoff)
+		purged += n
+		if err != nil {
+			return purged, err
+		}
+	}
+	if err := keys.Err(); err != nil {
+		return purged, fmt.Errorf("failed to scan diffs: %w", err)
+	}
+	return purged, nil
+}
+
+// purgeSnapshots strips personal data from the snapshots of a URL scraped
+// before cutoff, and from its latest collection if it is one of them
+func (r *RedisRepository) purgeSnapshots(ctx context.Context, url string, cutoff time.Time) (int, error) {
+	snapshotsKey := r.snapshotsKey(url)
+	old, err := r.client.ZRangeByScoreWithScores(ctx, snapshotsKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: fmt.Sprintf("(%d", cutoff.UnixMilli()),
+	}).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list old snapshots: %w", err)
+	}
+	// The latest collection is stored as the same member as its snapshot
+	latest, err := r.client.Get(ctx, r.collectionKey(url)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return 0, fmt.Errorf("failed to get job collection: %w", err)
+	}
+
+	purged := 0
+	for _, member := range old {
+		data, _ := member.Member.(string)
+		snapshot, err := decodeCollection([]byte(data))
+		if err != nil || !hasPersonalData(snapshot) {
+			continue // corrupt snapshots are reported when read
+		}
+		stripped, err := json.Marshal(newVersionedCollection(snapshot.StripPersonalData()))
+		if err != nil {
+			return purged, fmt.Errorf("failed to marshal job collection: %w", err)
+		}
+		_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.ZRem(ctx, snapshotsKey, data)
+			pipe.ZAdd(ctx, snapshotsKey, redis.Z{Score: member.Score, Member: stripped})
+			if data == latest {
+				pipe.Set(ctx, r.collectionKey(url), stripped, redis.KeepTTL)
+			}
+			return nil
+		})
+		if err != nil {
+			return purged, fmt.Errorf("failed to purge snapshot: %w", err)
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purgeDiffs strips job descriptions from the diffs of the sorted set key
+// computed before cutoff
+func (r *RedisRepository) purgeDiffs(ctx context.Context, key string, cutoff time.Time) (int, error) {
+	old, err := r.client.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: fmt.Sprintf("(%d", cutoff.UnixMilli()),
+	}).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list old diffs: %w", err)
+	}
+
+	purged := 0
+	for _, member := range old {
+		data, _ := member.Member.(string)
+		var record domain.DiffRecord
+		if err := json.Unmarshal([]byte(data), &record); err != nil || !record.HasPersonalData() {
+			continue // unreadable diffs are reported when read
+		}
+		stripped, err := json.Marshal(record.StripPersonalData())
+		if err != nil {
+			return purged, fmt.Errorf("failed to marshal diff: %w", err)
+		}
+		_, err = r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.ZRem(ctx, key, data)
+			pipe.ZAdd(ctx, key, redis.Z{Score: member.Score, Member: stripped})
+			return nil
+		})
+		if err != nil {
+			return purged, fmt.Errorf("failed to purge diff: %w", err)
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// DeleteSource removes the collection and snapshots of a source URL
+func (r *RedisRepository) DeleteSource(ctx context.Context, url string) (int, error) {
+	var latest *redis.IntCmd
+	var snapshots *redis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		snapshots = pipe.ZCard(ctx, r.snapshotsKey(url))
+		latest = pipe.Del(ctx, r.collectionKey(url))
+		pipe.Del(ctx, r.snapshotsKey(url))
+		pipe.Del(ctx, r.failureKey(url))
+		pipe.Del(ctx, r.diffsKey(url))
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete source %s: %w", url, err)
+	}
+	return int(latest.Val() + snapshots.Val()), nil
+}
+
+// PruneSnapshots deletes snapshots scraped before cutoff except the latest of each URL
+func (r *RedisRepository) PruneSnapshots(ctx context.Context, cutoff time.Time) (int, error) {
+	pruned := 0
+	keys := r.client.Scan(ctx, 0, r.prefix+"snapshots:*", 100).Iterator()
+	for keys.Next(ctx) {
+		snapshotsKey := keys.Val()
+		url := strings.TrimPrefix(snapshotsKey, r.prefix+"snapshots:")
+
+		old, err := r.client.ZRangeByScore(ctx, snapshotsKey, &redis.ZRangeBy{
+			Min: "-inf",
+			Max: fmt.Sprintf("(%d", cutoff.UnixMilli()),
+		}).Result()
+		if err != nil {
+			return pruned, fmt.Errorf("failed to list old snapshots: %w", err)
+		}
+		// The latest collection is stored as the same member as its snapshot
+		latest, err := r.client.Get(ctx, r.collectionKey(url)).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return pruned, fmt.Errorf("failed to get job collection: %w", err)
+		}
+
+		var members []interface{}
+		for _, member := range old {
+			if member != latest {
+				members = append(members, member)
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+		n, err := r.client.ZRem(ctx, snapshotsKey, members...).Result()
+		if err != nil {
+			return pruned, fmt.Errorf("failed to prune snapshots: %w", err)
+		}
+		pruned += int(n)
+	}
+	if err := keys.Err(); err != nil {
+		return pruned, fmt.Errorf("failed to scan snapshots: %w", err)
+	}
+
+	keys = r.client.Scan(ctx, 0, r.prefix+"diffs:*", 100).Iterator()
+	for keys.Next(ctx) {
+		err := r.client.ZRemRangeByScore(ctx, keys.Val(), "-inf", fmt.Sprintf("(%d", cutoff.UnixMilli())).Err()
+		if err != nil {
+			return pruned, fmt.Errorf("failed to prune diffs: %w", err)
+		}
+	}
+	if err := keys.Err(); err != nil {
+		return pruned, fmt.Errorf("failed to scan diffs: %w", err)
+	}
+	return pruned, nil
+}
+
+// PruneSources deletes the URLs whose latest collection was scraped before cutoff
+func (r *RedisRepository) PruneSources(ctx context.Context, cutoff time.Time) ([]string, error) {
+	var pruned []string
+	keys := r.client.Scan(ctx, 0, r.prefix+"collection:*", 100).Iterator()
+	for keys.Next(ctx) {
+		url := strings.TrimPrefix(keys.Val(), r.prefix+"collection:")
+		collection, err := r.GetLatestJobCollection(ctx, url)
+		if err != nil || !collection.ScrapedAt.Before(cutoff) {
+			continue // corrupt collections are reported when read
+		}
+		if _, err := r.DeleteSource(ctx, url); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, url)
+	}
+	if err := keys.Err(); err != nil {
+		return pruned, fmt.Errorf("failed to scan collections: %w", err)
+	}
+	return pruned, nil
+}
+
+// TryLock acquires the lock key for owner, or extends it if owner holds it already
+func (r *RedisRepository) TryLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error) {
+	acquired, err := redisLockScript.Run(ctx, r.client, []string{r.lockKey(key)}, owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lock %s: %w", key, err)
+	}
+	return acquired == 1, nil
+}
+
+// Unlock releases the lock key if owner holds it
+func (r *RedisRepository) Unlock(ctx context.Context, key, owner string) error {
+	if err := redisUnlockScript.Run(ctx, r.client, []string{r.lockKey(key)}, owner).Err(); err != nil {
+		return fmt.Errorf("failed to release lock %s: %w", key, err)
+	}
+	return nil
+}
+
+// collectionKey returns the key holding the latest collection of a URL
+func (r *RedisRepository) collectionKey(url string) string {
+	return r.prefix + "collection:" + url
+}
+
+// snapshotsKey returns the sorted set holding the snapshots of a URL
+func (r *RedisRepository) snapshotsKey(url string) string {
+	return r.prefix + "snapshots:" + url
+}
+
+// diffsKey returns the sorted set holding the diffs computed for a URL
+func (r *RedisRepository) diffsKey(url string) string {
+	return r.prefix + "diffs:" + url
+}
+
+// failureKey returns the key holding the last failed scrape of a URL
+func (r *RedisRepository) failureKey(url string) string {
+	return r.prefix + "failure:" + url
+}
+
+// lockKey returns the key of a named lock
+func (r *RedisRepository) lockKey(name string) string {
+	return r.prefix + "lock:" + name
+}
+
+var (
+	_ ports.JobRepository      = (*RedisRepository)(nil) // Ensure interface compliance
+	_ ports.PersonalDataPurger = (*RedisRepository)(nil)
+	_ ports.SourceDeleter      = (*RedisRepository)(nil)
+	_ ports.RetentionPruner    = (*RedisRepository)(nil)
+	_ ports.Locker             = (*RedisRepository)(nil)
+)
@@ -0,0 +1,262 @@
+// internal/adapters/repository/resilient_repository.go
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// queuedWrite is a write held back while the backend is unavailable
+type queuedWrite struct {
+	description string
+	apply       func(ctx context.Context, backend ports.JobRepository) error
+}
+
+// ResilientRepository is a JobRepository decorator keeping runs going while
+// the backend is briefly down. It remembers the latest collection of every
+// URL, so diffs are computed against it while the backend can't be read, and
+// queues writes that fail, replaying them in order once the backend answers
+// again. After a failure the backend is left alone for retryAfter.
+//
+// Any error other than domain.ErrNotFound or a corrupt snapshot counts as
+// the backend being unavailable. At most limit writes are queued; beyond that
+// the oldest are dropped. Queued writes live in memory only and are lost if
+// the process stops before the backend returns.
+//
+// Only the JobRepository methods are decorated, like in CachedRepository.
+type ResilientRepository struct {
+	backend    ports.JobRepository
+	retryAfter time.Duration
+	limit      int
+
+	writeMu sync.Mutex // serializes writes so queued ones are replayed in order
+	queue   []queuedWrite
+
+	mu        sync.Mutex
+	latest    map[string]domain.JobCollection
+	downUntil time.Time
+	lastErr   error
+}
+
+// NewResilientRepository wraps backend, queueing up to limit writes while it
+// is unavailable and retrying it at most every retryAfter
+func NewResilientRepository(backend ports.JobRepository, retryAfter time.Duration, limit int) *ResilientRepository {
+	return &ResilientRepository{
+		backend:    backend,
+		retryAfter: retryAfter,
+		limit:      limit,
+		latest:     make(map[string]domain.JobCollection),
+	}
+}
+
+// SaveJobCollection saves the collection, queueing it if the backend is down
+func (r *ResilientRepository) SaveJobCollection(ctx context.Context, collection domain.JobCollection) error {
+	r.remember(collection)
+	return r.write(ctx, "save job collection of "+collection.SourceURL, func(ctx context.Context, backend ports.JobRepository) error {
+		return backend.SaveJobCollection(ctx, collection)
+	})
+}
+
+// GetLatestJobCollection reads the latest collection of a URL from the
+// backend, or the remembered one while the backend is unavailable
+func (r *ResilientRepository) GetLatestJobCollection(ctx context.Context, url string) (domain.JobCollection, error) {
+	if r.Reconcile(ctx) == nil {
+		collection, err := r.backend.GetLatestJobCollection(ctx, url)
+		if err == nil {
+			r.remember(collection)
+			return collection, nil
+		}
+		if !unavailable(err) {
+			return domain.JobCollection{}, err
+		}
+		r.markDown(err)
+	}
+
+	r.mu.Lock()
+	collection, ok := r.latest[url]
+	r.mu.Unlock()
+	if ok {
+		log.Printf("Repository unavailable, diffing %s against the collection scraped at %s", url, collection.ScrapedAt.Format(time.RFC3339))
+		return collection, nil
+	}
+	return domain.JobCollection{}, r.unavailableError()
+}
+
+// SaveSnapshot saves a snapshot, queueing it if the backend is down
+func (r *ResilientRepository) SaveSnapshot(ctx context.Context, collection domain.JobCollection) error {
+	return r.write(ctx, "save snapshot of "+collection.SourceURL, func(ctx context.Context, backend ports.JobRepository) error {
+		return backend.SaveSnapshot(ctx, collection)
+	})
+}
+
+// ListSnapshots returns the snapshots of a URL from the backend
+func (r *ResilientRepository) ListSnapshots(ctx context.Context, url string, limit int) ([]domain.JobCollection, error) {
+	if err := r.Reconcile(ctx); err != nil {
+		return nil, err
+	}
+	snapshots, err := r.backend.ListSnapshots(ctx, url, limit)
+	r.observe(err)
+	return snapshots, err
+}
+
+// GetJobHistory returns the timeline of a job ID from the backend
+func (r *ResilientRepository) GetJobHistory(ctx context.Context, url, jobID string) (domain.JobHistory, error) {
+	if err := r.Reconcile(ctx); err != nil {
+		return domain.JobHistory{}, err
+	}
+	history, err := r.backend.GetJobHistory(ctx, url, jobID)
+	r.observe(err)
+	return history, err
+}
+
+// SaveScrapeFailure records a failed scrape, queueing it if the backend is down
+func (r *ResilientRepository) SaveScrapeFailure(ctx context.Context, failure domain.ScrapeFailure) error {
+	return r.write(ctx, "save scrape failure of "+failure.SourceURL, func(ctx context.Context, backend ports.JobRepository) error {
+		return backend.SaveScrapeFailure(ctx, failure)
+	})
+}
+
+// ListTrackedSources returns the state of every URL from the backend
+func (r *ResilientRepository) ListTrackedSources(ctx context.Context) ([]domain.TrackedSource, error) {
+	if err := r.Reconcile(ctx); err != nil {
+		return nil, err
+	}
+	sources, err := r.backend.ListTrackedSources(ctx)
+	r.observe(err)
+	return sources, err
+}
+
+// SaveDiff records a diff, queueing it if the backend is down
+func (r *ResilientRepository) SaveDiff(ctx context.Context, record domain.DiffRecord) error {
+	return r.write(ctx, "save diff of "+record.Diff.SourceURL, func(ctx context.Context, backend ports.JobRepository) error {
+		return backend.SaveDiff(ctx, record)
+	})
+}
+
+// GetDiffHistory returns the diffs recorded for a URL from the backend
+func (r *ResilientRepository) GetDiffHistory(ctx context.Context, url string, since time.Time) ([]domain.DiffRecord, error) {
+	if err := r.Reconcile(ctx); err != nil {
+		return nil, err
+	}
+	records, err := r.backend.GetDiffHistory(ctx, url, since)
+	r.observe(err)
+	return records, err
+}
+
+// Reconcile replays the queued writes, in order, unless the backend failed
+// less than retryAfter ago. It returns an error while the backend is
+// unavailable, leaving the writes not replayed yet in the queue.
+func (r *ResilientRepository) Reconcile(ctx context.Context) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	return r.flush(ctx)
+}
+
+// Pending returns the number of queued writes
+func (r *ResilientRepository) Pending() int {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	return len(r.queue)
+}
+
+// write applies a write to the backend once the queue is replayed, queueing
+// it instead while the backend is unavailable
+func (r *ResilientRepository) write(ctx context.Context, description string, apply func(context.Context, ports.JobRepository) error) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	if r.flush(ctx) == nil {
+		err := apply(ctx, r.backend)
+		if !unavailable(err) {
+			return err
+		}
+		r.markDown(err)
+	}
+
+	r.queue = append(r.queue, queuedWrite{description: description, apply: apply})
+	if len(r.queue) > r.limit {
+		log.Printf("Repository write queue full, dropping: %s", r.queue[0].description)
+		r.queue = r.queue[1:]
+	}
+	return nil
+}
+
+// flush replays the queued writes. The caller holds writeMu.
+func (r *ResilientRepository) flush(ctx context.Context) error {
+	r.mu.Lock()
+	down := time.Now().Before(r.downUntil)
+	r.mu.Unlock()
+	if down {
+		return r.unavailableError()
+	}
+	if len(r.queue) == 0 {
+		return nil
+	}
+	log.Printf("Replaying %d writes queued while the repository was unavailable", len(r.queue))
+	for len(r.queue) > 0 {
+		write := r.queue[0]
+		err := write.apply(ctx, r.backend)
+		if unavailable(err) {
+			r.markDown(err)
+			return r.unavailableError()
+		}
+		if err != nil {
+			log.Printf("Dropping queued write that failed to %s: %v", write.description, err)
+		}
+		r.queue = r.queue[1:]
+	}
+	log.Printf("Repository reconciled, all queued writes replayed")
+	return nil
+}
+
+// remember keeps a collection as the latest one of its URL
+func (r *ResilientRepository) remember(collection domain.JobCollection) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latest[collection.SourceURL] = collection
+}
+
+// observe marks the backend as down if a read failed for that reason
+func (r *ResilientRepository) observe(err error) {
+	if unavailable(err) {
+		r.markDown(err)
+	}
+}
+
+// markDown stops using the backend for retryAfter
+func (r *ResilientRepository) markDown(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lastErr == nil || time.Now().After(r.downUntil) {
+		log.Printf("Repository unavailable, queueing writes and retrying in %s: %v", r.retryAfter, err)
+	}
+	r.downUntil = time.Now().Add(r.retryAfter)
+	r.lastErr = err
+}
+
+// unavailableError describes the last backend failure
+func (r *ResilientRepository) unavailableError() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return fmt.Errorf("repository unavailable: %w", r.lastErr)
+}
+
+// unavailable reports whether an error means the backend could not be
+// reached, as opposed to a missing or damaged record
+func unavailable(err error) bool {
+	return err != nil &&
+		!errors.Is(err, domain.ErrNotFound) &&
+		!errors.Is(err, domain.ErrSnapshotCorrupted) &&
+		!errors.Is(err, context.Canceled)
+}
+
+var (
+	_ ports.JobRepository = (*ResilientRepository)(nil) // Ensure interface compliance
+)
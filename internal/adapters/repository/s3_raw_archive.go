@@ -0,0 +1,172 @@
+// internal/adapters/repository/s3_raw_archive.go
+package repository
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// Object layout:
+//
+//	<prefix>raw/<slug>/<time>.html.gz -> gzipped raw page of a scrape
+//	<prefix>raw/<slug>/<time>.png     -> screenshot of the rendered page, if taken
+
+// S3RawArchive implements the RawContentStore interface on S3-compatible
+// object storage. It shares the S3Options of the S3 repository; Retention is
+// ignored.
+type S3RawArchive struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// NewS3RawArchive connects to the storage and checks that the bucket exists
+func NewS3RawArchive(opts S3Options) (*S3RawArchive, error) {
+	client, err := newS3Client(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &S3RawArchive{
+		client: client,
+		bucket: opts.Bucket,
+		prefix: opts.Prefix,
+	}, nil
+}
+
+// PutRawContent compresses a raw page into the archive
+func (a *S3RawArchive) PutRawContent(ctx context.Context, url string, scrapedAt time.Time, content string) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := io.WriteString(zw, content); err != nil {
+		return fmt.Errorf("failed to compress raw page: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress raw page: %w", err)
+	}
+
+	if err := s3Put(ctx, a.client, a.bucket, a.key(url, scrapedAt), buf.Bytes(), "application/gzip"); err != nil {
+		return fmt.Errorf("failed to store raw page: %w", err)
+	}
+	return nil
+}
+
+// PutScreenshot uploads the screenshot of a scrape next to its raw page
+func (a *S3RawArchive) PutScreenshot(ctx context.Context, url string, scrapedAt time.Time, png []byte) error {
+	key := a.sourcePrefix(url) + "/" + scrapedAt.UTC().Format(snapshotTimeLayout) + screenshotSuffix
+	if err := s3Put(ctx, a.client, a.bucket, key, png, "image/png"); err != nil {
+		return fmt.Errorf("failed to store screenshot: %w", err)
+	}
+	return nil
+}
+
+// GetRawContent downloads an archived raw page
+func (a *S3RawArchive) GetRawContent(ctx context.Context, url string, scrapedAt time.Time) (string, error) {
+	data, err := s3Get(ctx, a.client, a.bucket, a.key(url, scrapedAt))
+	if err != nil {
+		return "", err
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress raw page: %w", err)
+	}
+	content, err := io.ReadAll(zr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read raw page: %w", err)
+	}
+	return string(content), nil
+}
+
+// ListRawContent returns the scrape times of the archived pages of url, newest first
+func (a *S3RawArchive) ListRawContent(ctx context.Context, url string) ([]time.Time, error) {
+	var times []time.Time
+	objects := a.client.ListObjects(ctx, a.bucket, minio.ListObjectsOptions{
+		Prefix: a.sourcePrefix(url) + "/",
+	})
+	for object := range objects {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list raw pages: %w", object.Err)
+		}
+		name := path.Base(object.Key)
+		scrapedAt, err := time.Parse(snapshotTimeLayout, strings.TrimSuffix(name, rawPageSuffix))
+		if err != nil || !strings.HasSuffix(name, rawPageSuffix) {
+			continue
+		}
+		times = append(times, scrapedAt)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].After(times[j]) })
+	return times, nil
+}
+
+// PruneRawContent removes the pages and screenshots of scrapes before cutoff
+func (a *S3RawArchive) PruneRawContent(ctx context.Context, cutoff time.Time) (int, error) {
+	removed := 0
+	objects := a.client.ListObjects(ctx, a.bucket, minio.ListObjectsOptions{
+		Prefix:    a.prefix + "raw/",
+		Recursive: true,
+	})
+	for object := range objects {
+		if object.Err != nil {
+			return removed, fmt.Errorf("failed to list raw pages: %w", object.Err)
+		}
+		scrapedAt, ok := archivedAt(path.Base(object.Key))
+		if !ok || !scrapedAt.Before(cutoff) {
+			continue
+		}
+		if err := a.client.RemoveObject(ctx, a.bucket, object.Key, minio.RemoveObjectOptions{}); err != nil {
+			return removed, fmt.Errorf("failed to remove raw page: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// PurgePersonalData removes the pages and screenshots of scrapes before
+// cutoff, which are all personal data
+func (a *S3RawArchive) PurgePersonalData(ctx context.Context, cutoff time.Time) (int, error) {
+	return a.PruneRawContent(ctx, cutoff)
+}
+
+// DeleteSource removes the pages and screenshots of a URL
+func (a *S3RawArchive) DeleteSource(ctx context.Context, url string) (int, error) {
+	removed := 0
+	objects := a.client.ListObjects(ctx, a.bucket, minio.ListObjectsOptions{
+		Prefix: a.sourcePrefix(url) + "/",
+	})
+	for object := range objects {
+		if object.Err != nil {
+			return removed, fmt.Errorf("failed to list raw pages of %s: %w", url, object.Err)
+		}
+		if err := a.client.RemoveObject(ctx, a.bucket, object.Key, minio.RemoveObjectOptions{}); err != nil {
+			return removed, fmt.Errorf("failed to delete raw pages of %s: %w", url, err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// sourcePrefix returns the key prefix of the raw pages of a URL
+func (a *S3RawArchive) sourcePrefix(url string) string {
+	return a.prefix + "raw/" + sourceSlug(url)
+}
+
+// key returns the key of a raw page
+func (a *S3RawArchive) key(url string, scrapedAt time.Time) string {
+	return a.sourcePrefix(url) + "/" + scrapedAt.UTC().Format(snapshotTimeLayout) + rawPageSuffix
+}
+
+var (
+	_ ports.RawContentStore = (*S3RawArchive)(nil) // Ensure interface compliance
+	_ ports.ScreenshotStore = (*S3RawArchive)(nil)
+)
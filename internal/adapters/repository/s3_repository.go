@@ -0,0 +1,658 @@
+// internal/adapters/repository/s3_repository.go
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// Object layout:
+//
+//	<prefix>sources/<slug>/latest                -> key of the latest snapshot
+//	<prefix>sources/<slug>/snapshots/<time>.json -> JobCollection JSON
+//	<prefix>sources/<slug>/failure.json          -> ScrapeFailure JSON of the last failed scrape
+//	<prefix>sources/<slug>/diffs/<time>.json     -> DiffRecord JSON
+
+// S3Options configures the S3 repository
+type S3Options struct {
+	Endpoint  string // host[:port], or a URL whose scheme selects TLS
+	Bucket    string
+	Prefix    string
+	Region    string
+	AccessKey string // empty uses the standard AWS/MinIO credential chain
+	SecretKey string
+	UseSSL    bool
+	Retention int // snapshots kept per URL, 0 keeps all
+	// RawRetention is the number of snapshots per URL keeping their raw page,
+	// 0 keeps it in all
+	RawRetention int
+}
+
+// S3Repository implements the JobRepository interface on S3-compatible object
+// storage, so several stateless containers can share their history
+type S3Repository struct {
+	client       *minio.Client
+	bucket       string
+	prefix       string
+	retention    int
+	rawRetention int
+}
+
+// NewS3Repository connects to the storage and checks that the bucket exists
+func NewS3Repository(opts S3Options) (*S3Repository, error) {
+	client, err := newS3Client(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Repository{
+		client:       client,
+		bucket:       opts.Bucket,
+		prefix:       opts.Prefix,
+		retention:    opts.Retention,
+		rawRetention: opts.RawRetention,
+	}, nil
+}
+
+// newS3Client connects to the storage and checks that the bucket exists
+func newS3Client(opts S3Options) (*minio.Client, error) {
+	endpoint, secure := opts.Endpoint, opts.UseSSL
+	if u, err := url.Parse(opts.Endpoint); err == nil && u.Host != "" {
+		endpoint, secure = u.Host, u.Scheme == "https"
+	}
+
+	creds := credentials.NewStaticV4(opts.AccessKey, opts.SecretKey, "")
+	if opts.AccessKey == "" {
+		creds = credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.EnvMinio{},
+			&credentials.FileAWSCredentials{},
+			&credentials.IAM{Client: &http.Client{Transport: http.DefaultTransport}},
+		})
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  creds,
+		Secure: secure,
+		Region: opts.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	exists, err := client.BucketExists(ctx, opts.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach S3 bucket %s: %w", opts.Bucket, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("S3 bucket %s does not exist", opts.Bucket)
+	}
+	return client, nil
+}
+
+// SaveJobCollection uploads the collection as a new snapshot and points the
+// source's latest object at it
+func (r *S3Repository) SaveJobCollection(
+	ctx context.Context,
+	collection domain.JobCollection,
+) error {
+	key, err := r.putSnapshot(ctx, collection)
+	if err != nil {
+		return err
+	}
+
+	if err := r.put(ctx, r.latestKey(collection.SourceURL), []byte(key), "text/plain"); err != nil {
+		return fmt.Errorf("failed to update latest snapshot: %w", err)
+	}
+
+	return r.prune(ctx, collection.SourceURL, key)
+}
+
+// SaveSnapshot uploads the collection as a snapshot without changing the
+// latest pointer of its source
+func (r *S3Repository) SaveSnapshot(ctx context.Context, collection domain.JobCollection) error {
+	if _, err := r.putSnapshot(ctx, collection); err != nil {
+		return err
+	}
+
+	latest, err := r.get(ctx, r.latestKey(collection.SourceURL))
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	return r.prune(ctx, collection.SourceURL, string(latest))
+}
+
+// putSnapshot uploads a collection to its snapshot key and returns the key
+func (r *S3Repository) putSnapshot(ctx context.Context, collection domain.JobCollection) (string, error) {
+	key := path.Join(r.sourcePrefix(collection.SourceURL), "snapshots",
+		collection.ScrapedAt.UTC().Format(snapshotTimeLayout)+".json")
+	return key, r.putCollection(ctx, key, collection.WithChecksum())
+}
+
+// prune deletes the oldest snapshots of a URL beyond the retention limit and
+// drops the raw page of the snapshot pushed beyond the raw retention limit,
+// never touching the latest one
+func (r *S3Repository) prune(ctx context.Context, url, latest string) error {
+	if r.retention <= 0 && r.rawRetention <= 0 {
+		return nil
+	}
+
+	keys, err := r.snapshotKeys(ctx, url)
+	if err != nil {
+		return err
+	}
+	if r.retention > 0 {
+		for _, key := range keys[min(r.retention, len(keys)):] {
+			if key == latest {
+				continue
+			}
+			if err := r.client.RemoveObject(ctx, r.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+				return fmt.Errorf("failed to remove old snapshot: %w", err)
+			}
+		}
+	}
+	if r.rawRetention > 0 && len(keys) > r.rawRetention && (r.retention <= 0 || r.rawRetention < r.retention) {
+		if key := keys[r.rawRetention]; key != latest {
+			return r.dropRawContent(ctx, key)
+		}
+	}
+	return nil
+}
+
+// dropRawContent rewrites the snapshot object key without its raw page.
+// Unreadable snapshots are left as they are.
+func (r *S3Repository) dropRawContent(ctx context.Context, key string) error {
+	data, err := r.get(ctx, key)
+	if err != nil {
+		return err
+	}
+	snapshot, err := decodeCollection(data)
+	if err != nil {
+		return nil
+	}
+	if snapshot, ok := withoutRawContent(snapshot); ok {
+		return r.putCollection(ctx, key, snapshot)
+	}
+	return nil
+}
+
+// snapshotKeys returns the snapshot keys of a URL, newest first
+func (r *S3Repository) snapshotKeys(ctx context.Context, url string) ([]string, error) {
+	var keys []string
+	objects := r.client.ListObjects(ctx, r.bucket, minio.ListObjectsOptions{
+		Prefix:    r.sourcePrefix(url) + "/snapshots/",
+		Recursive: true,
+	})
+	for object := range objects {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list snapshots: %w", object.Err)
+		}
+		keys = append(keys, object.Key)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(keys)))
+	return keys, nil
+}
+
+// GetLatestJobCollection retrieves the latest snapshot of a URL
+func (r *S3Repository) GetLatestJobCollection(
+	ctx context.Context,
+	url string,
+) (domain.JobCollection, error) {
+	key, err := r.get(ctx, r.latestKey(url))
+	if err != nil {
+		return domain.JobCollection{}, err
+	}
+
+	data, err := r.get(ctx, string(key))
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.JobCollection{}, &domain.CorruptSnapshotError{SourceURL: url, Reason: "latest snapshot is missing"}
+	}
+	if err != nil {
+		return domain.JobCollection{}, err
+	}
+
+	collection, err := decodeCollection(data)
+	if err != nil {
+		return domain.JobCollection{}, &domain.CorruptSnapshotError{SourceURL: url, Reason: err.Error()}
+	}
+
+	if err := collection.VerifyChecksum(); err != nil {
+		return domain.JobCollection{}, err
+	}
+
+	return collection, nil
+}
+
+// ListSnapshots returns up to limit snapshots of a URL, newest first
+func (r *S3Repository) ListSnapshots(ctx context.Context, url string, limit int) ([]domain.JobCollection, error) {
+	keys, err := r.snapshotKeys(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	snapshots := make([]domain.JobCollection, 0, len(keys))
+	for _, key := range keys {
+		data, err := r.get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		collection, err := decodeCollection(data)
+		if err != nil || collection.VerifyChecksum() != nil {
+			continue // corrupt snapshots are reported when read as latest
+		}
+		snapshots = append(snapshots, collection)
+	}
+	return snapshots, nil
+}
+
+// GetJobHistory returns the timeline of a job ID on a URL from its retained snapshots
+func (r *S3Repository) GetJobHistory(ctx context.Context, url, jobID string) (domain.JobHistory, error) {
+	return jobHistory(ctx, r, url, jobID)
+}
+
+// SaveScrapeFailure records the last failed scrape of a URL
+func (r *S3Repository) SaveScrapeFailure(ctx context.Context, failure domain.ScrapeFailure) error {
+	data, err := json.Marshal(failure)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scrape failure: %w", err)
+	}
+	if err := r.put(ctx, r.failureKey(failure.SourceURL), data, "application/json"); err != nil {
+		return fmt.Errorf("failed to save scrape failure: %w", err)
+	}
+	return nil
+}
+
+// SaveDiff uploads a diff computed for a URL, keyed by the time it was computed
+func (r *S3Repository) SaveDiff(ctx context.Context, record domain.DiffRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal diff: %w", err)
+	}
+	key := path.Join(r.sourcePrefix(record.Diff.SourceURL), "diffs",
+		record.ComputedAt.UTC().Format(snapshotTimeLayout)+".json")
+	if err := r.put(ctx, key, data, "application/json"); err != nil {
+		return fmt.Errorf("failed to save diff: %w", err)
+	}
+	return nil
+}
+
+// GetDiffHistory returns the diffs recorded for a URL since the given time,
+// oldest first. Diff keys carry their time, so older ones are never downloaded.
+func (r *S3Repository) GetDiffHistory(ctx context.Context, url string, since time.Time) ([]domain.DiffRecord, error) {
+	var keys []string
+	objects := r.client.ListObjects(ctx, r.bucket, minio.ListObjectsOptions{
+		Prefix:    r.sourcePrefix(url) + "/diffs/",
+		Recursive: true,
+	})
+	for object := range objects {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list diffs: %w", object.Err)
+		}
+		computedAt, err := time.Parse(snapshotTimeLayout, strings.TrimSuffix(path.Base(object.Key), ".json"))
+		if err != nil || computedAt.Before(since) {
+			continue
+		}
+		keys = append(keys, object.Key)
+	}
+	sort.Strings(keys)
+
+	records := make([]domain.DiffRecord, 0, len(keys))
+	for _, key := range keys {
+		data, err := r.get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		var record domain.DiffRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal diff: %w", err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// ListTrackedSources returns the state of every URL in the bucket. The latest
+// snapshot of each source is downloaded for its job count.
+func (r *S3Repository) ListTrackedSources(ctx context.Context) ([]domain.TrackedSource, error) {
+	var sources []domain.TrackedSource
+	var failures []domain.ScrapeFailure
+	objects := r.client.ListObjects(ctx, r.bucket, minio.ListObjectsOptions{
+		Prefix:    r.prefix + "sources/",
+		Recursive: true,
+	})
+	for object := range objects {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list sources: %w", object.Err)
+		}
+
+		switch path.Base(object.Key) {
+		case "latest":
+			key, err := r.get(ctx, object.Key)
+			if err != nil {
+				return nil, err
+			}
+			data, err := r.get(ctx, string(key))
+			if errors.Is(err, domain.ErrNotFound) {
+				continue // corrupt sources are reported when read
+			}
+			if err != nil {
+				return nil, err
+			}
+			collection, err := decodeCollection(data)
+			if err != nil {
+				continue
+			}
+			sources = append(sources, domain.TrackCollection(collection))
+
+		case "failure.json":
+			data, err := r.get(ctx, object.Key)
+			if err != nil {
+				return nil, err
+			}
+			var failure domain.ScrapeFailure
+			if err := json.Unmarshal(data, &failure); err == nil {
+				failures = append(failures, failure)
+			}
+		}
+	}
+
+	return domain.MergeScrapeFailures(sources, failures), nil
+}
+
+// PurgePersonalData strips personal data from snapshots scraped and diffs
+// computed before cutoff. Their keys carry their times, so newer ones are
+// never downloaded.
+func (r *S3Repository) PurgePersonalData(ctx context.Context, cutoff time.Time) (int, error) {
+	purged := 0
+	objects := r.client.ListObjects(ctx, r.bucket, minio.ListObjectsOptions{
+		Prefix:    r.prefix + "sources/",
+		Recursive: true,
+	})
+	for object := range objects {
+		if object.Err != nil {
+			return purged, fmt.Errorf("failed to list snapshots: %w", object.Err)
+		}
+		dir := path.Base(path.Dir(object.Key))
+		if dir != "snapshots" && dir != "diffs" {
+			continue
+		}
+		scrapedAt, err := time.Parse(snapshotTimeLayout, strings.TrimSuffix(path.Base(object.Key), ".json"))
+		if err != nil || !scrapedAt.Before(cutoff) {
+			continue
+		}
+		if dir == "diffs" {
+			stripped, err := r.purgeDiff(ctx, object.Key)
+			if err != nil {
+				return purged, err
+			}
+			if stripped {
+				purged++
+			}
+			continue
+		}
+
+		data, err := r.get(ctx, object.Key)
+		if err != nil {
+			return purged, err
+		}
+		collection, err := decodeCollection(data)
+		if err != nil {
+			continue // corrupt snapshots are reported when read
+		}
+		if !hasPersonalData(collection) {
+			continue
+		}
+
+		if err := r.putCollection(ctx, object.Key, collection.StripPersonalData()); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// purgeDiff strips job descriptions from the diff stored at key, reporting
+// whether it had any
+func (r *S3Repository) purgeDiff(ctx context.Context, key string) (bool, error) {
+	data, err := r.get(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	var record domain.DiffRecord
+	if err := json.Unmarshal(data, &record); err != nil || !record.HasPersonalData() {
+		return false, nil // unreadable diffs are reported when read
+	}
+
+	data, err = json.Marshal(record.StripPersonalData())
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal diff: %w", err)
+	}
+	if err := r.put(ctx, key, data, "application/json"); err != nil {
+		return false, fmt.Errorf("failed to purge diff: %w", err)
+	}
+	return true, nil
+}
+
+// DeleteSource removes all snapshots of a source URL
+func (r *S3Repository) DeleteSource(ctx context.Context, url string) (int, error) {
+	listed := r.client.ListObjects(ctx, r.bucket, minio.ListObjectsOptions{
+		Prefix:    r.sourcePrefix(url) + "/",
+		Recursive: true,
+	})
+
+	deleted := 0
+	toRemove := make(chan minio.ObjectInfo)
+	var listErr error
+	go func() {
+		defer close(toRemove)
+		for object := range listed {
+			if object.Err != nil {
+				listErr = object.Err
+				return
+			}
+			if path.Base(path.Dir(object.Key)) == "snapshots" {
+				deleted++
+			}
+			toRemove <- object
+		}
+	}()
+
+	var removeErr error
+	for result := range r.client.RemoveObjects(ctx, r.bucket, toRemove, minio.RemoveObjectsOptions{}) {
+		if removeErr == nil {
+			removeErr = result.Err
+		}
+	}
+	if removeErr != nil {
+		return 0, fmt.Errorf("failed to delete source %s: %w", url, removeErr)
+	}
+	if listErr != nil {
+		return 0, fmt.Errorf("failed to list source %s: %w", url, listErr)
+	}
+
+	return deleted, nil
+}
+
+// PruneSnapshots deletes snapshots scraped before cutoff except the latest of
+// each source, and diffs computed before cutoff. Keys carry their time, so
+// none are downloaded.
+func (r *S3Repository) PruneSnapshots(ctx context.Context, cutoff time.Time) (int, error) {
+	pruned := 0
+	latest := make(map[string]string) // source prefix -> latest snapshot key
+	objects := r.client.ListObjects(ctx, r.bucket, minio.ListObjectsOptions{
+		Prefix:    r.prefix + "sources/",
+		Recursive: true,
+	})
+	for object := range objects {
+		if object.Err != nil {
+			return pruned, fmt.Errorf("failed to list snapshots: %w", object.Err)
+		}
+		dir := path.Base(path.Dir(object.Key))
+		if dir != "snapshots" && dir != "diffs" {
+			continue
+		}
+		scrapedAt, err := time.Parse(snapshotTimeLayout, strings.TrimSuffix(path.Base(object.Key), ".json"))
+		if err != nil || !scrapedAt.Before(cutoff) {
+			continue
+		}
+		if dir == "diffs" {
+			if err := r.client.RemoveObject(ctx, r.bucket, object.Key, minio.RemoveObjectOptions{}); err != nil {
+				return pruned, fmt.Errorf("failed to remove old diff: %w", err)
+			}
+			continue
+		}
+
+		source := path.Dir(path.Dir(object.Key))
+		if _, ok := latest[source]; !ok {
+			key, err := r.get(ctx, source+"/latest")
+			if err != nil && !errors.Is(err, domain.ErrNotFound) {
+				return pruned, err
+			}
+			latest[source] = string(key)
+		}
+		if object.Key == latest[source] {
+			continue
+		}
+
+		if err := r.client.RemoveObject(ctx, r.bucket, object.Key, minio.RemoveObjectOptions{}); err != nil {
+			return pruned, fmt.Errorf("failed to remove old snapshot: %w", err)
+		}
+		pruned++
+	}
+
+	return pruned, nil
+}
+
+// PruneSources deletes the sources whose latest snapshot was scraped before cutoff
+func (r *S3Repository) PruneSources(ctx context.Context, cutoff time.Time) ([]string, error) {
+	var old []string
+	objects := r.client.ListObjects(ctx, r.bucket, minio.ListObjectsOptions{
+		Prefix:    r.prefix + "sources/",
+		Recursive: true,
+	})
+	for object := range objects {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list sources: %w", object.Err)
+		}
+		if path.Base(object.Key) != "latest" {
+			continue
+		}
+		key, err := r.get(ctx, object.Key)
+		if err != nil {
+			return nil, err
+		}
+		scrapedAt, err := time.Parse(snapshotTimeLayout, strings.TrimSuffix(path.Base(string(key)), ".json"))
+		if err == nil && scrapedAt.Before(cutoff) {
+			old = append(old, string(key))
+		}
+	}
+
+	// Keys only hold a slug of the URL, so it is read from the snapshot
+	var pruned []string
+	for _, key := range old {
+		data, err := r.get(ctx, key)
+		if err != nil {
+			return pruned, err
+		}
+		collection, err := decodeCollection(data)
+		if err != nil {
+			continue // corrupt snapshots are reported when read as latest
+		}
+		if _, err := r.DeleteSource(ctx, collection.SourceURL); err != nil {
+			return pruned, err
+		}
+		pruned = append(pruned, collection.SourceURL)
+	}
+
+	return pruned, nil
+}
+
+// putCollection uploads a collection as JSON
+func (r *S3Repository) putCollection(ctx context.Context, key string, collection domain.JobCollection) error {
+	data, err := json.Marshal(newVersionedCollection(collection))
+	if err != nil {
+		return fmt.Errorf("failed to marshal job collection: %w", err)
+	}
+	if err := r.put(ctx, key, data, "application/json"); err != nil {
+		return fmt.Errorf("failed to save job collection: %w", err)
+	}
+	return nil
+}
+
+// put uploads an object
+func (r *S3Repository) put(ctx context.Context, key string, data []byte, contentType string) error {
+	return s3Put(ctx, r.client, r.bucket, key, data, contentType)
+}
+
+// get downloads an object, returning ErrNotFound if it doesn't exist
+func (r *S3Repository) get(ctx context.Context, key string) ([]byte, error) {
+	return s3Get(ctx, r.client, r.bucket, key)
+}
+
+// s3Put uploads an object to a bucket
+func s3Put(ctx context.Context, client *minio.Client, bucket, key string, data []byte, contentType string) error {
+	_, err := client.PutObject(ctx, bucket, key, bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+// s3Get downloads an object from a bucket, returning ErrNotFound if it doesn't exist
+func s3Get(ctx context.Context, client *minio.Client, bucket, key string) ([]byte, error) {
+	object, err := client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+	if err == nil {
+		defer object.Close()
+		var data []byte
+		if data, err = io.ReadAll(object); err == nil {
+			return data, nil
+		}
+	}
+
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return nil, domain.ErrNotFound
+	}
+	return nil, fmt.Errorf("failed to download %s: %w", key, err)
+}
+
+// sourcePrefix returns the key prefix of everything stored about a URL
+func (r *S3Repository) sourcePrefix(url string) string {
+	return r.prefix + "sources/" + sourceSlug(url)
+}
+
+// latestKey returns the key of the pointer to a URL's latest snapshot
+func (r *S3Repository) latestKey(url string) string {
+	return r.sourcePrefix(url) + "/latest"
+}
+
+// failureKey returns the key of a URL's last failed scrape
+func (r *S3Repository) failureKey(url string) string {
+	return r.sourcePrefix(url) + "/failure.json"
+}
+
+var (
+	_ ports.JobRepository      = (*S3Repository)(nil) // Ensure interface compliance
+	_ ports.PersonalDataPurger = (*S3Repository)(nil)
+	_ ports.SourceDeleter      = (*S3Repository)(nil)
+	_ ports.RetentionPruner    = (*S3Repository)(nil)
+)
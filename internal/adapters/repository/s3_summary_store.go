@@ -0,0 +1,132 @@
+// internal/adapters/repository/s3_summary_store.go
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// Object layout:
+//
+//	<prefix>summaries/<name>             -> summary page, publicly readable under baseURL
+//	<prefix>summary-sources/<name>.json  -> source URLs of the page
+
+// S3SummaryStore implements the SummaryStore interface on S3-compatible
+// object storage. It shares the S3Options of the S3 repository; the bucket
+// or a CDN in front of it must serve the summaries prefix at baseURL. Pages
+// stored before their sources were recorded are only pruned by age.
+type S3SummaryStore struct {
+	client  *minio.Client
+	bucket  string
+	prefix  string
+	baseURL string
+}
+
+// NewS3SummaryStore connects to the storage and checks that the bucket exists
+func NewS3SummaryStore(opts S3Options, baseURL string) (*S3SummaryStore, error) {
+	client, err := newS3Client(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &S3SummaryStore{
+		client:  client,
+		bucket:  opts.Bucket,
+		prefix:  opts.Prefix,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// PutSummary uploads a summary page, after its sources so that no page is
+// left without them
+func (s *S3SummaryStore) PutSummary(ctx context.Context, name, contentType string, content []byte, sources []string) (string, error) {
+	data, err := json.Marshal(sources)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal summary sources: %w", err)
+	}
+	if err := s3Put(ctx, s.client, s.bucket, s.sourcesKey(name), data, "application/json"); err != nil {
+		return "", fmt.Errorf("failed to store summary sources: %w", err)
+	}
+	if err := s3Put(ctx, s.client, s.bucket, s.prefix+"summaries/"+name, content, contentType); err != nil {
+		return "", fmt.Errorf("failed to store summary: %w", err)
+	}
+	return s.baseURL + "/" + url.PathEscape(name), nil
+}
+
+// PruneSummaries removes the pages uploaded before cutoff
+func (s *S3SummaryStore) PruneSummaries(ctx context.Context, cutoff time.Time) (int, error) {
+	removed := 0
+	objects := s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: s.prefix + "summaries/"})
+	for object := range objects {
+		if object.Err != nil {
+			return removed, fmt.Errorf("failed to list summaries: %w", object.Err)
+		}
+		if !object.LastModified.Before(cutoff) {
+			continue
+		}
+		if err := s.remove(ctx, path.Base(object.Key)); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// PurgePersonalData removes the pages uploaded before cutoff, which are all
+// personal data
+func (s *S3SummaryStore) PurgePersonalData(ctx context.Context, cutoff time.Time) (int, error) {
+	return s.PruneSummaries(ctx, cutoff)
+}
+
+// DeleteSource removes the pages about a URL
+func (s *S3SummaryStore) DeleteSource(ctx context.Context, sourceURL string) (int, error) {
+	removed := 0
+	objects := s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: s.prefix + "summary-sources/"})
+	for object := range objects {
+		if object.Err != nil {
+			return removed, fmt.Errorf("failed to list summary sources: %w", object.Err)
+		}
+		data, err := s3Get(ctx, s.client, s.bucket, object.Key)
+		if err != nil {
+			return removed, err
+		}
+		var sources []string
+		if err := json.Unmarshal(data, &sources); err != nil || !slices.Contains(sources, sourceURL) {
+			continue
+		}
+		if err := s.remove(ctx, strings.TrimSuffix(path.Base(object.Key), ".json")); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// remove deletes a page and its sources
+func (s *S3SummaryStore) remove(ctx context.Context, name string) error {
+	if err := s.client.RemoveObject(ctx, s.bucket, s.prefix+"summaries/"+name, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to remove summary: %w", err)
+	}
+	if err := s.client.RemoveObject(ctx, s.bucket, s.sourcesKey(name), minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("failed to remove summary sources: %w", err)
+	}
+	return nil
+}
+
+// sourcesKey returns the key of the source URLs of a page
+func (s *S3SummaryStore) sourcesKey(name string) string {
+	return s.prefix + "summary-sources/" + name + ".json"
+}
+
+var (
+	_ ports.SummaryStore = (*S3SummaryStore)(nil) // Ensure interface compliance
+)
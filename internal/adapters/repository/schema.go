@@ -0,0 +1,168 @@
+// internal/adapters/repository/schema.go
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// storageSchemaVersion is the version of the JSON documents written by the
+// persistent repositories. Documents record the version they were
+// written with and are upgraded by schemaMigrations when read, so a domain
+// model change that needs stored data rewritten bumps the version and appends
+// a migration rather than breaking reads of old snapshots.
+const storageSchemaVersion = 3
+
+// schemaMigration upgrades stored documents by one version. Either function
+// may be nil if that kind of document is unchanged.
+type schemaMigration struct {
+	job        func(doc map[string]json.RawMessage) error // a Job object
+	collection func(doc map[string]json.RawMessage) error // a JobCollection object, its jobs already upgraded
+}
+
+// schemaMigrations[i] upgrades documents of version i to version i+1
+var schemaMigrations = []schemaMigration{
+	// 1: documents carry a schema version; unversioned ones need no changes
+	{},
+	// 2: jobs carry FirstSeenAt and LastSeenAt, approximated by the scrape
+	// that stored them
+	{job: func(doc map[string]json.RawMessage) error {
+		for _, field := range []string{"first_seen_at", "last_seen_at"} {
+			if _, ok := doc[field]; !ok && doc["scraped_at"] != nil {
+				doc[field] = doc["scraped_at"]
+			}
+		}
+		return nil
+	}},
+	// 3: jobs carry a Deadline, encoded as the zero time when unknown, which
+	// the checksums of older collections don't cover; upgrading recomputes them
+	{},
+}
+
+// versionedCollection is a JobCollection document with its schema version
+type versionedCollection struct {
+	SchemaVersion int `json:"schema_version"`
+	domain.JobCollection
+}
+
+// newVersionedCollection stamps a collection with the current schema version
+func newVersionedCollection(collection domain.JobCollection) versionedCollection {
+	return versionedCollection{SchemaVersion: storageSchemaVersion, JobCollection: collection}
+}
+
+// decodeCollection reads a versioned collection document, upgrading it to the
+// current schema first
+func decodeCollection(data []byte) (domain.JobCollection, error) {
+	var header struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return domain.JobCollection{}, err
+	}
+	return migrateCollection(data, header.SchemaVersion)
+}
+
+// migrateCollection upgrades a collection document written with the given
+// schema version and decodes it
+func migrateCollection(data []byte, version int) (domain.JobCollection, error) {
+	var collection domain.JobCollection
+	if err := checkSchemaVersion(version); err != nil {
+		return collection, err
+	}
+	if version == storageSchemaVersion {
+		err := json.Unmarshal(data, &collection)
+		return collection, err
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return collection, err
+	}
+	var jobs []json.RawMessage
+	if raw, ok := doc["Jobs"]; ok && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &jobs); err != nil {
+			return collection, err
+		}
+	}
+	for i, job := range jobs {
+		upgraded, err := migrateDocument(job, version, func(m schemaMigration) func(map[string]json.RawMessage) error { return m.job })
+		if err != nil {
+			return collection, fmt.Errorf("failed to migrate job %d: %w", i, err)
+		}
+		jobs[i] = upgraded
+	}
+	if jobs != nil {
+		raw, err := json.Marshal(jobs)
+		if err != nil {
+			return collection, err
+		}
+		doc["Jobs"] = raw
+	}
+
+	upgraded, err := json.Marshal(doc)
+	if err != nil {
+		return collection, err
+	}
+	if upgraded, err = migrateDocument(upgraded, version, func(m schemaMigration) func(map[string]json.RawMessage) error { return m.collection }); err != nil {
+		return collection, fmt.Errorf("failed to migrate job collection: %w", err)
+	}
+	if err := json.Unmarshal(upgraded, &collection); err != nil {
+		return collection, err
+	}
+
+	// The checksum covers the jobs as encoded by the current model; the data
+	// is trusted to have been intact when it was migrated
+	if collection.Checksum != "" {
+		collection = collection.WithChecksum()
+	}
+	return collection, nil
+}
+
+// decodeJob reads a job document written with the given schema version. It
+// reports whether the job had to be upgraded, in which case the checksum of
+// its collection needs recomputing.
+func decodeJob(data []byte, version int) (domain.Job, bool, error) {
+	var job domain.Job
+	if err := checkSchemaVersion(version); err != nil {
+		return job, false, err
+	}
+	if version == storageSchemaVersion {
+		err := json.Unmarshal(data, &job)
+		return job, false, err
+	}
+
+	upgraded, err := migrateDocument(data, version, func(m schemaMigration) func(map[string]json.RawMessage) error { return m.job })
+	if err != nil {
+		return job, false, fmt.Errorf("failed to migrate job: %w", err)
+	}
+	err = json.Unmarshal(upgraded, &job)
+	return job, true, err
+}
+
+// migrateDocument applies the migrations selected by step to a JSON object
+// written with the given schema version
+func migrateDocument(data []byte, version int, step func(schemaMigration) func(map[string]json.RawMessage) error) ([]byte, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	for v := version; v < storageSchemaVersion; v++ {
+		if migrate := step(schemaMigrations[v]); migrate != nil {
+			if err := migrate(doc); err != nil {
+				return nil, fmt.Errorf("schema version %d: %w", v+1, err)
+			}
+		}
+	}
+	return json.Marshal(doc)
+}
+
+// checkSchemaVersion rejects data written by a newer release, which this one
+// can't read correctly
+func checkSchemaVersion(version int) error {
+	if version < 0 || version > storageSchemaVersion {
+		return fmt.Errorf("stored data has schema version %d, this release supports up to %d", version, storageSchemaVersion)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// internal/adapters/repository/snapshots.go
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// snapshotTimeLayout names snapshot files and objects so they sort chronologically
+const snapshotTimeLayout = "20060102T150405.000000000Z"
+
+// sortSnapshots orders snapshots newest first
+func sortSnapshots(snapshots []domain.JobCollection) {
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].ScrapedAt.After(snapshots[j].ScrapedAt)
+	})
+}
+
+// limitSnapshots returns at most limit snapshots, all if limit <= 0
+func limitSnapshots(snapshots []domain.JobCollection, limit int) []domain.JobCollection {
+	if limit > 0 && len(snapshots) > limit {
+		return snapshots[:limit]
+	}
+	return snapshots
+}
+
+// withoutRawContent returns a snapshot without its raw page, false if it has
+// none to drop. Sealed collections keep theirs, since it holds their jobs.
+func withoutRawContent(collection domain.JobCollection) (domain.JobCollection, bool) {
+	if collection.RawContent == "" || strings.HasPrefix(collection.RawContent, sealedPrefix) {
+		return collection, false
+	}
+	collection.RawContent = ""
+	return collection, true
+}
+
+// dropRawContent drops the raw pages of the snapshots, newest first, beyond
+// the newest keep ones. keep <= 0 keeps all.
+func dropRawContent(snapshots []domain.JobCollection, keep int) {
+	if keep <= 0 {
+		return
+	}
+	for i := keep; i < len(snapshots); i++ {
+		snapshots[i], _ = withoutRawContent(snapshots[i])
+	}
+}
+
+// hasPersonalData reports whether a collection still holds fields removed by
+// StripPersonalData, so purged snapshots aren't written again
+func hasPersonalData(collection domain.JobCollection) bool {
+	if collection.RawContent != "" {
+		return true
+	}
+	for _, job := range collection.Jobs {
+		if job.Description != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// jobHistory builds the history of a job from all retained snapshots of its
+// source, returning ErrNotFound if no snapshot lists the job
+func jobHistory(ctx context.Context, repo ports.JobRepository, url, jobID string) (domain.JobHistory, error) {
+	snapshots, err := repo.ListSnapshots(ctx, url, 0)
+	if err != nil {
+		return domain.JobHistory{}, err
+	}
+	history, ok := domain.BuildJobHistory(snapshots, jobID)
+	if !ok {
+		return domain.JobHistory{}, domain.ErrNotFound
+	}
+	return history, nil
+}
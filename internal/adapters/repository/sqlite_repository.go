@@ -0,0 +1,1243 @@
+// internal/adapters/repository/sqlite_repository.go
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/repository/migrations"
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// SQLiteRepository implements the JobRepository interface using a SQLite
+// database, so state survives restarts
+type SQLiteRepository struct {
+	db           *sql.DB
+	retention    int // scrape runs kept per source, 0 keeps all
+	rawRetention int // scrape runs per source keeping their raw page, 0 keeps it in all
+}
+
+// NewSQLiteRepository opens (or creates) the database at path and migrates its
+// schema to the latest version. Up to retention scrape runs are kept per source, 0 keeps all.
+// Only the newest rawRetention runs keep their raw page, 0 keeps it in all.
+func NewSQLiteRepository(path string, retention, rawRetention int) (*SQLiteRepository, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)", path)
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SQLite database: %w", err)
+	}
+	// SQLite allows a single writer; one connection avoids SQLITE_BUSY between our own goroutines
+	db.SetMaxOpenConns(1)
+
+	if err := migrateSQLite(db); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate SQLite schema: %w", err)
+	}
+
+	return &SQLiteRepository{
+		db:           db,
+		retention:    retention,
+		rawRetention: rawRetention,
+	}, nil
+}
+
+// Close closes the database
+func (r *SQLiteRepository) Close() error {
+	return r.db.Close()
+}
+
+// sqliteRunColumns are the scrape run columns read by scanRun
+const sqliteRunColumns = `r.id, r.company_name, r.scraped_at, r.checksum, r.raw_content, r.changed_at, r.empty_since, r.departed`
+
+// SaveJobCollection records the collection as a new scrape run and makes it
+// the latest collection of its source
+func (r *SQLiteRepository) SaveJobCollection(
+	ctx context.Context,
+	collection domain.JobCollection,
+) error {
+	collection = collection.WithChecksum()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	runID, err := insertRun(ctx, tx, collection)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO collections (source_url, company_name, latest_run_id, updated_at)
+		 VALUES (?, ?, ?, ?)
+		 ON CONFLICT (source_url) DO UPDATE SET
+			company_name = excluded.company_name,
+			latest_run_id = excluded.latest_run_id,
+			updated_at = excluded.updated_at`,
+		collection.SourceURL, collection.CompanyName, runID, formatTime(time.Now()))
+	if err != nil {
+		return fmt.Errorf("failed to update collection: %w", err)
+	}
+
+	if err := r.pruneRuns(ctx, tx, collection.SourceURL); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit job collection: %w", err)
+	}
+	return nil
+}
+
+// SaveSnapshot records the collection as a scrape run without making it the
+// latest collection of its source
+func (r *SQLiteRepository) SaveSnapshot(ctx context.Context, collection domain.JobCollection) error {
+	collection = collection.WithChecksum()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := insertRun(ctx, tx, collection); err != nil {
+		return err
+	}
+	if err := r.pruneRuns(ctx, tx, collection.SourceURL); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit snapshot: %w", err)
+	}
+	return nil
+}
+
+// insertRun stores a collection and its jobs as a scrape run
+func insertRun(ctx context.Context, tx *sql.Tx, collection domain.JobCollection) (int64, error) {
+	var departed []byte
+	if len(collection.Departed) > 0 {
+		var err error
+		if departed, err = json.Marshal(collection.Departed); err != nil {
+			return 0, fmt.Errorf("failed to marshal departed jobs: %w", err)
+		}
+	}
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO scrape_runs (source_url, company_name, scraped_at, job_count, checksum, raw_content, changed_at, empty_since, departed)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		collection.SourceURL, collection.CompanyName, formatTime(collection.ScrapedAt),
+		len(collection.Jobs), collection.Checksum, collection.RawContent,
+		formatTime(collection.ChangedAt), formatTime(collection.EmptySince), string(departed))
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert scrape run: %w", err)
+	}
+	runID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get scrape run id: %w", err)
+	}
+
+	if err := insertJobs(ctx, tx, runID, collection.Jobs); err != nil {
+		return 0, err
+	}
+	return runID, nil
+}
+
+// pruneRuns deletes the scrape runs of a source beyond the retention limit,
+// never the latest one. Their jobs are deleted by the foreign key cascade.
+// The raw pages of runs beyond the raw retention limit are dropped.
+func (r *SQLiteRepository) pruneRuns(ctx context.Context, tx *sql.Tx, url string) error {
+	if err := r.dropRawContent(ctx, tx, url); err != nil {
+		return err
+	}
+	if r.retention <= 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx,
+		`DELETE FROM scrape_runs
+		 WHERE source_url = ?
+		   AND id NOT IN (SELECT id FROM scrape_runs WHERE source_url = ? ORDER BY scraped_at DESC, id DESC LIMIT ?)
+		   AND id NOT IN (SELECT latest_run_id FROM collections WHERE source_url = ?)`,
+		url, url, r.retention, url)
+	if err != nil {
+		return fmt.Errorf("failed to prune scrape runs: %w", err)
+	}
+	return nil
+}
+
+// dropRawContent clears the raw pages of the scrape runs of a source beyond
+// the raw retention limit, never of the latest one. Sealed collections keep
+// theirs, since it holds their jobs.
+func (r *SQLiteRepository) dropRawContent(ctx context.Context, tx *sql.Tx, url string) error {
+	if r.rawRetention <= 0 {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx,
+		`UPDATE scrape_runs SET raw_content = ''
+		 WHERE source_url = ? AND raw_content != '' AND substr(raw_content, 1, ?) != ?
+		   AND id NOT IN (SELECT id FROM scrape_runs WHERE source_url = ? ORDER BY scraped_at DESC, id DESC LIMIT ?)
+		   AND id NOT IN (SELECT latest_run_id FROM collections WHERE source_url = ?)`,
+		url, len(sealedPrefix), sealedPrefix, url, r.rawRetention, url)
+	if err != nil {
+		return fmt.Errorf("failed to drop raw pages of scrape runs: %w", err)
+	}
+	return nil
+}
+
+// insertJobs stores the jobs of a scrape run
+func insertJobs(ctx context.Context, tx *sql.Tx, runID int64, jobs []domain.Job) error {
+	stmt, err := tx.PrepareContext(ctx,
+		`INSERT INTO jobs (run_id, position, job_id, title, location, department, url, data, schema_version)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare job insert: %w", err)
+	}
+	defer stmt.Close()
+
+	for i, job := range jobs {
+		data, err := json.Marshal(job)
+		if err != nil {
+			return fmt.Errorf("failed to marshal job %s: %w", job.ID, err)
+		}
+		if _, err := stmt.ExecContext(ctx, runID, i, job.ID, job.Title, job.Location, job.Department, job.URL, string(data), storageSchemaVersion); err != nil {
+			return fmt.Errorf("failed to insert job %s: %w", job.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetLatestJobCollection retrieves the latest job collection for a URL
+func (r *SQLiteRepository) GetLatestJobCollection(
+	ctx context.Context,
+	url string,
+) (domain.JobCollection, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+sqliteRunColumns+`
+		 FROM collections c JOIN scrape_runs r ON r.id = c.latest_run_id
+		 WHERE c.source_url = ?`, url)
+	runID, collection, err := scanRun(row, url)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.JobCollection{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.JobCollection{}, fmt.Errorf("failed to query job collection: %w", err)
+	}
+
+	if collection, err = r.loadRunJobs(ctx, runID, collection); err != nil {
+		return domain.JobCollection{}, err
+	}
+
+	if err := collection.VerifyChecksum(); err != nil {
+		return domain.JobCollection{}, err
+	}
+
+	return collection, nil
+}
+
+// ListSnapshots returns up to limit scrape runs of a URL, newest first
+func (r *SQLiteRepository) ListSnapshots(ctx context.Context, url string, limit int) ([]domain.JobCollection, error) {
+	if limit <= 0 {
+		limit = -1 // no limit in SQLite
+	}
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT `+sqliteRunColumns+` FROM scrape_runs r
+		 WHERE r.source_url = ? ORDER BY r.scraped_at DESC, r.id DESC LIMIT ?`, url, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query snapshots: %w", err)
+	}
+
+	var runIDs []int64
+	var collections []domain.JobCollection
+	for rows.Next() {
+		runID, collection, err := scanRun(rows, url)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan snapshot: %w", err)
+		}
+		runIDs = append(runIDs, runID)
+		collections = append(collections, collection)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+
+	// Jobs are loaded after closing rows since the pool has a single connection
+	snapshots := make([]domain.JobCollection, 0, len(collections))
+	for i, collection := range collections {
+		if collection, err = r.loadRunJobs(ctx, runIDs[i], collection); err != nil {
+			return nil, err
+		}
+		if collection.VerifyChecksum() != nil {
+			continue // corrupt snapshots are reported when read as latest
+		}
+		snapshots = append(snapshots, collection)
+	}
+
+	return snapshots, nil
+}
+
+// GetJobHistory returns the timeline of a job ID on a URL from its retained snapshots
+func (r *SQLiteRepository) GetJobHistory(ctx context.Context, url, jobID string) (domain.JobHistory, error) {
+	return jobHistory(ctx, r, url, jobID)
+}
+
+// scanRun reads the sqliteRunColumns of a scrape run, without its jobs
+func scanRun(row interface{ Scan(...any) error }, url string) (int64, domain.JobCollection, error) {
+	var runID int64
+	var scrapedAt, changedAt, emptySince, departed string
+	collection := domain.JobCollection{SourceURL: url}
+
+	err := row.Scan(&runID, &collection.CompanyName, &scrapedAt, &collection.Checksum, &collection.RawContent, &changedAt, &emptySince, &departed)
+	if err != nil {
+		return 0, domain.JobCollection{}, err
+	}
+
+	if collection.ScrapedAt, err = parseTime(scrapedAt); err != nil {
+		return 0, domain.JobCollection{}, err
+	}
+	// Runs saved before activity tracking have no activity times
+	if changedAt != "" {
+		if collection.ChangedAt, err = parseTime(changedAt); err != nil {
+			return 0, domain.JobCollection{}, err
+		}
+	}
+	if emptySince != "" {
+		if collection.EmptySince, err = parseTime(emptySince); err != nil {
+			return 0, domain.JobCollection{}, err
+		}
+	}
+	if departed != "" {
+		if err := json.Unmarshal([]byte(departed), &collection.Departed); err != nil {
+			return 0, domain.JobCollection{}, fmt.Errorf("failed to unmarshal departed jobs: %w", err)
+		}
+	}
+
+	return runID, collection, nil
+}
+
+// ListRawSnapshots returns the scrape runs of url since the given time,
+// oldest first, with their raw content unless it was purged or archived
+func (r *SQLiteRepository) ListRawSnapshots(ctx context.Context, url string, since time.Time) ([]domain.RawSnapshot, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, scraped_at, job_count, raw_content FROM scrape_runs
+		 WHERE source_url = ? AND scraped_at >= ?
+		 ORDER BY id`, url, formatTime(since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query raw snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []domain.RawSnapshot
+	for rows.Next() {
+		snapshot := domain.RawSnapshot{SourceURL: url}
+		var scrapedAt string
+		if err := rows.Scan(&snapshot.Version, &scrapedAt, &snapshot.JobCount, &snapshot.RawContent); err != nil {
+			return nil, fmt.Errorf("failed to scan raw snapshot: %w", err)
+		}
+		if snapshot.ScrapedAt, err = parseTime(scrapedAt); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read raw snapshots: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+// ReplaceSnapshotJobs overwrites the jobs of a scrape run and updates its checksum
+func (r *SQLiteRepository) ReplaceSnapshotJobs(ctx context.Context, url string, version int64, jobs []domain.Job) error {
+	checksum := domain.JobCollection{SourceURL: url, Jobs: jobs}.ComputeChecksum()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE scrape_runs SET job_count = ?, checksum = ? WHERE id = ? AND source_url = ?`,
+		len(jobs), checksum, version, url)
+	if err != nil {
+		return fmt.Errorf("failed to update scrape run: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return domain.ErrNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM jobs WHERE run_id = ?`, version); err != nil {
+		return fmt.Errorf("failed to delete jobs: %w", err)
+	}
+	if err := insertJobs(ctx, tx, version, jobs); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit reparsed jobs: %w", err)
+	}
+	return nil
+}
+
+// PurgePersonalData strips personal data from scrape runs older than cutoff
+// that were not purged before, and from the diffs computed and notifications
+// captured or sent before cutoff
+func (r *SQLiteRepository) PurgePersonalData(ctx context.Context, cutoff time.Time) (int, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, source_url, checksum FROM scrape_runs
+		 WHERE scraped_at < ? AND id NOT IN (SELECT run_id FROM purges)`, formatTime(cutoff))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query scrape runs to purge: %w", err)
+	}
+
+	type pendingRun struct {
+		id        int64
+		sourceURL string
+		checksum  string
+	}
+	var pending []pendingRun
+	for rows.Next() {
+		var run pendingRun
+		if err := rows.Scan(&run.id, &run.sourceURL, &run.checksum); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan scrape run: %w", err)
+		}
+		pending = append(pending, run)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read scrape runs: %w", err)
+	}
+
+	for i, run := range pending {
+		jobs, _, err := r.runJobs(ctx, run.id)
+		if err != nil {
+			return i, err
+		}
+		collection := domain.JobCollection{SourceURL: run.sourceURL, Jobs: jobs, Checksum: run.checksum}
+		if err := r.purgeRun(ctx, run.id, collection.StripPersonalData()); err != nil {
+			return i, err
+		}
+	}
+
+	diffs, err := r.purgeDiffs(ctx, cutoff)
+	if err != nil {
+		return len(pending) + diffs, err
+	}
+	captures, err := r.purgeCapturedNotifications(ctx, cutoff)
+	if err != nil {
+		return len(pending) + diffs + captures, err
+	}
+	sent, err := r.purgeNotificationHistory(ctx, cutoff)
+	return len(pending) + diffs + captures + sent, err
+}
+
+// purgeDiffs strips job descriptions from the diffs computed before cutoff
+func (r *SQLiteRepository) purgeDiffs(ctx context.Context, cutoff time.Time) (int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, data FROM diffs WHERE computed_at < ?`, formatTime(cutoff))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query diffs to purge: %w", err)
+	}
+
+	type pendingDiff struct {
+		id     int64
+		record domain.DiffRecord
+	}
+	var pending []pendingDiff
+	for rows.Next() {
+		var diff pendingDiff
+		var data string
+		if err := rows.Scan(&diff.id, &data); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan diff: %w", err)
+		}
+		if err := json.Unmarshal([]byte(data), &diff.record.Diff); err != nil {
+			continue // unreadable diffs are reported when read
+		}
+		if diff.record.HasPersonalData() {
+			pending = append(pending, diff)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read diffs: %w", err)
+	}
+
+	for i, diff := range pending {
+		data, err := json.Marshal(diff.record.StripPersonalData().Diff)
+		if err != nil {
+			return i, fmt.Errorf("failed to marshal diff: %w", err)
+		}
+		if _, err := r.db.ExecContext(ctx, `UPDATE diffs SET data = ? WHERE id = ?`, string(data), diff.id); err != nil {
+			return i, fmt.Errorf("failed to purge diff: %w", err)
+		}
+	}
+	return len(pending), nil
+}
+
+// purgeCapturedNotifications strips personal data from the notifications
+// captured before cutoff
+func (r *SQLiteRepository) purgeCapturedNotifications(ctx context.Context, cutoff time.Time) (int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, data FROM captured_notifications WHERE captured_at < ?`, formatTime(cutoff))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query captured notifications to purge: %w", err)
+	}
+
+	var pending []domain.CapturedNotification
+	for rows.Next() {
+		var id int64
+		var data string
+		if err := rows.Scan(&id, &data); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan captured notification: %w", err)
+		}
+		var capture domain.CapturedNotification
+		if err := json.Unmarshal([]byte(data), &capture); err != nil {
+			continue // unreadable captures are reported when read
+		}
+		capture.ID = id
+		if capture.HasPersonalData() {
+			pending = append(pending, capture)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read captured notifications: %w", err)
+	}
+
+	for i, capture := range pending {
+		if err := updateCapture(ctx, r.db, capture.StripPersonalData()); err != nil {
+			return i, err
+		}
+	}
+	return len(pending), nil
+}
+
+// deleteSourceCaptures removes what the captured notifications say about a
+// source URL, deleting those left empty, and returns how many were deleted
+func deleteSourceCaptures(ctx context.Context, tx *sql.Tx, url string) (int, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id, data FROM captured_notifications`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query captured notifications: %w", err)
+	}
+
+	var concerned []domain.CapturedNotification
+	for rows.Next() {
+		var id int64
+		var data string
+		if err := rows.Scan(&id, &data); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan captured notification: %w", err)
+		}
+		var capture domain.CapturedNotification
+		if err := json.Unmarshal([]byte(data), &capture); err != nil {
+			continue // unreadable captures are reported when read
+		}
+		capture.ID = id
+		if capture.Concerns(url) {
+			concerned = append(concerned, capture)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read captured notifications: %w", err)
+	}
+
+	deleted := 0
+	for _, capture := range concerned {
+		capture, left := capture.WithoutSource(url)
+		if left {
+			if err := updateCapture(ctx, tx, capture); err != nil {
+				return deleted, err
+			}
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM captured_notifications WHERE id = ?`, capture.ID); err != nil {
+			return deleted, fmt.Errorf("failed to delete captured notification: %w", err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// execer is a database or a transaction executing statements
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// updateCapture overwrites a stored captured notification
+func updateCapture(ctx context.Context, db execer, capture domain.CapturedNotification) error {
+	data, err := json.Marshal(capture)
+	if err != nil {
+		return fmt.Errorf("failed to marshal captured notification: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE captured_notifications SET data = ? WHERE id = ?`, string(data), capture.ID); err != nil {
+		return fmt.Errorf("failed to update captured notification: %w", err)
+	}
+	return nil
+}
+
+// purgeNotificationHistory strips personal data from the notifications sent
+// before cutoff
+func (r *SQLiteRepository) purgeNotificationHistory(ctx context.Context, cutoff time.Time) (int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, data FROM notification_history WHERE created_at < ?`, formatTime(cutoff))
+	if err != nil {
+		return 0, fmt.Errorf("failed to query notifications to purge: %w", err)
+	}
+
+	var pending []domain.NotificationRecord
+	for rows.Next() {
+		var id int64
+		var data string
+		if err := rows.Scan(&id, &data); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		var record domain.NotificationRecord
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			continue // unreadable notifications are reported when read
+		}
+		record.ID = id
+		if record.HasPersonalData() {
+			pending = append(pending, record)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read notifications: %w", err)
+	}
+
+	for i, record := range pending {
+		if err := updateNotificationRecord(ctx, r.db, record.StripPersonalData()); err != nil {
+			return i, err
+		}
+	}
+	return len(pending), nil
+}
+
+// deleteSourceNotifications removes what the sent notifications say about a
+// source URL, deleting those left empty, and returns how many were deleted
+func deleteSourceNotifications(ctx context.Context, tx *sql.Tx, url string) (int, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id, data FROM notification_history`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query notifications: %w", err)
+	}
+
+	var concerned []domain.NotificationRecord
+	for rows.Next() {
+		var id int64
+		var data string
+		if err := rows.Scan(&id, &data); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		var record domain.NotificationRecord
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			continue // unreadable notifications are reported when read
+		}
+		record.ID = id
+		if record.Concerns(url) {
+			concerned = append(concerned, record)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read notifications: %w", err)
+	}
+
+	deleted := 0
+	for _, record := range concerned {
+		record, left := record.WithoutSource(url)
+		if left {
+			if err := updateNotificationRecord(ctx, tx, record); err != nil {
+				return deleted, err
+			}
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM notification_history WHERE id = ?`, record.ID); err != nil {
+			return deleted, fmt.Errorf("failed to delete notification: %w", err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// updateNotificationRecord overwrites a stored sent notification
+func updateNotificationRecord(ctx context.Context, db execer, record domain.NotificationRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+	if _, err := db.ExecContext(ctx, `UPDATE notification_history SET data = ? WHERE id = ?`, string(data), record.ID); err != nil {
+		return fmt.Errorf("failed to update notification: %w", err)
+	}
+	return nil
+}
+
+// purgeRun replaces the stored jobs of a run with their stripped versions
+func (r *SQLiteRepository) purgeRun(ctx context.Context, runID int64, stripped domain.JobCollection) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, job := range stripped.Jobs {
+		data, err := json.Marshal(job)
+		if err != nil {
+			return fmt.Errorf("failed to marshal job %s: %w", job.ID, err)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE jobs SET data = ?, schema_version = ? WHERE run_id = ? AND position = ?`, string(data), storageSchemaVersion, runID, i); err != nil {
+			return fmt.Errorf("failed to purge job %s: %w", job.ID, err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE scrape_runs SET raw_content = '', checksum = ? WHERE id = ?`, stripped.Checksum, runID); err != nil {
+		return fmt.Errorf("failed to purge scrape run: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO purges (run_id, purged_at) VALUES (?, ?)`, runID, formatTime(time.Now())); err != nil {
+		return fmt.Errorf("failed to record purge: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit purge: %w", err)
+	}
+	return nil
+}
+
+// SaveScrapeFailure records the last failed scrape of a source
+func (r *SQLiteRepository) SaveScrapeFailure(ctx context.Context, failure domain.ScrapeFailure) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO scrape_failures (source_url, failed_at, error) VALUES (?, ?, ?)
+		 ON CONFLICT (source_url) DO UPDATE SET
+			failed_at = excluded.failed_at,
+			error = excluded.error`,
+		failure.SourceURL, formatTime(failure.FailedAt), failure.Error)
+	if err != nil {
+		return fmt.Errorf("failed to save scrape failure: %w", err)
+	}
+	return nil
+}
+
+// SaveDiff records a diff computed for a source
+func (r *SQLiteRepository) SaveDiff(ctx context.Context, record domain.DiffRecord) error {
+	data, err := json.Marshal(record.Diff)
+	if err != nil {
+		return fmt.Errorf("failed to marshal diff: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO diffs (source_url, run_id, computed_at, data) VALUES (?, ?, ?, ?)`,
+		record.Diff.SourceURL, record.RunID, formatTime(record.ComputedAt), string(data))
+	if err != nil {
+		return fmt.Errorf("failed to save diff: %w", err)
+	}
+	return nil
+}
+
+// GetDiffHistory returns the diffs recorded for a source since the given time, oldest first
+func (r *SQLiteRepository) GetDiffHistory(ctx context.Context, url string, since time.Time) ([]domain.DiffRecord, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT run_id, computed_at, data FROM diffs
+		 WHERE source_url = ? AND computed_at >= ? ORDER BY computed_at, id`, url, formatTime(since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query diffs: %w", err)
+	}
+	defer rows.Close()
+
+	var records []domain.DiffRecord
+	for rows.Next() {
+		var record domain.DiffRecord
+		var computedAt, data string
+		if err := rows.Scan(&record.RunID, &computedAt, &data); err != nil {
+			return nil, fmt.Errorf("failed to scan diff: %w", err)
+		}
+		if record.ComputedAt, err = parseTime(computedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(data), &record.Diff); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal diff: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read diffs: %w", err)
+	}
+
+	return records, nil
+}
+
+// ListTrackedSources returns the state of every source in the database
+func (r *SQLiteRepository) ListTrackedSources(ctx context.Context) ([]domain.TrackedSource, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT c.source_url, r.company_name, r.scraped_at, r.job_count
+		 FROM collections c JOIN scrape_runs r ON r.id = c.latest_run_id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sources: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []domain.TrackedSource
+	for rows.Next() {
+		var source domain.TrackedSource
+		var scrapedAt string
+		if err := rows.Scan(&source.SourceURL, &source.CompanyName, &scrapedAt, &source.JobCount); err != nil {
+			return nil, fmt.Errorf("failed to scan source: %w", err)
+		}
+		if source.LastScrapedAt, err = parseTime(scrapedAt); err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read sources: %w", err)
+	}
+
+	failures, err := r.scrapeFailures(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.MergeScrapeFailures(sources, failures), nil
+}
+
+// scrapeFailures returns the last failure of every source
+func (r *SQLiteRepository) scrapeFailures(ctx context.Context) ([]domain.ScrapeFailure, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT source_url, failed_at, error FROM scrape_failures`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scrape failures: %w", err)
+	}
+	defer rows.Close()
+
+	var failures []domain.ScrapeFailure
+	for rows.Next() {
+		var failure domain.ScrapeFailure
+		var failedAt string
+		if err := rows.Scan(&failure.SourceURL, &failedAt, &failure.Error); err != nil {
+			return nil, fmt.Errorf("failed to scan scrape failure: %w", err)
+		}
+		if failure.FailedAt, err = parseTime(failedAt); err != nil {
+			return nil, err
+		}
+		failures = append(failures, failure)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read scrape failures: %w", err)
+	}
+	return failures, nil
+}
+
+// DeleteSource removes the collection, all scrape runs and the captured and
+// sent notifications of a source URL
+func (r *SQLiteRepository) DeleteSource(ctx context.Context, url string) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM collections WHERE source_url = ?`, url); err != nil {
+		return 0, fmt.Errorf("failed to delete collection: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM scrape_failures WHERE source_url = ?`, url); err != nil {
+		return 0, fmt.Errorf("failed to delete scrape failure: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM diffs WHERE source_url = ?`, url); err != nil {
+		return 0, fmt.Errorf("failed to delete diffs: %w", err)
+	}
+	// Jobs and purge records are removed by cascade
+	result, err := tx.ExecContext(ctx, `DELETE FROM scrape_runs WHERE source_url = ?`, url)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete scrape runs: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted scrape runs: %w", err)
+	}
+	captures, err := deleteSourceCaptures(ctx, tx, url)
+	if err != nil {
+		return 0, err
+	}
+	sent, err := deleteSourceNotifications(ctx, tx, url)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit source deletion: %w", err)
+	}
+	return int(deleted) + captures + sent, nil
+}
+
+// PruneSnapshots deletes scrape runs older than cutoff except the latest run
+// of each source. Their jobs are deleted by the foreign key cascade. Diffs
+// computed and notifications captured or sent before cutoff are deleted too.
+func (r *SQLiteRepository) PruneSnapshots(ctx context.Context, cutoff time.Time) (int, error) {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM diffs WHERE computed_at < ?`, formatTime(cutoff)); err != nil {
+		return 0, fmt.Errorf("failed to prune diffs: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM captured_notifications WHERE captured_at < ?`, formatTime(cutoff)); err != nil {
+		return 0, fmt.Errorf("failed to prune captured notifications: %w", err)
+	}
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM notification_history WHERE created_at < ?`, formatTime(cutoff)); err != nil {
+		return 0, fmt.Errorf("failed to prune notification history: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx,
+		`DELETE FROM scrape_runs
+		 WHERE scraped_at < ? AND id NOT IN (SELECT latest_run_id FROM collections)`, formatTime(cutoff))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune scrape runs: %w", err)
+	}
+	pruned, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pruned scrape runs: %w", err)
+	}
+	return int(pruned), nil
+}
+
+// PruneSources deletes the sources whose latest run is older than cutoff
+func (r *SQLiteRepository) PruneSources(ctx context.Context, cutoff time.Time) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT c.source_url FROM collections c JOIN scrape_runs r ON r.id = c.latest_run_id
+		 WHERE r.scraped_at < ?`, formatTime(cutoff))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sources to prune: %w", err)
+	}
+	var urls []string
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan source: %w", err)
+		}
+		urls = append(urls, url)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read sources: %w", err)
+	}
+
+	for i, url := range urls {
+		if _, err := r.DeleteSource(ctx, url); err != nil {
+			return urls[:i], err
+		}
+	}
+	return urls, nil
+}
+
+// AppendAudit appends an entry to the audit log
+func (r *SQLiteRepository) AppendAudit(ctx context.Context, entry domain.AuditEntry) error {
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO audit_log (at, actor, action, target, previous, current) VALUES (?, ?, ?, ?, ?, ?)`,
+		formatTime(entry.At), entry.Actor, entry.Action, entry.Target, entry.Previous, entry.Current)
+	if err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return nil
+}
+
+// QueryAudit returns the matching audit entries, newest first
+func (r *SQLiteRepository) QueryAudit(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error) {
+	query := `SELECT id, at, actor, action, target, previous, current FROM audit_log WHERE 1 = 1`
+	var args []interface{}
+	if filter.Actor != "" {
+		query += ` AND actor = ?`
+		args = append(args, filter.Actor)
+	}
+	if filter.Action != "" {
+		query += ` AND action = ?`
+		args = append(args, filter.Action)
+	}
+	if filter.Target != "" {
+		query += ` AND target = ?`
+		args = append(args, filter.Target)
+	}
+	if !filter.Since.IsZero() {
+		query += ` AND at >= ?`
+		args = append(args, formatTime(filter.Since))
+	}
+	query += ` ORDER BY id DESC`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []domain.AuditEntry
+	for rows.Next() {
+		var entry domain.AuditEntry
+		var at string
+		if err := rows.Scan(&entry.ID, &at, &entry.Actor, &entry.Action, &entry.Target, &entry.Previous, &entry.Current); err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		if entry.At, err = parseTime(at); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// SaveCapture stores a captured notification, deleting the oldest beyond
+// maxCaptures
+func (r *SQLiteRepository) SaveCapture(ctx context.Context, capture domain.CapturedNotification) error {
+	data, err := json.Marshal(capture)
+	if err != nil {
+		return fmt.Errorf("failed to marshal captured notification: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO captured_notifications (captured_at, kind, data) VALUES (?, ?, ?)`,
+		formatTime(capture.CapturedAt), string(capture.Kind), string(data))
+	if err != nil {
+		return fmt.Errorf("failed to save captured notification: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx,
+		`DELETE FROM captured_notifications
+		 WHERE id NOT IN (SELECT id FROM captured_notifications ORDER BY id DESC LIMIT ?)`, maxCaptures)
+	if err != nil {
+		return fmt.Errorf("failed to delete old captured notifications: %w", err)
+	}
+	return nil
+}
+
+// ListCaptures returns up to limit captured notifications, newest first
+func (r *SQLiteRepository) ListCaptures(ctx context.Context, limit int) ([]domain.CapturedNotification, error) {
+	if limit <= 0 {
+		limit = -1 // no limit in SQLite
+	}
+	rows, err := r.db.QueryContext(ctx, `SELECT id, data FROM captured_notifications ORDER BY id DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query captured notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var captures []domain.CapturedNotification
+	for rows.Next() {
+		var id int64
+		var data string
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, fmt.Errorf("failed to scan captured notification: %w", err)
+		}
+		var capture domain.CapturedNotification
+		if err := json.Unmarshal([]byte(data), &capture); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal captured notification: %w", err)
+		}
+		capture.ID = id
+		captures = append(captures, capture)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read captured notifications: %w", err)
+	}
+
+	return captures, nil
+}
+
+// RecordNotification stores a sent notification with its delivery attempts,
+// deleting the oldest beyond maxNotificationRecords
+func (r *SQLiteRepository) RecordNotification(ctx context.Context, record domain.NotificationRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO notification_history (created_at, kind, data) VALUES (?, ?, ?)`,
+		formatTime(record.CreatedAt), string(record.Kind), string(data))
+	if err != nil {
+		return fmt.Errorf("failed to record notification: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx,
+		`DELETE FROM notification_history
+		 WHERE id NOT IN (SELECT id FROM notification_history ORDER BY id DESC LIMIT ?)`, maxNotificationRecords)
+	if err != nil {
+		return fmt.Errorf("failed to delete old notifications: %w", err)
+	}
+	return nil
+}
+
+// QueryNotifications returns the matching sent notifications, newest first.
+// Only the time is filtered in SQL since sources and attempts are kept as JSON.
+func (r *SQLiteRepository) QueryNotifications(ctx context.Context, filter domain.NotificationFilter) ([]domain.NotificationRecord, error) {
+	query := `SELECT id, data FROM notification_history`
+	var args []interface{}
+	if !filter.Since.IsZero() {
+		query += ` WHERE created_at >= ?`
+		args = append(args, formatTime(filter.Since))
+	}
+	query += ` ORDER BY id DESC`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var records []domain.NotificationRecord
+	for rows.Next() {
+		if filter.Limit > 0 && len(records) == filter.Limit {
+			break
+		}
+		var id int64
+		var data string
+		if err := rows.Scan(&id, &data); err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		var record domain.NotificationRecord
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal notification: %w", err)
+		}
+		record.ID = id
+		if filter.Matches(record) {
+			records = append(records, record)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read notifications: %w", err)
+	}
+
+	return records, nil
+}
+
+// loadRunJobs sets the jobs of a scrape run on its collection. The checksum is
+// recomputed for jobs upgraded from an older storage schema, which no longer
+// encode as they did when it was taken.
+func (r *SQLiteRepository) loadRunJobs(ctx context.Context, runID int64, collection domain.JobCollection) (domain.JobCollection, error) {
+	jobs, migrated, err := r.runJobs(ctx, runID)
+	if err != nil {
+		return collection, err
+	}
+	collection.Jobs = jobs
+	if migrated && collection.Checksum != "" {
+		collection = collection.WithChecksum()
+	}
+	return collection, nil
+}
+
+// runJobs loads the jobs of a scrape run in their scraped order, reporting
+// whether any were upgraded from an older storage schema
+func (r *SQLiteRepository) runJobs(ctx context.Context, runID int64) ([]domain.Job, bool, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT data, schema_version FROM jobs WHERE run_id = ? ORDER BY position`, runID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []domain.Job
+	var migrated bool
+	for rows.Next() {
+		var data string
+		var version int
+		if err := rows.Scan(&data, &version); err != nil {
+			return nil, false, fmt.Errorf("failed to scan job: %w", err)
+		}
+		job, upgraded, err := decodeJob([]byte(data), version)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to unmarshal job: %w", err)
+		}
+		migrated = migrated || upgraded
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, fmt.Errorf("failed to read jobs: %w", err)
+	}
+
+	return jobs, migrated, nil
+}
+
+// migrateSQLite brings the schema of db up to date. Databases created before
+// migrations were tracked are baselined from the tables they already have.
+func migrateSQLite(db *sql.DB) error {
+	ctx := context.Background()
+	all, err := migrations.SQLite()
+	if err != nil {
+		return err
+	}
+
+	version, err := migrations.Version(ctx, db)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		legacy, err := legacySQLiteVersion(ctx, db)
+		if err != nil {
+			return err
+		}
+		if err := migrations.Baseline(ctx, db, all, legacy); err != nil {
+			return err
+		}
+	}
+
+	applied, err := migrations.Apply(ctx, db, all)
+	if err != nil {
+		return err
+	}
+	if applied > 0 {
+		log.Printf("Applied %d SQLite schema migrations", applied)
+	}
+	return nil
+}
+
+// legacySQLiteVersion detects the schema version of a database created before
+// migrations were tracked, 0 for a new database
+func legacySQLiteVersion(ctx context.Context, db *sql.DB) (int, error) {
+	var tables, columns int
+	err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'scrape_runs'`).Scan(&tables)
+	if err != nil || tables == 0 {
+		return 0, err
+	}
+	err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM pragma_table_info('scrape_runs') WHERE name = 'changed_at'`).Scan(&columns)
+	if err != nil {
+		return 0, err
+	}
+	if columns > 0 {
+		return 2, nil
+	}
+	return 1, nil
+}
+
+// sqliteTimeLayout is a fixed-width UTC layout, so stored times sort as text
+const sqliteTimeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// formatTime encodes a timestamp for storage
+func formatTime(t time.Time) string {
+	return t.UTC().Format(sqliteTimeLayout)
+}
+
+// parseTime decodes a stored timestamp
+func parseTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse stored time %q: %w", value, err)
+	}
+	return t, nil
+}
+
+var (
+	_ ports.JobRepository = (*SQLiteRepository)(nil) // Ensure interface compliance
+	_ ports.AuditLog            = (*SQLiteRepository)(nil)
+	_ ports.CaptureStore        = (*SQLiteRepository)(nil)
+	_ ports.NotificationHistory = (*SQLiteRepository)(nil)
+	_ ports.PersonalDataPurger  = (*SQLiteRepository)(nil)
+	_ ports.SourceDeleter       = (*SQLiteRepository)(nil)
+	_ ports.SnapshotArchive     = (*SQLiteRepository)(nil)
+	_ ports.RetentionPruner     = (*SQLiteRepository)(nil)
+)
@@ -3,34 +3,140 @@ package scheduler
 
 import (
 	"context"
+	"errors"
+	"math/rand"
 	"sync"
+	"time"
 	
 	"github.com/robfig/cron/v3"
 	"log"
 	"github.com/fuzztobread/job-scheduler/internal/core/ports"
 )
 
+// cronParser accepts standard 5-field expressions, an optional leading seconds
+// field and descriptors such as @daily
+var cronParser = cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
 // CronScheduler implements the Scheduler interface using cron
 type CronScheduler struct {
-	cron   *cron.Cron
-	jobs   map[cron.EntryID]context.CancelFunc
-	mu     sync.Mutex
+	cron     *cron.Cron
+	jobs     map[cron.EntryID]context.CancelFunc
+	mu       sync.Mutex
+	jitter   time.Duration  // longest random delay of a run after its tick
+	timeout  time.Duration  // deadline of a run, 0 for none
+	location *time.Location // timezone the specifications are read in
+	stopped  chan struct{}  // closed by Stop, ending the delays of pending runs
+	leader   Leadership     // when this instance runs the jobs, nil if it always does
+	stop     sync.Once
+}
+
+// Leadership tells whether this instance runs the jobs, when several
+// instances share them
+type Leadership interface {
+	// Lead returns a context derived from ctx which is canceled once the
+	// instance stops leading, or false if it doesn't lead
+	Lead(ctx context.Context) (context.Context, context.CancelFunc, bool)
+}
+
+// CronOption configures a CronScheduler
+type CronOption func(*CronScheduler)
+
+// WithJitter delays every run by a random duration up to max, so runs of
+// several schedules, or of several instances, sharing a tick don't start at
+// once
+func WithJitter(max time.Duration) CronOption {
+	return func(s *CronScheduler) {
+		s.jitter = max
+	}
+}
+
+// WithTimeout cancels the context of a run once it took timeout, so a hung
+// job, e.g. a scrape waiting on a stuck browser, doesn't run forever
+func WithTimeout(timeout time.Duration) CronOption {
+	return func(s *CronScheduler) {
+		s.timeout = timeout
+	}
+}
+
+// WithLocation reads the specifications in the timezone of location instead
+// of the local time of the machine, so "0 9-17 * * MON-FRI" runs in the
+// business hours of that timezone. A specification may still name its own
+// with a CRON_TZ= prefix.
+func WithLocation(location *time.Location) CronOption {
+	return func(s *CronScheduler) {
+		s.location = location
+	}
+}
+
+// WithLeadership runs the jobs only while the instance leads, e.g. a
+// LeaderElection, and cancels the context of a run when it stops leading
+// meanwhile. Standbys keep their schedule, so they run the next tick after
+// taking over.
+func WithLeadership(leader Leadership) CronOption {
+	return func(s *CronScheduler) {
+		s.leader = leader
+	}
 }
 
 // NewCronScheduler creates a new CronScheduler instance
-func NewCronScheduler() *CronScheduler {
-	return &CronScheduler{
-		cron: cron.New(cron.WithSeconds()),
-		jobs: make(map[cron.EntryID]context.CancelFunc),
+func NewCronScheduler(opts ...CronOption) *CronScheduler {
+	s := &CronScheduler{
+		jobs:     make(map[cron.EntryID]context.CancelFunc),
+		stopped:  make(chan struct{}),
+		location: time.Local,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.cron = cron.New(cron.WithParser(cronParser), cron.WithLocation(s.location))
+	return s
 }
 
-// Schedule schedules a new job with the given cron specification
+// Schedule schedules a new job with the given schedule, as ParseSpec accepts it
 func (s *CronScheduler) Schedule(spec string, job ports.Job) error {
-    _, err := s.cron.AddFunc(spec, func() {
-        // Just run the job with a background context
+    cronSpec, err := ParseSpec(spec)
+    if err != nil {
+        return err
+    }
+    _, err = s.cron.AddFunc(cronSpec, func() {
+        // Spread runs sharing a tick, unless the scheduler stops meanwhile
+        if !s.delay() {
+            return
+        }
+        
+        // Run the job with a background context, bounded by the leadership
+        // of the instance, if any, and the timeout
         ctx := context.Background()
-        if err := job(ctx); err != nil {
+        if s.leader != nil {
+            var cancel context.CancelFunc
+            var leads bool
+            ctx, cancel, leads = s.leader.Lead(ctx)
+            defer cancel()
+            if !leads {
+                // Leave the run to the leader when standing by
+                return
+            }
+        }
+        if s.timeout > 0 {
+            var cancel context.CancelFunc
+            ctx, cancel = context.WithTimeout(ctx, s.timeout)
+            defer cancel()
+        }
+        err := job(ctx)
+        if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+            // Report timeouts apart from failures, they point at a hung job
+            if err != nil {
+                log.Printf("Job %q timed out after %s: %v", spec, s.timeout, err)
+            } else {
+                log.Printf("Job %q timed out after %s", spec, s.timeout)
+            }
+            return
+        }
+        if errors.Is(ctx.Err(), context.Canceled) {
+            log.Printf("Job %q stopped, this instance no longer leads", spec)
+            return
+        }
+        if err != nil {
             // Log the error
             log.Printf("Job execution error: %v", err)
         }
@@ -51,6 +157,58 @@ func (s *CronScheduler) Start(ctx context.Context) error {
 // Stop stops the scheduler
 func (s *CronScheduler) Stop() error {
     // This stops all jobs
+    s.stop.Do(func() { close(s.stopped) })
     s.cron.Stop()
     return nil
-}
\ No newline at end of file
+}
+
+// delay waits a random duration up to the jitter of the scheduler before a
+// run, returning false if the scheduler stopped meanwhile
+func (s *CronScheduler) delay() bool {
+	if s.jitter <= 0 {
+		return true
+	}
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(s.jitter) + 1)))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-s.stopped:
+		return false
+	}
+}
+
+// Interval returns the time between two consecutive runs of a cron
+// specification, starting from now
+func Interval(spec string) (time.Duration, error) {
+	schedule, err := parseSchedule(spec)
+	if err != nil {
+		return 0, err
+	}
+	next := schedule.Next(time.Now())
+	return schedule.Next(next).Sub(next), nil
+}
+
+// MinInterval returns the shortest time between two consecutive runs of a cron
+// specification over the coming week, when runs are not evenly spaced
+func MinInterval(spec string) (time.Duration, error) {
+	schedule, err := parseSchedule(spec)
+	if err != nil {
+		return 0, err
+	}
+
+	prev := schedule.Next(time.Now())
+	end := prev.Add(7 * 24 * time.Hour)
+	shortest := time.Duration(0)
+	for i := 0; i < 100000 && prev.Before(end); i++ {
+		next := schedule.Next(prev)
+		if next.IsZero() {
+			break
+		}
+		if gap := next.Sub(prev); shortest == 0 || gap < shortest {
+			shortest = gap
+		}
+		prev = next
+	}
+	return shortest, nil
+}
@@ -0,0 +1,92 @@
+// internal/adapters/scheduler/spec.go
+package scheduler
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// everyUnit matches an interval written with a unit, e.g. "2 hours", "1 day"
+// or "15min"
+var everyUnit = regexp.MustCompile(`^(\d+)?\s*(s|secs?|seconds?|m|mins?|minutes?|h|hrs?|hours?|d|days?)$`)
+
+// periodDescriptors are the words accepted for the descriptors of cron
+var periodDescriptors = map[string]string{
+	"hourly":   "@hourly",
+	"daily":    "@daily",
+	"midnight": "@midnight",
+	"weekly":   "@weekly",
+	"monthly":  "@monthly",
+	"yearly":   "@yearly",
+	"annually": "@annually",
+}
+
+// ParseSpec returns the cron specification of a schedule, which may be a
+// cron expression, a descriptor such as @hourly, a period word such as
+// "daily", or an interval such as "every 30m", "every 2 hours" or "every
+// day". Intervals run that long after the start and after each other run;
+// the other forms run on the clock. The error says what was expected.
+func ParseSpec(spec string) (string, error) {
+	spec = strings.TrimSpace(spec)
+	lower := strings.ToLower(spec)
+	if descriptor, ok := periodDescriptors[lower]; ok {
+		return descriptor, nil
+	}
+	if rest, ok := strings.CutPrefix(lower, "every "); ok {
+		interval, err := parseEvery(strings.TrimSpace(rest))
+		if err != nil {
+			return "", fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		return "@every " + interval.String(), nil
+	}
+	if rest, ok := strings.CutPrefix(lower, "@every "); ok {
+		if _, err := parseEvery(strings.TrimSpace(rest)); err != nil {
+			return "", fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		return spec, nil
+	}
+
+	if _, err := cronParser.Parse(spec); err != nil {
+		return "", fmt.Errorf("invalid schedule %q: %v; use a cron expression such as \"*/5 * * * *\", a descriptor such as @hourly, or an interval such as \"every 30m\"", spec, err)
+	}
+	return spec, nil
+}
+
+// parseEvery parses the interval of an "every" schedule: a Go duration such
+// as 1h30m, or a count and unit such as "2 hours", the count defaulting to 1
+func parseEvery(text string) (time.Duration, error) {
+	interval, err := time.ParseDuration(text)
+	if err != nil {
+		match := everyUnit.FindStringSubmatch(text)
+		if match == nil {
+			return 0, fmt.Errorf("%q is not an interval such as 30m, 1h30m or 2 hours", text)
+		}
+		count := 1
+		if match[1] != "" {
+			count, _ = strconv.Atoi(match[1])
+		}
+		unit := map[byte]time.Duration{'s': time.Second, 'm': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour}[match[2][0]]
+		interval = time.Duration(count) * unit
+	}
+	switch {
+	case interval < time.Second:
+		return 0, fmt.Errorf("the interval must be at least 1s, got %s", interval)
+	case interval%time.Second != 0:
+		return 0, fmt.Errorf("the interval must be whole seconds, got %s", interval)
+	}
+	return interval, nil
+}
+
+// parseSchedule parses a schedule as ParseSpec accepts it
+func parseSchedule(spec string) (cron.Schedule, error) {
+	cronSpec, err := ParseSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	return cronParser.Parse(cronSpec)
+}
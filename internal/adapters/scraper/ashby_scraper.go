@@ -0,0 +1,199 @@
+// internal/adapters/scraper/ashby_scraper.go
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// ashbyHost serves the job boards hosted by Ashby, e.g.
+// https://jobs.ashbyhq.com/acme
+const ashbyHost = "jobs.ashbyhq.com"
+
+// ashbyAPI is the public posting API listing the jobs of a board
+const ashbyAPI = "https://api.ashbyhq.com/posting-api/job-board/"
+
+// ashbyPeriods maps Ashby compensation intervals to salary periods
+var ashbyPeriods = map[string]domain.SalaryPeriod{
+	"1 YEAR":  domain.SalaryPeriodYear,
+	"1 MONTH": domain.SalaryPeriodMonth,
+	"1 WEEK":  domain.SalaryPeriodWeek,
+	"1 DAY":   domain.SalaryPeriodDay,
+	"1 HOUR":  domain.SalaryPeriodHour,
+}
+
+// AshbyScraper implements the BoardScraper interface for boards hosted by
+// Ashby, reading the listed postings from the posting API
+type AshbyScraper struct {
+	client *http.Client
+	api    string
+}
+
+// ashbyBoard is the response of the posting API
+type ashbyBoard struct {
+	Jobs []ashbyJob `json:"jobs"`
+}
+
+// ashbyJob is a posting of the posting API
+type ashbyJob struct {
+	ID                 string `json:"id"`
+	Title              string `json:"title"`
+	Department         string `json:"department"`
+	Team               string `json:"team"`
+	EmploymentType     string `json:"employmentType"`
+	Location           string `json:"location"`
+	SecondaryLocations []struct {
+		Location string `json:"location"`
+	} `json:"secondaryLocations"`
+	IsRemote         bool               `json:"isRemote"`
+	WorkplaceType    string             `json:"workplaceType"`
+	IsListed         *bool              `json:"isListed"`
+	DescriptionPlain string             `json:"descriptionPlain"`
+	PublishedAt      time.Time          `json:"publishedAt"`
+	JobURL           string             `json:"jobUrl"`
+	ApplyURL         string             `json:"applyUrl"`
+	Compensation     *ashbyCompensation `json:"compensation"`
+}
+
+// ashbyCompensation is the compensation of a posting, requested with
+// includeCompensation
+type ashbyCompensation struct {
+	SummaryComponents []struct {
+		CompensationType string   `json:"compensationType"`
+		Interval         string   `json:"interval"`
+		CurrencyCode     string   `json:"currencyCode"`
+		MinValue         *float64 `json:"minValue"`
+		MaxValue         *float64 `json:"maxValue"`
+	} `json:"summaryComponents"`
+}
+
+// NewAshbyScraper creates a scraper querying the Ashby API with client
+func NewAshbyScraper(client *http.Client) *AshbyScraper {
+	return &AshbyScraper{client: client, api: ashbyAPI}
+}
+
+// Matches reports whether url is a board hosted by Ashby
+func (s *AshbyScraper) Matches(url string) bool {
+	return boardSlug(url, ashbyHost) != ""
+}
+
+// Scrape lists the postings of the board
+func (s *AshbyScraper) Scrape(ctx context.Context, sourceURL string) (domain.JobCollection, error) {
+	slug := boardSlug(sourceURL, ashbyHost)
+	result := domain.JobCollection{
+		CompanyName: companyFromSlug(slug),
+		SourceURL:   sourceURL,
+		ScrapedAt:   time.Now(),
+	}
+	log.Printf("Querying the Ashby job board %s", slug)
+
+	body, err := getBoard(ctx, s.client, s.api+url.PathEscape(slug)+"?includeCompensation=true")
+	if err != nil {
+		return result, fmt.Errorf("failed to list Ashby postings: %w", err)
+	}
+	result.RawContent = string(body)
+
+	result.Jobs, err = s.ParseJobs(result.RawContent, sourceURL)
+	if err != nil {
+		return result, err
+	}
+	log.Printf("Found %d jobs on the Ashby job board %s", len(result.Jobs), slug)
+	return result, nil
+}
+
+// ParseJobs extracts the listed postings from a posting API response
+func (s *AshbyScraper) ParseJobs(raw, sourceURL string) ([]domain.Job, error) {
+	var board ashbyBoard
+	if err := decodeBoard([]byte(raw), &board); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.Job, 0, len(board.Jobs))
+	for _, posting := range board.Jobs {
+		if posting.IsListed != nil && !*posting.IsListed {
+			continue
+		}
+		jobs = append(jobs, posting.job())
+	}
+	return jobs, nil
+}
+
+// job converts a posting into a job
+func (p ashbyJob) job() domain.Job {
+	job := domain.Job{
+		ID:          p.ID,
+		Title:       strings.TrimSpace(p.Title),
+		Description: strings.TrimSpace(p.DescriptionPlain),
+		Location:    p.Location,
+		Department:  p.Department,
+		URL:         p.JobURL,
+		ApplyURL:    p.ApplyURL,
+		Salary:      p.salary(),
+		PostedDate:  p.PublishedAt,
+		ScrapedAt:   time.Now(),
+	}
+	if job.ID == "" {
+		job.ID = path.Base(p.JobURL)
+	}
+
+	metadata := make(map[string]string)
+	if p.Team != "" && p.Team != p.Department {
+		metadata["team"] = p.Team
+	}
+	if p.EmploymentType != "" {
+		metadata["employment_type"] = p.EmploymentType
+	}
+	if p.WorkplaceType != "" {
+		metadata["workplace"] = p.WorkplaceType
+	} else if p.IsRemote {
+		metadata["workplace"] = "Remote"
+	}
+	var others []string
+	for _, secondary := range p.SecondaryLocations {
+		if secondary.Location != "" && secondary.Location != p.Location {
+			others = append(others, secondary.Location)
+		}
+	}
+	if len(others) > 0 {
+		metadata["other_locations"] = strings.Join(others, "; ")
+	}
+	if len(metadata) > 0 {
+		job.Metadata = metadata
+	}
+	return job
+}
+
+// salary returns the salary component of the compensation, nil if none
+func (p ashbyJob) salary() *domain.Salary {
+	if p.Compensation == nil {
+		return nil
+	}
+	for _, component := range p.Compensation.SummaryComponents {
+		if component.CompensationType != "Salary" || component.CurrencyCode == "" {
+			continue
+		}
+		salary := &domain.Salary{Currency: component.CurrencyCode, Period: ashbyPeriods[component.Interval]}
+		if component.MinValue != nil {
+			salary.Min = *component.MinValue
+		}
+		if component.MaxValue != nil {
+			salary.Max = *component.MaxValue
+		}
+		if salary.Min > 0 || salary.Max > 0 {
+			return salary
+		}
+	}
+	return nil
+}
+
+var (
+	_ BoardScraper = (*AshbyScraper)(nil) // Ensure interface compliance
+)
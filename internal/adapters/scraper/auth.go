@@ -0,0 +1,120 @@
+// internal/adapters/scraper/auth.go
+package scraper
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Login step actions
+const (
+	LoginFill  = "fill"  // type Value into the field at Selector
+	LoginClick = "click" // click the element at Selector
+	LoginWait  = "wait"  // wait for the element at Selector, or for the page to settle
+)
+
+// Cookie is set in the browser before a source is loaded, e.g. the session
+// cookie of a gated career portal
+type Cookie struct {
+	Name   string
+	Value  string
+	Domain string // the source's host if empty
+	Path   string
+}
+
+// LoginStep is a step of a scripted login. Values are credentials and never
+// logged.
+type LoginStep struct {
+	Action   string // one of the Login* constants
+	Selector string
+	Value    string
+}
+
+// SourceAuth configures how the browser signs in to a source before it is
+// scraped: cookies are set first, then the steps are run on the login page
+type SourceAuth struct {
+	Cookies  []Cookie
+	LoginURL string
+	Steps    []LoginStep
+}
+
+// WithAuth signs in to the sources of auth, keyed by source URL, before
+// scraping them
+func WithAuth(auth map[string]SourceAuth) GoRodOption {
+	return func(s *GoRodScraper) {
+		s.auth = auth
+	}
+}
+
+// authenticate sets the cookies of a source in page and runs its login
+func (s *GoRodScraper) authenticate(page *rod.Page, url string) error {
+	auth, ok := s.auth[url]
+	if !ok {
+		return nil
+	}
+
+	if len(auth.Cookies) > 0 {
+		cookies := make([]*proto.NetworkCookieParam, 0, len(auth.Cookies))
+		for _, cookie := range auth.Cookies {
+			param := &proto.NetworkCookieParam{Name: cookie.Name, Value: cookie.Value, Domain: cookie.Domain, Path: cookie.Path}
+			if cookie.Domain == "" {
+				param.URL = url
+			}
+			cookies = append(cookies, param)
+		}
+		if err := page.SetCookies(cookies); err != nil {
+			return fmt.Errorf("failed to set cookies: %w", err)
+		}
+		log.Printf("Set %d cookies for %s", len(cookies), url)
+	}
+
+	if auth.LoginURL == "" || len(auth.Steps) == 0 {
+		return nil
+	}
+	log.Printf("Logging in at %s...", auth.LoginURL)
+	if err := page.Navigate(auth.LoginURL); err != nil {
+		return fmt.Errorf("failed to open login page: %w", err)
+	}
+	if err := page.WaitLoad(); err != nil {
+		return fmt.Errorf("failed to load login page: %w", err)
+	}
+	for i, step := range auth.Steps {
+		if err := loginStep(page, step); err != nil {
+			return fmt.Errorf("login step %d (%s %s) failed: %w", i+1, step.Action, step.Selector, err)
+		}
+	}
+	return nil
+}
+
+// loginStep runs a step of a login on page
+func loginStep(page *rod.Page, step LoginStep) error {
+	switch step.Action {
+	case LoginFill:
+		el, err := page.Element(step.Selector)
+		if err != nil {
+			return err
+		}
+		if err := el.SelectAllText(); err != nil {
+			return err
+		}
+		return el.Input(step.Value)
+	case LoginClick:
+		el, err := page.Element(step.Selector)
+		if err != nil {
+			return err
+		}
+		return el.Click(proto.InputMouseButtonLeft, 1)
+	case LoginWait:
+		if step.Selector == "" {
+			return page.WaitStable(time.Second)
+		}
+		_, err := page.Element(step.Selector)
+		return err
+	default:
+		return fmt.Errorf("unknown action %q", step.Action)
+	}
+}
@@ -0,0 +1,56 @@
+// internal/adapters/scraper/blocked.go
+package scraper
+
+import (
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// blockMarker is a lowercase snippet of the HTML of a bot protection or
+// CAPTCHA interstitial
+type blockMarker struct {
+	snippet string
+	reason  string
+}
+
+// interstitialMarkers only appear on interstitials, never on the pages they
+// stand in for
+var interstitialMarkers = []blockMarker{
+	{"<title>just a moment...</title>", "Cloudflare challenge"},
+	{"window._cf_chl_opt", "Cloudflare challenge"},
+	{"attention required! | cloudflare", "Cloudflare block page"},
+	{"<title>ddos-guard</title>", "DDoS-Guard challenge"},
+	{"geo.captcha-delivery.com", "DataDome CAPTCHA"},
+	{"px-captcha", "PerimeterX CAPTCHA"},
+	{"incapsula incident id", "Imperva block page"},
+}
+
+// captchaMarkers also appear on regular pages, e.g. in application forms, so
+// they only count on pages without jobs
+var captchaMarkers = []blockMarker{
+	{"verify you are human", "human verification"},
+	{"are you a robot", "human verification"},
+	{"checking your browser", "browser check"},
+	{"unusual traffic from your computer", "human verification"},
+	{"cf-turnstile", "Cloudflare Turnstile CAPTCHA"},
+	{"g-recaptcha", "reCAPTCHA"},
+	{"hcaptcha.com", "hCaptcha"},
+}
+
+// detectBlock returns a ScrapeBlocked error if the page of sourceURL is a bot
+// protection or CAPTCHA interstitial, nil otherwise. jobs is the number of
+// jobs parsed from the page.
+func detectBlock(html, sourceURL string, jobs int) error {
+	page := strings.ToLower(html)
+	markers := interstitialMarkers
+	if jobs == 0 {
+		markers = append(markers[:len(markers):len(markers)], captchaMarkers...)
+	}
+	for _, marker := range markers {
+		if strings.Contains(page, marker.snippet) {
+			return &domain.ScrapeBlocked{SourceURL: sourceURL, Reason: marker.reason}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// internal/adapters/scraper/browser.go
+package scraper
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/launcher"
+)
+
+// browserCloseTimeout bounds closing a browser, which may have stopped
+// responding
+const browserCloseTimeout = 5 * time.Second
+
+// WithBrowserURL makes the scraper connect to the browser at controlURL,
+// e.g. a browserless or headless-shell sidecar, instead of launching a local
+// Chromium. See connectBrowser.
+func WithBrowserURL(controlURL string) GoRodOption {
+	return func(s *GoRodScraper) {
+		s.browserURL = controlURL
+	}
+}
+
+// connectBrowser connects browser to the browser at controlURL and returns
+// it with the function to close it. Without a URL a local Chromium is
+// launched and closed with the scrape. A remote browser is shared with
+// others, so scrapes get an incognito context of their own, which is
+// disposed instead of closing the browser.
+//
+// controlURL is either the DevTools websocket URL (ws:// or wss://), used
+// as it is, or the HTTP address of the browser, e.g. http://chrome:9222,
+// whose websocket URL is looked up.
+func connectBrowser(browser *rod.Browser, controlURL string) (*rod.Browser, func() error, error) {
+	if controlURL == "" {
+		if err := browser.Connect(); err != nil {
+			return nil, nil, err
+		}
+		return browser, func() error { return browser.Timeout(browserCloseTimeout).Close() }, nil
+	}
+
+	wsURL, err := resolveBrowserURL(controlURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	ws := &cdp.WebSocket{}
+	if err := ws.Connect(browser.GetContext(), wsURL, nil); err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %s: %w", redactURL(wsURL), err)
+	}
+	browser = browser.Client(cdp.New().Start(ws))
+	if err := browser.Connect(); err != nil {
+		_ = ws.Close()
+		return nil, nil, err
+	}
+	incognito, err := browser.Incognito()
+	if err != nil {
+		_ = ws.Close()
+		return nil, nil, fmt.Errorf("failed to create browser context: %w", err)
+	}
+	return incognito, func() error {
+		err := incognito.Timeout(browserCloseTimeout).Close()
+		if closeErr := ws.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	}, nil
+}
+
+// resolveBrowserURL returns the DevTools websocket URL of the browser at
+// controlURL
+func resolveBrowserURL(controlURL string) (string, error) {
+	u, err := url.Parse(controlURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid browser URL: %w", err)
+	}
+	if u.Scheme == "ws" || u.Scheme == "wss" {
+		return controlURL, nil
+	}
+	wsURL, err := launcher.ResolveURL(controlURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the DevTools URL of %s: %w", redactURL(controlURL), err)
+	}
+	return wsURL, nil
+}
+
+// redactURL drops the credentials and query of a browser URL, which often
+// carries an API token, for logging
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "browser"
+	}
+	u.User = nil
+	u.RawQuery = ""
+	return u.String()
+}
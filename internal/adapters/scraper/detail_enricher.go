@@ -0,0 +1,121 @@
+// internal/adapters/scraper/detail_enricher.go
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// detailDescriptions select the description of a job on detail pages without
+// structured data, most specific first
+var detailDescriptions = []string{
+	`[itemprop="description"]`,
+	`[class*="job-description"], [class*="jobDescription"], [id*="job-description"], [id*="jobDescription"]`,
+	`[class*="description"]`,
+	"article",
+	"main",
+}
+
+// minDetailDescription is the length below which a selected description is
+// taken for a teaser rather than the description
+const minDetailDescription = 200
+
+// DetailEnricher implements the JobEnricher interface with the detail pages
+// of jobs, downloaded over plain HTTP. Fields come from the JobPosting
+// structured data of a page, else its description from the page content.
+// Fields the listing already filled are kept.
+type DetailEnricher struct {
+	fetcher ports.PageFetcher
+}
+
+// NewDetailEnricher creates an enricher downloading detail pages with fetcher
+func NewDetailEnricher(fetcher ports.PageFetcher) *DetailEnricher {
+	return &DetailEnricher{fetcher: fetcher}
+}
+
+// EnrichJob fills the empty description, posted date, deadline, salary and
+// location of a job from its detail page. Jobs without a URL are returned as they are.
+func (e *DetailEnricher) EnrichJob(ctx context.Context, job domain.Job) (domain.Job, error) {
+	if job.URL == "" {
+		return job, nil
+	}
+	html, err := e.fetcher.FetchPage(ctx, job.URL)
+	if err != nil {
+		return job, fmt.Errorf("failed to fetch detail page: %w", err)
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return job, fmt.Errorf("failed to parse detail page: %w", err)
+	}
+
+	var detail domain.Job
+	if postings := parseJSONLD(doc, job.URL); len(postings) > 0 {
+		detail = postings[0]
+	}
+	if detail.Description == "" {
+		detail.Description = detailDescription(doc)
+	}
+	return fillJob(job, detail), nil
+}
+
+// detailDescription returns the text of the description of a detail page,
+// "" if none stands out
+func detailDescription(doc *goquery.Document) string {
+	for _, selector := range detailDescriptions {
+		var longest string
+		doc.Find(selector).Each(func(i int, s *goquery.Selection) {
+			s.Find("script, style, nav, header, footer, form").Remove()
+			if text := strings.Join(strings.Fields(s.Text()), " "); len(text) > len(longest) {
+				longest = text
+			}
+		})
+		if len(longest) >= minDetailDescription {
+			return longest
+		}
+	}
+	return ""
+}
+
+// fillJob fills the empty fields of job with those of detail
+func fillJob(job, detail domain.Job) domain.Job {
+	if job.Description == "" {
+		job.Description = detail.Description
+	}
+	if job.PostedDate.IsZero() {
+		job.PostedDate = detail.PostedDate
+	}
+	if job.Deadline.IsZero() {
+		job.Deadline = detail.Deadline
+	}
+	if job.Salary == nil {
+		job.Salary = detail.Salary
+	}
+	if job.Location == "" {
+		job.Location = detail.Location
+	}
+	if job.ApplyURL == "" {
+		job.ApplyURL = detail.ApplyURL
+	}
+	if len(detail.Metadata) == 0 {
+		return job
+	}
+	metadata := make(map[string]string, len(job.Metadata)+len(detail.Metadata))
+	for key, value := range detail.Metadata {
+		metadata[key] = value
+	}
+	for key, value := range job.Metadata {
+		metadata[key] = value // the listing's fields win
+	}
+	job.Metadata = metadata
+	return job
+}
+
+var (
+	_ ports.JobEnricher = (*DetailEnricher)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,129 @@
+// internal/adapters/scraper/diagnostics.go
+package scraper
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// Parsers a page can be read with, see SelectorReport
+const (
+	ParserRule    = "rule"    // a configured selector rule
+	ParserProfile = "profile" // a selector profile of the registry
+	ParserGeneric = "generic" // JSON-LD or the built-in guesses
+)
+
+// SelectorMatch is how much of a page a selector matched
+type SelectorMatch struct {
+	Field    string `json:"field"` // field of the rule, e.g. "title", or the name of a custom field
+	Selector string `json:"selector"`
+	Matches  int    `json:"matches"`  // elements matched in the page, or in all containers for field selectors
+	Listings int    `json:"listings"` // containers with at least one match, for field selectors
+}
+
+// SelectorReport diagnoses how the selectors of a source apply to a page,
+// for iterating on the selector configuration of a new site
+type SelectorReport struct {
+	SourceURL  string          `json:"source_url"`
+	Parser     string          `json:"parser"`         // one of the Parser* constants
+	Rule       *SelectorRule   `json:"rule,omitempty"` // rule or profile the page is parsed with, nil for the generic parser
+	Containers int             `json:"containers"`     // listings matched by the container of Rule
+	Selectors  []SelectorMatch `json:"selectors"`
+	JSONLD     int             `json:"json_ld"` // JobPosting objects embedded in the page
+	Jobs       []domain.Job    `json:"jobs"`    // jobs parsed from the page, as ParseJobs returns them
+}
+
+// DiagnoseSelectors parses html like ParseJobs and reports how many
+// elements each selector of the rule and each custom field of sourceURL
+// matched. Nothing is saved or logged beyond what parsing logs.
+func (s *GoRodScraper) DiagnoseSelectors(html, sourceURL string) (SelectorReport, error) {
+	report := SelectorReport{SourceURL: sourceURL, Parser: ParserGeneric}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return report, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	rule, ok := matchRule(s.rules, sourceURL)
+	if ok {
+		report.Parser = ParserRule
+	} else if s.profiles != nil {
+		if rule, ok = matchRule(s.profiles.Rules(), sourceURL); ok {
+			report.Parser = ParserProfile
+		}
+	}
+	if ok && rule.WaitFor != "" {
+		report.Selectors = append(report.Selectors, SelectorMatch{
+			Field:    "wait_for",
+			Selector: rule.WaitFor,
+			Matches:  doc.Find(rule.WaitFor).Length(),
+		})
+	}
+	if ok && !rule.parses() {
+		ok = false // a wait-only rule, the page is parsed by the generic parser
+		report.Parser = ParserGeneric
+	}
+
+	listings := doc.Selection
+	if ok {
+		report.Rule = &rule
+		containers := doc.Find(rule.Container)
+		report.Containers = containers.Length()
+		listings = containers
+		for _, field := range []struct{ name, selector string }{
+			{"title", rule.Title},
+			{"location", rule.Location},
+			{"department", rule.Department},
+			{"description", rule.Description},
+			{"link", rule.Link},
+			{"apply_link", rule.ApplyLink},
+		} {
+			if field.selector != "" {
+				report.Selectors = append(report.Selectors, matchWithin(containers, field.name, field.selector))
+			}
+		}
+		if rule.IDAttr != "" {
+			match := SelectorMatch{Field: "id", Selector: "[" + rule.IDAttr + "]"}
+			containers.Each(func(_ int, listing *goquery.Selection) {
+				if _, ok := listing.Attr(rule.IDAttr); ok {
+					match.Matches++
+					match.Listings++
+				}
+			})
+			report.Selectors = append(report.Selectors, match)
+		}
+	}
+	for _, field := range s.fields[sourceURL] {
+		selector := field.Selector
+		if selector == "" {
+			selector = ":scope"
+		}
+		match := SelectorMatch{Field: field.Name, Selector: selector}
+		listings.Each(func(_ int, listing *goquery.Selection) {
+			if _, ok := field.extract(listing); ok {
+				match.Matches++
+				match.Listings++
+			}
+		})
+		report.Selectors = append(report.Selectors, match)
+	}
+
+	report.JSONLD = len(parseJSONLD(doc, sourceURL))
+	report.Jobs, err = s.ParseJobs(html, sourceURL)
+	return report, err
+}
+
+// matchWithin counts the elements selector matches within each container
+func matchWithin(containers *goquery.Selection, field, selector string) SelectorMatch {
+	match := SelectorMatch{Field: field, Selector: selector}
+	containers.Each(func(_ int, listing *goquery.Selection) {
+		if n := listing.Find(selector).Length(); n > 0 {
+			match.Matches += n
+			match.Listings++
+		}
+	})
+	return match
+}
@@ -0,0 +1,330 @@
+// internal/adapters/scraper/feed_scraper.go
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// feedAccept asks servers for a feed rather than an HTML page
+const feedAccept = "application/rss+xml, application/atom+xml, application/xml;q=0.9, text/xml;q=0.9"
+
+// feedNames are the last path segments of feed URLs, e.g. /careers/feed or
+// /jobs.rss
+var feedNames = map[string]bool{
+	"feed": true, "rss": true, "atom": true,
+	"feed.xml": true, "rss.xml": true, "atom.xml": true, "jobs.xml": true,
+}
+
+// feedExtensions are the extensions of feed URLs
+var feedExtensions = map[string]bool{".rss": true, ".atom": true}
+
+// feedTimeLayouts are the date formats seen in pubDate of RSS feeds, besides
+// the RFC 3339 dates of Atom
+var feedTimeLayouts = []string{
+	time.RFC1123Z, time.RFC1123, time.RFC3339,
+	"Mon, 2 Jan 2006 15:04:05 -0700", "Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700", "2006-01-02 15:04:05", "2006-01-02",
+}
+
+// FeedScraper implements the BoardScraper interface for RSS and Atom job
+// feeds published by companies and aggregators. Feeds are recognized by their
+// URL, or by the content of the page the fallback rendered.
+type FeedScraper struct {
+	client *http.Client
+}
+
+// feedDocument is an RSS 2.0, RSS 1.0 or Atom document. Elements are matched
+// by their local name, so the job extensions some feeds add, e.g.
+// <job:location>, are read whatever their namespace.
+type feedDocument struct {
+	XMLName xml.Name
+	Title   string        `xml:"title"`
+	Channel *feedDocument `xml:"channel"`
+	Items   []feedItem    `xml:"item"`
+	Entries []feedEntry   `xml:"entry"`
+}
+
+// feedItem is an item of an RSS feed
+type feedItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        string   `xml:"guid"`
+	Description string   `xml:"description"`
+	Content     string   `xml:"encoded"`
+	PubDate     string   `xml:"pubDate"`
+	Date        string   `xml:"date"`
+	Categories  []string `xml:"category"`
+	Location    string   `xml:"location"`
+	Company     string   `xml:"company"`
+	JobType     string   `xml:"jobtype"`
+}
+
+// feedEntry is an entry of an Atom feed
+type feedEntry struct {
+	ID    string `xml:"id"`
+	Title string `xml:"title"`
+	Links []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+	Summary    string `xml:"summary"`
+	Content    string `xml:"content"`
+	Published  string `xml:"published"`
+	Updated    string `xml:"updated"`
+	Categories []struct {
+		Term string `xml:"term,attr"`
+	} `xml:"category"`
+	Location string `xml:"location"`
+	Company  string `xml:"company"`
+}
+
+// NewFeedScraper creates a scraper reading job feeds with client
+func NewFeedScraper(client *http.Client) *FeedScraper {
+	return &FeedScraper{client: client}
+}
+
+// Matches reports whether url looks like the URL of a feed
+func (s *FeedScraper) Matches(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	name := strings.ToLower(path.Base(u.Path))
+	if feedNames[name] || feedExtensions[path.Ext(name)] {
+		return true
+	}
+	format := strings.ToLower(u.Query().Get("format"))
+	return format == "rss" || format == "atom"
+}
+
+// Detects reports whether a page is an RSS or Atom document, from the root
+// element near its start
+func (s *FeedScraper) Detects(page string) bool {
+	head := page
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	for _, root := range []string{"<rss", "<feed", "<rdf:RDF"} {
+		for rest := head; ; {
+			i := strings.Index(rest, root)
+			if i < 0 {
+				break
+			}
+			if rest = rest[i+len(root):]; rest != "" && strings.ContainsRune(" \t\r\n>", rune(rest[0])) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Scrape reads the jobs of the feed
+func (s *FeedScraper) Scrape(ctx context.Context, sourceURL string) (domain.JobCollection, error) {
+	result := domain.JobCollection{
+		SourceURL: sourceURL,
+		ScrapedAt: time.Now(),
+	}
+	log.Printf("Reading the job feed %s", sourceURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", feedAccept)
+	body, err := fetchBoard(s.client, req)
+	if err != nil {
+		return result, fmt.Errorf("failed to read job feed: %w", err)
+	}
+	result.RawContent = string(body)
+
+	feed, err := decodeFeed(body)
+	if err != nil {
+		return result, err
+	}
+	result.CompanyName = strings.TrimSpace(feed.Title)
+	if feed.Channel != nil {
+		result.CompanyName = strings.TrimSpace(feed.Channel.Title)
+	}
+	if result.CompanyName == "" {
+		if u, err := url.Parse(sourceURL); err == nil {
+			result.CompanyName = u.Hostname()
+		}
+	}
+
+	result.Jobs = feed.jobs(sourceURL)
+	log.Printf("Found %d jobs in the job feed %s", len(result.Jobs), sourceURL)
+	return result, nil
+}
+
+// ParseJobs extracts the jobs from a feed
+func (s *FeedScraper) ParseJobs(raw, sourceURL string) ([]domain.Job, error) {
+	feed, err := decodeFeed([]byte(raw))
+	if err != nil {
+		return nil, err
+	}
+	return feed.jobs(sourceURL), nil
+}
+
+// decodeFeed unmarshals an RSS or Atom document, accepting the non-UTF-8
+// encodings it declares as long as they are ASCII compatible
+func decodeFeed(data []byte) (*feedDocument, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	var feed feedDocument
+	if err := decoder.Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to parse job feed: %w", err)
+	}
+	switch strings.ToLower(feed.XMLName.Local) {
+	case "rss", "feed", "rdf":
+		return &feed, nil
+	}
+	return nil, fmt.Errorf("failed to parse job feed: unexpected root element <%s>", feed.XMLName.Local)
+}
+
+// jobs converts the items or entries of the feed into jobs, skipping those
+// without a title
+func (f *feedDocument) jobs(sourceURL string) []domain.Job {
+	base, _ := url.Parse(sourceURL)
+	items := f.Items
+	if f.Channel != nil {
+		items = append(items, f.Channel.Items...)
+	}
+
+	var jobs []domain.Job
+	seen := make(map[string]bool)
+	add := func(job domain.Job) {
+		if job.Title == "" || seen[job.ID] {
+			return
+		}
+		seen[job.ID] = true
+		jobs = append(jobs, job)
+	}
+	for _, item := range items {
+		add(item.job(base))
+	}
+	for _, entry := range f.Entries {
+		add(entry.job(base))
+	}
+	return jobs
+}
+
+// job converts an RSS item into a job
+func (i feedItem) job(base *url.URL) domain.Job {
+	description := i.Content
+	if description == "" {
+		description = i.Description
+	}
+	published := i.PubDate
+	if published == "" {
+		published = i.Date
+	}
+	job := domain.Job{
+		ID:          strings.TrimSpace(i.GUID),
+		Title:       strings.TrimSpace(i.Title),
+		Description: htmlText(description),
+		Location:    strings.TrimSpace(i.Location),
+		URL:         resolveURL(base, i.Link),
+		PostedDate:  feedTime(published),
+		ScrapedAt:   time.Now(),
+	}
+	if len(i.Categories) > 0 {
+		job.Department = strings.TrimSpace(i.Categories[0])
+	}
+	job.Metadata = feedMetadata(i.Company, i.JobType)
+	job.ID = feedJobID(job)
+	return job
+}
+
+// job converts an Atom entry into a job
+func (e feedEntry) job(base *url.URL) domain.Job {
+	description := e.Content
+	if description == "" {
+		description = e.Summary
+	}
+	published := e.Published
+	if published == "" {
+		published = e.Updated
+	}
+	job := domain.Job{
+		ID:          strings.TrimSpace(e.ID),
+		Title:       strings.TrimSpace(e.Title),
+		Description: htmlText(description),
+		Location:    strings.TrimSpace(e.Location),
+		PostedDate:  feedTime(published),
+		ScrapedAt:   time.Now(),
+	}
+	for _, link := range e.Links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			job.URL = resolveURL(base, link.Href)
+			break
+		}
+	}
+	if len(e.Categories) > 0 {
+		job.Department = strings.TrimSpace(e.Categories[0].Term)
+	}
+	job.Metadata = feedMetadata(e.Company, "")
+	job.ID = feedJobID(job)
+	return job
+}
+
+// feedMetadata returns the metadata of a feed job, nil if it has none
+func feedMetadata(company, jobType string) map[string]string {
+	metadata := make(map[string]string)
+	if company = strings.TrimSpace(company); company != "" {
+		metadata["company"] = company
+	}
+	if jobType = strings.TrimSpace(jobType); jobType != "" {
+		metadata["employment_type"] = jobType
+	}
+	if len(metadata) == 0 {
+		return nil
+	}
+	return metadata
+}
+
+// feedJobID returns the ID of a feed job: its GUID or Atom ID, else its link,
+// else a hash of its title and location
+func feedJobID(job domain.Job) string {
+	if job.ID != "" {
+		return job.ID
+	}
+	if job.URL != "" {
+		return job.URL
+	}
+	hash := sha256.Sum256([]byte(job.Title + "\n" + job.Location))
+	return hex.EncodeToString(hash[:])
+}
+
+// feedTime parses the publication date of a feed job, zero if it has none or
+// an unknown format
+func feedTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range feedTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+var (
+	_ BoardScraper  = (*FeedScraper)(nil) // Ensure interface compliance
+	_ BoardDetector = (*FeedScraper)(nil)
+)
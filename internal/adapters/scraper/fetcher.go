@@ -0,0 +1,98 @@
+// internal/adapters/scraper/fetcher.go
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// maxFetchedPage bounds how much of a page is read by the HTTPFetcher
+const maxFetchedPage = 10 << 20
+
+// maxBlockedPage bounds how much of an error response is read to tell bot
+// protections from other errors
+const maxBlockedPage = 64 << 10
+
+// StatusError reports a non-2xx response to a request of a scraper
+type StatusError struct {
+	Target     string // URL or host the request was sent to
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s returned status %d", e.Target, e.StatusCode)
+}
+
+// HTTPFetcher implements the PageFetcher interface with plain GET requests,
+// which is enough for the server-rendered detail pages of most boards
+type HTTPFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPFetcher creates a page fetcher sending requests with client
+func NewHTTPFetcher(client *http.Client) *HTTPFetcher {
+	return &HTTPFetcher{client: client}
+}
+
+// Validators are the cache validators of a downloaded page. Sent back, they
+// let the server answer 304 Not Modified while the page is unchanged.
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// FetchPage downloads a page, failing on non-2xx responses
+func (f *HTTPFetcher) FetchPage(ctx context.Context, url string) (string, error) {
+	page, _, err := f.FetchModified(ctx, url, Validators{})
+	return page, err
+}
+
+// FetchModified downloads a page unless it is unchanged since the response
+// that returned validators, failing with domain.ErrNotModified then. The
+// validators of the downloaded page are returned with it.
+func (f *HTTPFetcher) FetchModified(ctx context.Context, url string, validators Validators) (string, Validators, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", Validators{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", Validators{}, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && validators != (Validators{}) {
+		return "", validators, fmt.Errorf("%s: %w", url, domain.ErrNotModified)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// Bot protections answer with an error status, which retrying won't fix
+		if body, err := io.ReadAll(io.LimitReader(resp.Body, maxBlockedPage)); err == nil {
+			if blocked := detectBlock(string(body), url, 0); blocked != nil {
+				return "", Validators{}, blocked
+			}
+		}
+		return "", Validators{}, &StatusError{Target: url, StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFetchedPage))
+	if err != nil {
+		return "", Validators{}, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	return string(body), Validators{ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}, nil
+}
+
+var (
+	_ ports.PageFetcher = (*HTTPFetcher)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,145 @@
+// internal/adapters/scraper/fields.go
+package scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// FieldSelector extracts a custom field of a job listing into Job.Metadata,
+// for details some boards expose such as visa sponsorship or team size
+type FieldSelector struct {
+	Name     string // metadata key
+	Selector string // CSS selector within the listing, empty for the listing itself
+	Attr     string // attribute of the selected element to read instead of its text
+	JSONPath string // dotted path into the value parsed as JSON, e.g. "team.size" or "tags.0"
+}
+
+// extractFields reads the fields from a job listing. Fields that don't match
+// are left out; nil is returned if none match.
+func extractFields(listing *goquery.Selection, fields []FieldSelector) map[string]string {
+	var metadata map[string]string
+	for _, field := range fields {
+		value, ok := field.extract(listing)
+		if !ok {
+			continue
+		}
+		if metadata == nil {
+			metadata = make(map[string]string, len(fields))
+		}
+		metadata[field.Name] = value
+	}
+	return metadata
+}
+
+// extract reads the field from a job listing
+func (f FieldSelector) extract(listing *goquery.Selection) (string, bool) {
+	selection := listing
+	if f.Selector != "" {
+		selection = listing.Find(f.Selector).First()
+		if selection.Length() == 0 {
+			return "", false
+		}
+	}
+
+	var value string
+	if f.Attr != "" {
+		attr, ok := selection.Attr(f.Attr)
+		if !ok {
+			return "", false
+		}
+		value = attr
+	} else {
+		value = selection.Text()
+	}
+
+	if f.JSONPath != "" {
+		return lookupJSONPath([]byte(value), f.JSONPath)
+	}
+	value = strings.Join(strings.Fields(value), " ")
+	return value, value != ""
+}
+
+// lookupJSONPath returns the value at a dotted path into a JSON document.
+// Segments are object keys or array indexes. Strings are returned as they are,
+// arrays of plain values joined with commas and objects as compact JSON.
+func lookupJSONPath(data []byte, path string) (string, bool) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return "", false
+	}
+	value, ok := walkJSONPath(value, path)
+	if !ok {
+		return "", false
+	}
+	return formatJSONValue(value)
+}
+
+// walkJSONPath returns the value at a dotted path into a decoded JSON value,
+// value itself for an empty path
+func walkJSONPath(value interface{}, path string) (interface{}, bool) {
+	if path == "" {
+		return value, true
+	}
+	for _, segment := range strings.Split(path, ".") {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			child, ok := node[segment]
+			if !ok {
+				return nil, false
+			}
+			value = child
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			value = node[index]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// formatJSONValue renders a JSON value as metadata
+func formatJSONValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, v != ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			part, ok := formatJSONValue(item)
+			if !ok {
+				continue
+			}
+			if _, nested := item.(map[string]interface{}); nested {
+				return compactJSON(v)
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, ", "), len(parts) > 0
+	default:
+		return compactJSON(v)
+	}
+}
+
+// compactJSON encodes a value as JSON
+func compactJSON(value interface{}) (string, bool) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value), true
+	}
+	return string(data), true
+}
@@ -5,27 +5,83 @@ import (
 	"context"
 	"fmt"
 	"time"
-	"crypto/sha256"
-	"encoding/hex"
+	"net/http"
 	"strings"
 	
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
 	"log"
 	"github.com/PuerkitoBio/goquery"
 	
 	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/normalize"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
 )
 
 // GoRodScraper implements the Scraper interface using go-rod
 type GoRodScraper struct {
-	timeout time.Duration
+	timeout     time.Duration
+	client      *http.Client
+	pages       *PagePool
+	fields      map[string][]FieldSelector // custom fields per source URL
+	rules       []SelectorRule             // configured listings of sites, tried before guessing
+	profiles    RuleSource                 // registry profiles, tried after the configured rules
+	maxScrolls  int                        // scrolls loading lazy listings, 0 disables scrolling
+	scrollIdle  time.Duration              // how long a scroll may load nothing
+	screenshots bool                       // take a screenshot of each rendered page
+	auth        map[string]SourceAuth      // sign-in of gated sources per source URL
+	intercept   map[string]InterceptRule   // API responses the jobs of sources are read from, per source URL
+	browserURL  string                     // DevTools URL of a remote browser, a local one is launched if empty
 }
 
-// NewGoRodScraper creates a new GoRodScraper instance
-func NewGoRodScraper(timeout time.Duration) *GoRodScraper {
-	return &GoRodScraper{
+
+// GoRodOption configures a GoRodScraper
+type GoRodOption func(*GoRodScraper)
+
+// WithPagePool makes the scraper reuse pages of a shared browser instead of
+// launching a browser for every scrape
+func WithPagePool(pool *PagePool) GoRodOption {
+	return func(s *GoRodScraper) {
+		s.pages = pool
+	}
+}
+
+// WithFields extracts custom fields of the job listings of each source URL
+// into Job.Metadata
+func WithFields(fields map[string][]FieldSelector) GoRodOption {
+	return func(s *GoRodScraper) {
+		s.fields = fields
+	}
+}
+
+// WithSelectors parses the pages of sites with a matching rule using its
+// selectors instead of guessing where the listings are
+func WithSelectors(rules []SelectorRule) GoRodOption {
+	return func(s *GoRodScraper) {
+		s.rules = rules
+	}
+}
+
+// WithSelectorProfiles parses the pages of sites without a configured rule
+// using a matching profile of source, such as a SelectorRegistry
+func WithSelectorProfiles(source RuleSource) GoRodOption {
+	return func(s *GoRodScraper) {
+		s.profiles = source
+	}
+}
+
+// NewGoRodScraper creates a new GoRodScraper instance. If client is not nil,
+// every request of the browser is made through it instead, so its TLS settings
+// (custom CAs, client certificates) apply to scraped pages too.
+func NewGoRodScraper(timeout time.Duration, client *http.Client, opts ...GoRodOption) *GoRodScraper {
+	s := &GoRodScraper{
 		timeout: timeout,
+		client:  client,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Scrape scrapes a career page and returns the job listings
@@ -41,32 +97,77 @@ func (s *GoRodScraper) Scrape(ctx context.Context, url string) (domain.JobCollec
 	result.CompanyName = extractCompanyName(url)
 	log.Printf("Extracted company name: %s", result.CompanyName)
 	
-	// Launch a new browser
-	log.Printf("Launching browser...")
-	browser := rod.New().Timeout(s.timeout)
-	defer browser.Close()
+	// Borrow a page of the shared browser if there is one, in a context of
+	// its own for a source signed in to so its session stays with it
+	if s.pages != nil {
+		acquire := s.pages.Acquire
+		if _, gated := s.auth[url]; gated {
+			acquire = s.pages.AcquireIsolated
+		}
+		page, release, err := acquire(ctx)
+		if err != nil {
+			return result, fmt.Errorf("failed to get browser page: %w", err)
+		}
+		result, err = s.scrapePage(page.Context(ctx).Timeout(s.timeout), result)
+		release(err != nil)
+		return result, err
+	}
 	
-	// Connect to the browser
+	// Launch a new browser, or connect to the remote one
 	log.Printf("Connecting to browser...")
-	if err := browser.Connect(); err != nil {
+	browser, closeBrowser, err := connectBrowser(rod.New().Timeout(s.timeout), s.browserURL)
+	if err != nil {
 		return result, fmt.Errorf("failed to connect to browser: %w", err)
 	}
+	defer closeBrowser()
 	
 	// Create a new page
 	log.Printf("Creating new page...")
 	page := browser.MustPage()
 	defer page.Close()
 	
-	// Navigate to the career page
+	// Route the browser's requests through our HTTP client
+	if s.client != nil {
+		router := page.HijackRequests()
+		if err := router.Add("*", "", hijackWith(s.client, func() context.Context { return ctx })); err != nil {
+			return result, fmt.Errorf("failed to hijack browser requests: %w", err)
+		}
+		go router.Run()
+		defer router.Stop()
+	}
+	
+	return s.scrapePage(page, result)
+}
+
+// scrapePage loads the collection's source URL in page and parses its jobs
+func (s *GoRodScraper) scrapePage(page *rod.Page, result domain.JobCollection) (domain.JobCollection, error) {
+	url := result.SourceURL
+	
+	// Sign in to gated portals first
+	if err := s.authenticate(page, url); err != nil {
+		return result, fmt.Errorf("failed to sign in: %w", err)
+	}
+	
+	// Navigate to the career page, watching its requests if the page waits for network idle
+	wait := s.waitStrategy(page, url)
+	defer wait.release()
+	capture := s.captureResponses(page, url)
+	defer capture.close()
 	log.Printf("Navigating to %s...", url)
 	if err := page.Navigate(url); err != nil {
 		return result, fmt.Errorf("failed to navigate to career page: %w", err)
 	}
 	
 	// Wait for the page to load
-	log.Printf("Waiting for page to stabilize...")
-	if err := page.WaitStable(2 * time.Second); err != nil {
-		return result, fmt.Errorf("failed to wait for page to stabilize: %w", err)
+	if err := wait.wait(); err != nil {
+		return result, fmt.Errorf("failed to wait for page to load: %w", err)
+	}
+	
+	// Scroll down so lazily loaded listings are included
+	if s.maxScrolls > 0 {
+		if err := s.scrollToEnd(page); err != nil {
+			log.Printf("Failed to scroll %s, reading what is loaded: %v", url, err)
+		}
 	}
 	
 	// Get the HTML content
@@ -79,27 +180,93 @@ func (s *GoRodScraper) Scrape(ctx context.Context, url string) (domain.JobCollec
 	result.RawContent = html
 	log.Printf("Retrieved HTML content (%d bytes)", len(html))
 	
+	// Capture what the page showed, for debugging its parsing
+	if s.screenshots {
+		if result.Screenshot, err = screenshot(page); err != nil {
+			log.Printf("Skipping the screenshot of %s: %v", url, err)
+		}
+	}
+	
+	// Read the jobs of single-page apps from their API responses if there are any
+	if capture != nil {
+		if jobs, raw := s.interceptedJobs(capture, url); len(jobs) > 0 {
+			result.RawContent = raw
+			result.Jobs = jobs
+			log.Printf("Found %d jobs in intercepted API responses", len(jobs))
+			return result, nil
+		}
+		log.Printf("No jobs in the intercepted API responses of %s, parsing the HTML", url)
+	}
+	
 	// Parse the HTML
 	log.Printf("Parsing jobs from HTML...")
-	jobs, err := s.parseJobs(html, url)
+	jobs, err := s.ParseJobs(html, url)
 	if err != nil {
 		return result, fmt.Errorf("failed to parse jobs: %w", err)
 	}
 	
+	// Fail on bot checks instead of reporting their jobs, or that there are none
+	if err := detectBlock(html, url, len(jobs)); err != nil {
+		return result, err
+	}
+	
 	result.Jobs = jobs
 	log.Printf("Found %d jobs on page", len(jobs))
 	
 	return result, nil
 }
 
-// parseJobs parses job listings from HTML content
-func (s *GoRodScraper) parseJobs(html, sourceURL string) ([]domain.Job, error) {
+// hijackWith returns a handler fulfilling browser requests using client, in
+// the context of the current scrape so its values, e.g. the pinned proxy,
+// apply to them
+func hijackWith(client *http.Client, scrape func() context.Context) func(*rod.Hijack) {
+	return func(h *rod.Hijack) {
+		if ctx := scrape(); ctx != nil {
+			h.Request.SetContext(ctx)
+		}
+		if err := h.LoadResponse(client, true); err != nil {
+			log.Printf("Failed to load %s: %v", h.Request.URL(), err)
+			h.Response.Fail(proto.NetworkErrorReasonFailed)
+		}
+	}
+}
+
+// ParseJobs parses job listings from HTML content
+func (s *GoRodScraper) ParseJobs(html, sourceURL string) ([]domain.Job, error) {
+	// Archived API responses of intercepted sources are read as such
+	if jobs, ok := s.parseIntercepted(html, sourceURL); ok {
+		return jobs, nil
+	}
+	
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
 	}
 	
 	var jobs []domain.Job
+	fields := s.fields[sourceURL]
+	
+	// Use the configured selectors of the site if there are any
+	if rule, ok := matchRule(s.rules, sourceURL); ok && rule.parses() {
+		log.Printf("Using configured selectors for %s: %s", sourceURL, rule.Container)
+		jobs = parseWithRule(doc, sourceURL, rule, fields)
+		log.Printf("Found %d jobs using configured selectors", len(jobs))
+		return jobs, nil
+	}
+	if s.profiles != nil {
+		if rule, ok := matchRule(s.profiles.Rules(), sourceURL); ok {
+			log.Printf("Using selector profile %s for %s", rule.Name, sourceURL)
+			jobs = parseWithRule(doc, sourceURL, rule, fields)
+			log.Printf("Found %d jobs using selector profile %s", len(jobs), rule.Name)
+			return jobs, nil
+		}
+	}
+	
+	// Use the schema.org JobPosting data embedded in the page if there is any
+	if jobs := parseJSONLD(doc, sourceURL); len(jobs) > 0 {
+		log.Printf("Found %d jobs in JobPosting structured data", len(jobs))
+		return jobs, nil
+	}
 	
 	// This is a generic selector - you'll need to customize it for each site
 	// Common job listing patterns to look for
@@ -142,20 +309,14 @@ func (s *GoRodScraper) parseJobs(html, sourceURL string) ([]domain.Job, error) {
 				ScrapedAt: time.Now(),
 			}
 			
-			// Try to extract job ID
+			// Try to extract job ID; listings without one are identified
+			// by the configured JobIdentity when normalized
 			jobID, exists := s.Attr("data-job-id")
 			if !exists {
-				jobID, exists = s.Attr("id")
-			}
-			
-			// If we still don't have an ID, generate one from the content
-			if !exists || jobID == "" {
-				// Create a hash from the job content
-				hash := sha256.Sum256([]byte(s.Text()))
-				jobID = hex.EncodeToString(hash[:])
+				jobID, _ = s.Attr("id")
 			}
 			
-			job.ID = jobID
+			job.ID = strings.TrimSpace(jobID)
 			
 			// Special handling for F1soft career site structure
 			if s.HasClass("features-job") {
@@ -241,8 +402,24 @@ func (s *GoRodScraper) parseJobs(html, sourceURL string) ([]domain.Job, error) {
 					}
 					job.URL = jobURL
 				}
+				
+				// Look for a separate link to the application form
+				applyURL, exists := s.Find(`a.apply, a.apply-button, a[href*="apply"]`).First().Attr("href")
+				if exists {
+					if strings.HasPrefix(applyURL, "/") {
+						urlParts := strings.Split(sourceURL, "/")
+						baseURL := strings.Join(urlParts[:3], "/")
+						applyURL = baseURL + applyURL
+					}
+					job.ApplyURL = applyURL
+				}
 			}
 			
+			job.Metadata = extractFields(s, fields)
+			
+			// Read the posting date and deadline shown on the listing
+			job = normalize.ListingDates(job, strings.Join(strings.Fields(s.Text()), " "))
+			
 			// Only add jobs with at least a title
 			if job.Title != "" {
 				jobs = append(jobs, job)
@@ -335,4 +512,9 @@ func extractCompanyName(url string) string {
 	}
 	
 	return "Unknown Company"
-}
\ No newline at end of file
+}
+
+var (
+	_ ports.Scraper   = (*GoRodScraper)(nil) // Ensure interface compliance
+	_ ports.JobParser = (*GoRodScraper)(nil)
+)
@@ -0,0 +1,221 @@
+// internal/adapters/scraper/greenhouse_scraper.go
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// greenhouseHosts serve the job boards hosted by Greenhouse, e.g.
+// https://boards.greenhouse.io/acme
+var greenhouseHosts = []string{"boards.greenhouse.io", "job-boards.greenhouse.io", "boards.eu.greenhouse.io", "job-boards.eu.greenhouse.io"}
+
+// greenhouseAPI is the public job board API; {token} is replaced by the board
+// token
+const greenhouseAPI = "https://boards-api.greenhouse.io/v1/boards/{token}"
+
+// greenhouseEmbed matches the job board widget career pages of other sites
+// embed, e.g. boards.greenhouse.io/embed/job_board?for=acme
+var greenhouseEmbed = regexp.MustCompile(`(?:job-)?boards(?:\.eu)?\.greenhouse\.io/embed/job_board(?:/js)?\?for=([A-Za-z0-9_-]+)`)
+
+// greenhouseApplication is the application form of a job embedded by
+// career pages of other sites; {token} and {id} are replaced by the board
+// token and the job ID
+const greenhouseApplication = "https://boards.greenhouse.io/embed/job_app?for={token}&token={id}"
+
+// greenhouseReserved are the first path segments of board hosts that are not
+// board tokens
+var greenhouseReserved = map[string]bool{"embed": true, "v1": true}
+
+// GreenhouseScraper implements the BoardScraper interface for job boards
+// hosted by Greenhouse, reading the listed jobs from the job board API. Career
+// pages of other sites embedding a board are located by its widget.
+type GreenhouseScraper struct {
+	client *http.Client
+	api    string
+}
+
+// greenhouseJobs is the response of the jobs endpoint
+type greenhouseJobs struct {
+	Jobs []greenhouseJob `json:"jobs"`
+}
+
+// greenhouseJob is a job of the jobs endpoint
+type greenhouseJob struct {
+	ID             int64  `json:"id"`
+	InternalJobID  int64  `json:"internal_job_id"`
+	Title          string `json:"title"`
+	UpdatedAt      string `json:"updated_at"`
+	FirstPublished string `json:"first_published"`
+	AbsoluteURL    string `json:"absolute_url"`
+	Content        string `json:"content"`
+	Location       struct {
+		Name string `json:"name"`
+	} `json:"location"`
+	Departments []struct {
+		Name string `json:"name"`
+	} `json:"departments"`
+	Offices []struct {
+		Name string `json:"name"`
+	} `json:"offices"`
+}
+
+// greenhouseBoard is the response of the board endpoint
+type greenhouseBoard struct {
+	Name string `json:"name"`
+}
+
+// NewGreenhouseScraper creates a scraper querying the Greenhouse API with
+// client
+func NewGreenhouseScraper(client *http.Client) *GreenhouseScraper {
+	return &GreenhouseScraper{client: client, api: greenhouseAPI}
+}
+
+// Matches reports whether url is a job board hosted by Greenhouse
+func (s *GreenhouseScraper) Matches(url string) bool {
+	return greenhouseToken(url) != ""
+}
+
+// Locate returns the URL of the board whose widget a page embeds
+func (s *GreenhouseScraper) Locate(page string) string {
+	match := greenhouseEmbed.FindStringSubmatch(page)
+	if match == nil {
+		return ""
+	}
+	return "https://boards.greenhouse.io/" + match[1]
+}
+
+// Detects reports whether a page is a response of the jobs endpoint, so
+// archived responses of located boards are parsed again by the board
+func (s *GreenhouseScraper) Detects(page string) bool {
+	return strings.HasPrefix(strings.TrimSpace(page), "{") && strings.Contains(page, `"internal_job_id"`)
+}
+
+// Scrape lists the jobs of the board
+func (s *GreenhouseScraper) Scrape(ctx context.Context, sourceURL string) (domain.JobCollection, error) {
+	token := greenhouseToken(sourceURL)
+	result := domain.JobCollection{
+		CompanyName: companyFromSlug(token),
+		SourceURL:   sourceURL,
+		ScrapedAt:   time.Now(),
+	}
+	if token == "" {
+		return result, fmt.Errorf("no Greenhouse board in %s", sourceURL)
+	}
+	log.Printf("Querying the Greenhouse board %s", token)
+
+	api := strings.ReplaceAll(s.api, "{token}", url.PathEscape(token))
+	body, err := getBoard(ctx, s.client, api+"/jobs?content=true")
+	if err != nil {
+		return result, fmt.Errorf("failed to list Greenhouse jobs: %w", err)
+	}
+	result.RawContent = string(body)
+
+	var board greenhouseBoard
+	if body, err := getBoard(ctx, s.client, api); err != nil {
+		log.Printf("Failed to read the name of the Greenhouse board %s: %v", token, err)
+	} else if decodeBoard(body, &board) == nil && board.Name != "" {
+		result.CompanyName = board.Name
+	}
+
+	result.Jobs, err = s.ParseJobs(result.RawContent, sourceURL)
+	if err != nil {
+		return result, err
+	}
+	log.Printf("Found %d jobs on the Greenhouse board %s", len(result.Jobs), token)
+	return result, nil
+}
+
+// ParseJobs extracts the jobs from a response of the jobs endpoint
+func (s *GreenhouseScraper) ParseJobs(raw, sourceURL string) ([]domain.Job, error) {
+	var response greenhouseJobs
+	if err := decodeBoard([]byte(raw), &response); err != nil {
+		return nil, err
+	}
+
+	token := greenhouseToken(sourceURL)
+	jobs := make([]domain.Job, 0, len(response.Jobs))
+	for _, job := range response.Jobs {
+		jobs = append(jobs, job.job(token))
+	}
+	return jobs, nil
+}
+
+// job converts a job of the board token of the API into a job. The content
+// is escaped HTML.
+func (j greenhouseJob) job(token string) domain.Job {
+	job := domain.Job{
+		ID:          fmt.Sprint(j.ID),
+		Title:       strings.TrimSpace(j.Title),
+		Description: htmlText(html.UnescapeString(j.Content)),
+		Location:    strings.TrimSpace(j.Location.Name),
+		URL:         j.AbsoluteURL,
+		ApplyURL:    j.applyURL(token),
+		ScrapedAt:   time.Now(),
+	}
+	if len(j.Departments) > 0 {
+		job.Department = j.Departments[0].Name
+	}
+	published := j.FirstPublished
+	if published == "" {
+		published = j.UpdatedAt
+	}
+	if t, err := time.Parse(time.RFC3339, published); err == nil {
+		job.PostedDate = t
+	}
+
+	var offices []string
+	for _, office := range j.Offices {
+		if office.Name != "" && office.Name != job.Location {
+			offices = append(offices, office.Name)
+		}
+	}
+	if len(offices) > 0 {
+		job.Metadata = map[string]string{"offices": strings.Join(offices, "; ")}
+	}
+	return job
+}
+
+// applyURL returns the link to the application form of the job: the form at
+// the bottom of its page on a board hosted by Greenhouse, or the embeddable
+// form for jobs listed on the career page of another site
+func (j greenhouseJob) applyURL(token string) string {
+	if u, err := url.Parse(j.AbsoluteURL); err == nil && slices.Contains(greenhouseHosts, u.Hostname()) {
+		u.Fragment = "app"
+		return u.String()
+	}
+	if token == "" {
+		return ""
+	}
+	return strings.NewReplacer("{token}", url.QueryEscape(token), "{id}", fmt.Sprint(j.ID)).Replace(greenhouseApplication)
+}
+
+// greenhouseToken returns the board token of a job board URL on Greenhouse,
+// also of the embedded widget, or "" if the URL is elsewhere
+func greenhouseToken(rawURL string) string {
+	if match := greenhouseEmbed.FindStringSubmatch(rawURL); match != nil {
+		return match[1]
+	}
+	for _, host := range greenhouseHosts {
+		if token := boardSlug(rawURL, host); token != "" && !greenhouseReserved[token] {
+			return token
+		}
+	}
+	return ""
+}
+
+var (
+	_ BoardScraper  = (*GreenhouseScraper)(nil) // Ensure interface compliance
+	_ BoardDetector = (*GreenhouseScraper)(nil)
+	_ BoardLocator  = (*GreenhouseScraper)(nil)
+)
@@ -0,0 +1,158 @@
+// internal/adapters/scraper/http_scraper.go
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// HTTPScraper implements the Scraper interface for server-rendered career
+// pages, downloading them with a plain GET request instead of rendering them
+// in a browser. Pages are parsed like rendered ones, e.g. by a GoRodScraper,
+// which parses without a browser. Fetchers supporting conditional requests,
+// such as the HTTPFetcher, are sent the validators of the previous scrape, so
+// unchanged pages fail with domain.ErrNotModified instead of being parsed.
+type HTTPScraper struct {
+	fetcher    ports.PageFetcher
+	parser     ports.JobParser
+	validators map[string]Validators // validators of the last parsed download per URL
+	mu         sync.Mutex
+}
+
+// modifiedFetcher is a page fetcher sending conditional requests
+type modifiedFetcher interface {
+	FetchModified(ctx context.Context, url string, validators Validators) (string, Validators, error)
+}
+
+// NewHTTPScraper creates a scraper downloading pages with fetcher and
+// extracting their jobs with parser
+func NewHTTPScraper(fetcher ports.PageFetcher, parser ports.JobParser) *HTTPScraper {
+	return &HTTPScraper{
+		fetcher:    fetcher,
+		parser:     parser,
+		validators: make(map[string]Validators),
+	}
+}
+
+// Scrape downloads a career page and returns its job listings
+func (s *HTTPScraper) Scrape(ctx context.Context, url string) (domain.JobCollection, error) {
+	result := domain.JobCollection{
+		CompanyName: extractCompanyName(url),
+		SourceURL:   url,
+		ScrapedAt:   time.Now(),
+	}
+	log.Printf("Downloading %s without a browser", url)
+
+	html, validators, err := s.fetch(ctx, url)
+	if errors.Is(err, domain.ErrNotModified) {
+		log.Printf("%s is unchanged since the last download", url)
+		return result, err
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to download career page: %w", err)
+	}
+	result.RawContent = html
+
+	result.Jobs, err = s.parser.ParseJobs(html, url)
+	if err != nil {
+		return result, fmt.Errorf("failed to parse jobs: %w", err)
+	}
+	if err := detectBlock(html, url, len(result.Jobs)); err != nil {
+		result.Jobs = nil
+		return result, err
+	}
+	log.Printf("Found %d jobs on page", len(result.Jobs))
+
+	s.mu.Lock()
+	if validators != (Validators{}) {
+		s.validators[url] = validators
+	} else {
+		delete(s.validators, url)
+	}
+	s.mu.Unlock()
+	return result, nil
+}
+
+// fetch downloads a page, conditionally if the fetcher supports it
+func (s *HTTPScraper) fetch(ctx context.Context, url string) (string, Validators, error) {
+	fetcher, ok := s.fetcher.(modifiedFetcher)
+	if !ok {
+		html, err := s.fetcher.FetchPage(ctx, url)
+		return html, Validators{}, err
+	}
+	s.mu.Lock()
+	validators := s.validators[url]
+	s.mu.Unlock()
+	return fetcher.FetchModified(ctx, url, validators)
+}
+
+// ParseJobs parses a downloaded page with the parser
+func (s *HTTPScraper) ParseJobs(html, sourceURL string) ([]domain.Job, error) {
+	return s.parser.ParseJobs(html, sourceURL)
+}
+
+// Render modes of career pages
+const (
+	RenderBrowser = "browser" // render the page in a headless browser
+	RenderHTTP    = "http"    // download the page, for server-rendered pages
+)
+
+// RenderScraper scrapes each URL with the browser or plain HTTP, as its
+// render mode says
+type RenderScraper struct {
+	browser     ports.Scraper
+	http        ports.Scraper
+	modes       map[string]string // render mode by URL
+	defaultMode string
+}
+
+// NewRenderScraper creates a scraper rendering the URLs of modes as they say
+// and every other URL in defaultMode
+func NewRenderScraper(browser, http ports.Scraper, modes map[string]string, defaultMode string) *RenderScraper {
+	return &RenderScraper{
+		browser:     browser,
+		http:        http,
+		modes:       modes,
+		defaultMode: defaultMode,
+	}
+}
+
+// Scrape scrapes a URL in its render mode
+func (r *RenderScraper) Scrape(ctx context.Context, url string) (domain.JobCollection, error) {
+	return r.scraper(url).Scrape(ctx, url)
+}
+
+// ParseJobs parses a page with the parser of its render mode
+func (r *RenderScraper) ParseJobs(html, sourceURL string) ([]domain.Job, error) {
+	parser, ok := r.scraper(sourceURL).(ports.JobParser)
+	if !ok {
+		return nil, fmt.Errorf("no parser for %s", sourceURL)
+	}
+	return parser.ParseJobs(html, sourceURL)
+}
+
+// scraper returns the scraper of a URL's render mode
+func (r *RenderScraper) scraper(url string) ports.Scraper {
+	mode, ok := r.modes[url]
+	if !ok {
+		mode = r.defaultMode
+	}
+	if mode == RenderHTTP {
+		return r.http
+	}
+	return r.browser
+}
+
+var (
+	_ ports.Scraper   = (*HTTPScraper)(nil) // Ensure interface compliance
+	_ ports.JobParser = (*HTTPScraper)(nil)
+	_ ports.Scraper   = (*RenderScraper)(nil)
+	_ ports.JobParser = (*RenderScraper)(nil)
+)
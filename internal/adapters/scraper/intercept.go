@@ -0,0 +1,236 @@
+// internal/adapters/scraper/intercept.go
+package scraper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// maxInterceptedResponses bounds the responses captured per scrape, so a page
+// polling its API can't grow the raw content without end
+const maxInterceptedResponses = 50
+
+// InterceptRule reads the jobs of a single-page app from the JSON responses
+// of the API calls it makes while loading, instead of its rendered DOM.
+// Paths are dotted paths as in FieldSelector.JSONPath; empty field paths read
+// the field of the same name, e.g. "title".
+type InterceptRule struct {
+	URLPattern  *regexp.Regexp // URLs of the responses read
+	Jobs        string         // path to the array of jobs in a response, "" if the response is the array
+	ID          string         // paths within a job
+	Title       string
+	Location    string
+	Department  string
+	URL         string // resolved against the source URL
+	Description string
+	PostedDate  string // RFC 3339 or a plain date
+}
+
+// WithInterception reads the jobs of the sources of rules, keyed by source
+// URL, from the API responses their pages receive. A page whose responses hold
+// no jobs is parsed from its HTML as usual.
+func WithInterception(rules map[string]InterceptRule) GoRodOption {
+	return func(s *GoRodScraper) {
+		s.intercept = rules
+	}
+}
+
+// interceptedPage is the raw content of a scrape read from API responses,
+// which ParseJobs recognizes when archived scrapes are parsed again
+type interceptedPage struct {
+	Responses []interceptedResponse `json:"intercepted_responses"`
+}
+
+// interceptedResponse is a captured API response
+type interceptedResponse struct {
+	URL  string          `json:"url"`
+	Body json.RawMessage `json:"body"`
+}
+
+// responseCapture records the JSON responses of a page matching a rule while
+// the page loads
+type responseCapture struct {
+	page *rod.Page
+	rule InterceptRule
+	stop func()
+
+	mu      sync.Mutex
+	loading map[proto.NetworkRequestID]string // URLs of the matching responses still loading
+	loaded  []capturedRequest
+}
+
+// capturedRequest is a loaded matching response, whose body is fetched once
+// the page is read
+type capturedRequest struct {
+	id  proto.NetworkRequestID
+	url string
+}
+
+// captureResponses starts capturing the API responses of page for url, nil
+// if url has no interception rule. It must be called before the page
+// navigates.
+func (s *GoRodScraper) captureResponses(page *rod.Page, url string) *responseCapture {
+	rule, ok := s.intercept[url]
+	if !ok {
+		return nil
+	}
+	listening, stop := page.WithCancel()
+	c := &responseCapture{
+		page:    page,
+		rule:    rule,
+		stop:    stop,
+		loading: make(map[proto.NetworkRequestID]string),
+	}
+	wait := listening.EachEvent(func(e *proto.NetworkResponseReceived) {
+		if e.Response == nil || !rule.URLPattern.MatchString(e.Response.URL) {
+			return
+		}
+		c.mu.Lock()
+		c.loading[e.RequestID] = e.Response.URL
+		c.mu.Unlock()
+	}, func(e *proto.NetworkLoadingFinished) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if responseURL, ok := c.loading[e.RequestID]; ok && len(c.loaded) < maxInterceptedResponses {
+			delete(c.loading, e.RequestID)
+			c.loaded = append(c.loaded, capturedRequest{id: e.RequestID, url: responseURL})
+		}
+	})
+	go wait()
+	return c
+}
+
+// responses fetches the bodies of the loaded matching responses and stops
+// the capture. Responses whose body isn't JSON are dropped.
+func (c *responseCapture) responses() []interceptedResponse {
+	c.mu.Lock()
+	loaded := c.loaded
+	c.mu.Unlock()
+
+	var responses []interceptedResponse
+	for _, request := range loaded {
+		body, err := proto.NetworkGetResponseBody{RequestID: request.id}.Call(c.page)
+		if err != nil {
+			log.Printf("Failed to read the intercepted response %s: %v", request.url, err)
+			continue
+		}
+		data := []byte(body.Body)
+		if body.Base64Encoded {
+			if data, err = base64.StdEncoding.DecodeString(body.Body); err != nil {
+				continue
+			}
+		}
+		if !json.Valid(data) {
+			continue
+		}
+		responses = append(responses, interceptedResponse{URL: request.url, Body: data})
+	}
+	c.close()
+	return responses
+}
+
+// close stops the capture; it is a no-op on a nil or stopped capture
+func (c *responseCapture) close() {
+	if c != nil {
+		c.stop()
+	}
+}
+
+// interceptedJobs returns the jobs of the captured responses of a source and
+// the raw content they are archived as, no jobs if the responses hold none
+func (s *GoRodScraper) interceptedJobs(c *responseCapture, sourceURL string) ([]domain.Job, string) {
+	page := interceptedPage{Responses: c.responses()}
+	jobs := interceptJobs(page, c.rule, sourceURL)
+	if len(jobs) == 0 {
+		return nil, ""
+	}
+	raw, err := json.Marshal(page)
+	if err != nil {
+		return nil, ""
+	}
+	return jobs, string(raw)
+}
+
+// parseIntercepted parses the raw content of a scrape read from API
+// responses, false if raw is the HTML of a page
+func (s *GoRodScraper) parseIntercepted(raw, sourceURL string) ([]domain.Job, bool) {
+	rule, ok := s.intercept[sourceURL]
+	if !ok || !strings.HasPrefix(strings.TrimSpace(raw), "{") {
+		return nil, false
+	}
+	var page interceptedPage
+	if err := json.Unmarshal([]byte(raw), &page); err != nil || page.Responses == nil {
+		return nil, false
+	}
+	return interceptJobs(page, rule, sourceURL), true
+}
+
+// interceptJobs maps the jobs of API responses with rule, dropping the
+// duplicates of pages fetching the same jobs more than once
+func interceptJobs(page interceptedPage, rule InterceptRule, sourceURL string) []domain.Job {
+	base, _ := url.Parse(sourceURL)
+	var jobs []domain.Job
+	seen := make(map[string]bool)
+	for _, response := range page.Responses {
+		var doc interface{}
+		if err := json.Unmarshal(response.Body, &doc); err != nil {
+			continue
+		}
+		listed, ok := walkJSONPath(doc, rule.Jobs)
+		if !ok {
+			continue
+		}
+		items, _ := listed.([]interface{})
+		for _, item := range items {
+			job := interceptJob(item, rule, base)
+			if job.Title == "" {
+				continue
+			}
+			if key := feedJobID(job); !seen[key] {
+				seen[key] = true
+				jobs = append(jobs, job)
+			}
+		}
+	}
+	return jobs
+}
+
+// interceptJob maps a job of an API response
+func interceptJob(item interface{}, rule InterceptRule, base *url.URL) domain.Job {
+	field := func(path, name string) string {
+		if path == "" {
+			path = name
+		}
+		value, ok := walkJSONPath(item, path)
+		if !ok {
+			return ""
+		}
+		text, _ := formatJSONValue(value)
+		return strings.TrimSpace(text)
+	}
+	job := domain.Job{
+		ID:          field(rule.ID, "id"),
+		Title:       strings.Join(strings.Fields(field(rule.Title, "title")), " "),
+		Location:    field(rule.Location, "location"),
+		Department:  field(rule.Department, "department"),
+		URL:         field(rule.URL, "url"),
+		Description: field(rule.Description, "description"),
+		PostedDate:  feedTime(field(rule.PostedDate, "posted_date")),
+		ScrapedAt:   time.Now(),
+	}
+	if u, err := url.Parse(job.URL); err == nil && job.URL != "" && base != nil {
+		job.URL = base.ResolveReference(u).String()
+	}
+	return job
+}
@@ -0,0 +1,310 @@
+// internal/adapters/scraper/jsonld.go
+package scraper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// jsonLDTimeLayouts are the date formats seen in datePosted and validThrough
+var jsonLDTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+
+// jsonLDPeriods maps the unitText of QuantitativeValue salaries to periods
+var jsonLDPeriods = map[string]domain.SalaryPeriod{
+	"YEAR":  domain.SalaryPeriodYear,
+	"MONTH": domain.SalaryPeriodMonth,
+	"WEEK":  domain.SalaryPeriodWeek,
+	"DAY":   domain.SalaryPeriodDay,
+	"HOUR":  domain.SalaryPeriodHour,
+}
+
+// parseJSONLD extracts the schema.org JobPosting objects a page embeds in
+// <script type="application/ld+json"> tags, found at the top level, in
+// arrays, in @graph or in lists. Scripts that are not valid JSON are skipped.
+func parseJSONLD(doc *goquery.Document, sourceURL string) []domain.Job {
+	base, _ := url.Parse(sourceURL)
+	var jobs []domain.Job
+	seen := make(map[string]bool)
+	doc.Find(`script[type*="ld+json"]`).Each(func(i int, script *goquery.Selection) {
+		var data interface{}
+		if err := json.Unmarshal([]byte(script.Text()), &data); err != nil {
+			log.Printf("Skipping invalid JSON-LD on %s: %v", sourceURL, err)
+			return
+		}
+		for _, posting := range jobPostings(data) {
+			job, ok := jsonLDJob(posting, base)
+			if !ok || seen[job.ID] {
+				continue
+			}
+			seen[job.ID] = true
+			jobs = append(jobs, job)
+		}
+	})
+	return jobs
+}
+
+// jobPostings returns the JobPosting objects within a JSON-LD value
+func jobPostings(value interface{}) []map[string]interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		var postings []map[string]interface{}
+		for _, item := range v {
+			postings = append(postings, jobPostings(item)...)
+		}
+		return postings
+	case map[string]interface{}:
+		if hasType(v, "JobPosting") {
+			return []map[string]interface{}{v}
+		}
+		var postings []map[string]interface{}
+		for _, key := range []string{"@graph", "itemListElement", "item", "mainEntity"} {
+			if nested, ok := v[key]; ok {
+				postings = append(postings, jobPostings(nested)...)
+			}
+		}
+		return postings
+	}
+	return nil
+}
+
+// jsonLDJob converts a JobPosting into a job. Postings without a title are
+// skipped.
+func jsonLDJob(posting map[string]interface{}, base *url.URL) (domain.Job, bool) {
+	job := domain.Job{
+		Title:       strings.TrimSpace(jsonLDText(posting["title"])),
+		Description: htmlText(jsonLDText(posting["description"])),
+		URL:         resolveURL(base, jsonLDText(posting["url"])),
+		Salary:      jsonLDSalary(posting["baseSalary"]),
+		PostedDate:  jsonLDTime(posting["datePosted"]),
+		Deadline:    jsonLDTime(posting["validThrough"]),
+		ScrapedAt:   time.Now(),
+	}
+	if job.Title == "" {
+		job.Title = strings.TrimSpace(jsonLDText(posting["name"]))
+	}
+	if job.Title == "" {
+		return job, false
+	}
+
+	locations := jsonLDLocations(posting["jobLocation"])
+	if len(locations) > 0 {
+		job.Location = locations[0]
+	}
+
+	metadata := make(map[string]string)
+	if len(locations) > 1 {
+		metadata["other_locations"] = strings.Join(locations[1:], "; ")
+	}
+	if employment := jsonLDList(posting["employmentType"]); len(employment) > 0 {
+		metadata["employment_type"] = strings.Join(employment, ", ")
+	}
+	if strings.EqualFold(jsonLDText(posting["jobLocationType"]), "TELECOMMUTE") {
+		metadata["workplace"] = "Remote"
+	}
+	if validThrough := jsonLDTime(posting["validThrough"]); !validThrough.IsZero() {
+		metadata["valid_through"] = validThrough.Format(time.RFC3339)
+	}
+	if organization, ok := posting["hiringOrganization"].(map[string]interface{}); ok {
+		if name := jsonLDText(organization["name"]); name != "" {
+			metadata["hiring_organization"] = name
+		}
+	}
+	if len(metadata) > 0 {
+		job.Metadata = metadata
+	}
+
+	job.ID = jsonLDIdentifier(posting["identifier"])
+	if job.ID == "" {
+		job.ID = job.URL
+	}
+	if job.ID == "" {
+		hash := sha256.Sum256([]byte(job.Title + "\n" + job.Location))
+		job.ID = hex.EncodeToString(hash[:])
+	}
+	return job, true
+}
+
+// jsonLDLocations returns the places of a jobLocation, one or several Place
+// objects whose address is a PostalAddress or plain text
+func jsonLDLocations(value interface{}) []string {
+	var locations []string
+	for _, place := range jsonLDObjects(value) {
+		address := place["address"]
+		if text, ok := address.(string); ok {
+			locations = append(locations, strings.TrimSpace(text))
+			continue
+		}
+		postal, ok := address.(map[string]interface{})
+		if !ok {
+			if name := jsonLDText(place["name"]); name != "" {
+				locations = append(locations, name)
+			}
+			continue
+		}
+
+		var parts []string
+		for _, key := range []string{"addressLocality", "addressRegion", "addressCountry"} {
+			part := jsonLDText(postal[key])
+			if country, ok := postal[key].(map[string]interface{}); ok {
+				part = jsonLDText(country["name"])
+			}
+			if part = strings.TrimSpace(part); part != "" && !containsFold(parts, part) {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) > 0 {
+			locations = append(locations, strings.Join(parts, ", "))
+		}
+	}
+	return locations
+}
+
+// jsonLDSalary returns the salary of a baseSalary MonetaryAmount, nil if it
+// has no currency or amount
+func jsonLDSalary(value interface{}) *domain.Salary {
+	amount, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	salary := &domain.Salary{Currency: strings.ToUpper(jsonLDText(amount["currency"]))}
+
+	switch quantity := amount["value"].(type) {
+	case map[string]interface{}:
+		salary.Min, _ = jsonLDNumber(quantity["minValue"])
+		salary.Max, _ = jsonLDNumber(quantity["maxValue"])
+		if exact, ok := jsonLDNumber(quantity["value"]); ok && salary.Min == 0 && salary.Max == 0 {
+			salary.Min, salary.Max = exact, exact
+		}
+		salary.Period = jsonLDPeriods[strings.ToUpper(jsonLDText(quantity["unitText"]))]
+	default:
+		if exact, ok := jsonLDNumber(quantity); ok {
+			salary.Min, salary.Max = exact, exact
+		}
+	}
+	if salary.Currency == "" || (salary.Min <= 0 && salary.Max <= 0) {
+		return nil
+	}
+	return salary
+}
+
+// jsonLDIdentifier returns the value of an identifier, a PropertyValue or
+// plain text
+func jsonLDIdentifier(value interface{}) string {
+	if property, ok := value.(map[string]interface{}); ok {
+		return jsonLDText(property["value"])
+	}
+	return jsonLDText(value)
+}
+
+// jsonLDTime parses a date of a posting, zero if it has none or an unknown
+// format
+func jsonLDTime(value interface{}) time.Time {
+	text := strings.TrimSpace(jsonLDText(value))
+	for _, layout := range jsonLDTimeLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// jsonLDText returns a string or number value as text, "" for other values
+func jsonLDText(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+// jsonLDNumber returns a number value, also given as text
+func jsonLDNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(v), ",", ""), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// jsonLDList returns the texts of a value that is a single text or a list
+func jsonLDList(value interface{}) []string {
+	if list, ok := value.([]interface{}); ok {
+		var texts []string
+		for _, item := range list {
+			if text := jsonLDText(item); text != "" {
+				texts = append(texts, text)
+			}
+		}
+		return texts
+	}
+	if text := jsonLDText(value); text != "" {
+		return []string{text}
+	}
+	return nil
+}
+
+// jsonLDObjects returns the objects of a value that is a single object or a
+// list of them
+func jsonLDObjects(value interface{}) []map[string]interface{} {
+	if object, ok := value.(map[string]interface{}); ok {
+		return []map[string]interface{}{object}
+	}
+	var objects []map[string]interface{}
+	if list, ok := value.([]interface{}); ok {
+		for _, item := range list {
+			if object, ok := item.(map[string]interface{}); ok {
+				objects = append(objects, object)
+			}
+		}
+	}
+	return objects
+}
+
+// hasType reports whether a JSON-LD object has a @type, possibly among
+// several
+func hasType(object map[string]interface{}, name string) bool {
+	for _, t := range jsonLDList(object["@type"]) {
+		if t == name || strings.HasSuffix(t, "/"+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveURL resolves a link of a page against its URL, "" if it has none
+func resolveURL(base *url.URL, link string) string {
+	link = strings.TrimSpace(link)
+	if link == "" || base == nil {
+		return link
+	}
+	resolved, err := base.Parse(link)
+	if err != nil {
+		return link
+	}
+	return resolved.String()
+}
+
+// containsFold reports whether list holds value, ignoring case
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+// internal/adapters/scraper/lever_scraper.go
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// leverHosts serve the job sites hosted by Lever, e.g.
+// https://jobs.lever.co/acme, mapped to the postings API of their region
+var leverHosts = map[string]string{
+	"jobs.lever.co":    "https://api.lever.co/v0/postings/",
+	"jobs.eu.lever.co": "https://api.eu.lever.co/v0/postings/",
+}
+
+// leverLink matches links to a Lever job site in career pages of other sites
+var leverLink = regexp.MustCompile(`https?://jobs(?:\.eu)?\.lever\.co/([A-Za-z0-9_.-]+)`)
+
+// leverPeriods maps Lever salary intervals to salary periods
+var leverPeriods = map[string]domain.SalaryPeriod{
+	"per-year-salary":  domain.SalaryPeriodYear,
+	"per-month-salary": domain.SalaryPeriodMonth,
+	"per-week-salary":  domain.SalaryPeriodWeek,
+	"per-day-wage":     domain.SalaryPeriodDay,
+	"per-hour-wage":    domain.SalaryPeriodHour,
+}
+
+// LeverScraper implements the BoardScraper interface for job sites hosted by
+// Lever, reading the published postings from the postings API. Career pages
+// of other sites linking to a job site are located by their links.
+type LeverScraper struct {
+	client *http.Client
+	apis   map[string]string // postings API by job site host
+}
+
+// leverPosting is a posting of the postings API
+type leverPosting struct {
+	ID         string `json:"id"`
+	Text       string `json:"text"`
+	HostedURL  string `json:"hostedUrl"`
+	ApplyURL   string `json:"applyUrl"`
+	CreatedAt  int64  `json:"createdAt"`
+	Categories struct {
+		Team         string   `json:"team"`
+		Department   string   `json:"department"`
+		Location     string   `json:"location"`
+		Commitment   string   `json:"commitment"`
+		AllLocations []string `json:"allLocations"`
+	} `json:"categories"`
+	DescriptionPlain string `json:"descriptionPlain"`
+	WorkplaceType    string `json:"workplaceType"`
+	SalaryRange      *struct {
+		Min      float64 `json:"min"`
+		Max      float64 `json:"max"`
+		Currency string  `json:"currency"`
+		Interval string  `json:"interval"`
+	} `json:"salaryRange"`
+}
+
+// NewLeverScraper creates a scraper querying the Lever API with client
+func NewLeverScraper(client *http.Client) *LeverScraper {
+	return &LeverScraper{client: client, apis: leverHosts}
+}
+
+// Matches reports whether url is a job site hosted by Lever
+func (s *LeverScraper) Matches(url string) bool {
+	_, company := s.site(url)
+	return company != ""
+}
+
+// Locate returns the URL of the job site a page links to
+func (s *LeverScraper) Locate(page string) string {
+	match := leverLink.FindStringSubmatch(page)
+	if match == nil {
+		return ""
+	}
+	return strings.TrimRight(match[0], ".")
+}
+
+// Detects reports whether a page is a response of the postings API, so
+// archived responses of located job sites are parsed again by the board
+func (s *LeverScraper) Detects(page string) bool {
+	return strings.HasPrefix(strings.TrimSpace(page), "[") && strings.Contains(page, `"hostedUrl"`)
+}
+
+// Scrape lists the published postings of the job site
+func (s *LeverScraper) Scrape(ctx context.Context, sourceURL string) (domain.JobCollection, error) {
+	api, company := s.site(sourceURL)
+	result := domain.JobCollection{
+		CompanyName: companyFromSlug(company),
+		SourceURL:   sourceURL,
+		ScrapedAt:   time.Now(),
+	}
+	if company == "" {
+		return result, fmt.Errorf("no Lever job site in %s", sourceURL)
+	}
+	log.Printf("Querying the Lever job site %s", company)
+
+	body, err := getBoard(ctx, s.client, api+url.PathEscape(company)+"?mode=json")
+	if err != nil {
+		return result, fmt.Errorf("failed to list Lever postings: %w", err)
+	}
+	result.RawContent = string(body)
+
+	result.Jobs, err = s.ParseJobs(result.RawContent, sourceURL)
+	if err != nil {
+		return result, err
+	}
+	log.Printf("Found %d jobs on the Lever job site %s", len(result.Jobs), company)
+	return result, nil
+}
+
+// ParseJobs extracts the postings from a postings API response
+func (s *LeverScraper) ParseJobs(raw, sourceURL string) ([]domain.Job, error) {
+	var postings []leverPosting
+	if err := decodeBoard([]byte(raw), &postings); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.Job, 0, len(postings))
+	for _, posting := range postings {
+		jobs = append(jobs, posting.job())
+	}
+	return jobs, nil
+}
+
+// site returns the postings API and company of a job site URL on Lever, ""
+// for other URLs
+func (s *LeverScraper) site(rawURL string) (string, string) {
+	for host, api := range s.apis {
+		if company := boardSlug(rawURL, host); company != "" {
+			return api, company
+		}
+	}
+	return "", ""
+}
+
+// job converts a posting into a job. The department falls back to the team
+// of the posting.
+func (p leverPosting) job() domain.Job {
+	job := domain.Job{
+		ID:          p.ID,
+		Title:       strings.TrimSpace(p.Text),
+		Description: strings.TrimSpace(p.DescriptionPlain),
+		Location:    p.Categories.Location,
+		Department:  p.Categories.Department,
+		URL:         p.HostedURL,
+		ApplyURL:    p.ApplyURL,
+		Salary:      p.salary(),
+		ScrapedAt:   time.Now(),
+	}
+	if p.CreatedAt > 0 {
+		job.PostedDate = time.UnixMilli(p.CreatedAt).UTC()
+	}
+
+	metadata := make(map[string]string)
+	if job.Department == "" {
+		job.Department = p.Categories.Team
+	} else if p.Categories.Team != "" && p.Categories.Team != job.Department {
+		metadata["team"] = p.Categories.Team
+	}
+	if p.Categories.Commitment != "" {
+		metadata["employment_type"] = p.Categories.Commitment
+	}
+	var others []string
+	for _, location := range p.Categories.AllLocations {
+		if location != "" && location != job.Location {
+			others = append(others, location)
+		}
+	}
+	if len(others) > 0 {
+		metadata["other_locations"] = strings.Join(others, "; ")
+	}
+	switch p.WorkplaceType {
+	case "remote":
+		metadata["workplace"] = "Remote"
+	case "hybrid":
+		metadata["workplace"] = "Hybrid"
+	case "onsite":
+		metadata["workplace"] = "On-site"
+	}
+	if len(metadata) > 0 {
+		job.Metadata = metadata
+	}
+	return job
+}
+
+// salary returns the salary range of the posting, nil if it shows none
+func (p leverPosting) salary() *domain.Salary {
+	if p.SalaryRange == nil || p.SalaryRange.Currency == "" || (p.SalaryRange.Min <= 0 && p.SalaryRange.Max <= 0) {
+		return nil
+	}
+	return &domain.Salary{
+		Min:      p.SalaryRange.Min,
+		Max:      p.SalaryRange.Max,
+		Currency: strings.ToUpper(p.SalaryRange.Currency),
+		Period:   leverPeriods[p.SalaryRange.Interval],
+	}
+}
+
+var (
+	_ BoardScraper  = (*LeverScraper)(nil) // Ensure interface compliance
+	_ BoardDetector = (*LeverScraper)(nil)
+	_ BoardLocator  = (*LeverScraper)(nil)
+)
@@ -0,0 +1,325 @@
+// internal/adapters/scraper/page_pool.go
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// pageHealthTimeout bounds the health check of an idle page
+const pageHealthTimeout = 2 * time.Second
+
+// pooledPage is a browser page together with its bookkeeping
+type pooledPage struct {
+	page        *rod.Page
+	browser     *rod.Browser // browser the page was opened in
+	navigations int
+	stopHijack  func()
+	scrape      atomic.Value // scrapeContext of the scrape using the page
+}
+
+// scrapeContext holds the context of a scrape, as an atomic.Value can't hold
+// different context types
+type scrapeContext struct {
+	ctx context.Context
+}
+
+// PagePool shares a long-lived browser between scrapes. Acquire queues until a
+// page is free, so a page is never used by two scrapes at once. Pages are
+// health-checked before reuse and recycled after a number of navigations to
+// keep Chromium's memory in check. The browser is launched on the first
+// Acquire, and relaunched when it stops responding, e.g. after a crash,
+// dropping the pages of the old one.
+type PagePool struct {
+	browser        *rod.Browser
+	closeBrowser   func() error
+	browserURL     string // DevTools URL of a remote browser, see WithBrowserURL
+	client         *http.Client
+	maxNavigations int
+	slots          chan *pooledPage // nil entries are free slots without a page yet
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewPagePool creates a pool of up to size pages of a shared browser, each
+// recycled after maxNavigations scrapes (0 never recycles). The browser is the
+// one at browserURL, else a local one. If client is not nil, every request of
+// the pages is made through it, see NewGoRodScraper.
+func NewPagePool(size, maxNavigations int, browserURL string, client *http.Client) (*PagePool, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("page pool size must be positive, got %d", size)
+	}
+
+	p := &PagePool{
+		browserURL:     browserURL,
+		client:         client,
+		maxNavigations: maxNavigations,
+		slots:          make(chan *pooledPage, size),
+	}
+	for i := 0; i < size; i++ {
+		p.slots <- nil
+	}
+	return p, nil
+}
+
+// Acquire waits for a free page and returns it with the function to release
+// it. Pass true to release if the page may be in a bad state; it is closed
+// and replaced on the next Acquire.
+func (p *PagePool) Acquire(ctx context.Context) (*rod.Page, func(broken bool), error) {
+	var slot *pooledPage
+	select {
+	case slot = <-p.slots:
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+
+	browser := p.currentBrowser()
+	if slot != nil && slot.browser != browser {
+		p.closePage(slot)
+		slot = nil
+	}
+	if slot != nil && !p.healthy(slot) {
+		log.Printf("Recycling unresponsive browser page")
+		p.closePage(slot)
+		slot = nil
+	}
+	if slot == nil {
+		var err error
+		if slot, err = p.openPage(browser); err != nil {
+			p.slots <- nil
+			return nil, nil, err
+		}
+	}
+
+	slot.scrape.Store(scrapeContext{ctx: ctx})
+	var once sync.Once
+	release := func(broken bool) {
+		once.Do(func() { p.release(slot, broken) })
+	}
+	return slot.page, release, nil
+}
+
+// release returns a page to the pool, recycling it if needed
+func (p *PagePool) release(slot *pooledPage, broken bool) {
+	slot.navigations++
+	recycle := broken || (p.maxNavigations > 0 && slot.navigations >= p.maxNavigations)
+
+	// Leave the page blank so idle pages don't keep scripts and memory alive
+	if !recycle {
+		if err := slot.page.Timeout(pageHealthTimeout).Navigate("about:blank"); err != nil {
+			recycle = true
+		}
+	}
+	if recycle {
+		p.closePage(slot)
+		slot = nil
+	}
+	p.put(slot)
+}
+
+// put returns a slot to the pool, closing its page if the pool is closed
+func (p *PagePool) put(slot *pooledPage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		if slot != nil {
+			p.closePage(slot)
+		}
+		return
+	}
+	p.slots <- slot
+}
+
+// AcquireIsolated waits for a free slot like Acquire, but returns a page in
+// an incognito context of the shared browser of its own. The context is
+// disposed on release, so the cookies and sessions of the scrape, e.g. of a
+// source it signed in to, don't reach the scrapes after it.
+func (p *PagePool) AcquireIsolated(ctx context.Context) (*rod.Page, func(broken bool), error) {
+	var slot *pooledPage
+	select {
+	case slot = <-p.slots:
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+
+	incognito, err := p.incognito()
+	if err != nil {
+		p.put(slot)
+		return nil, nil, err
+	}
+	isolated, err := p.newPage(incognito)
+	if err != nil {
+		_ = incognito.Timeout(browserCloseTimeout).Close()
+		p.put(slot)
+		return nil, nil, err
+	}
+
+	isolated.scrape.Store(scrapeContext{ctx: ctx})
+	var once sync.Once
+	release := func(bool) {
+		once.Do(func() {
+			p.closePage(isolated)
+			if err := incognito.Timeout(browserCloseTimeout).Close(); err != nil {
+				log.Printf("Failed to dispose incognito browser context: %v", err)
+			}
+			p.put(slot)
+		})
+	}
+	return isolated.page, release, nil
+}
+
+// incognito creates an incognito context of the shared browser, launching a
+// browser first if none runs yet or the current one stopped responding
+func (p *PagePool) incognito() (*rod.Browser, error) {
+	browser := p.currentBrowser()
+	if browser != nil {
+		incognito, err := browser.Incognito()
+		if err == nil {
+			return incognito, nil
+		}
+		if browserHealthy(browser) {
+			return nil, fmt.Errorf("failed to create browser context: %w", err)
+		}
+	}
+	browser, err := p.launch(browser)
+	if err != nil {
+		return nil, err
+	}
+	incognito, err := browser.Incognito()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create browser context: %w", err)
+	}
+	return incognito, nil
+}
+
+// openPage opens a page in browser, launching a browser first if none runs
+// yet or browser stopped responding
+func (p *PagePool) openPage(browser *rod.Browser) (*pooledPage, error) {
+	if browser != nil {
+		slot, err := p.newPage(browser)
+		if err == nil || browserHealthy(browser) {
+			return slot, err
+		}
+	}
+	browser, err := p.launch(browser)
+	if err != nil {
+		return nil, err
+	}
+	return p.newPage(browser)
+}
+
+// newPage opens a page in browser, routing its requests through the client if
+// there is one
+func (p *PagePool) newPage(browser *rod.Browser) (*pooledPage, error) {
+	page, err := browser.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create browser page: %w", err)
+	}
+
+	slot := &pooledPage{page: page, browser: browser, stopHijack: func() {}}
+	if p.client != nil {
+		router := page.HijackRequests()
+		if err := router.Add("*", "", hijackWith(p.client, slot.context)); err != nil {
+			page.Close()
+			return nil, fmt.Errorf("failed to hijack browser requests: %w", err)
+		}
+		go router.Run()
+		slot.stopHijack = func() { router.Stop() }
+	}
+	return slot, nil
+}
+
+// context returns the context of the scrape using the page, nil before the
+// first one
+func (slot *pooledPage) context() context.Context {
+	scrape, _ := slot.scrape.Load().(scrapeContext)
+	return scrape.ctx
+}
+
+// healthy reports whether an idle page still responds
+func (p *PagePool) healthy(slot *pooledPage) bool {
+	_, err := slot.page.Timeout(pageHealthTimeout).Eval(`() => document.readyState`)
+	return err == nil
+}
+
+// currentBrowser returns the browser new pages are opened in
+func (p *PagePool) currentBrowser() *rod.Browser {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.browser
+}
+
+// launch replaces failed, a browser that stopped responding or nil before
+// the first scrape, with a newly launched browser and returns it. If another
+// scrape replaced failed already, the current browser is returned.
+func (p *PagePool) launch(failed *rod.Browser) (*rod.Browser, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil, fmt.Errorf("page pool is closed")
+	}
+	if p.browser != failed {
+		return p.browser, nil
+	}
+
+	if failed != nil {
+		log.Printf("Browser stopped responding, relaunching it")
+		if err := p.closeBrowser(); err != nil {
+			log.Printf("Failed to close unresponsive browser: %v", err)
+		}
+	}
+	browser, closeBrowser, err := connectBrowser(rod.New(), p.browserURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to browser: %w", err)
+	}
+	p.browser = browser
+	p.closeBrowser = closeBrowser
+	return browser, nil
+}
+
+// browserHealthy reports whether a browser still responds
+func browserHealthy(browser *rod.Browser) bool {
+	_, err := browser.Timeout(pageHealthTimeout).Version()
+	return err == nil
+}
+
+// closePage closes a page and its request router
+func (p *PagePool) closePage(slot *pooledPage) {
+	slot.stopHijack()
+	if err := slot.page.Close(); err != nil {
+		log.Printf("Failed to close browser page: %v", err)
+	}
+}
+
+// Close closes the idle pages and the browser. Pages in use are closed with
+// the browser.
+func (p *PagePool) Close() error {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+
+	for {
+		select {
+		case slot := <-p.slots:
+			if slot != nil {
+				p.closePage(slot)
+			}
+		default:
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if p.browser != nil {
+				return p.closeBrowser()
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,261 @@
+// internal/adapters/scraper/pdf_scraper.go
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/normalize"
+)
+
+// pdfCommandTimeout bounds a run of the PDF text command
+const pdfCommandTimeout = time.Minute
+
+// pdfAccept asks servers for the PDF rather than a viewer page
+const pdfAccept = "application/pdf, */*;q=0.8"
+
+// pdfParagraphs separates the listings of a PDF unless its source says how
+var pdfParagraphs = regexp.MustCompile(`\n[ \t]*\n`)
+
+// pdfLabel matches a labeled line of a listing, e.g. "Location: Geneva" or
+// "Vacancy No. - 2024/117"
+var pdfLabel = regexp.MustCompile(`(?i)^(location|place of work|duty station|based in|department|unit|division|section|reference|ref\.?|vacancy (?:no\.?|number)|job (?:id|no\.?|number)|posting (?:no\.?|number))\s*[:\-–]\s*(.+)$`)
+
+// PDFSource tells how the jobs of a PDF are listed. Pattern matches a listing
+// in the text of the PDF, naming its fields with the groups title, location,
+// department, id, url and description; the text up to the next match is the
+// description if it has no description group. Without a pattern, the text is
+// split into listings by Separator, or blank lines if nil, each listing being
+// a title line followed by its details.
+type PDFSource struct {
+	Pattern   *regexp.Regexp
+	Separator *regexp.Regexp
+}
+
+// PDFScraper implements the BoardScraper interface for vacancies published as
+// PDF documents. It downloads the file and extracts its text, built in or
+// with a configured command such as pdftotext, and reads the listings of the
+// text by the rules of the source. The text is the raw content of the
+// collection, so changed rules apply to archived scrapes.
+type PDFScraper struct {
+	client  *http.Client
+	command []string // converts a PDF on its standard input to text on its standard output, the built-in extractor if empty
+	sources map[string]PDFSource
+}
+
+// NewPDFScraper creates a scraper reading PDF listings with client. sources
+// holds the rules of sources, keyed by source URL; other URLs ending in .pdf
+// are read with the default rules.
+func NewPDFScraper(client *http.Client, command []string, sources map[string]PDFSource) *PDFScraper {
+	return &PDFScraper{
+		client:  client,
+		command: command,
+		sources: sources,
+	}
+}
+
+// Matches reports whether url is a PDF source
+func (s *PDFScraper) Matches(rawURL string) bool {
+	if _, ok := s.sources[rawURL]; ok {
+		return true
+	}
+	u, err := url.Parse(rawURL)
+	return err == nil && strings.EqualFold(path.Ext(u.Path), ".pdf")
+}
+
+// Scrape downloads the PDF and reads its listings
+func (s *PDFScraper) Scrape(ctx context.Context, sourceURL string) (domain.JobCollection, error) {
+	result := domain.JobCollection{
+		SourceURL:   sourceURL,
+		CompanyName: extractCompanyName(sourceURL),
+		ScrapedAt:   time.Now(),
+	}
+	log.Printf("Reading the PDF listing %s", sourceURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sourceURL, nil)
+	if err != nil {
+		return result, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", pdfAccept)
+	body, err := fetchBoard(s.client, req)
+	if err != nil {
+		return result, fmt.Errorf("failed to download PDF: %w", err)
+	}
+	text, err := s.text(ctx, body)
+	if err != nil {
+		if blocked := detectBlock(string(body), sourceURL, 0); errors.Is(err, errNotPDF) && blocked != nil {
+			return result, blocked
+		}
+		return result, fmt.Errorf("failed to read PDF: %w", err)
+	}
+	result.RawContent = text
+
+	result.Jobs = s.parse(text, sourceURL, result.ScrapedAt)
+	log.Printf("Found %d jobs in the PDF listing %s", len(result.Jobs), sourceURL)
+	return result, nil
+}
+
+// ParseJobs extracts the jobs from the text of a PDF
+func (s *PDFScraper) ParseJobs(raw, sourceURL string) ([]domain.Job, error) {
+	return s.parse(raw, sourceURL, time.Now()), nil
+}
+
+// text returns the text of a PDF document
+func (s *PDFScraper) text(ctx context.Context, data []byte) (string, error) {
+	if len(s.command) == 0 {
+		return ExtractPDFText(data)
+	}
+	if !isPDF(data) {
+		return "", errNotPDF
+	}
+	ctx, cancel := context.WithTimeout(ctx, pdfCommandTimeout)
+	defer cancel()
+	out, err := runPluginCommand(ctx, s.command, data)
+	if err != nil {
+		return "", fmt.Errorf("%s failed: %w", s.command[0], err)
+	}
+	return strings.TrimSpace(strings.ReplaceAll(string(out), "\f", "\n\n")), nil
+}
+
+// parse reads the listings of the text of a PDF by the rules of its source,
+// dropping duplicates
+func (s *PDFScraper) parse(text, sourceURL string, scrapedAt time.Time) []domain.Job {
+	source := s.sources[sourceURL]
+	var listed []domain.Job
+	if source.Pattern != nil {
+		listed = pdfPatternJobs(text, source.Pattern)
+	} else {
+		separator := source.Separator
+		if separator == nil {
+			separator = pdfParagraphs
+		}
+		listed = pdfBlockJobs(text, separator)
+	}
+
+	base, _ := url.Parse(sourceURL)
+	jobs := make([]domain.Job, 0, len(listed))
+	seen := make(map[string]bool)
+	for _, job := range listed {
+		if job.URL == "" {
+			job.URL = sourceURL
+		} else if u, err := url.Parse(job.URL); err == nil && base != nil {
+			job.URL = base.ResolveReference(u).String()
+		}
+		if job.ID == "" {
+			// Listings of a PDF share its URL, so the title and location
+			// tell them apart
+			job.ID = normalize.TitleLocationIdentity{}.JobID(job)
+		}
+		if job.ID == "" || seen[job.ID] {
+			continue
+		}
+		seen[job.ID] = true
+		job.ScrapedAt = scrapedAt
+		jobs = append(jobs, normalize.ListingDates(job, job.Description))
+	}
+	return jobs
+}
+
+// pdfPatternJobs returns the listings pattern matches in text
+func pdfPatternJobs(text string, pattern *regexp.Regexp) []domain.Job {
+	matches := pattern.FindAllStringSubmatchIndex(text, -1)
+	names := pattern.SubexpNames()
+	var jobs []domain.Job
+	for i, match := range matches {
+		var job domain.Job
+		hasDescription := false
+		for group, name := range names {
+			if name == "" || match[2*group] < 0 {
+				continue
+			}
+			value := strings.TrimSpace(text[match[2*group]:match[2*group+1]])
+			switch name {
+			case "title":
+				job.Title = strings.Join(strings.Fields(value), " ")
+			case "location":
+				job.Location = strings.Join(strings.Fields(value), " ")
+			case "department":
+				job.Department = strings.Join(strings.Fields(value), " ")
+			case "id":
+				job.ID = value
+			case "url":
+				job.URL = value
+			case "description":
+				job.Description = value
+				hasDescription = true
+			}
+		}
+		if !hasDescription {
+			end := len(text)
+			if i+1 < len(matches) {
+				end = matches[i+1][0]
+			}
+			job.Description = strings.TrimSpace(text[match[0]:end])
+		}
+		if job.Title != "" {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// pdfBlockJobs returns the listings of text split by separator: blocks whose
+// first line reads like a title and that have details below it, labeled
+// lines giving the location, department and reference of the job
+func pdfBlockJobs(text string, separator *regexp.Regexp) []domain.Job {
+	var jobs []domain.Job
+	for _, block := range separator.Split(text, -1) {
+		var lines []string
+		for _, line := range strings.Split(block, "\n") {
+			if line = strings.Join(strings.Fields(line), " "); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		if len(lines) < 2 || !pdfTitleLine(lines[0]) {
+			continue
+		}
+
+		job := domain.Job{Title: lines[0]}
+		for _, line := range lines[1:] {
+			match := pdfLabel.FindStringSubmatch(line)
+			if match == nil {
+				continue
+			}
+			switch label := strings.ToLower(match[1]); {
+			case label == "location" || label == "place of work" || label == "duty station" || label == "based in":
+				job.Location = match[2]
+			case label == "department" || label == "unit" || label == "division" || label == "section":
+				job.Department = match[2]
+			default:
+				job.ID = match[2]
+			}
+		}
+		job.Description = strings.Join(lines[1:], "\n")
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+// pdfTitleLine reports whether a line reads like a job title rather than a
+// sentence or a label: short, with letters, not ending in a period or colon
+func pdfTitleLine(line string) bool {
+	if pdfLabel.MatchString(line) || strings.HasSuffix(line, ".") || strings.HasSuffix(line, ":") {
+		return false
+	}
+	words := len(strings.Fields(line))
+	return words >= 1 && words <= 12 && len([]rune(line)) <= 120 && strings.IndexFunc(line, unicode.IsLetter) >= 0
+}
+
+var (
+	_ BoardScraper = (*PDFScraper)(nil) // Ensure interface compliance
+)
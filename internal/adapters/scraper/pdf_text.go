@@ -0,0 +1,447 @@
+// internal/adapters/scraper/pdf_text.go
+package scraper
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// maxPDFStream bounds the decompressed size of a PDF stream
+const maxPDFStream = 20 << 20
+
+// pdfStream matches the dictionary and start of a stream object
+var pdfStream = regexp.MustCompile(`(?s)<<((?:[^<>]|<<(?:[^<>]|<<[^<>]*>>)*>>|<[0-9A-Fa-f\s]*>)*)>>\s*stream\r?\n`)
+
+// pdfSkippedStreams are the stream types that hold no page text
+var pdfSkippedStreams = regexp.MustCompile(`/(?:XRef|ObjStm|XObject|Image|FontFile\d?|Length1|Metadata)\b`)
+
+// pdfCMapSection matches the code mappings of a ToUnicode CMap
+var pdfCMapSection = regexp.MustCompile(`(?s)beginbf(char|range)(.*?)endbf(?:char|range)`)
+
+// pdfCMapRange matches a code range of a ToUnicode CMap, e.g. "<0003> <0005>
+// <0041>", and pdfCMapChar a single code, e.g. "<0003> <0041>"
+var (
+	pdfCMapRange = regexp.MustCompile(`<([0-9A-Fa-f]+)>\s*<([0-9A-Fa-f]+)>\s*<([0-9A-Fa-f]+)>`)
+	pdfCMapChar  = regexp.MustCompile(`<([0-9A-Fa-f]+)>\s*<([0-9A-Fa-f]+)>`)
+)
+
+var errNotPDF = errors.New("not a PDF document")
+
+// ExtractPDFText returns the text shown on the pages of a PDF document, in
+// the order it is drawn, with a line break between lines and a blank line
+// between paragraphs. It reads uncompressed and Flate-compressed content
+// streams and maps glyphs through the ToUnicode CMaps of the document;
+// encrypted documents and text drawn as images yield no text.
+func ExtractPDFText(data []byte) (string, error) {
+	if !isPDF(data) {
+		return "", errNotPDF
+	}
+
+	var contents [][]byte
+	cmap := make(pdfCMap)
+	for _, loc := range pdfStream.FindAllSubmatchIndex(data, -1) {
+		dict := data[loc[2]:loc[3]]
+		start := loc[1]
+		end := bytes.Index(data[start:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		if pdfSkippedStreams.Match(dict) {
+			continue
+		}
+		stream, err := decodePDFStream(dict, data[start:start+end])
+		if err != nil {
+			continue // an unsupported filter, e.g. an embedded image
+		}
+		if bytes.Contains(stream, []byte("begincmap")) {
+			cmap.parse(stream)
+			continue
+		}
+		contents = append(contents, stream)
+	}
+
+	var text pdfTextWriter
+	for _, content := range contents {
+		text.content(content, cmap)
+	}
+	return strings.TrimSpace(text.String()), nil
+}
+
+// isPDF reports whether data starts with the header of a PDF document
+func isPDF(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimLeft(data, "\x00\t\r\n "), []byte("%PDF-"))
+}
+
+// decodePDFStream decodes the data of a stream with the filter its
+// dictionary names
+func decodePDFStream(dict, data []byte) ([]byte, error) {
+	switch {
+	case bytes.Contains(dict, []byte("/FlateDecode")):
+		reader, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		stream, err := io.ReadAll(io.LimitReader(reader, maxPDFStream))
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, err
+		}
+		return stream, nil
+	case bytes.Contains(dict, []byte("/Filter")):
+		return nil, errors.New("unsupported stream filter")
+	}
+	return data, nil
+}
+
+// pdfCMap maps the character codes of fonts, as hex, to their text. Codes of
+// all fonts share the map, which is right for the documents office suites
+// write, where fonts rarely reuse a code for another character.
+type pdfCMap map[string]string
+
+// parse adds the mappings of a ToUnicode CMap
+func (m pdfCMap) parse(stream []byte) {
+	for _, section := range pdfCMapSection.FindAllSubmatch(stream, -1) {
+		if string(section[1]) == "char" {
+			for _, match := range pdfCMapChar.FindAllSubmatch(section[2], -1) {
+				m[strings.ToUpper(string(match[1]))] = utf16Hex(string(match[2]))
+			}
+			continue
+		}
+		for _, match := range pdfCMapRange.FindAllSubmatch(section[2], -1) {
+			low, err1 := strconv.ParseUint(string(match[1]), 16, 32)
+			high, err2 := strconv.ParseUint(string(match[2]), 16, 32)
+			first, err3 := strconv.ParseUint(string(match[3]), 16, 32)
+			if err1 != nil || err2 != nil || err3 != nil || high < low || high-low > 0xFFFF {
+				continue
+			}
+			width := len(match[1])
+			for code := low; code <= high; code++ {
+				m[strings.ToUpper(strconv.FormatUint(code|1<<(4*width), 16)[1:])] = string(rune(first + code - low))
+			}
+		}
+	}
+}
+
+// decode returns the text of the codes of a shown string: looked up in the
+// CMap two bytes at a time if it has two-byte codes, else one byte at a time,
+// unmapped bytes being read as Latin-1
+func (m pdfCMap) decode(codes []byte) string {
+	if len(codes) >= 2 && codes[0] == 0xFE && codes[1] == 0xFF {
+		units := make([]uint16, 0, len(codes)/2)
+		for i := 2; i+1 < len(codes); i += 2 {
+			units = append(units, uint16(codes[i])<<8|uint16(codes[i+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+	width := 1
+	if len(codes)%2 == 0 && m.hasTwoByteCodes() {
+		width = 2
+	}
+	var text strings.Builder
+	for i := 0; i+width <= len(codes); i += width {
+		code := strings.ToUpper(hexBytes(codes[i : i+width]))
+		if mapped, ok := m[code]; ok {
+			text.WriteString(mapped)
+		} else if width == 1 {
+			text.WriteRune(rune(codes[i]))
+		}
+	}
+	return text.String()
+}
+
+// hasTwoByteCodes reports whether the CMap maps two-byte codes, as the
+// Identity-H encoded fonts of most generated PDFs use
+func (m pdfCMap) hasTwoByteCodes() bool {
+	for code := range m {
+		if len(code) == 4 {
+			return true
+		}
+	}
+	return false
+}
+
+// pdfTextWriter collects the text of content streams
+type pdfTextWriter struct {
+	strings.Builder
+	y, lineY float64 // vertical position of the text cursor and of the last text shown
+	leading  float64
+	size     float64
+}
+
+// content interprets the text operators of a content stream
+func (w *pdfTextWriter) content(stream []byte, cmap pdfCMap) {
+	var operands []pdfToken
+	lexer := pdfLexer{data: stream}
+	for {
+		token, ok := lexer.next()
+		if !ok {
+			return
+		}
+		if token.kind != pdfOperator {
+			operands = append(operands, token)
+			continue
+		}
+		switch token.text {
+		case "Tf":
+			if len(operands) >= 1 {
+				w.size = math.Abs(operands[len(operands)-1].number)
+			}
+		case "TL":
+			if len(operands) >= 1 {
+				w.leading = operands[len(operands)-1].number
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				ty := operands[len(operands)-1].number
+				w.y += ty
+				if token.text == "TD" {
+					w.leading = -ty
+				}
+				if ty == 0 && w.Len() > 0 && operands[len(operands)-2].number > 0 {
+					w.WriteByte(' ')
+				}
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				w.y = operands[len(operands)-1].number
+			}
+		case "T*":
+			w.y -= w.leading
+		case "Tj", "'", "\"":
+			if token.text != "Tj" {
+				w.y -= w.leading
+			}
+			if len(operands) >= 1 {
+				w.show(cmap.decode(operands[len(operands)-1].bytes))
+			}
+		case "TJ":
+			for _, part := range operands {
+				switch {
+				case part.kind == pdfString:
+					w.show(cmap.decode(part.bytes))
+				case part.kind == pdfNumber && part.number < -200:
+					w.WriteByte(' ') // a gap wide enough to be a space
+				}
+			}
+		case "BT":
+			w.y = 0
+		}
+		operands = operands[:0]
+	}
+}
+
+// show writes text at the cursor, breaking the line first if the cursor moved
+// to another line, and leaving a blank line if it skipped more than one
+func (w *pdfTextWriter) show(text string) {
+	if text == "" {
+		return
+	}
+	if w.Len() > 0 && w.y != w.lineY {
+		gap := math.Abs(w.lineY - w.y)
+		w.WriteByte('\n')
+		if size := math.Max(w.size, 1); gap > 1.8*size && gap < 100*size {
+			w.WriteByte('\n')
+		}
+	}
+	w.lineY = w.y
+	w.WriteString(text)
+}
+
+// PDF content tokens
+const (
+	pdfOperator = iota
+	pdfNumber
+	pdfString
+	pdfOther // names, arrays delimiters and dictionaries, which text operators don't use
+)
+
+// pdfToken is a token of a content stream
+type pdfToken struct {
+	kind   int
+	text   string  // the operator
+	number float64 // the value of a number
+	bytes  []byte  // the codes of a string
+}
+
+// pdfLexer splits a content stream into tokens
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+// next returns the next token, false at the end of the stream
+func (l *pdfLexer) next() (pdfToken, bool) {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch {
+		case isPDFSpace(c):
+			l.pos++
+		case c == '%':
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+		case c == '(':
+			return pdfToken{kind: pdfString, bytes: l.literal()}, true
+		case c == '<' && l.pos+1 < len(l.data) && l.data[l.pos+1] == '<':
+			l.pos += 2
+			return pdfToken{kind: pdfOther}, true
+		case c == '<':
+			end := bytes.IndexByte(l.data[l.pos:], '>')
+			if end < 0 {
+				l.pos = len(l.data)
+				return pdfToken{}, false
+			}
+			digits := l.data[l.pos+1 : l.pos+end]
+			l.pos += end + 1
+			return pdfToken{kind: pdfString, bytes: unhexBytes(digits)}, true
+		case c == '[' || c == ']' || c == '>' || c == '{' || c == '}' || c == ')':
+			l.pos++
+			return pdfToken{kind: pdfOther}, true
+		case c == '/':
+			l.word()
+			return pdfToken{kind: pdfOther}, true
+		default:
+			word := l.word()
+			if word == "" {
+				l.pos++
+				continue
+			}
+			if number, err := strconv.ParseFloat(word, 64); err == nil {
+				return pdfToken{kind: pdfNumber, number: number}, true
+			}
+			if word == "BI" {
+				l.skipInlineImage()
+				continue
+			}
+			return pdfToken{kind: pdfOperator, text: word}, true
+		}
+	}
+	return pdfToken{}, false
+}
+
+// word reads a run of regular characters
+func (l *pdfLexer) word() string {
+	start := l.pos
+	if l.pos < len(l.data) && l.data[l.pos] == '/' {
+		l.pos++
+	}
+	for l.pos < len(l.data) && !isPDFSpace(l.data[l.pos]) && !strings.ContainsRune("()<>[]{}/%", rune(l.data[l.pos])) {
+		l.pos++
+	}
+	return string(l.data[start:l.pos])
+}
+
+// literal reads a literal string, resolving its escapes
+func (l *pdfLexer) literal() []byte {
+	var out []byte
+	depth := 0
+	for l.pos++; l.pos < len(l.data); l.pos++ {
+		c := l.data[l.pos]
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				l.pos++
+				return out
+			}
+			depth--
+		case '\\':
+			l.pos++
+			if l.pos >= len(l.data) {
+				return out
+			}
+			c = l.data[l.pos]
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				continue // a line continuation
+			default:
+				if c >= '0' && c <= '7' {
+					value := 0
+					for n := 0; n < 3 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; n++ {
+						value = value*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					l.pos--
+					c = byte(value)
+				}
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// skipInlineImage skips the data of an inline image up to its EI operator
+func (l *pdfLexer) skipInlineImage() {
+	if end := bytes.Index(l.data[l.pos:], []byte("EI")); end >= 0 {
+		l.pos += end + 2
+		return
+	}
+	l.pos = len(l.data)
+}
+
+// isPDFSpace reports whether c is PDF whitespace
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == '\f' || c == 0
+}
+
+// unhexBytes decodes the digits of a hex string, ignoring whitespace; an odd
+// last digit is followed by 0
+func unhexBytes(digits []byte) []byte {
+	var clean []byte
+	for _, c := range digits {
+		if !isPDFSpace(c) {
+			clean = append(clean, c)
+		}
+	}
+	if len(clean)%2 == 1 {
+		clean = append(clean, '0')
+	}
+	out := make([]byte, 0, len(clean)/2)
+	for i := 0; i+1 < len(clean); i += 2 {
+		value, err := strconv.ParseUint(string(clean[i:i+2]), 16, 8)
+		if err != nil {
+			return out
+		}
+		out = append(out, byte(value))
+	}
+	return out
+}
+
+// hexBytes returns bytes as hex digits
+func hexBytes(codes []byte) string {
+	const digits = "0123456789ABCDEF"
+	out := make([]byte, 0, 2*len(codes))
+	for _, c := range codes {
+		out = append(out, digits[c>>4], digits[c&0xF])
+	}
+	return string(out)
+}
+
+// utf16Hex decodes the UTF-16BE text a CMap maps a code to
+func utf16Hex(digits string) string {
+	codes := unhexBytes([]byte(digits))
+	units := make([]uint16, 0, len(codes)/2)
+	for i := 0; i+1 < len(codes); i += 2 {
+		units = append(units, uint16(codes[i])<<8|uint16(codes[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,204 @@
+// internal/adapters/scraper/plugin_scraper.go
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// DefaultPluginTimeout bounds a plugin run without a timeout of its own
+const DefaultPluginTimeout = 2 * time.Minute
+
+// maxPluginStderr bounds how much of the error output of a failed plugin
+// command is reported
+const maxPluginStderr = 2 << 10
+
+// Plugin is an external scraper: a command run for every scrape, or an HTTP
+// endpoint, speaking the plugin protocol, see PluginScraper
+type Plugin struct {
+	Name     string
+	Command  []string      // executable and arguments, run without a shell
+	Endpoint string        // URL the request is POSTed to, used if Command is empty
+	Timeout  time.Duration // longest run of a scrape, DefaultPluginTimeout if 0
+}
+
+// pluginRequest is sent to a plugin: as the standard input of its command,
+// or as the body of the POST to its endpoint
+type pluginRequest struct {
+	URL string `json:"url"`
+}
+
+// pluginResponse is returned by a plugin: on the standard output of its
+// command, or as the body of a 2xx response of its endpoint. Jobs use the
+// JSON form of domain.Job; jobs without an ID get their URL, else a hash of
+// their title and location. A plugin fails a scrape by exiting non-zero,
+// answering with a non-2xx status, or setting Error.
+type pluginResponse struct {
+	CompanyName string       `json:"company_name,omitempty"`
+	Jobs        []domain.Job `json:"jobs"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// PluginScraper implements the BoardScraper interface with plugins, letting
+// users write the scrapers of hard sites in any language. Each source
+// configured with a plugin is scraped by it: the plugin gets the source URL
+// and returns its jobs as JSON, which is the raw content of the collection.
+type PluginScraper struct {
+	client  *http.Client
+	sources map[string]Plugin
+}
+
+// NewPluginScraper creates a scraper running the plugins of the given
+// sources, keyed by source URL. client sends the requests of endpoint plugins.
+func NewPluginScraper(client *http.Client, sources map[string]Plugin) *PluginScraper {
+	return &PluginScraper{
+		client:  client,
+		sources: sources,
+	}
+}
+
+// Matches reports whether url is a source scraped by a plugin
+func (s *PluginScraper) Matches(url string) bool {
+	_, ok := s.sources[url]
+	return ok
+}
+
+// Scrape runs the plugin of a source
+func (s *PluginScraper) Scrape(ctx context.Context, sourceURL string) (domain.JobCollection, error) {
+	result := domain.JobCollection{
+		SourceURL: sourceURL,
+		ScrapedAt: time.Now(),
+	}
+	plugin, ok := s.sources[sourceURL]
+	if !ok {
+		return result, fmt.Errorf("no plugin configured for %s", sourceURL)
+	}
+	timeout := plugin.Timeout
+	if timeout <= 0 {
+		timeout = DefaultPluginTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request, err := json.Marshal(pluginRequest{URL: sourceURL})
+	if err != nil {
+		return result, fmt.Errorf("failed to encode plugin request: %w", err)
+	}
+	log.Printf("Scraping %s with plugin %s", sourceURL, plugin.Name)
+	var raw []byte
+	if len(plugin.Command) > 0 {
+		raw, err = runPluginCommand(ctx, plugin.Command, request)
+	} else {
+		raw, err = s.callPluginEndpoint(ctx, plugin.Endpoint, request)
+	}
+	if err != nil {
+		return result, fmt.Errorf("plugin %s failed: %w", plugin.Name, err)
+	}
+
+	response, err := decodePluginResponse(raw)
+	if err != nil {
+		return result, fmt.Errorf("plugin %s: %w", plugin.Name, err)
+	}
+	result.RawContent = string(raw)
+	result.CompanyName = response.CompanyName
+	if result.CompanyName == "" {
+		result.CompanyName = extractCompanyName(sourceURL)
+	}
+	result.Jobs = pluginJobs(response.Jobs, result.ScrapedAt)
+	log.Printf("Plugin %s found %d jobs at %s", plugin.Name, len(result.Jobs), sourceURL)
+	return result, nil
+}
+
+// ParseJobs extracts the jobs from an archived plugin response
+func (s *PluginScraper) ParseJobs(raw, sourceURL string) ([]domain.Job, error) {
+	response, err := decodePluginResponse([]byte(raw))
+	if err != nil {
+		return nil, err
+	}
+	return pluginJobs(response.Jobs, time.Now()), nil
+}
+
+// runPluginCommand runs the command of a plugin with request on its standard
+// input and returns its standard output
+func runPluginCommand(ctx context.Context, command []string, request []byte) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdin = bytes.NewReader(request)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		message := strings.TrimSpace(stderr.String())
+		if len(message) > maxPluginStderr {
+			message = message[len(message)-maxPluginStderr:]
+		}
+		if message != "" {
+			return nil, fmt.Errorf("%w: %s", err, message)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+// callPluginEndpoint POSTs request to the endpoint of a plugin and returns
+// the body of a 2xx response
+func (s *PluginScraper) callPluginEndpoint(ctx context.Context, endpoint string, request []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(request))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return fetchBoard(s.client, req)
+}
+
+// decodePluginResponse decodes a plugin response, returning the error the
+// plugin reported if any
+func decodePluginResponse(raw []byte) (pluginResponse, error) {
+	var response pluginResponse
+	if err := json.Unmarshal(raw, &response); err != nil {
+		return response, fmt.Errorf("failed to parse plugin response: %w", err)
+	}
+	if response.Error != "" {
+		return response, errors.New(response.Error)
+	}
+	return response, nil
+}
+
+// pluginJobs returns the titled jobs of a plugin response with their IDs
+// filled in, dropping duplicates
+func pluginJobs(listed []domain.Job, scrapedAt time.Time) []domain.Job {
+	jobs := make([]domain.Job, 0, len(listed))
+	seen := make(map[string]bool)
+	for _, job := range listed {
+		job.Title = strings.TrimSpace(job.Title)
+		if job.Title == "" {
+			continue
+		}
+		job.ID = feedJobID(job)
+		if seen[job.ID] {
+			continue
+		}
+		seen[job.ID] = true
+		if job.ScrapedAt.IsZero() {
+			job.ScrapedAt = scrapedAt
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs
+}
+
+var (
+	_ BoardScraper = (*PluginScraper)(nil) // Ensure interface compliance
+)
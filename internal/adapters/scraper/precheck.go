@@ -0,0 +1,102 @@
+// internal/adapters/scraper/precheck.go
+package scraper
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// Precheck methods
+const (
+	PrecheckHead = "head" // fingerprint the ETag, Last-Modified and Content-Length headers
+	PrecheckGet  = "get"  // fingerprint a hash of the response body
+)
+
+// maxPrecheckBody bounds how much of a page is hashed by GET prechecks
+const maxPrecheckBody = 10 << 20
+
+// PrecheckSource configures the precheck of a source URL
+type PrecheckSource struct {
+	Method string // PrecheckHead or PrecheckGet
+	URL    string // page or API to poll, the source URL if empty
+}
+
+// HTTPPrecheck implements the ChangeDetector interface with plain HTTP
+// requests, which are far cheaper than rendering the page in a browser
+type HTTPPrecheck struct {
+	client  *http.Client
+	sources map[string]PrecheckSource
+}
+
+// NewHTTPPrecheck creates a change detector for the given sources, keyed by
+// source URL. Other URLs have no precheck.
+func NewHTTPPrecheck(client *http.Client, sources map[string]PrecheckSource) *HTTPPrecheck {
+	return &HTTPPrecheck{
+		client:  client,
+		sources: sources,
+	}
+}
+
+// Fingerprint polls the precheck URL of a source
+func (p *HTTPPrecheck) Fingerprint(ctx context.Context, url string) (string, error) {
+	source, ok := p.sources[url]
+	if !ok {
+		return "", nil
+	}
+	target := source.URL
+	if target == "" {
+		target = url
+	}
+
+	method := http.MethodGet
+	if source.Method == PrecheckHead {
+		method = http.MethodHead
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create precheck request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to precheck %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("precheck of %s returned status %d", target, resp.StatusCode)
+	}
+
+	if source.Method == PrecheckHead {
+		return headFingerprint(resp.Header), nil
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, io.LimitReader(resp.Body, maxPrecheckBody)); err != nil {
+		return "", fmt.Errorf("failed to read precheck response: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// headFingerprint combines the validators of a response. It is empty if the
+// server sends none, so the source is scraped every time.
+func headFingerprint(header http.Header) string {
+	var parts []string
+	for _, name := range []string{"ETag", "Last-Modified", "Content-Length"} {
+		if value := header.Get(name); value != "" {
+			parts = append(parts, name+"="+value)
+		}
+	}
+	return strings.Join(parts, ";")
+}
+
+var (
+	_ ports.ChangeDetector = (*HTTPPrecheck)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,67 @@
+// internal/adapters/scraper/proxy_scraper.go
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync/atomic"
+
+	"github.com/fuzztobread/job-scheduler/internal/adapters/httpclient"
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// ProxyScraper pins the proxy of each scrape, so every request of a scrape,
+// the browser's included, leaves through the same proxy: the proxy of the
+// source URL if it has one, else the next proxy of a rotation. The requests
+// must be sent by a client from httpclient.New.
+type ProxyScraper struct {
+	next     ports.Scraper
+	proxies  map[string]*url.URL // proxy by source URL
+	rotation []*url.URL          // proxies taking turns for other URLs
+	turn     atomic.Uint64
+}
+
+// NewProxyScraper creates a scraper pinning the proxies of next's scrapes
+func NewProxyScraper(next ports.Scraper, proxies map[string]*url.URL, rotation []*url.URL) *ProxyScraper {
+	return &ProxyScraper{
+		next:     next,
+		proxies:  proxies,
+		rotation: rotation,
+	}
+}
+
+// Scrape scrapes a URL through its proxy
+func (s *ProxyScraper) Scrape(ctx context.Context, url string) (domain.JobCollection, error) {
+	if proxy := s.proxy(url); proxy != nil {
+		ctx = httpclient.WithProxy(ctx, proxy)
+	}
+	return s.next.Scrape(ctx, url)
+}
+
+// ParseJobs parses a page with the parser of the scraper
+func (s *ProxyScraper) ParseJobs(html, sourceURL string) ([]domain.Job, error) {
+	parser, ok := s.next.(ports.JobParser)
+	if !ok {
+		return nil, fmt.Errorf("no parser for %s", sourceURL)
+	}
+	return parser.ParseJobs(html, sourceURL)
+}
+
+// proxy returns the proxy of a scrape of url, nil to leave the choice to the
+// client
+func (s *ProxyScraper) proxy(url string) *url.URL {
+	if proxy, ok := s.proxies[url]; ok {
+		return proxy
+	}
+	if len(s.rotation) == 0 {
+		return nil
+	}
+	return s.rotation[(s.turn.Add(1)-1)%uint64(len(s.rotation))]
+}
+
+var (
+	_ ports.Scraper   = (*ProxyScraper)(nil) // Ensure interface compliance
+	_ ports.JobParser = (*ProxyScraper)(nil)
+)
@@ -0,0 +1,225 @@
+// internal/adapters/scraper/recruitee_scraper.go
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// recruiteeDomain hosts the career sites of Recruitee, one subdomain per
+// company, e.g. https://acme.recruitee.com
+const recruiteeDomain = ".recruitee.com"
+
+// recruiteeAPI is the public offers API of a career site; {company} is
+// replaced by the subdomain
+const recruiteeAPI = "https://{company}.recruitee.com/api/offers/"
+
+// recruiteeTimeLayout is the format of publication dates of the offers API
+const recruiteeTimeLayout = "2006-01-02 15:04:05 MST"
+
+// recruiteePeriods maps Recruitee salary periods to salary periods
+var recruiteePeriods = map[string]domain.SalaryPeriod{
+	"year":  domain.SalaryPeriodYear,
+	"month": domain.SalaryPeriodMonth,
+	"week":  domain.SalaryPeriodWeek,
+	"day":   domain.SalaryPeriodDay,
+	"hour":  domain.SalaryPeriodHour,
+}
+
+// recruiteeReserved are subdomains of recruitee.com that are not career sites
+var recruiteeReserved = map[string]bool{"www": true, "app": true, "api": true, "blog": true, "support": true}
+
+// RecruiteeScraper implements the BoardScraper interface for career sites
+// hosted by Recruitee, reading the published offers from the offers API
+type RecruiteeScraper struct {
+	client *http.Client
+	api    string
+}
+
+// recruiteeOffers is the response of the offers API
+type recruiteeOffers struct {
+	Offers []recruiteeOffer `json:"offers"`
+}
+
+// recruiteeOffer is an offer of the offers API
+type recruiteeOffer struct {
+	ID              json.Number `json:"id"`
+	Slug            string      `json:"slug"`
+	Title           string      `json:"title"`
+	Description     string      `json:"description"`
+	Requirements    string      `json:"requirements"`
+	Location        string      `json:"location"`
+	Department      string      `json:"department"`
+	CompanyName     string      `json:"company_name"`
+	CareersURL      string      `json:"careers_url"`
+	CareersApplyURL string      `json:"careers_apply_url"`
+	PublishedAt     string      `json:"published_at"`
+	Status          string      `json:"status"`
+	EmploymentType  string      `json:"employment_type_code"`
+	Experience      string      `json:"experience_code"`
+	Remote          bool        `json:"remote"`
+	Hybrid          bool        `json:"hybrid"`
+	Salary          *struct {
+		Min      recruiteeAmount `json:"min"`
+		Max      recruiteeAmount `json:"max"`
+		Currency string          `json:"currency"`
+		Period   string          `json:"period"`
+	} `json:"salary"`
+}
+
+// recruiteeAmount is a salary bound, which the API returns as a number or
+// as a string
+type recruiteeAmount float64
+
+// UnmarshalJSON accepts numbers, numeric strings and null
+func (a *recruiteeAmount) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	value, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return fmt.Errorf("invalid salary amount %s", data)
+	}
+	*a = recruiteeAmount(value)
+	return nil
+}
+
+// NewRecruiteeScraper creates a scraper querying the Recruitee API with client
+func NewRecruiteeScraper(client *http.Client) *RecruiteeScraper {
+	return &RecruiteeScraper{client: client, api: recruiteeAPI}
+}
+
+// Matches reports whether url is a career site hosted by Recruitee
+func (s *RecruiteeScraper) Matches(url string) bool {
+	return recruiteeCompany(url) != ""
+}
+
+// Scrape lists the published offers of the career site
+func (s *RecruiteeScraper) Scrape(ctx context.Context, sourceURL string) (domain.JobCollection, error) {
+	company := recruiteeCompany(sourceURL)
+	result := domain.JobCollection{
+		CompanyName: companyFromSlug(company),
+		SourceURL:   sourceURL,
+		ScrapedAt:   time.Now(),
+	}
+	log.Printf("Querying the Recruitee career site %s", company)
+
+	body, err := getBoard(ctx, s.client, strings.ReplaceAll(s.api, "{company}", company))
+	if err != nil {
+		return result, fmt.Errorf("failed to list Recruitee offers: %w", err)
+	}
+	result.RawContent = string(body)
+
+	var offers recruiteeOffers
+	if err := decodeBoard(body, &offers); err != nil {
+		return result, err
+	}
+	if len(offers.Offers) > 0 && offers.Offers[0].CompanyName != "" {
+		result.CompanyName = offers.Offers[0].CompanyName
+	}
+
+	result.Jobs, err = s.ParseJobs(result.RawContent, sourceURL)
+	if err != nil {
+		return result, err
+	}
+	log.Printf("Found %d jobs on the Recruitee career site %s", len(result.Jobs), company)
+	return result, nil
+}
+
+// ParseJobs extracts the published offers from an offers API response
+func (s *RecruiteeScraper) ParseJobs(raw, sourceURL string) ([]domain.Job, error) {
+	var offers recruiteeOffers
+	if err := decodeBoard([]byte(raw), &offers); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.Job, 0, len(offers.Offers))
+	for _, offer := range offers.Offers {
+		if offer.Status != "" && offer.Status != "published" {
+			continue
+		}
+		jobs = append(jobs, offer.job())
+	}
+	return jobs, nil
+}
+
+// job converts an offer into a job
+func (o recruiteeOffer) job() domain.Job {
+	job := domain.Job{
+		ID:          o.ID.String(),
+		Title:       strings.TrimSpace(o.Title),
+		Description: strings.TrimSpace(htmlText(o.Description) + "\n\n" + htmlText(o.Requirements)),
+		Location:    o.Location,
+		Department:  o.Department,
+		URL:         o.CareersURL,
+		ApplyURL:    o.CareersApplyURL,
+		Salary:      o.salary(),
+		ScrapedAt:   time.Now(),
+	}
+	if job.ID == "" {
+		job.ID = o.Slug
+	}
+	if published, err := time.Parse(recruiteeTimeLayout, o.PublishedAt); err == nil {
+		job.PostedDate = published
+	}
+
+	metadata := make(map[string]string)
+	if o.EmploymentType != "" {
+		metadata["employment_type"] = o.EmploymentType
+	}
+	if o.Experience != "" {
+		metadata["experience_level"] = o.Experience
+	}
+	switch {
+	case o.Remote:
+		metadata["workplace"] = "Remote"
+	case o.Hybrid:
+		metadata["workplace"] = "Hybrid"
+	}
+	if len(metadata) > 0 {
+		job.Metadata = metadata
+	}
+	return job
+}
+
+// salary returns the salary of the offer, nil if it shows none
+func (o recruiteeOffer) salary() *domain.Salary {
+	if o.Salary == nil || o.Salary.Currency == "" || (o.Salary.Min <= 0 && o.Salary.Max <= 0) {
+		return nil
+	}
+	return &domain.Salary{
+		Min:      float64(o.Salary.Min),
+		Max:      float64(o.Salary.Max),
+		Currency: strings.ToUpper(o.Salary.Currency),
+		Period:   recruiteePeriods[strings.ToLower(o.Salary.Period)],
+	}
+}
+
+// recruiteeCompany returns the company subdomain of a career site URL on
+// Recruitee, or "" if the URL is elsewhere
+func recruiteeCompany(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	company, ok := strings.CutSuffix(strings.ToLower(u.Hostname()), recruiteeDomain)
+	if !ok || company == "" || strings.Contains(company, ".") || recruiteeReserved[company] {
+		return ""
+	}
+	return company
+}
+
+var (
+	_ BoardScraper = (*RecruiteeScraper)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,270 @@
+// internal/adapters/scraper/registry.go
+package scraper
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRegistryIndex bounds the size of a registry index and its signature
+const maxRegistryIndex = 5 << 20
+
+// RuleSource provides selector rules that may change while the scraper runs
+type RuleSource interface {
+	Rules() []SelectorRule
+}
+
+// registryIndex is the document served by a selector registry
+type registryIndex struct {
+	Version     string            `json:"version"`
+	PublishedAt time.Time         `json:"published_at"`
+	Profiles    []registryProfile `json:"profiles"`
+}
+
+// registryProfile is the selector rule of a commonly monitored site or ATS
+type registryProfile struct {
+	Name        string `json:"name"`
+	URL         string `json:"url,omitempty"`
+	Domain      string `json:"domain,omitempty"`
+	Container   string `json:"container"`
+	Title       string `json:"title"`
+	Location    string `json:"location,omitempty"`
+	Department  string `json:"department,omitempty"`
+	Description string `json:"description,omitempty"`
+	Link        string `json:"link,omitempty"`
+	ApplyLink   string `json:"apply_link,omitempty"`
+	IDAttr      string `json:"id_attr,omitempty"`
+}
+
+// registryCache is the last verified index as stored on disk, with its
+// signature so it is verified again when loaded
+type registryCache struct {
+	Index     []byte `json:"index"`
+	Signature []byte `json:"signature"`
+}
+
+// SelectorRegistry implements RuleSource with curated selector profiles from
+// a remote registry, so sites keep working when their markup changes without
+// every user patching their configuration. The registry is an HTTPS index in
+// JSON, such as a file of a Git repository served raw, with a detached
+// ed25519 signature of its exact bytes, base64 encoded, at the same URL plus
+// ".sig". Indexes that fail verification are ignored.
+//
+// Pinning to a version rejects every index of another version; a "{version}"
+// placeholder in the URL is replaced with the pin, e.g. to fetch a Git tag.
+// When following the latest version, indexes published before the current
+// one are rejected so a stale mirror can't roll profiles back.
+type SelectorRegistry struct {
+	client    *http.Client
+	url       string
+	key       ed25519.PublicKey
+	pin       string
+	cacheFile string
+	mu        sync.RWMutex
+	index     registryIndex
+	rules     []SelectorRule
+}
+
+// NewSelectorRegistry creates a registry fetching url with client and
+// verifying it with key. cacheFile keeps the last verified index; empty
+// disables the cache.
+func NewSelectorRegistry(client *http.Client, url string, key ed25519.PublicKey, pin, cacheFile string) *SelectorRegistry {
+	return &SelectorRegistry{
+		client:    client,
+		url:       strings.ReplaceAll(url, "{version}", pin),
+		key:       key,
+		pin:       pin,
+		cacheFile: cacheFile,
+	}
+}
+
+// Load reads the cached index, then fetches the latest one. It only fails if
+// neither yields an index. An outdated cache is worth more than no profiles.
+func (r *SelectorRegistry) Load(ctx context.Context) error {
+	cacheErr := r.loadCache()
+	if cacheErr != nil && !errors.Is(cacheErr, os.ErrNotExist) {
+		log.Printf("Ignoring cached selector registry: %v", cacheErr)
+	}
+	err := r.Refresh(ctx)
+	if err != nil && cacheErr == nil {
+		log.Printf("Failed to update selector registry, using cached version %s: %v", r.Version(), err)
+		return nil
+	}
+	return err
+}
+
+// Refresh fetches and verifies the index, replacing the profiles in use
+func (r *SelectorRegistry) Refresh(ctx context.Context) error {
+	data, err := r.fetch(ctx, r.url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch selector registry: %w", err)
+	}
+	encoded, err := r.fetch(ctx, r.url+".sig")
+	if err != nil {
+		return fmt.Errorf("failed to fetch selector registry signature: %w", err)
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return fmt.Errorf("malformed selector registry signature: %w", err)
+	}
+
+	index, err := r.verify(data, signature)
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	current := r.index
+	r.mu.RUnlock()
+	if index.Version == current.Version {
+		return nil
+	}
+	if r.pin == "" && index.PublishedAt.Before(current.PublishedAt) {
+		return fmt.Errorf("selector registry serves version %s published before the current %s", index.Version, current.Version)
+	}
+
+	r.use(index)
+	log.Printf("Using selector registry version %s with %d profiles", index.Version, len(r.Rules()))
+	if err := r.saveCache(data, signature); err != nil {
+		log.Printf("Failed to cache selector registry: %v", err)
+	}
+	return nil
+}
+
+// Rules returns the profiles of the index in use as selector rules
+func (r *SelectorRegistry) Rules() []SelectorRule {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.rules
+}
+
+// Version returns the version of the index in use, empty if there is none
+func (r *SelectorRegistry) Version() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.index.Version
+}
+
+// verify checks the signature and pin of an index and parses it
+func (r *SelectorRegistry) verify(data, signature []byte) (registryIndex, error) {
+	if !ed25519.Verify(r.key, data, signature) {
+		return registryIndex{}, errors.New("selector registry signature does not match SelectorRegistryKey")
+	}
+
+	var index registryIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return registryIndex{}, fmt.Errorf("failed to parse selector registry: %w", err)
+	}
+	if index.Version == "" {
+		return registryIndex{}, errors.New("selector registry has no version")
+	}
+	if r.pin != "" && index.Version != r.pin {
+		return registryIndex{}, fmt.Errorf("selector registry serves version %s, pinned to %s", index.Version, r.pin)
+	}
+	return index, nil
+}
+
+// use makes the valid profiles of an index the rules in use
+func (r *SelectorRegistry) use(index registryIndex) {
+	rules := make([]SelectorRule, 0, len(index.Profiles))
+	for _, profile := range index.Profiles {
+		rule := SelectorRule{
+			Name:        profile.Name,
+			URL:         profile.URL,
+			Domain:      profile.Domain,
+			Container:   profile.Container,
+			Title:       profile.Title,
+			Location:    profile.Location,
+			Department:  profile.Department,
+			Description: profile.Description,
+			Link:        profile.Link,
+			ApplyLink:   profile.ApplyLink,
+			IDAttr:      profile.IDAttr,
+		}
+		if err := rule.validate(); err != nil {
+			log.Printf("Skipping selector profile %s of registry version %s: %v", profile.Name, index.Version, err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.index = index
+	r.rules = rules
+}
+
+// fetch downloads a registry file
+func (r *SelectorRegistry) fetch(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxRegistryIndex))
+}
+
+// loadCache verifies and uses the cached index
+func (r *SelectorRegistry) loadCache() error {
+	if r.cacheFile == "" {
+		return os.ErrNotExist
+	}
+	data, err := os.ReadFile(r.cacheFile)
+	if err != nil {
+		return err
+	}
+
+	var cache registryCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return fmt.Errorf("failed to parse cache: %w", err)
+	}
+	index, err := r.verify(cache.Index, cache.Signature)
+	if err != nil {
+		return err
+	}
+	r.use(index)
+	return nil
+}
+
+// saveCache stores a verified index and its signature
+func (r *SelectorRegistry) saveCache(index, signature []byte) error {
+	if r.cacheFile == "" {
+		return nil
+	}
+	data, err := json.Marshal(registryCache{Index: index, Signature: signature})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.cacheFile), 0o755); err != nil {
+		return err
+	}
+	tmp := r.cacheFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.cacheFile)
+}
+
+var (
+	_ RuleSource = (*SelectorRegistry)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,142 @@
+// internal/adapters/scraper/retry_scraper.go
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-rod/rod"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// permanentNavigations are the reasons of failed navigations that repeating
+// won't fix
+var permanentNavigations = []string{
+	"ERR_NAME_NOT_RESOLVED",
+	"ERR_INVALID_URL",
+	"ERR_CERT_",
+	"ERR_BLOCKED_BY_",
+	"ERR_TOO_MANY_REDIRECTS",
+	"ERR_UNSAFE_",
+}
+
+// RetryPolicy configures how often and how long apart a failed scrape is
+// repeated
+type RetryPolicy struct {
+	MaxRetries int           // retries after the first attempt, 0 disables retries
+	Backoff    time.Duration // wait before the first retry, doubled for each further retry
+	MaxBackoff time.Duration // cap of the wait, none if 0
+}
+
+// RetryScraper repeats scrapes that failed transiently, e.g. on timeouts,
+// dropped connections or 5xx responses, with exponential backoff and jitter,
+// so a hiccup of a site doesn't fail its URL until the next scheduled run.
+// Permanent failures are returned immediately.
+type RetryScraper struct {
+	next   ports.Scraper
+	policy RetryPolicy
+}
+
+// NewRetryScraper creates a scraper repeating the failed scrapes of next
+func NewRetryScraper(next ports.Scraper, policy RetryPolicy) *RetryScraper {
+	return &RetryScraper{next: next, policy: policy}
+}
+
+// Scrape scrapes a URL, retrying transient failures
+func (s *RetryScraper) Scrape(ctx context.Context, url string) (domain.JobCollection, error) {
+	for attempt := 0; ; attempt++ {
+		result, err := s.next.Scrape(ctx, url)
+		if err == nil || attempt >= s.policy.MaxRetries || ctx.Err() != nil || !Retryable(err) {
+			return result, err
+		}
+
+		wait := s.backoff(attempt)
+		log.Printf("Scrape %d of %s failed, retrying in %s: %v", attempt+1, url, wait.Round(time.Millisecond), err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, fmt.Errorf("%w (retry canceled: %v)", err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// ParseJobs parses a page with the parser of the scraper
+func (s *RetryScraper) ParseJobs(html, sourceURL string) ([]domain.Job, error) {
+	parser, ok := s.next.(ports.JobParser)
+	if !ok {
+		return nil, fmt.Errorf("no parser for %s", sourceURL)
+	}
+	return parser.ParseJobs(html, sourceURL)
+}
+
+// backoff returns the wait before retry attempt+1: the doubled backoff,
+// capped, of which a random half is waited so retries of URLs failing
+// together spread out
+func (s *RetryScraper) backoff(attempt int) time.Duration {
+	wait := s.policy.Backoff << attempt
+	if wait <= 0 || (s.policy.MaxBackoff > 0 && wait > s.policy.MaxBackoff) {
+		wait = s.policy.MaxBackoff
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// Retryable reports whether a scrape failing with err may succeed when
+// repeated: timeouts, dropped connections, navigation failures other than
+// permanent ones, 429 and 5xx responses. Cancellations, 4xx responses and
+// parse failures are permanent.
+func Retryable(err error) bool {
+	var status *StatusError
+	if errors.As(err, &status) {
+		return status.StatusCode == http.StatusTooManyRequests || status.StatusCode >= 500
+	}
+
+	var navigation *rod.NavigationError
+	if errors.As(err, &navigation) {
+		for _, reason := range permanentNavigations {
+			if strings.Contains(navigation.Reason, reason) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return false
+	case errors.Is(err, context.DeadlineExceeded):
+		return true // the timeout of an attempt, the context of the scrape is still live
+	}
+
+	var dns *net.DNSError
+	if errors.As(err, &dns) {
+		return dns.IsTemporary || dns.IsTimeout
+	}
+	for _, dropped := range []error{syscall.ECONNRESET, syscall.ECONNREFUSED, syscall.ECONNABORTED, io.EOF, io.ErrUnexpectedEOF} {
+		if errors.Is(err, dropped) {
+			return true
+		}
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+var (
+	_ ports.Scraper   = (*RetryScraper)(nil) // Ensure interface compliance
+	_ ports.JobParser = (*RetryScraper)(nil)
+)
@@ -0,0 +1,237 @@
+// internal/adapters/scraper/router.go
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// BoardScraper scrapes the career pages hosted by one job board through its
+// API or plain pages rather than a browser. The raw content of its
+// collections is the board's response, which ParseJobs turns back into jobs.
+type BoardScraper interface {
+	ports.Scraper
+	ports.JobParser
+	// Matches reports whether url is a career page hosted by the board
+	Matches(url string) bool
+}
+
+// BoardDetector is implemented by board scrapers that also recognize their
+// career pages on custom domains, from the page rendered by the fallback, or
+// their own archived responses
+type BoardDetector interface {
+	// Detects reports whether a page is a career page hosted by the board
+	Detects(page string) bool
+}
+
+// BoardLocator is implemented by board scrapers that find their boards
+// embedded in career pages of other sites, e.g. through a job board widget
+type BoardLocator interface {
+	// Locate returns the URL of the board a page embeds, "" if it embeds none
+	Locate(page string) string
+}
+
+// RouterScraper scrapes each URL with the first board scraper matching it and
+// every other URL with a fallback scraper, usually the browser. When a board
+// detects the page the fallback rendered, or locates its board embedded in
+// it, the URL is scraped by the board from then on. Users can thus add any
+// career page and get the best strategy for it.
+type RouterScraper struct {
+	fallback ports.Scraper
+	boards   []BoardScraper
+	detected map[string]detection // boards detected by URL
+	mu       sync.Mutex
+}
+
+// detection is the board detected on a career page
+type detection struct {
+	board  BoardScraper
+	target string // URL the board scrapes, the career page or the board it embeds
+}
+
+// NewRouterScraper creates a scraper routing URLs to the boards hosting them
+func NewRouterScraper(fallback ports.Scraper, boards ...BoardScraper) *RouterScraper {
+	return &RouterScraper{
+		fallback: fallback,
+		boards:   boards,
+		detected: make(map[string]detection),
+	}
+}
+
+// Scrape scrapes a URL with the scraper of its board
+func (r *RouterScraper) Scrape(ctx context.Context, url string) (domain.JobCollection, error) {
+	if board, target := r.board(url); board != nil {
+		return scrapeBoard(ctx, board, target, url)
+	}
+
+	collection, err := r.fallback.Scrape(ctx, url)
+	if err != nil {
+		return collection, err
+	}
+	board, target := r.detect(collection.RawContent, url)
+	if board == nil {
+		return collection, nil
+	}
+	detected, err := scrapeBoard(ctx, board, target, url)
+	if err != nil {
+		log.Printf("Failed to scrape %s with the job board detected on it, keeping the rendered page: %v", url, err)
+		return collection, nil
+	}
+	if target != url {
+		log.Printf("Located the job board %s embedded in %s, scraping it through the board from now on", target, url)
+	} else {
+		log.Printf("Detected a known job board at %s, scraping it through the board from now on", url)
+	}
+	r.mu.Lock()
+	r.detected[url] = detection{board: board, target: target}
+	r.mu.Unlock()
+	return detected, nil
+}
+
+// scrapeBoard scrapes target with board, as the collection of url
+func scrapeBoard(ctx context.Context, board BoardScraper, target, url string) (domain.JobCollection, error) {
+	collection, err := board.Scrape(ctx, target)
+	collection.SourceURL = url
+	return collection, err
+}
+
+// ParseJobs parses an archived page or API response with the parser of the
+// board of its source URL
+func (r *RouterScraper) ParseJobs(html, sourceURL string) ([]domain.Job, error) {
+	if board, target := r.board(sourceURL); board != nil {
+		return board.ParseJobs(html, target)
+	}
+	if board, target := r.detect(html, sourceURL); board != nil {
+		return board.ParseJobs(html, target)
+	}
+	parser, ok := r.fallback.(ports.JobParser)
+	if !ok {
+		return nil, fmt.Errorf("no parser for %s", sourceURL)
+	}
+	return parser.ParseJobs(html, sourceURL)
+}
+
+// board returns the board scraper of a URL and the URL it scrapes, nil for
+// other career pages
+func (r *RouterScraper) board(url string) (BoardScraper, string) {
+	for _, board := range r.boards {
+		if board.Matches(url) {
+			return board, url
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	detected := r.detected[url]
+	return detected.board, detected.target
+}
+
+// detect returns the first board scraper detecting the page of url or
+// locating its board in it, and the URL the board scrapes. It returns nil if
+// no board does.
+func (r *RouterScraper) detect(page, url string) (BoardScraper, string) {
+	if page == "" {
+		return nil, ""
+	}
+	for _, board := range r.boards {
+		if locator, ok := board.(BoardLocator); ok {
+			if target := locator.Locate(page); target != "" {
+				return board, target
+			}
+		}
+		if detector, ok := board.(BoardDetector); ok && detector.Detects(page) {
+			return board, url
+		}
+	}
+	return nil, ""
+}
+
+// maxBoardResponse bounds how much of a job board API response is read
+const maxBoardResponse = 20 << 20
+
+// fetchBoard sends a request of a board scraper, accepting JSON unless the
+// request asks for something else, and returns the body of a 2xx response
+func fetchBoard(client *http.Client, req *http.Request) ([]byte, error) {
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &StatusError{Target: req.URL.Host, StatusCode: resp.StatusCode}
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBoardResponse))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response of %s: %w", req.URL.Host, err)
+	}
+	return body, nil
+}
+
+// getBoard sends a GET request of a board scraper
+func getBoard(ctx context.Context, client *http.Client, endpoint string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	return fetchBoard(client, req)
+}
+
+// decodeBoard unmarshals an API response of a board scraper
+func decodeBoard(data []byte, v interface{}) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse job board response: %w", err)
+	}
+	return nil
+}
+
+// boardSlug returns the first path segment of a career page URL on host,
+// which job boards use to name a company's board, or "" if the URL is on
+// another host
+func boardSlug(rawURL, host string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || !strings.EqualFold(u.Hostname(), host) {
+		return ""
+	}
+	slug, _, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	return slug
+}
+
+// companyFromSlug turns a board name such as "acme-labs" into "Acme Labs",
+// for boards whose API doesn't return the company name
+func companyFromSlug(slug string) string {
+	words := strings.FieldsFunc(slug, func(r rune) bool { return r == '-' || r == '_' || r == '.' })
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// htmlText returns the text of an HTML fragment returned by a board API, with
+// its whitespace collapsed
+func htmlText(fragment string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(fragment))
+	if err != nil {
+		return strings.TrimSpace(fragment)
+	}
+	return strings.Join(strings.Fields(doc.Text()), " ")
+}
+
+var (
+	_ ports.Scraper   = (*RouterScraper)(nil) // Ensure interface compliance
+	_ ports.JobParser = (*RouterScraper)(nil)
+)
@@ -0,0 +1,29 @@
+// internal/adapters/scraper/screenshot.go
+package scraper
+
+import (
+	"fmt"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// WithScreenshots makes the scraper take a full-page screenshot of each
+// rendered page into JobCollection.Screenshot, to see what the page showed
+// when parsing found fewer jobs than expected
+func WithScreenshots() GoRodOption {
+	return func(s *GoRodScraper) {
+		s.screenshots = true
+	}
+}
+
+// screenshot captures the whole of page as a PNG
+func screenshot(page *rod.Page) ([]byte, error) {
+	png, err := page.Screenshot(true, &proto.PageCaptureScreenshot{
+		Format: proto.PageCaptureScreenshotFormatPng,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to take screenshot: %w", err)
+	}
+	return png, nil
+}
@@ -0,0 +1,84 @@
+// internal/adapters/scraper/scroll.go
+package scraper
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+// scrollPoll is how often a scrolled page is checked for new content
+const scrollPoll = 250 * time.Millisecond
+
+// scrollScript scrolls the page and its tallest scrollable element, which
+// some career pages list their jobs in, to the bottom
+const scrollScript = `() => {
+	window.scrollTo(0, document.documentElement.scrollHeight);
+	let tallest = null;
+	for (const el of document.querySelectorAll("main, section, div, ul")) {
+		if (el.scrollHeight > el.clientHeight + 50 && ["auto", "scroll"].includes(getComputedStyle(el).overflowY)) {
+			if (!tallest || el.scrollHeight > tallest.scrollHeight) {
+				tallest = el;
+			}
+		}
+	}
+	if (tallest) {
+		tallest.scrollTop = tallest.scrollHeight;
+	}
+}`
+
+// sizeScript measures how much content a page shows
+const sizeScript = `() => document.documentElement.scrollHeight + ":" + document.getElementsByTagName("*").length`
+
+// WithScrolling makes the scraper scroll to the bottom of each page up to
+// maxScrolls times before reading it, so lazily loaded listings are
+// included. Scrolling stops early once a scroll loads nothing for idle.
+func WithScrolling(maxScrolls int, idle time.Duration) GoRodOption {
+	return func(s *GoRodScraper) {
+		s.maxScrolls = maxScrolls
+		s.scrollIdle = idle
+	}
+}
+
+// scrollToEnd scrolls page until it stops growing or maxScrolls is reached
+func (s *GoRodScraper) scrollToEnd(page *rod.Page) error {
+	size, err := pageSize(page)
+	if err != nil {
+		return err
+	}
+	for scroll := 1; scroll <= s.maxScrolls; scroll++ {
+		if _, err := page.Eval(scrollScript); err != nil {
+			return fmt.Errorf("failed to scroll: %w", err)
+		}
+
+		grown := false
+		for deadline := time.Now().Add(s.scrollIdle); !grown && time.Now().Before(deadline); {
+			time.Sleep(scrollPoll)
+			current, err := pageSize(page)
+			if err != nil {
+				return err
+			}
+			grown = current != size
+			size = current
+		}
+		if !grown {
+			if scroll > 1 {
+				log.Printf("Page stopped loading content after %d scrolls", scroll-1)
+			}
+			return nil
+		}
+	}
+	log.Printf("Page still loading content after %d scrolls, reading what is loaded", s.maxScrolls)
+	return nil
+}
+
+// pageSize returns a fingerprint of the height and element count of a page
+func pageSize(page *rod.Page) (string, error) {
+	size, err := page.Eval(sizeScript)
+	if err != nil {
+		return "", fmt.Errorf("failed to measure page: %w", err)
+	}
+	return size.Value.Str(), nil
+}
@@ -0,0 +1,324 @@
+// internal/adapters/scraper/search_scraper.go
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/normalize"
+)
+
+// DefaultSearchMaxPages bounds the result pages read per scrape of a search
+// without a limit of its own
+const DefaultSearchMaxPages = 3
+
+// Job boards whose searches are scraped, see SearchScraper
+const (
+	searchBoardIndeed   = "indeed"
+	searchBoardLinkedIn = "linkedin"
+)
+
+// Result pages of the boards: Indeed pages by 10 results, the guest API of
+// LinkedIn by 25
+const (
+	indeedPageSize   = 10
+	linkedInPageSize = 25
+	linkedInGuestAPI = "https://www.linkedin.com/jobs-guest/jobs/api/seeMoreJobPostings/search"
+)
+
+// searchPageSeparator separates the result pages in the raw content of a
+// search collection
+const searchPageSeparator = "\n<!-- search page -->\n"
+
+// SearchScraper implements the BoardScraper interface for saved searches on
+// job boards, e.g. https://www.indeed.com/jobs?q=golang+remote or
+// https://www.linkedin.com/jobs/search?keywords=golang&location=Remote. The
+// results of a search make its collection, so new matches are notified like
+// new jobs of a career page. Results are read over plain HTTP, following
+// the result pages up to a limit per search.
+type SearchScraper struct {
+	client   *http.Client
+	maxPages map[string]int // result pages read per search URL
+}
+
+// NewSearchScraper creates a scraper running job board searches with client,
+// reading up to maxPages result pages of each search URL, DefaultSearchMaxPages
+// for the others
+func NewSearchScraper(client *http.Client, maxPages map[string]int) *SearchScraper {
+	return &SearchScraper{
+		client:   client,
+		maxPages: maxPages,
+	}
+}
+
+// Matches reports whether url is a search on a supported job board
+func (s *SearchScraper) Matches(url string) bool {
+	return searchBoard(url) != ""
+}
+
+// Scrape reads the result pages of a search
+func (s *SearchScraper) Scrape(ctx context.Context, sourceURL string) (domain.JobCollection, error) {
+	result := domain.JobCollection{
+		SourceURL: sourceURL,
+		ScrapedAt: time.Now(),
+	}
+	board := searchBoard(sourceURL)
+	if board == "" {
+		return result, fmt.Errorf("%s is not a job board search", sourceURL)
+	}
+	result.CompanyName = searchName(board, sourceURL)
+	maxPages := s.maxPages[sourceURL]
+	if maxPages <= 0 {
+		maxPages = DefaultSearchMaxPages
+	}
+
+	var pages []string
+	seen := make(map[string]bool)
+	for page := 0; page < maxPages; page++ {
+		pageURL, err := searchPageURL(board, sourceURL, page)
+		if err != nil {
+			return result, err
+		}
+		body, err := s.get(ctx, pageURL)
+		if err != nil {
+			if page > 0 {
+				log.Printf("Stopping the %s search %s at page %d: %v", board, sourceURL, page+1, err)
+				break
+			}
+			return result, fmt.Errorf("failed to run %s search: %w", board, err)
+		}
+		jobs := parseSearchPage(board, string(body), sourceURL)
+		if err := detectBlock(string(body), sourceURL, len(jobs)); err != nil {
+			if page > 0 {
+				break
+			}
+			return result, err
+		}
+		pages = append(pages, string(body))
+		added := 0
+		for _, job := range jobs {
+			if !seen[job.ID] {
+				seen[job.ID] = true
+				added++
+			}
+		}
+		if added == 0 {
+			break // past the last page, or the board repeats it
+		}
+	}
+	result.RawContent = strings.Join(pages, searchPageSeparator)
+
+	var err error
+	if result.Jobs, err = s.ParseJobs(result.RawContent, sourceURL); err != nil {
+		return result, err
+	}
+	log.Printf("Found %d results of the %s search %s in %d pages", len(result.Jobs), board, sourceURL, len(pages))
+	return result, nil
+}
+
+// ParseJobs extracts the results from the result pages of a search
+func (s *SearchScraper) ParseJobs(raw, sourceURL string) ([]domain.Job, error) {
+	board := searchBoard(sourceURL)
+	if board == "" {
+		return nil, fmt.Errorf("%s is not a job board search", sourceURL)
+	}
+	return searchJobs(board, raw, sourceURL), nil
+}
+
+// get fetches a result page
+func (s *SearchScraper) get(ctx context.Context, pageURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+	return fetchBoard(s.client, req)
+}
+
+// searchJobs returns the results of the result pages of a search, dropping
+// the promoted results boards repeat on every page
+func searchJobs(board, raw, sourceURL string) []domain.Job {
+	var jobs []domain.Job
+	seen := make(map[string]bool)
+	for _, page := range strings.Split(raw, searchPageSeparator) {
+		for _, job := range parseSearchPage(board, page, sourceURL) {
+			if seen[job.ID] {
+				continue
+			}
+			seen[job.ID] = true
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
+
+// parseSearchPage extracts the results of a result page of board
+func parseSearchPage(board, page, sourceURL string) []domain.Job {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		return nil
+	}
+	switch board {
+	case searchBoardIndeed:
+		return parseIndeedResults(doc, sourceURL)
+	case searchBoardLinkedIn:
+		return parseLinkedInResults(doc)
+	}
+	return nil
+}
+
+// parseIndeedResults extracts the result cards of an Indeed result page, each
+// holding a title link with the job key in data-jk
+func parseIndeedResults(doc *goquery.Document, sourceURL string) []domain.Job {
+	base, err := url.Parse(sourceURL)
+	if err != nil {
+		return nil
+	}
+	var jobs []domain.Job
+	doc.Find("a[data-jk]").Each(func(i int, link *goquery.Selection) {
+		key := strings.TrimSpace(link.AttrOr("data-jk", ""))
+		if key == "" {
+			return
+		}
+		card := link.Closest("div.job_seen_beacon, td.resultContent, li")
+		if card.Length() == 0 {
+			card = link.Parent()
+		}
+		job := domain.Job{
+			ID:        key,
+			Title:     strings.TrimSpace(link.Find("span[title]").First().AttrOr("title", "")),
+			Location:  cardText(card, `[data-testid="text-location"], .companyLocation`),
+			URL:       base.Scheme + "://" + base.Host + "/viewjob?jk=" + url.QueryEscape(key),
+			ScrapedAt: time.Now(),
+		}
+		if job.Title == "" {
+			job.Title = strings.Join(strings.Fields(link.Text()), " ")
+		}
+		if job.Title == "" {
+			return
+		}
+		if company := cardText(card, `[data-testid="company-name"], .companyName`); company != "" {
+			job.Metadata = map[string]string{"company": company}
+		}
+		jobs = append(jobs, normalize.ListingDates(job, cardText(card, `[data-testid="myJobsStateDate"], .date`)))
+	})
+	return jobs
+}
+
+// parseLinkedInResults extracts the result cards of a page of the LinkedIn
+// guest API, identified by the job posting URN of the card
+func parseLinkedInResults(doc *goquery.Document) []domain.Job {
+	var jobs []domain.Job
+	doc.Find("[data-entity-urn]").Each(func(i int, card *goquery.Selection) {
+		urn := card.AttrOr("data-entity-urn", "")
+		id := urn[strings.LastIndex(urn, ":")+1:]
+		if !strings.Contains(urn, "jobPosting") || id == "" {
+			return
+		}
+		job := domain.Job{
+			ID:        id,
+			Title:     cardText(card, ".base-search-card__title"),
+			Location:  cardText(card, ".job-search-card__location"),
+			ScrapedAt: time.Now(),
+		}
+		if job.Title == "" {
+			return
+		}
+		if link, ok := card.Find("a.base-card__full-link").First().Attr("href"); ok {
+			if u, err := url.Parse(strings.TrimSpace(link)); err == nil {
+				u.RawQuery = ""
+				job.URL = u.String()
+			}
+		}
+		if company := cardText(card, ".base-search-card__subtitle"); company != "" {
+			job.Metadata = map[string]string{"company": company}
+		}
+		if salary := cardText(card, ".job-search-card__salary-info"); salary != "" {
+			if parsed, ok := normalize.ParseSalary(salary); ok {
+				job.Salary = &parsed
+			}
+		}
+		if posted, err := time.Parse("2006-01-02", card.Find("time").First().AttrOr("datetime", "")); err == nil {
+			job.PostedDate = posted
+		}
+		jobs = append(jobs, job)
+	})
+	return jobs
+}
+
+// cardText returns the whitespace-collapsed text of the first element of a
+// result card matching selector
+func cardText(card *goquery.Selection, selector string) string {
+	return strings.Join(strings.Fields(card.Find(selector).First().Text()), " ")
+}
+
+// searchBoard returns the job board a search URL runs on, "" if url is no
+// search of a supported board
+func searchBoard(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case (host == "indeed.com" || strings.HasSuffix(host, ".indeed.com")) && u.Path == "/jobs" && u.Query().Has("q"):
+		return searchBoardIndeed
+	case (host == "linkedin.com" || strings.HasSuffix(host, ".linkedin.com")) && strings.TrimSuffix(u.Path, "/") == "/jobs/search" && u.Query().Has("keywords"):
+		return searchBoardLinkedIn
+	}
+	return ""
+}
+
+// searchPageURL returns the URL of result page n, counted from 0, of a search
+func searchPageURL(board, sourceURL string, n int) (string, error) {
+	u, err := url.Parse(sourceURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid search URL %s: %w", sourceURL, err)
+	}
+	query := u.Query()
+	switch board {
+	case searchBoardIndeed:
+		if n > 0 {
+			query.Set("start", strconv.Itoa(n*indeedPageSize))
+		}
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	case searchBoardLinkedIn:
+		// The search page renders its results with scripts; the guest API
+		// returns the same cards for the same filters
+		query.Set("start", strconv.Itoa(n*linkedInPageSize))
+		return linkedInGuestAPI + "?" + query.Encode(), nil
+	}
+	return "", fmt.Errorf("unknown job board %q", board)
+}
+
+// searchName names the collection of a search after its board and query,
+// e.g. "Indeed: golang remote"
+func searchName(board, sourceURL string) string {
+	u, err := url.Parse(sourceURL)
+	if err != nil {
+		return board
+	}
+	query := u.Query()
+	name, terms, location := "Indeed", query.Get("q"), query.Get("l")
+	if board == searchBoardLinkedIn {
+		name, terms, location = "LinkedIn", query.Get("keywords"), query.Get("location")
+	}
+	if location != "" {
+		return fmt.Sprintf("%s: %s in %s", name, terms, location)
+	}
+	return name + ": " + terms
+}
+
+var (
+	_ BoardScraper = (*SearchScraper)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,153 @@
+// internal/adapters/scraper/selectors.go
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/normalize"
+)
+
+// SelectorRule tells the scraper where the job listings of a site are, so it
+// doesn't have to guess. Field selectors are relative to each container.
+type SelectorRule struct {
+	Name        string // registry profile the rule comes from, empty for configured rules
+	URL         string // source URL the rule applies to
+	Domain      string // or domain whose sources, subdomains included, it applies to
+	Container   string
+	Title       string
+	Location    string
+	Department  string
+	Description string
+	Link        string        // element whose href is the job URL, the container itself if empty
+	ApplyLink   string        // element whose href is the application form, none if empty
+	IDAttr      string        // attribute of the container holding the job ID, identified by JobIdentity if empty
+	WaitFor     string        // element waited for before the page is read, see pageWait
+	WaitIdle    bool          // wait for the network to go idle before the page is read
+	WaitTimeout time.Duration // longest wait of WaitFor and WaitIdle
+}
+
+// parses reports whether the rule has the selectors to parse pages, rather
+// than only a wait strategy
+func (r SelectorRule) parses() bool {
+	return r.Container != ""
+}
+
+// validate checks that a rule has the required selectors and that they compile
+func (r SelectorRule) validate() error {
+	switch {
+	case r.URL == "" && r.Domain == "":
+		return errors.New("URL or domain is required")
+	case r.Container == "":
+		return errors.New("container selector is required")
+	case r.Title == "":
+		return errors.New("title selector is required")
+	}
+	for _, selector := range []string{r.Container, r.Title, r.Location, r.Department, r.Description, r.Link, r.ApplyLink, r.WaitFor} {
+		if selector == "" {
+			continue
+		}
+		if _, err := cascadia.Compile(selector); err != nil {
+			return fmt.Errorf("invalid CSS selector %q: %w", selector, err)
+		}
+	}
+	return nil
+}
+
+// matchRule returns the rule for a source URL: the one configured for the
+// URL, or else the one for the most specific domain the URL is on
+func matchRule(rules []SelectorRule, sourceURL string) (SelectorRule, bool) {
+	host := ""
+	if u, err := url.Parse(sourceURL); err == nil {
+		host = strings.ToLower(u.Hostname())
+	}
+
+	var match SelectorRule
+	found := false
+	for _, rule := range rules {
+		if rule.URL != "" {
+			if rule.URL == sourceURL {
+				return rule, true
+			}
+			continue
+		}
+		domain := strings.ToLower(strings.TrimPrefix(rule.Domain, "."))
+		if host != domain && !strings.HasSuffix(host, "."+domain) {
+			continue
+		}
+		if !found || len(domain) > len(match.Domain) {
+			match, found = rule, true
+		}
+	}
+	return match, found
+}
+
+// parseWithRule extracts the jobs of a page with a selector rule. Listings
+// without a title are skipped.
+func parseWithRule(doc *goquery.Document, sourceURL string, rule SelectorRule, fields []FieldSelector) []domain.Job {
+	var jobs []domain.Job
+	doc.Find(rule.Container).Each(func(i int, listing *goquery.Selection) {
+		job := domain.Job{
+			ScrapedAt:   time.Now(),
+			Title:       selectText(listing, rule.Title),
+			Location:    selectText(listing, rule.Location),
+			Department:  selectText(listing, rule.Department),
+			Description: selectText(listing, rule.Description),
+			URL:         selectLink(listing, rule.Link, sourceURL),
+			Metadata:    extractFields(listing, fields),
+		}
+		if rule.ApplyLink != "" {
+			job.ApplyURL = selectLink(listing, rule.ApplyLink, sourceURL)
+		}
+		if job.Title == "" {
+			return
+		}
+		job = normalize.ListingDates(job, strings.Join(strings.Fields(listing.Text()), " "))
+
+		if rule.IDAttr != "" {
+			id, _ := listing.Attr(rule.IDAttr)
+			job.ID = strings.TrimSpace(id)
+		}
+		jobs = append(jobs, job)
+	})
+	return jobs
+}
+
+// selectText returns the whitespace-collapsed text of the first element
+// matching selector within a listing, or "" if selector is empty
+func selectText(listing *goquery.Selection, selector string) string {
+	if selector == "" {
+		return ""
+	}
+	return strings.Join(strings.Fields(listing.Find(selector).First().Text()), " ")
+}
+
+// selectLink returns the absolute href of the first element matching
+// selector within a listing, or of the listing itself if selector is empty
+func selectLink(listing *goquery.Selection, selector, sourceURL string) string {
+	element := listing
+	if selector != "" {
+		element = listing.Find(selector).First()
+	}
+	href, ok := element.Attr("href")
+	if !ok || strings.TrimSpace(href) == "" {
+		return ""
+	}
+
+	base, err := url.Parse(sourceURL)
+	if err != nil {
+		return href
+	}
+	ref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return href
+	}
+	return base.ResolveReference(ref).String()
+}
@@ -0,0 +1,337 @@
+// internal/adapters/scraper/sitemap_scraper.go
+package scraper
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// DefaultSitemapPattern matches the URLs of job postings on most sites
+const DefaultSitemapPattern = `(?i)/(jobs?|careers?|positions?|openings?|vacanc(y|ies)|stellen(angebote)?)/[^/]+`
+
+// DefaultSitemapMaxPages bounds the postings read per scrape of a source
+const DefaultSitemapMaxPages = 100
+
+// maxSitemapIndexes bounds the sitemaps read through sitemap indexes per scrape
+const maxSitemapIndexes = 50
+
+// SitemapSource configures the sitemap discovery of a source URL
+type SitemapSource struct {
+	Sitemap  string         // sitemap or sitemap index listing the site's pages
+	Pattern  *regexp.Regexp // URLs of postings, DefaultSitemapPattern if nil
+	MaxPages int            // postings read per scrape, DefaultSitemapMaxPages if 0
+}
+
+// SitemapScraper implements the BoardScraper interface for sources whose job
+// list is hidden behind scripts but whose postings are listed in the sitemap.
+// Each posting page is read over plain HTTP and parsed from its JobPosting
+// structured data, or else its title. Postings whose lastmod is unchanged
+// since the previous scrape are not read again.
+type SitemapScraper struct {
+	client  *http.Client
+	sources map[string]SitemapSource
+	cache   map[string]map[string]sitemapPage // postings of the last scrape by source and posting URL
+	mu      sync.Mutex
+}
+
+// sitemapDocument is a sitemap or a sitemap index
+type sitemapDocument struct {
+	XMLName  xml.Name
+	URLs     []sitemapEntry `xml:"url"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+// sitemapEntry is a page or sitemap listed by a sitemap
+type sitemapEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
+
+// sitemapPage is a posting page trimmed to the elements it is parsed from,
+// the raw content of a collection being the list of its postings
+type sitemapPage struct {
+	URL     string `json:"url"`
+	LastMod string `json:"lastmod,omitempty"`
+	HTML    string `json:"html"`
+}
+
+// NewSitemapScraper creates a scraper discovering the postings of the given
+// sources, keyed by source URL, from their sitemaps
+func NewSitemapScraper(client *http.Client, sources map[string]SitemapSource) *SitemapScraper {
+	return &SitemapScraper{
+		client:  client,
+		sources: sources,
+		cache:   make(map[string]map[string]sitemapPage),
+	}
+}
+
+// Matches reports whether url is a source discovered through its sitemap
+func (s *SitemapScraper) Matches(url string) bool {
+	_, ok := s.sources[url]
+	return ok
+}
+
+// Scrape reads the postings the sitemap of a source lists
+func (s *SitemapScraper) Scrape(ctx context.Context, sourceURL string) (domain.JobCollection, error) {
+	result := domain.JobCollection{
+		SourceURL: sourceURL,
+		ScrapedAt: time.Now(),
+	}
+	source, ok := s.sources[sourceURL]
+	if !ok {
+		return result, fmt.Errorf("no sitemap configured for %s", sourceURL)
+	}
+	pattern := source.Pattern
+	if pattern == nil {
+		pattern = regexp.MustCompile(DefaultSitemapPattern)
+	}
+	maxPages := source.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultSitemapMaxPages
+	}
+
+	log.Printf("Discovering the postings of %s from %s", sourceURL, source.Sitemap)
+	entries, err := s.postings(ctx, source.Sitemap, pattern)
+	if err != nil {
+		return result, err
+	}
+	if len(entries) > maxPages {
+		log.Printf("Sitemap %s lists %d postings, reading the first %d", source.Sitemap, len(entries), maxPages)
+		entries = entries[:maxPages]
+	}
+
+	s.mu.Lock()
+	cached := s.cache[sourceURL]
+	s.mu.Unlock()
+	pages := make([]sitemapPage, 0, len(entries))
+	fresh := make(map[string]sitemapPage, len(entries))
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+		page, ok := cached[entry.Loc]
+		if !ok || entry.LastMod == "" || page.LastMod != entry.LastMod {
+			if page, err = s.page(ctx, entry); err != nil {
+				log.Printf("Skipping posting %s: %v", entry.Loc, err)
+				continue
+			}
+		}
+		pages = append(pages, page)
+		fresh[entry.Loc] = page
+	}
+	s.mu.Lock()
+	s.cache[sourceURL] = fresh
+	s.mu.Unlock()
+	if len(entries) > 0 && len(pages) == 0 {
+		return result, fmt.Errorf("failed to read any of the %d postings in %s", len(entries), source.Sitemap)
+	}
+
+	raw, err := json.Marshal(pages)
+	if err != nil {
+		return result, fmt.Errorf("failed to encode postings: %w", err)
+	}
+	result.RawContent = string(raw)
+	result.CompanyName = sitemapCompanyName(pages, sourceURL)
+
+	result.Jobs, err = s.ParseJobs(result.RawContent, sourceURL)
+	if err != nil {
+		return result, err
+	}
+	log.Printf("Found %d jobs in the sitemap of %s", len(result.Jobs), sourceURL)
+	return result, nil
+}
+
+// ParseJobs extracts the jobs from the postings of a collection
+func (s *SitemapScraper) ParseJobs(raw, sourceURL string) ([]domain.Job, error) {
+	var pages []sitemapPage
+	if err := json.Unmarshal([]byte(raw), &pages); err != nil {
+		return nil, fmt.Errorf("failed to parse sitemap postings: %w", err)
+	}
+
+	jobs := make([]domain.Job, 0, len(pages))
+	seen := make(map[string]bool)
+	for _, page := range pages {
+		job, ok := page.job()
+		if !ok || seen[job.ID] {
+			continue
+		}
+		seen[job.ID] = true
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// postings returns the entries of a sitemap matching pattern, following
+// sitemap indexes
+func (s *SitemapScraper) postings(ctx context.Context, sitemap string, pattern *regexp.Regexp) ([]sitemapEntry, error) {
+	var postings []sitemapEntry
+	seen := make(map[string]bool)
+	queue := []string{sitemap}
+	for read := 0; len(queue) > 0 && read < maxSitemapIndexes; read++ {
+		next := queue[0]
+		queue = queue[1:]
+		doc, err := s.sitemap(ctx, next)
+		if err != nil {
+			if next == sitemap {
+				return nil, err
+			}
+			log.Printf("Skipping sitemap %s: %v", next, err)
+			continue
+		}
+		for _, child := range doc.Sitemaps {
+			if loc := strings.TrimSpace(child.Loc); loc != "" {
+				queue = append(queue, loc)
+			}
+		}
+		for _, entry := range doc.URLs {
+			entry.Loc = strings.TrimSpace(entry.Loc)
+			if entry.Loc == "" || seen[entry.Loc] || !pattern.MatchString(entry.Loc) {
+				continue
+			}
+			seen[entry.Loc] = true
+			entry.LastMod = strings.TrimSpace(entry.LastMod)
+			postings = append(postings, entry)
+		}
+	}
+	return postings, nil
+}
+
+// sitemap fetches and decodes a sitemap, gzipped or not
+func (s *SitemapScraper) sitemap(ctx context.Context, sitemapURL string) (*sitemapDocument, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sitemapURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/xml, text/xml")
+	body, err := fetchBoard(s.client, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sitemap: %w", err)
+	}
+	if len(body) > 1 && body[0] == 0x1f && body[1] == 0x8b {
+		gz, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress sitemap: %w", err)
+		}
+		body, err = io.ReadAll(io.LimitReader(gz, maxBoardResponse))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress sitemap: %w", err)
+		}
+	}
+
+	var doc sitemapDocument
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse sitemap: %w", err)
+	}
+	if doc.XMLName.Local != "urlset" && doc.XMLName.Local != "sitemapindex" {
+		return nil, fmt.Errorf("failed to parse sitemap: unexpected root element <%s>", doc.XMLName.Local)
+	}
+	return &doc, nil
+}
+
+// page fetches the posting page of a sitemap entry
+func (s *SitemapScraper) page(ctx context.Context, entry sitemapEntry) (sitemapPage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, entry.Loc, nil)
+	if err != nil {
+		return sitemapPage{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+	body, err := fetchBoard(s.client, req)
+	if err != nil {
+		return sitemapPage{}, err
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return sitemapPage{}, fmt.Errorf("failed to parse posting: %w", err)
+	}
+
+	return sitemapPage{URL: entry.Loc, LastMod: entry.LastMod, HTML: trimPosting(doc)}, nil
+}
+
+// job converts a posting page into a job: its first JobPosting, or else a job
+// titled after the page. Pages without a title are skipped.
+func (p sitemapPage) job() (domain.Job, bool) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(p.HTML))
+	if err != nil {
+		return domain.Job{}, false
+	}
+	if jobs := parseJSONLD(doc, p.URL); len(jobs) > 0 {
+		job := jobs[0]
+		if job.URL == "" {
+			job.URL = p.URL
+		}
+		return job, true
+	}
+
+	job := domain.Job{
+		ID:          p.URL,
+		URL:         p.URL,
+		Description: strings.TrimSpace(doc.Find(`meta[name="description"]`).AttrOr("content", "")),
+		ScrapedAt:   time.Now(),
+	}
+	for _, title := range []string{
+		doc.Find(`meta[property="og:title"]`).AttrOr("content", ""),
+		doc.Find("h1").First().Text(),
+		doc.Find("title").First().Text(),
+	} {
+		if job.Title = strings.Join(strings.Fields(title), " "); job.Title != "" {
+			return job, true
+		}
+	}
+	return job, false
+}
+
+// trimPosting keeps the elements of a posting page it is parsed from, so the
+// postings of a collection stay small enough to archive
+func trimPosting(doc *goquery.Document) string {
+	var page strings.Builder
+	page.WriteString("<html><head>")
+	doc.Find(`title, meta[property^="og:"], meta[name="description"], script[type*="ld+json"]`).Each(func(i int, s *goquery.Selection) {
+		if html, err := goquery.OuterHtml(s); err == nil {
+			page.WriteString(html)
+		}
+	})
+	page.WriteString("</head><body>")
+	if html, err := goquery.OuterHtml(doc.Find("h1").First()); err == nil {
+		page.WriteString(html)
+	}
+	page.WriteString("</body></html>")
+	return page.String()
+}
+
+// sitemapCompanyName returns the company of a source, from the site name of
+// its postings or else its host
+func sitemapCompanyName(pages []sitemapPage, sourceURL string) string {
+	for _, page := range pages {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(page.HTML))
+		if err != nil {
+			continue
+		}
+		if name := strings.TrimSpace(doc.Find(`meta[property="og:site_name"]`).AttrOr("content", "")); name != "" {
+			return name
+		}
+	}
+	if u, err := url.Parse(sourceURL); err == nil {
+		return u.Hostname()
+	}
+	return ""
+}
+
+var (
+	_ BoardScraper = (*SitemapScraper)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,217 @@
+// internal/adapters/scraper/smartrecruiters_scraper.go
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// smartRecruitersHosts serve the career pages hosted by SmartRecruiters, e.g.
+// https://careers.smartrecruiters.com/Acme
+var smartRecruitersHosts = []string{"careers.smartrecruiters.com", "jobs.smartrecruiters.com"}
+
+// smartRecruitersAPI is the public postings API of a company
+const smartRecruitersAPI = "https://api.smartrecruiters.com/v1/companies/"
+
+// smartRecruitersPageSize is the number of postings requested per page, the
+// most the API returns
+const smartRecruitersPageSize = 100
+
+// smartRecruitersMaxPages bounds the pages read per scrape, in case the API
+// keeps reporting more postings than it returns
+const smartRecruitersMaxPages = 100
+
+// SmartRecruitersScraper implements the BoardScraper interface for career
+// pages hosted by SmartRecruiters, paging through the postings API
+type SmartRecruitersScraper struct {
+	client *http.Client
+	api    string
+}
+
+// smartRecruitersPage is a page of the postings API. A scrape keeps the
+// postings of all pages in one page as its raw content.
+type smartRecruitersPage struct {
+	TotalFound int               `json:"totalFound"`
+	Content    []json.RawMessage `json:"content"`
+}
+
+// smartRecruitersLabel is a labelled attribute of a posting
+type smartRecruitersLabel struct {
+	Label string `json:"label"`
+}
+
+// smartRecruitersPosting is a posting of the postings API
+type smartRecruitersPosting struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Company struct {
+		Identifier string `json:"identifier"`
+		Name       string `json:"name"`
+	} `json:"company"`
+	ReleasedDate time.Time `json:"releasedDate"`
+	Location     struct {
+		City         string `json:"city"`
+		Region       string `json:"region"`
+		Country      string `json:"country"`
+		Remote       bool   `json:"remote"`
+		FullLocation string `json:"fullLocation"`
+	} `json:"location"`
+	Department       smartRecruitersLabel `json:"department"`
+	Function         smartRecruitersLabel `json:"function"`
+	TypeOfEmployment smartRecruitersLabel `json:"typeOfEmployment"`
+	ExperienceLevel  smartRecruitersLabel `json:"experienceLevel"`
+}
+
+// NewSmartRecruitersScraper creates a scraper querying the SmartRecruiters
+// API with client
+func NewSmartRecruitersScraper(client *http.Client) *SmartRecruitersScraper {
+	return &SmartRecruitersScraper{client: client, api: smartRecruitersAPI}
+}
+
+// Matches reports whether url is a career page hosted by SmartRecruiters
+func (s *SmartRecruitersScraper) Matches(url string) bool {
+	return smartRecruitersCompany(url) != ""
+}
+
+// smartRecruitersCompany returns the company identifier of a career page URL,
+// "" for other URLs
+func smartRecruitersCompany(url string) string {
+	for _, host := range smartRecruitersHosts {
+		if slug := boardSlug(url, host); slug != "" {
+			return slug
+		}
+	}
+	return ""
+}
+
+// Scrape lists the postings of the company, page by page
+func (s *SmartRecruitersScraper) Scrape(ctx context.Context, sourceURL string) (domain.JobCollection, error) {
+	company := smartRecruitersCompany(sourceURL)
+	result := domain.JobCollection{
+		CompanyName: companyFromSlug(company),
+		SourceURL:   sourceURL,
+		ScrapedAt:   time.Now(),
+	}
+	log.Printf("Querying the SmartRecruiters postings of %s", company)
+
+	var all smartRecruitersPage
+	for page := 0; page < smartRecruitersMaxPages; page++ {
+		query := url.Values{
+			"offset": {fmt.Sprint(len(all.Content))},
+			"limit":  {fmt.Sprint(smartRecruitersPageSize)},
+		}
+		body, err := getBoard(ctx, s.client, s.api+url.PathEscape(company)+"/postings?"+query.Encode())
+		if err != nil {
+			return result, fmt.Errorf("failed to list SmartRecruiters postings: %w", err)
+		}
+
+		var next smartRecruitersPage
+		if err := decodeBoard(body, &next); err != nil {
+			return result, err
+		}
+		all.TotalFound = next.TotalFound
+		all.Content = append(all.Content, next.Content...)
+		if len(next.Content) == 0 || len(all.Content) >= next.TotalFound {
+			break
+		}
+	}
+
+	raw, err := json.Marshal(all)
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal SmartRecruiters postings: %w", err)
+	}
+	result.RawContent = string(raw)
+
+	result.Jobs, err = s.ParseJobs(result.RawContent, sourceURL)
+	if err != nil {
+		return result, err
+	}
+	var first smartRecruitersPosting
+	if len(all.Content) > 0 && json.Unmarshal(all.Content[0], &first) == nil && first.Company.Name != "" {
+		result.CompanyName = first.Company.Name
+	}
+	log.Printf("Found %d jobs in the SmartRecruiters postings of %s", len(result.Jobs), company)
+	return result, nil
+}
+
+// ParseJobs extracts the postings from the raw content of a scrape
+func (s *SmartRecruitersScraper) ParseJobs(raw, sourceURL string) ([]domain.Job, error) {
+	var page smartRecruitersPage
+	if err := decodeBoard([]byte(raw), &page); err != nil {
+		return nil, err
+	}
+
+	jobs := make([]domain.Job, 0, len(page.Content))
+	for _, data := range page.Content {
+		var posting smartRecruitersPosting
+		if err := decodeBoard(data, &posting); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, posting.job(smartRecruitersCompany(sourceURL)))
+	}
+	return jobs, nil
+}
+
+// job converts a posting into a job. The department falls back to the
+// function of the posting.
+func (p smartRecruitersPosting) job(company string) domain.Job {
+	if p.Company.Identifier != "" {
+		company = p.Company.Identifier
+	}
+	job := domain.Job{
+		ID:         p.ID,
+		Title:      strings.TrimSpace(p.Name),
+		Location:   p.location(),
+		Department: p.Department.Label,
+		URL:        fmt.Sprintf("https://jobs.smartrecruiters.com/%s/%s", url.PathEscape(company), url.PathEscape(p.ID)),
+		PostedDate: p.ReleasedDate,
+		ScrapedAt:  time.Now(),
+	}
+
+	metadata := make(map[string]string)
+	if job.Department == "" {
+		job.Department = p.Function.Label
+	} else if p.Function.Label != "" && p.Function.Label != job.Department {
+		metadata["function"] = p.Function.Label
+	}
+	if p.TypeOfEmployment.Label != "" {
+		metadata["employment_type"] = p.TypeOfEmployment.Label
+	}
+	if p.ExperienceLevel.Label != "" {
+		metadata["experience_level"] = p.ExperienceLevel.Label
+	}
+	if p.Location.Remote {
+		metadata["workplace"] = "Remote"
+	}
+	if len(metadata) > 0 {
+		job.Metadata = metadata
+	}
+	return job
+}
+
+// location returns the full location of a posting, built from its parts if
+// the API didn't return it
+func (p smartRecruitersPosting) location() string {
+	if p.Location.FullLocation != "" {
+		return p.Location.FullLocation
+	}
+	var parts []string
+	for _, part := range []string{p.Location.City, p.Location.Region, strings.ToUpper(p.Location.Country)} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+var (
+	_ BoardScraper = (*SmartRecruitersScraper)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,253 @@
+// internal/adapters/scraper/teamtailor_scraper.go
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// teamtailorDomain hosts the career sites of Teamtailor, one subdomain per
+// company, e.g. https://acme.teamtailor.com. Many companies serve theirs on
+// a custom domain, which is detected from the rendered page instead.
+const teamtailorDomain = ".teamtailor.com"
+
+// teamtailorMarkers appear on every page of a Teamtailor career site, in the
+// asset URLs of its CDN
+var teamtailorMarkers = []string{"teamtailor-cdn.com", "assets.teamtailor.com"}
+
+// teamtailorMaxPages bounds the "show more" pages read per scrape
+const teamtailorMaxPages = 20
+
+// teamtailorJobPath matches the path of a job on a career site, e.g.
+// /jobs/3456789-backend-engineer
+var teamtailorJobPath = regexp.MustCompile(`^/jobs/(\d+)(?:-[^/]*)?$`)
+
+// teamtailorWorkplaces lists the remote status labels shown next to jobs
+var teamtailorWorkplaces = map[string]bool{"remote": true, "fully remote": true, "hybrid": true, "on-site": true, "onsite": true}
+
+// TeamtailorScraper implements the BoardScraper interface for career sites
+// hosted by Teamtailor. The public API of Teamtailor needs a key of the
+// company, so the job list all career sites share is read over plain HTTP
+// instead, following its "show more" pages.
+type TeamtailorScraper struct {
+	client *http.Client
+}
+
+// NewTeamtailorScraper creates a scraper reading Teamtailor career sites with
+// client
+func NewTeamtailorScraper(client *http.Client) *TeamtailorScraper {
+	return &TeamtailorScraper{client: client}
+}
+
+// Matches reports whether url is a career site on a Teamtailor subdomain
+func (s *TeamtailorScraper) Matches(url string) bool {
+	return teamtailorCompany(url) != ""
+}
+
+// Detects reports whether a page belongs to a Teamtailor career site
+func (s *TeamtailorScraper) Detects(page string) bool {
+	for _, marker := range teamtailorMarkers {
+		if strings.Contains(page, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scrape reads the job list of the career site
+func (s *TeamtailorScraper) Scrape(ctx context.Context, sourceURL string) (domain.JobCollection, error) {
+	result := domain.JobCollection{
+		SourceURL: sourceURL,
+		ScrapedAt: time.Now(),
+	}
+	listURL, err := teamtailorJobList(sourceURL)
+	if err != nil {
+		return result, err
+	}
+	log.Printf("Reading the Teamtailor job list at %s", listURL)
+
+	var pages []string
+	next := listURL
+	for page := 0; next != "" && page < teamtailorMaxPages; page++ {
+		body, err := s.get(ctx, next)
+		if err != nil {
+			return result, fmt.Errorf("failed to read Teamtailor job list: %w", err)
+		}
+		pages = append(pages, string(body))
+		next = teamtailorShowMore(string(body), next)
+	}
+	result.RawContent = strings.Join(pages, "\n")
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(pages[0]))
+	if err != nil {
+		return result, fmt.Errorf("failed to parse Teamtailor job list: %w", err)
+	}
+	result.CompanyName = teamtailorCompanyName(doc, sourceURL)
+
+	result.Jobs, err = s.ParseJobs(result.RawContent, sourceURL)
+	if err != nil {
+		return result, err
+	}
+	log.Printf("Found %d jobs on the Teamtailor career site %s", len(result.Jobs), listURL)
+	return result, nil
+}
+
+// ParseJobs extracts the jobs from the job list pages of a career site,
+// skipping links to jobs elsewhere
+func (s *TeamtailorScraper) ParseJobs(raw, sourceURL string) ([]domain.Job, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Teamtailor job list: %w", err)
+	}
+	base, err := url.Parse(sourceURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source URL %s: %w", sourceURL, err)
+	}
+
+	var jobs []domain.Job
+	seen := make(map[string]bool)
+	doc.Find(`a[href*="/jobs/"]`).Each(func(i int, link *goquery.Selection) {
+		href, _ := link.Attr("href")
+		jobURL, err := base.Parse(href)
+		if err != nil || !strings.EqualFold(jobURL.Host, base.Host) {
+			return
+		}
+		match := teamtailorJobPath.FindStringSubmatch(jobURL.Path)
+		if match == nil || seen[match[1]] {
+			return
+		}
+		seen[match[1]] = true
+		jobs = append(jobs, teamtailorJob(link, match[1], jobURL.String()))
+	})
+	return jobs, nil
+}
+
+// get fetches a page of the career site
+func (s *TeamtailorScraper) get(ctx context.Context, pageURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+	return fetchBoard(s.client, req)
+}
+
+// teamtailorJob converts the link of a job list entry into a job. The entry
+// shows the title and a line of details separated by dots: department,
+// locations and remote status, each only if set.
+func teamtailorJob(link *goquery.Selection, id, jobURL string) domain.Job {
+	job := domain.Job{
+		ID:        id,
+		URL:       jobURL,
+		ScrapedAt: time.Now(),
+	}
+
+	title := link.Find("span[title]").First()
+	if job.Title = strings.TrimSpace(title.AttrOr("title", "")); job.Title == "" {
+		job.Title = strings.TrimSpace(link.Find("span").First().Text())
+	}
+	if job.Title == "" {
+		job.Title = strings.Join(strings.Fields(link.Text()), " ")
+	}
+
+	var details []string
+	link.Find("div span").Each(func(i int, span *goquery.Selection) {
+		if text := strings.TrimSpace(span.Text()); text != "" && text != "·" && text != job.Title {
+			details = append(details, text)
+		}
+	})
+	if n := len(details); n > 0 && teamtailorWorkplaces[strings.ToLower(details[n-1])] {
+		job.Metadata = map[string]string{"workplace": details[n-1]}
+		details = details[:n-1]
+	}
+	switch len(details) {
+	case 0:
+	case 1:
+		job.Location = details[0]
+	default:
+		job.Department = details[0]
+		job.Location = strings.Join(details[1:], ", ")
+	}
+	return job
+}
+
+// teamtailorJobList returns the URL of the job list of a career site, keeping
+// the filters of a source URL already pointing at it
+func teamtailorJobList(sourceURL string) (string, error) {
+	u, err := url.Parse(sourceURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid source URL %s", sourceURL)
+	}
+	if u.Path != "/jobs" {
+		u.Path = "/jobs"
+		u.RawQuery = ""
+	}
+	u.Fragment = ""
+	return u.String(), nil
+}
+
+// teamtailorShowMore returns the URL of the next page of a job list page, ""
+// on the last page
+func teamtailorShowMore(page, pageURL string) string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		return ""
+	}
+	href, ok := doc.Find(`a[href*="/jobs/show_more"]`).Last().Attr("href")
+	if !ok {
+		return ""
+	}
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return ""
+	}
+	next, err := base.Parse(href)
+	if err != nil || next.String() == pageURL {
+		return ""
+	}
+	return next.String()
+}
+
+// teamtailorCompanyName returns the name of the career site's company, from
+// the site name of its pages or else its subdomain or host
+func teamtailorCompanyName(doc *goquery.Document, sourceURL string) string {
+	if name := strings.TrimSpace(doc.Find(`meta[property="og:site_name"]`).AttrOr("content", "")); name != "" {
+		return name
+	}
+	if company := teamtailorCompany(sourceURL); company != "" {
+		return companyFromSlug(company)
+	}
+	if u, err := url.Parse(sourceURL); err == nil {
+		return u.Hostname()
+	}
+	return ""
+}
+
+// teamtailorCompany returns the company subdomain of a career site URL on
+// Teamtailor, or "" if the URL is elsewhere
+func teamtailorCompany(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	company, ok := strings.CutSuffix(strings.ToLower(u.Hostname()), teamtailorDomain)
+	if !ok || company == "" || strings.Contains(company, ".") || company == "www" || company == "app" {
+		return ""
+	}
+	return company
+}
+
+var (
+	_ BoardScraper  = (*TeamtailorScraper)(nil) // Ensure interface compliance
+	_ BoardDetector = (*TeamtailorScraper)(nil)
+)
@@ -0,0 +1,82 @@
+// internal/adapters/scraper/wait.go
+package scraper
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+// defaultWaitStable is how long a page without a wait strategy must stop
+// changing before it is read
+const defaultWaitStable = 2 * time.Second
+
+// defaultWaitTimeout bounds the wait of a strategy without a timeout
+const defaultWaitTimeout = 20 * time.Second
+
+// requestIdle is how long no request may be in flight for the network to
+// count as idle
+const requestIdle = 500 * time.Millisecond
+
+// pageWait waits until a page is ready to be read, as the wait strategy of
+// its selector rule says: until an element appears, until the network is
+// idle, or both. Pages without a strategy wait until they stop changing.
+type pageWait struct {
+	page    *rod.Page // the page with the timeout of the wait
+	rule    SelectorRule
+	idle    func() // waits for the network to go idle, nil if not wanted
+	timeout time.Duration
+}
+
+// waitStrategy prepares the wait of page for url. It must be called before
+// the page navigates, so the requests of the navigation count towards
+// network idle.
+func (s *GoRodScraper) waitStrategy(page *rod.Page, url string) *pageWait {
+	rule, ok := matchRule(s.rules, url)
+	if !ok || (rule.WaitFor == "" && !rule.WaitIdle) {
+		return &pageWait{page: page}
+	}
+	timeout := rule.WaitTimeout
+	if timeout <= 0 {
+		timeout = defaultWaitTimeout
+	}
+	wait := &pageWait{page: page.Timeout(timeout), rule: rule, timeout: timeout}
+	if rule.WaitIdle {
+		wait.idle = wait.page.WaitRequestIdle(requestIdle, nil, nil, nil)
+	}
+	return wait
+}
+
+// wait blocks until the page is ready. A strategy running into its timeout
+// is not an error; the page is read as far as it loaded.
+func (w *pageWait) wait() error {
+	if w.timeout == 0 {
+		log.Printf("Waiting for page to stabilize...")
+		return w.page.WaitStable(defaultWaitStable)
+	}
+
+	if w.idle != nil {
+		log.Printf("Waiting for the network to go idle...")
+		w.idle()
+	}
+	if w.rule.WaitFor != "" {
+		log.Printf("Waiting for %s...", w.rule.WaitFor)
+		if _, err := w.page.Element(w.rule.WaitFor); err != nil {
+			if !errors.Is(err, context.DeadlineExceeded) {
+				return err
+			}
+			log.Printf("%s did not appear within %s, reading the page as loaded", w.rule.WaitFor, w.timeout)
+		}
+	}
+	return nil
+}
+
+// release ends the timeout of the wait
+func (w *pageWait) release() {
+	if w.timeout != 0 {
+		w.page.CancelTimeout()
+	}
+}
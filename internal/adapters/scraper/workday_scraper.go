@@ -0,0 +1,271 @@
+// internal/adapters/scraper/workday_scraper.go
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// workdayDomains host the career sites of Workday, e.g.
+// https://acme.wd5.myworkdayjobs.com/en-US/External or
+// https://wd3.myworkdaysite.com/recruiting/acme/External
+const (
+	workdayJobsDomain = ".myworkdayjobs.com"
+	workdaySiteDomain = ".myworkdaysite.com"
+)
+
+// workdayPageSize is the number of postings requested per page, the most the
+// API returns
+const workdayPageSize = 20
+
+// workdayMaxPages bounds the pages read per scrape
+const workdayMaxPages = 50
+
+// workdayLocale matches the locale prefix of career site paths, e.g. en-US
+var workdayLocale = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+
+// workdayPostedDays matches how long ago a posting was posted, e.g. "Posted 3
+// Days Ago"
+var workdayPostedDays = regexp.MustCompile(`(?i)posted (\d+) days? ago`)
+
+// WorkdayScraper implements the BoardScraper interface for career sites
+// hosted by Workday, paging through the jobs API the sites load their lists
+// from
+type WorkdayScraper struct {
+	client *http.Client
+}
+
+// workdaySite is a career site on Workday
+type workdaySite struct {
+	api  string // jobs API of the site
+	base string // URL job paths are relative to
+	name string // tenant of the site
+}
+
+// workdayPage is a page of the jobs API. A scrape keeps the postings of all
+// pages in one page as its raw content.
+type workdayPage struct {
+	Total       int              `json:"total"`
+	JobPostings []workdayPosting `json:"jobPostings"`
+}
+
+// workdayPosting is a posting of the jobs API
+type workdayPosting struct {
+	Title         string   `json:"title"`
+	ExternalPath  string   `json:"externalPath"`
+	LocationsText string   `json:"locationsText"`
+	PostedOn      string   `json:"postedOn"`
+	RemoteType    string   `json:"remoteType"`
+	BulletFields  []string `json:"bulletFields"`
+}
+
+// workdayQuery is the body of a jobs API request
+type workdayQuery struct {
+	AppliedFacets map[string]interface{} `json:"appliedFacets"`
+	Limit         int                    `json:"limit"`
+	Offset        int                    `json:"offset"`
+	SearchText    string                 `json:"searchText"`
+}
+
+// NewWorkdayScraper creates a scraper querying Workday career sites with
+// client
+func NewWorkdayScraper(client *http.Client) *WorkdayScraper {
+	return &WorkdayScraper{client: client}
+}
+
+// Matches reports whether url is a career site hosted by Workday
+func (s *WorkdayScraper) Matches(url string) bool {
+	_, ok := parseWorkdaySite(url)
+	return ok
+}
+
+// Detects reports whether a page is a response of the jobs API
+func (s *WorkdayScraper) Detects(page string) bool {
+	return strings.HasPrefix(strings.TrimSpace(page), "{") && strings.Contains(page, `"jobPostings"`) && strings.Contains(page, `"externalPath"`)
+}
+
+// Scrape lists the postings of the career site, page by page
+func (s *WorkdayScraper) Scrape(ctx context.Context, sourceURL string) (domain.JobCollection, error) {
+	result := domain.JobCollection{
+		SourceURL: sourceURL,
+		ScrapedAt: time.Now(),
+	}
+	site, ok := parseWorkdaySite(sourceURL)
+	if !ok {
+		return result, fmt.Errorf("no Workday career site in %s", sourceURL)
+	}
+	result.CompanyName = companyFromSlug(site.name)
+	log.Printf("Querying the Workday career site %s", site.api)
+
+	var all workdayPage
+	for page := 0; page < workdayMaxPages; page++ {
+		next, err := s.page(ctx, site, len(all.JobPostings))
+		if err != nil {
+			return result, fmt.Errorf("failed to list Workday postings: %w", err)
+		}
+		if page == 0 {
+			all.Total = next.Total // later pages report 0
+		}
+		all.JobPostings = append(all.JobPostings, next.JobPostings...)
+		if len(next.JobPostings) < workdayPageSize || len(all.JobPostings) >= all.Total {
+			break
+		}
+	}
+
+	raw, err := json.Marshal(all)
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal Workday postings: %w", err)
+	}
+	result.RawContent = string(raw)
+
+	result.Jobs, err = s.ParseJobs(result.RawContent, sourceURL)
+	if err != nil {
+		return result, err
+	}
+	log.Printf("Found %d jobs on the Workday career site of %s", len(result.Jobs), site.name)
+	return result, nil
+}
+
+// ParseJobs extracts the postings from the raw content of a scrape
+func (s *WorkdayScraper) ParseJobs(raw, sourceURL string) ([]domain.Job, error) {
+	var page workdayPage
+	if err := decodeBoard([]byte(raw), &page); err != nil {
+		return nil, err
+	}
+	site, _ := parseWorkdaySite(sourceURL)
+
+	jobs := make([]domain.Job, 0, len(page.JobPostings))
+	seen := make(map[string]bool)
+	for _, posting := range page.JobPostings {
+		job := posting.job(site.base)
+		if job.ID == "" || seen[job.ID] {
+			continue
+		}
+		seen[job.ID] = true
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// page requests the postings of a career site from offset
+func (s *WorkdayScraper) page(ctx context.Context, site workdaySite, offset int) (workdayPage, error) {
+	var page workdayPage
+	body, err := json.Marshal(workdayQuery{
+		AppliedFacets: map[string]interface{}{},
+		Limit:         workdayPageSize,
+		Offset:        offset,
+	})
+	if err != nil {
+		return page, fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, site.api, bytes.NewReader(body))
+	if err != nil {
+		return page, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	response, err := fetchBoard(s.client, req)
+	if err != nil {
+		return page, err
+	}
+	err = decodeBoard(response, &page)
+	return page, err
+}
+
+// job converts a posting into a job, identified by its requisition ID or
+// else its path
+func (p workdayPosting) job(base string) domain.Job {
+	job := domain.Job{
+		ID:         p.ExternalPath,
+		Title:      strings.TrimSpace(p.Title),
+		Location:   strings.TrimSpace(p.LocationsText),
+		PostedDate: workdayPosted(p.PostedOn, time.Now()),
+		ScrapedAt:  time.Now(),
+	}
+	if len(p.BulletFields) > 0 && p.BulletFields[0] != "" {
+		job.ID = p.BulletFields[0]
+	}
+	if base != "" && p.ExternalPath != "" {
+		job.URL = base + p.ExternalPath
+	}
+	if p.RemoteType != "" {
+		job.Metadata = map[string]string{"workplace": p.RemoteType}
+	}
+	return job
+}
+
+// workdayPosted returns the day a posting was posted as of now, from the
+// relative dates the API returns. Older postings, "30+ Days Ago", have no
+// date.
+func workdayPosted(postedOn string, now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	lower := strings.ToLower(postedOn)
+	switch {
+	case strings.Contains(lower, "+"):
+		return time.Time{}
+	case strings.Contains(lower, "today"):
+		return today
+	case strings.Contains(lower, "yesterday"):
+		return today.AddDate(0, 0, -1)
+	}
+	if match := workdayPostedDays.FindStringSubmatch(postedOn); match != nil {
+		days, _ := strconv.Atoi(match[1])
+		return today.AddDate(0, 0, -days)
+	}
+	return time.Time{}
+}
+
+// parseWorkdaySite returns the career site of a URL on Workday
+func parseWorkdaySite(rawURL string) (workdaySite, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return workdaySite{}, false
+	}
+	host := strings.ToLower(u.Hostname())
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+
+	switch {
+	case strings.HasSuffix(host, workdayJobsDomain):
+		tenant, _, _ := strings.Cut(host, ".")
+		if len(segments) > 0 && workdayLocale.MatchString(segments[0]) {
+			segments = segments[1:]
+		}
+		if len(segments) == 0 || tenant == "" || tenant == "www" {
+			return workdaySite{}, false
+		}
+		return workdaySite{
+			api:  fmt.Sprintf("https://%s/wday/cxs/%s/%s/jobs", host, tenant, segments[0]),
+			base: fmt.Sprintf("https://%s/%s", host, segments[0]),
+			name: tenant,
+		}, true
+	case strings.HasSuffix(host, workdaySiteDomain):
+		if len(segments) > 0 && workdayLocale.MatchString(segments[0]) {
+			segments = segments[1:]
+		}
+		if len(segments) < 3 || segments[0] != "recruiting" {
+			return workdaySite{}, false
+		}
+		return workdaySite{
+			api:  fmt.Sprintf("https://%s/wday/cxs/%s/%s/jobs", host, segments[1], segments[2]),
+			base: fmt.Sprintf("https://%s/recruiting/%s/%s", host, segments[1], segments[2]),
+			name: segments[1],
+		}, true
+	}
+	return workdaySite{}, false
+}
+
+var (
+	_ BoardScraper  = (*WorkdayScraper)(nil) // Ensure interface compliance
+	_ BoardDetector = (*WorkdayScraper)(nil)
+)
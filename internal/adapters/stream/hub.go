@@ -0,0 +1,148 @@
+// internal/adapters/stream/hub.go
+package stream
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// subscriberBuffer is the number of events a subscriber may fall behind by
+// before it misses events
+const subscriberBuffer = 256
+
+// logTimeLayout is the timestamp the standard logger prefixes lines with
+const logTimeLayout = "2006/01/02 15:04:05"
+
+// Event is an entry of a stream. IDs increase by one per event, so clients
+// can resume after the last ID they saw.
+type Event struct {
+	ID   int64       `json:"id"`
+	Type string      `json:"type"`
+	Time time.Time   `json:"time"`
+	Data interface{} `json:"data"`
+}
+
+// LogEntry is a line written by the daemon's logger
+type LogEntry struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// Hub fans events out to subscribers and keeps the most recent ones, so new
+// subscribers see what just happened. Subscribers that fall behind miss
+// events rather than slowing down the daemon.
+type Hub struct {
+	mu          sync.Mutex
+	recent      []Event
+	size        int
+	nextID      int64
+	subscribers map[chan Event]struct{}
+}
+
+// NewHub creates a hub keeping the last size events
+func NewHub(size int) *Hub {
+	return &Hub{
+		size:        size,
+		nextID:      1,
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Publish adds an event to the stream
+func (h *Hub) Publish(eventType string, data interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	event := Event{ID: h.nextID, Type: eventType, Time: time.Now(), Data: data}
+	h.nextID++
+	h.recent = append(h.recent, event)
+	if len(h.recent) > h.size {
+		h.recent = h.recent[len(h.recent)-h.size:]
+	}
+	for ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default: // subscriber too slow, it misses this event
+		}
+	}
+}
+
+// Subscribe returns the kept events after the ID given, then streams new
+// ones until cancel is called. Pass 0 to get all kept events.
+func (h *Hub) Subscribe(after int64) ([]Event, <-chan Event, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var backlog []Event
+	for _, event := range h.recent {
+		if event.ID > after {
+			backlog = append(backlog, event)
+		}
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	h.subscribers[ch] = struct{}{}
+	cancel := func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers, ch)
+	}
+	return backlog, ch, cancel
+}
+
+// PublishJobEvents adds job events to the stream, typed by their event type
+func (h *Hub) PublishJobEvents(ctx context.Context, events []domain.JobEvent) {
+	for _, event := range events {
+		h.Publish(string(event.Type), event)
+	}
+}
+
+// LogWriter returns a writer publishing every line written to it as a
+// LogEntry, for use as (part of) the output of the standard logger
+func (h *Hub) LogWriter() *LogWriter {
+	return &LogWriter{hub: h}
+}
+
+// LogWriter turns log output into LogEntry events
+type LogWriter struct {
+	hub     *Hub
+	mu      sync.Mutex
+	partial []byte
+}
+
+// Write publishes each complete line of p, keeping a trailing partial line
+// until the rest of it is written
+func (w *LogWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.hub.Publish("log", parseLogLine(string(w.partial[:i])))
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// parseLogLine splits the timestamp of the standard logger off a line
+func parseLogLine(line string) LogEntry {
+	if len(line) > len(logTimeLayout) {
+		if at, err := time.ParseInLocation(logTimeLayout, line[:len(logTimeLayout)], time.Local); err == nil {
+			return LogEntry{Time: at, Message: line[len(logTimeLayout)+1:]}
+		}
+	}
+	return LogEntry{Time: time.Now(), Message: line}
+}
+
+var (
+	_ ports.JobEventPublisher = (*Hub)(nil) // Ensure interface compliance
+)
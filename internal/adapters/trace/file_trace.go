@@ -0,0 +1,144 @@
+// internal/adapters/trace/file_trace.go
+package trace
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// FileTraceStore implements the FilterTraceStore interface by appending JSON
+// lines to a file, which the CLI can read while the daemon is running
+type FileTraceStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileTraceStore creates a new FileTraceStore instance
+func NewFileTraceStore(path string) *FileTraceStore {
+	return &FileTraceStore{
+		path: path,
+	}
+}
+
+// RecordRejections appends the rejections to the trace file
+func (s *FileTraceStore) RecordRejections(ctx context.Context, rejections []domain.FilterRejection) error {
+	if len(rejections) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open filter trace file: %w", err)
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, rejection := range rejections {
+		if err := encoder.Encode(rejection); err != nil {
+			return fmt.Errorf("failed to write filter trace: %w", err)
+		}
+	}
+
+	return writer.Flush()
+}
+
+// FindRejections scans the trace file for matching rejections, oldest first
+func (s *FileTraceStore) FindRejections(ctx context.Context, url, title string) ([]domain.FilterRejection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open filter trace file: %w", err)
+	}
+	defer file.Close()
+
+	title = strings.ToLower(title)
+	var matches []domain.FilterRejection
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var rejection domain.FilterRejection
+		if err := json.Unmarshal(scanner.Bytes(), &rejection); err != nil {
+			// Skip lines cut short by a crash mid-write
+			continue
+		}
+		if url != "" && rejection.SourceURL != url {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(rejection.Title), title) {
+			continue
+		}
+		matches = append(matches, rejection)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read filter trace file: %w", err)
+	}
+
+	return matches, nil
+}
+
+// DeleteSource rewrites the trace file without the rejections of a source URL
+func (s *FileTraceStore) DeleteSource(ctx context.Context, url string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read filter trace file: %w", err)
+	}
+
+	var kept bytes.Buffer
+	deleted := 0
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		var rejection domain.FilterRejection
+		if err := json.Unmarshal(line, &rejection); err == nil && rejection.SourceURL == url {
+			deleted++
+			continue
+		}
+		kept.Write(line)
+		kept.WriteByte('\n')
+	}
+	if deleted == 0 {
+		return 0, nil
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, kept.Bytes(), 0o644); err != nil {
+		return 0, fmt.Errorf("failed to write filter trace file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return 0, fmt.Errorf("failed to replace filter trace file: %w", err)
+	}
+
+	return deleted, nil
+}
+
+var (
+	_ ports.FilterTraceStore = (*FileTraceStore)(nil) // Ensure interface compliance
+	_ ports.SourceDeleter    = (*FileTraceStore)(nil)
+)
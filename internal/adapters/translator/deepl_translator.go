@@ -0,0 +1,110 @@
+// internal/adapters/translator/deepl_translator.go
+package translator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// DeepL API endpoints; keys of the free plan end in ":fx"
+const (
+	deepLURL     = "https://api.deepl.com/v2/translate"
+	deepLFreeURL = "https://api-free.deepl.com/v2/translate"
+)
+
+// DeepLTranslator implements the Translator interface with the DeepL API
+type DeepLTranslator struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// deepLRequest represents the body of a DeepL translation request
+type deepLRequest struct {
+	Text       []string `json:"text"`
+	SourceLang string   `json:"source_lang,omitempty"`
+	TargetLang string   `json:"target_lang"`
+}
+
+// deepLResponse represents the body of a DeepL translation response
+type deepLResponse struct {
+	Translations []struct {
+		Text string `json:"text"`
+	} `json:"translations"`
+}
+
+// NewDeepLTranslator creates a new DeepLTranslator instance. An empty url
+// selects the API of the plan of apiKey.
+func NewDeepLTranslator(url, apiKey string, client *http.Client) *DeepLTranslator {
+	if url == "" {
+		url = deepLURL
+		if strings.HasSuffix(apiKey, ":fx") {
+			url = deepLFreeURL
+		}
+	}
+	return &DeepLTranslator{
+		url:    url,
+		apiKey: apiKey,
+		client: client,
+	}
+}
+
+// Translate translates texts from source to target; an empty source is
+// detected by DeepL
+func (t *DeepLTranslator) Translate(ctx context.Context, texts []string, source, target string) ([]string, error) {
+	body, err := json.Marshal(deepLRequest{
+		Text:       texts,
+		SourceLang: strings.ToUpper(source),
+		TargetLang: strings.ToUpper(target),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode translation request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", t.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+t.apiKey)
+
+	var response deepLResponse
+	if err := doTranslation(t.client, req, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Translations) != len(texts) {
+		return nil, fmt.Errorf("translator returned %d translations for %d texts", len(response.Translations), len(texts))
+	}
+
+	translations := make([]string, len(texts))
+	for i, translation := range response.Translations {
+		translations[i] = translation.Text
+	}
+	return translations, nil
+}
+
+// doTranslation sends a translation request and decodes the body of a 2xx
+// response into response
+func doTranslation(client *http.Client, req *http.Request, response any) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send translation request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("translator returned non-success status: %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("failed to decode translation: %w", err)
+	}
+	return nil
+}
+
+var _ ports.Translator = (*DeepLTranslator)(nil) // Ensure interface compliance
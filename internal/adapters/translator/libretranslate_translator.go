@@ -0,0 +1,81 @@
+// internal/adapters/translator/libretranslate_translator.go
+package translator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// LibreTranslateTranslator implements the Translator interface with a
+// LibreTranslate server, e.g. a self-hosted one
+type LibreTranslateTranslator struct {
+	url    string
+	apiKey string
+	client *http.Client
+}
+
+// libreTranslateRequest represents the body of a LibreTranslate request
+type libreTranslateRequest struct {
+	Q      []string `json:"q"`
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Format string   `json:"format"`
+	APIKey string   `json:"api_key,omitempty"`
+}
+
+// libreTranslateResponse represents the body of a LibreTranslate response
+type libreTranslateResponse struct {
+	TranslatedText []string `json:"translatedText"`
+}
+
+// NewLibreTranslateTranslator creates a new LibreTranslateTranslator
+// instance for the server at url, e.g. http://libretranslate:5000. apiKey
+// may be empty for servers not requiring one.
+func NewLibreTranslateTranslator(url, apiKey string, client *http.Client) *LibreTranslateTranslator {
+	return &LibreTranslateTranslator{
+		url:    strings.TrimSuffix(url, "/") + "/translate",
+		apiKey: apiKey,
+		client: client,
+	}
+}
+
+// Translate translates texts from source to target; an empty source is
+// detected by the server
+func (t *LibreTranslateTranslator) Translate(ctx context.Context, texts []string, source, target string) ([]string, error) {
+	if source == "" {
+		source = "auto"
+	}
+	body, err := json.Marshal(libreTranslateRequest{
+		Q:      texts,
+		Source: source,
+		Target: target,
+		Format: "text",
+		APIKey: t.apiKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode translation request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", t.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create translation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var response libreTranslateResponse
+	if err := doTranslation(t.client, req, &response); err != nil {
+		return nil, err
+	}
+	if len(response.TranslatedText) != len(texts) {
+		return nil, fmt.Errorf("translator returned %d translations for %d texts", len(response.TranslatedText), len(texts))
+	}
+	return response.TranslatedText, nil
+}
+
+var _ ports.Translator = (*LibreTranslateTranslator)(nil) // Ensure interface compliance
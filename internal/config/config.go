@@ -2,42 +2,494 @@
 package config
 
 import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
 	"strings"
-	
+	"time"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/normalize"
+	"github.com/fuzztobread/job-scheduler/internal/core/query"
 	"github.com/spf13/viper"
 )
 
+// WebhookMode values
+const (
+	WebhookModeDiff   = "diff"    // one event per source with changes
+	WebhookModePerJob = "per-job" // one event per created, updated or removed job
+)
+
+// SummaryFormat values
+const (
+	SummaryFormatHTML     = "html"
+	SummaryFormatMarkdown = "markdown"
+)
+
+// RenderMode values
+const (
+	RenderModeBrowser = "browser" // render pages in a headless browser
+	RenderModeHTTP    = "http"    // download pages with plain GET requests, for server-rendered pages
+)
+
+// StartupRun values
+const (
+	StartupRunOff     = "off"      // wait for the first scheduled run
+	StartupRunAlways  = "always"   // scrape immediately on startup
+	StartupRunIfStale = "if-stale" // scrape on startup if the last run is older than StartupStaleAfter
+)
+
+// LeaderElection values
+const (
+	LeaderElectionOff        = ""           // every instance runs the scheduled jobs
+	LeaderElectionRedis      = "redis"      // a lock key on the Redis server of RedisURL
+	LeaderElectionKubernetes = "kubernetes" // a Lease in the namespace of LeaderNamespace
+)
+
+// Translator values
+const (
+	TranslatorDeepL          = "deepl"          // the DeepL API, TranslatorAPIKey required
+	TranslatorLibreTranslate = "libretranslate" // a LibreTranslate server at TranslatorURL
+)
+
+// SearchConfig.Board values
+const (
+	SearchBoardIndeed   = "indeed"
+	SearchBoardLinkedIn = "linkedin"
+)
+
 // Config holds the application configuration
 type Config struct {
-	URLs                []string
-	ScrapeInterval      string
-	NotifierType        string
-	DiscordWebhookURL   string
-	SlackToken          string
-	SlackChannel        string
-	EmailSMTP           string
-	EmailFrom           string
-	EmailTo             string
-	LogLevel            string
-	LogFormat           string
+	URLs                  []string
+	ScrapeInterval        string        // cron expression, descriptor such as @hourly or interval such as "every 30m"
+	ScrapeConcurrency     int           // URLs scraped at once
+	HostConcurrency       int           // URLs sharing a host (e.g. greenhouse.io) scraped at once, 0 for no cap
+	FailureBackoffLimit   int           // most consecutive scheduled runs a failing URL sits out, e.g. 32; 0 (default) retries failing URLs every run
+	ScheduleJitter        time.Duration // longest random delay of a scheduled run after its tick, 0 runs on the tick
+	JobTimeout            time.Duration // deadline of a run of a scheduled job, such as a scrape of all URLs, 0 for none
+	Timezone              string        // IANA timezone schedules are read in, e.g. Europe/Berlin, the local time of the machine if empty
+	StartupRun            string        // one of the StartupRun* constants
+	LeaderElection        string        // one of the LeaderElection* constants; only the elected leader runs the scheduled jobs
+	LeaderLease           string        // name of the lock or Lease the instances compete for
+	LeaderLeaseTTL        time.Duration // how long the lease of a leader that stopped renewing it holds
+	LeaderID              string        // identity of this instance in the election, the hostname and process ID if empty
+	LeaderNamespace       string        // namespace of the Kubernetes Lease, the namespace of the pod if empty
+	StartupStaleAfter     time.Duration
+	ServerAddr            string            // address of the dashboard and HTTP API, empty disables the server
+	BatchAPIToken         string            // bearer token of external collectors submitting job batches, empty disables it
+	TriggerAPIToken       string            // bearer token of triggering a scrape with POST /api/scrape, empty disables it
+	AdminAPITokens        map[string]string // administrator name -> bearer token, for reading the audit trail and changing snoozes over the API
+	NotifierTypes         []string
+	NotifierMinSeverity   map[string]string // notifier type -> minimum severity delivered
+	NotificationHistory   bool              // record sent notifications and delivery attempts in the repository
+	NotifyErrors          bool
+	NotifierSelfTest      bool
+	DiscordWebhookURL     string
+	DiscordSigningSecret  string
+	DiscordScreenshots    bool // attach the screenshot of the scrape to change notifications, requires Screenshots
+	DiscordMentions       []DiscordMentionConfig
+	WebhookURL            string
+	WebhookSigningSecret  string
+	WebhookMode           string
+	AppriseURL            string
+	AppriseTag            string
+	ConsoleFile           string // console notifier output, empty for stdout
+	OverflowSummaries     bool   // link to a hosted summary instead of truncating changes a chat notifier can't show
+	SummaryStoreType      string
+	SummaryDir            string
+	SummaryBaseURL        string // URL the stored summaries are served under, e.g. http://host:8080/summaries
+	SummaryFormat         string
+	SnoozeFile            string // snoozes silencing sources and jobs per notifier, empty disables snoozing
+	SlackToken            string
+	SlackChannel          string
+	EmailSMTP             string
+	EmailFrom             string
+	EmailTo               string
+	ComplianceMode        bool
+	ComplianceMaxAge      time.Duration
+	ComplianceSchedule    string
+	StaleReport           bool
+	StaleAfter            time.Duration
+	StaleReportSchedule   string
+	ExitReportNotify      bool // also send the exit report logged on shutdown through the notifiers
+	RepositoryType        string
+	RepositorySecondary   string        // repository type also written to while migrating backends, empty disables it
+	RepositoryCache       bool          // keep the latest collections in memory in front of the repository
+	RepositoryCacheTTL    time.Duration // 0 keeps cached collections until the next save
+	RepositoryFallback    bool          // diff against remembered collections and queue writes while the repository is down
+	RepositoryRetryAfter  time.Duration // wait before trying a failed repository again
+	RepositoryQueueLimit  int           // writes queued while the repository is down, the oldest are dropped beyond
+	MemoryMaxSources      int           // URLs whose history the memory repository keeps, the least recently used lose it beyond; 0 keeps all
+	MemoryDumpFile        string        // file the memory repository is loaded from and dumped to, empty disables it
+	MemoryDumpInterval    time.Duration // dump the memory repository this often, 0 only dumps on shutdown
+	EncryptionKey         string        // base64 AES key encrypting stored collections, empty stores them in the clear
+	SnapshotRetention     int           // snapshots kept per URL, 0 keeps all
+	RawContentRetention   int           // newest snapshots per URL keeping their raw page, older ones keep their jobs only; 0 keeps it in all, encrypted collections always keep it
+	SnapshotMaxAge        time.Duration // snapshots older are pruned, 0 keeps them forever
+	JobMaxAge             time.Duration // sources not scraped for longer are pruned, 0 keeps them forever
+	RetentionSchedule     string
+	DataDir               string
+	BootstrapDataset      string // URL or file of a published backup seeding sources not tracked yet, empty disables it
+	BootstrapSHA256       string // hex SHA-256 the dataset must match, empty skips the check
+	RawArchive            bool   // keep raw pages in a separate archive instead of the repository
+	RawArchiveType        string
+	RawArchiveDir         string
+	SQLitePath            string
+	BoltPath              string
+	RedisURL              string
+	RedisKeyPrefix        string
+	RedisTTL              time.Duration // 0 keeps collections forever
+	S3Endpoint            string
+	S3Bucket              string
+	S3Prefix              string
+	S3Region              string
+	S3AccessKey           string // empty uses the AWS/MinIO credential chain
+	S3SecretKey           string
+	S3UseSSL              bool
+	RenderMode            string        // how career pages are rendered, one of the RenderMode* constants
+	BrowserURL            string        // DevTools URL of a remote Chrome to connect to instead of launching one, ws(s):// or http(s)://
+	BrowserPages          int           // pages of a shared browser used for scraping, 0 launches a browser per scrape
+	BrowserPageRecycle    int           // navigations before a page is recycled, 0 never recycles
+	ScrollMax             int           // scrolls to the bottom of a page to load lazy listings, 0 disables scrolling
+	ScrollIdle            time.Duration // how long a scroll may load nothing before the page counts as fully loaded
+	Screenshots           bool          // take a full-page screenshot of each rendered page, kept in the raw archive
+	EnrichDetails         bool          // complete new jobs from their detail pages before notifying
+	EnrichWorkers         int           // detail pages read concurrently
+	EnrichMaxJobs         int           // new jobs enriched per scrape of a source, 0 for all
+	ScrapeRetries         int           // retries of a scrape failing transiently (timeouts, 5xx), 0 disables retries
+	ScrapeRetryBackoff    time.Duration // wait before the first retry, doubled for each further retry
+	ScrapeRetryMaxBackoff time.Duration // cap of the wait between retries
+	HTTPTimeout           time.Duration
+	HTTPProxy             string
+	HTTPProxies           []string // proxies scrapes take turns with, one proxy per scrape
+	HTTPMaxRetries        int
+	HTTPRetryBackoff      time.Duration
+	HostRateLimit         int // scrapes per minute started on a single host, spaced out evenly and checked by config lint; 0 for no limit
+	HTTPMaxIdleConns      int
+	HTTPUserAgent         string
+	TLSCAFile             string
+	TLSCertFile           string
+	TLSKeyFile            string
+	TLSInsecureSkipVerify bool
+	LogLevel              string
+	LogFormat             string
+	FilterQuery           string
+	FilterLanguages       []string
+	FilterTraceFile       string
+	FilterTraceLimit      int
+	DigestMode            bool
+	DigestSimilarity      float64
+	SalaryCurrency        string
+	SalaryRates           map[string]float64 // units of each currency per one SalaryCurrency
+	SalaryRatesURL        string
+	SalaryRatesTTL        time.Duration
+	FilterSalaryMin       float64
+	FilterSalaryMax       float64
+	FilterSalaryRequired  bool
+	Watches               []WatchConfig
+	LocationAliases       []LocationAliasConfig
+	CategoryMappings      []CategoryMappingConfig
+	CategorySimilarity    float64
+	ExtractSalary         bool     // read salaries written in the title or description of jobs without one
+	JobIdentity           []string // strategies jobs are identified by across scrapes, tried in order: id, url and title-location
+	Translator            string   // service translating the titles of jobs in other languages, one of the Translator* constants, empty disables translation
+	TranslatorURL         string   // API URL of the translator, the DeepL API of the key's plan if empty
+	TranslatorAPIKey      string
+	TranslateTo           string // ISO 639-1 code of the language titles are translated to
+	Sources               []SourceConfig
+	Searches              []SearchConfig // saved job board searches, scraped in addition to URLs
+	Selectors             []SelectorConfig
+	Plugins               []PluginConfig // external scrapers sources can be scraped with
+	PDFTextCommand        []string       // command converting a PDF on its standard input to text, e.g. pdftotext -layout - -, the built-in extractor if empty
+	SelectorRegistryURL   string         // signed index of community selector profiles, empty disables the registry
+	SelectorRegistryKey   string         // base64 ed25519 public key the index must be signed with
+	SelectorRegistryPin   string         // index version to stay on, empty follows the latest
+	SelectorCacheFile     string         // last verified index, used while the registry is unreachable
+	SelectorRefresh       string         // schedule of registry updates
+}
+
+// DiscordMentionConfig pings a Discord role and/or user when a new job title
+// contains one of the keywords
+type DiscordMentionConfig struct {
+	Keywords []string
+	RoleID   string
+	UserID   string
+}
+
+// WatchConfig describes a watch rule raising alerts for matching jobs
+type WatchConfig struct {
+	Name      string
+	Owner     string // user the watch belongs to; their matching watches share one alert per job
+	Query     string
+	SalaryMin float64
+	SalaryMax float64
+}
+
+// LocationAliasConfig maps alternative or localized spellings to a canonical
+// location, extending the built-in normalization tables
+type LocationAliasConfig struct {
+	Canonical string
+	Aliases   []string
+}
+
+// CategoryMappingConfig maps department names to a standard category,
+// extending the built-in taxonomy
+type CategoryMappingConfig struct {
+	Category string
+	Aliases  []string
+}
+
+// SourceConfig holds per-source settings. Source URLs are scraped in
+// addition to URLs.
+type SourceConfig struct {
+	URL             string
+	Precheck        string // "head" or "get" to poll the page cheaply before scraping, empty to always scrape
+	PrecheckURL     string // page or API polled by the precheck, URL if empty
+	Render          string // "browser" or "http", RenderMode if empty
+	Proxy           string // proxy the source is always scraped through, instead of HTTPProxies
+	Plugin          string // name of the plugin scraping the source instead of the built-in scrapers
+	Sitemap         string // sitemap listing the source's postings, scraped one by one instead of URL
+	SitemapPattern  string // regexp of the posting URLs in Sitemap, job and career paths if empty
+	SitemapMaxPages int    // postings read per scrape, 100 if 0
+	PDFPattern      string // regexp of a listing in the text of a PDF source, naming fields with the groups title, location, department, id, url and description
+	PDFSeparator    string // regexp separating the listings of a PDF source without PDFPattern, blank lines if empty
+	Fields          []FieldConfig
+	Owner           *OwnerConfig   // person failure alerts of the source are addressed to
+	Cookies         []CookieConfig // cookies set in the browser before the source is loaded
+	Login           *LoginConfig   // scripted login run in the browser before the source is loaded
+	Intercept       *InterceptConfig
+}
+
+// SearchConfig is a saved search on a job board. Its results make a
+// collection like the jobs of a career page, so new matches are notified.
+type SearchConfig struct {
+	Board    string // one of the SearchBoard* constants
+	Query    string // keywords searched, e.g. "golang remote"
+	Location string // place searched near, empty for anywhere
+	MaxPages int    // result pages read per scrape, 3 if 0
+}
+
+// URL returns the URL of the search on its board
+func (s SearchConfig) URL() string {
+	values := url.Values{}
+	switch s.Board {
+	case SearchBoardIndeed:
+		values.Set("q", s.Query)
+		values.Set("l", s.Location)
+		return "https://www.indeed.com/jobs?" + values.Encode()
+	case SearchBoardLinkedIn:
+		values.Set("keywords", s.Query)
+		if s.Location != "" {
+			values.Set("location", s.Location)
+		}
+		return "https://www.linkedin.com/jobs/search?" + values.Encode()
+	}
+	return ""
+}
+
+// PluginConfig is an external scraper, written in any language: Command is
+// run, or Endpoint is POSTed to, with {"url": ...} for every scrape of a
+// source using it, and returns {"company_name": ..., "jobs": [...]}
+type PluginConfig struct {
+	Name     string
+	Command  []string      // executable and arguments, run without a shell
+	Endpoint string        // http(s) URL of a plugin server, instead of Command
+	Timeout  time.Duration // longest run of a scrape, 2m if 0
+}
+
+// InterceptConfig reads the jobs of a source from the JSON responses of the
+// API calls its page makes in the browser, instead of the rendered page. Paths
+// are dotted paths such as "data.jobs" or "location.city"; empty field paths
+// read the field of the same name, e.g. "title".
+type InterceptConfig struct {
+	URLPattern  string // regexp of the URLs of the responses read, e.g. "/api/v1/jobs"
+	Jobs        string // path to the array of jobs in a response, empty if the response is the array
+	ID          string
+	Title       string
+	Location    string
+	Department  string
+	URL         string
+	Description string
+	PostedDate  string
+}
+
+// CookieConfig is set in the browser before a source is loaded, e.g. the
+// session cookie of a gated career portal. Its value may reference
+// environment variables as ${NAME}, keeping credentials out of the config.
+type CookieConfig struct {
+	Name   string
+	Value  string
+	Domain string // the source's host if empty
+	Path   string
+}
+
+// LoginConfig signs in to a gated career portal: the browser opens URL and
+// runs the steps before it loads the source
+type LoginConfig struct {
+	URL   string
+	Steps []LoginStepConfig
+}
+
+// LoginStepConfig is a step of a login. Values may reference environment
+// variables as ${NAME} and are never logged.
+type LoginStepConfig struct {
+	Action   string // "fill" Value into Selector, "click" Selector, or "wait" for Selector or the page to settle
+	Selector string
+	Value    string
+}
+
+// OwnerConfig names the person responsible for a source. Failure alerts of
+// the source mention the owner and, if Notifier is set, are sent through that
+// notifier type instead of the shared NotifierType notifiers. Target replaces
+// where the notifier delivers to: the webhook URL of discord and webhook, the
+// tag of apprise or the file of console.
+type OwnerConfig struct {
+	Name     string
+	Contact  string // included in alerts, e.g. an email address or a Discord mention such as <@123>
+	Notifier string // notifier type to escalate to, empty for the shared notifiers
+	Target   string // destination of the notifier, empty for its usual settings
+}
+
+// FieldConfig extracts a custom field of the source's job listings into the
+// job metadata, usable in filters as metadata.<Name>
+type FieldConfig struct {
+	Name     string
+	Selector string // CSS selector within a listing, empty for the listing itself
+	Attr     string // attribute to read instead of the text
+	JSONPath string // dotted path into the value parsed as JSON
+}
+
+// SelectorConfig tells the scraper where the job listings of a site are,
+// replacing the built-in guesses. It applies to one source URL, or to every
+// source on a domain and its subdomains; a URL rule wins over a domain rule.
+// Field selectors are CSS selectors within the container; empty ones leave the
+// field empty.
+type SelectorConfig struct {
+	URL         string
+	Domain      string
+	Container   string // matches every job listing
+	Title       string
+	Location    string
+	Department  string
+	Description string
+	Link        string        // element whose href is the job URL, the container itself if empty
+	ApplyLink   string        // element whose href is the application form
+	IDAttr      string        // attribute of the container holding the job ID, identified by JobIdentity if empty
+	WaitFor     string        // element waited for before the page is read, instead of waiting for the page to settle
+	WaitIdle    bool          // wait for the network to go idle before the page is read
+	WaitTimeout time.Duration // longest wait of WaitFor and WaitIdle, 20s if 0; the page is read as loaded then
 }
 
 // LoadConfig loads the configuration from environment variables or config file
 func LoadConfig() (*Config, error) {
 	viper.SetDefault("ScrapeInterval", "*/5 * * * *")
+	viper.SetDefault("ScrapeConcurrency", 1)
+	viper.SetDefault("HostConcurrency", 1)
+	viper.SetDefault("FailureBackoffLimit", 0)
+	viper.SetDefault("ScheduleJitter", 0)
+	viper.SetDefault("JobTimeout", "30m")
+	viper.SetDefault("Timezone", "")
+	viper.SetDefault("StartupRun", StartupRunAlways)
+	viper.SetDefault("LeaderElection", LeaderElectionOff)
+	viper.SetDefault("LeaderLease", "careerscraper-leader")
+	viper.SetDefault("LeaderLeaseTTL", "15s")
+	viper.SetDefault("LeaderID", "")
+	viper.SetDefault("LeaderNamespace", "")
 	viper.SetDefault("NotifierType", "discord")
+	viper.SetDefault("WebhookMode", WebhookModeDiff)
+	viper.SetDefault("OverflowSummaries", false)
+	viper.SetDefault("SummaryStoreType", "file")
+	viper.SetDefault("SummaryDir", "data/summaries")
+	viper.SetDefault("SummaryBaseURL", "")
+	viper.SetDefault("SummaryFormat", SummaryFormatHTML)
+	viper.SetDefault("SnoozeFile", "data/snoozes.json")
+	viper.SetDefault("NotificationHistory", false)
+	viper.SetDefault("ComplianceMaxAge", "720h")
+	viper.SetDefault("ComplianceSchedule", "@daily")
+	viper.SetDefault("StaleReport", true)
+	viper.SetDefault("StaleAfter", "2160h") // 90 days
+	viper.SetDefault("StaleReportSchedule", "@weekly")
+	viper.SetDefault("ExitReportNotify", false)
+	viper.SetDefault("RepositoryType", "memory")
+	viper.SetDefault("RepositorySecondary", "")
+	viper.SetDefault("RepositoryCache", false)
+	viper.SetDefault("RepositoryCacheTTL", "1h")
+	viper.SetDefault("RepositoryFallback", false)
+	viper.SetDefault("RepositoryRetryAfter", "30s")
+	viper.SetDefault("RepositoryQueueLimit", 10000)
+	viper.SetDefault("MemoryMaxSources", 0)
+	viper.SetDefault("MemoryDumpFile", "")
+	viper.SetDefault("MemoryDumpInterval", "5m")
+	viper.SetDefault("EncryptionKey", "")
+	viper.SetDefault("SnapshotRetention", 100)
+	viper.SetDefault("RawContentRetention", 10)
+	viper.SetDefault("SnapshotMaxAge", 0)
+	viper.SetDefault("JobMaxAge", 0)
+	viper.SetDefault("RetentionSchedule", "@daily")
+	viper.SetDefault("DataDir", "data")
+	viper.SetDefault("BootstrapDataset", "")
+	viper.SetDefault("BootstrapSHA256", "")
+	viper.SetDefault("RawArchive", false)
+	viper.SetDefault("RawArchiveType", "file")
+	viper.SetDefault("RawArchiveDir", "data/raw")
+	viper.SetDefault("SQLitePath", "careerscraper.db")
+	viper.SetDefault("BoltPath", "careerscraper.bolt")
+	viper.SetDefault("RedisURL", "redis://localhost:6379/0")
+	viper.SetDefault("RedisKeyPrefix", "careerscraper:")
+	viper.SetDefault("S3Endpoint", "s3.amazonaws.com")
+	viper.SetDefault("S3Prefix", "careerscraper/")
+	viper.SetDefault("S3UseSSL", true)
+	viper.SetDefault("RenderMode", RenderModeBrowser)
+	viper.SetDefault("BrowserPages", 1)
+	viper.SetDefault("BrowserPageRecycle", 50)
+	viper.SetDefault("ScrollMax", 10)
+	viper.SetDefault("ScrollIdle", "1500ms")
+	viper.SetDefault("Screenshots", false)
+	viper.SetDefault("EnrichDetails", false)
+	viper.SetDefault("EnrichWorkers", 4)
+	viper.SetDefault("EnrichMaxJobs", 25)
+	viper.SetDefault("DiscordScreenshots", false)
+	viper.SetDefault("ScrapeRetries", 2)
+	viper.SetDefault("ScrapeRetryBackoff", "5s")
+	viper.SetDefault("ScrapeRetryMaxBackoff", "1m")
+	viper.SetDefault("HTTPTimeout", "10s")
+	viper.SetDefault("HTTPMaxRetries", 2)
+	viper.SetDefault("HTTPRetryBackoff", "500ms")
+	viper.SetDefault("HostRateLimit", 0)
+	viper.SetDefault("HTTPMaxIdleConns", 100)
+	viper.SetDefault("HTTPUserAgent", "career-scraper")
 	viper.SetDefault("LogLevel", "info")
 	viper.SetDefault("LogFormat", "json")
-	
+	viper.SetDefault("DigestSimilarity", 0.85)
+	viper.SetDefault("CategorySimilarity", 0.8)
+	viper.SetDefault("ExtractSalary", true)
+	viper.SetDefault("JobIdentity", strings.Join(normalize.DefaultJobIdentity, ","))
+	viper.SetDefault("TranslateTo", "en")
+	viper.SetDefault("FilterTraceLimit", 100)
+	viper.SetDefault("SalaryCurrency", "USD")
+	viper.SetDefault("SalaryRatesTTL", "24h")
+	viper.SetDefault("SelectorRegistryURL", "")
+	viper.SetDefault("SelectorRegistryKey", "")
+	viper.SetDefault("SelectorRegistryPin", "")
+	viper.SetDefault("SelectorCacheFile", "data/selector-registry.json")
+	viper.SetDefault("SelectorRefresh", "@daily")
+
 	viper.SetConfigName("config")
 	viper.SetConfigType("yaml")
 	viper.AddConfigPath(".")
 	viper.AddConfigPath("./config")
-	
+
 	// Read from environment variables
 	viper.SetEnvPrefix("CAREERSCRAPER")
 	viper.AutomaticEnv()
-	
+	_ = viper.BindEnv("BrowserURL", "CAREERSCRAPER_BROWSER_URL", "CAREERSCRAPER_BROWSERURL")
+
 	// Read from config file
 	if err := viper.ReadInConfig(); err != nil {
 		// It's okay if config file doesn't exist
@@ -45,25 +497,714 @@ func LoadConfig() (*Config, error) {
 			return nil, err
 		}
 	}
-	
+
 	config := &Config{
-		ScrapeInterval:    viper.GetString("ScrapeInterval"),
-		NotifierType:      viper.GetString("NotifierType"),
-		DiscordWebhookURL: viper.GetString("DiscordWebhookURL"),
-		SlackToken:        viper.GetString("SlackToken"),
-		SlackChannel:      viper.GetString("SlackChannel"),
-		EmailSMTP:         viper.GetString("EmailSMTP"),
-		EmailFrom:         viper.GetString("EmailFrom"),
-		EmailTo:           viper.GetString("EmailTo"),
-		LogLevel:          viper.GetString("LogLevel"),
-		LogFormat:         viper.GetString("LogFormat"),
-	}
-	
+		ScrapeInterval:        viper.GetString("ScrapeInterval"),
+		ScrapeConcurrency:     viper.GetInt("ScrapeConcurrency"),
+		HostConcurrency:       viper.GetInt("HostConcurrency"),
+		FailureBackoffLimit:   viper.GetInt("FailureBackoffLimit"),
+		ScheduleJitter:        viper.GetDuration("ScheduleJitter"),
+		JobTimeout:            viper.GetDuration("JobTimeout"),
+		Timezone:              viper.GetString("Timezone"),
+		StartupRun:            viper.GetString("StartupRun"),
+		LeaderElection:        viper.GetString("LeaderElection"),
+		LeaderLease:           viper.GetString("LeaderLease"),
+		LeaderLeaseTTL:        viper.GetDuration("LeaderLeaseTTL"),
+		LeaderID:              viper.GetString("LeaderID"),
+		LeaderNamespace:       viper.GetString("LeaderNamespace"),
+		StartupStaleAfter:     viper.GetDuration("StartupStaleAfter"),
+		ServerAddr:            viper.GetString("ServerAddr"),
+		BatchAPIToken:         viper.GetString("BatchAPIToken"),
+		TriggerAPIToken:       viper.GetString("TriggerAPIToken"),
+		AdminAPITokens:        viper.GetStringMapString("AdminAPITokens"),
+		NotifierTypes:         getStringList("NotifierType"),
+		NotifierMinSeverity:   viper.GetStringMapString("NotifierMinSeverity"),
+		NotificationHistory:   viper.GetBool("NotificationHistory"),
+		NotifyErrors:          viper.GetBool("NotifyErrors"),
+		NotifierSelfTest:      viper.GetBool("NotifierSelfTest"),
+		DiscordWebhookURL:     viper.GetString("DiscordWebhookURL"),
+		DiscordSigningSecret:  viper.GetString("DiscordSigningSecret"),
+		DiscordScreenshots:    viper.GetBool("DiscordScreenshots"),
+		WebhookURL:            viper.GetString("WebhookURL"),
+		WebhookSigningSecret:  viper.GetString("WebhookSigningSecret"),
+		WebhookMode:           viper.GetString("WebhookMode"),
+		AppriseURL:            viper.GetString("AppriseURL"),
+		AppriseTag:            viper.GetString("AppriseTag"),
+		ConsoleFile:           viper.GetString("ConsoleFile"),
+		OverflowSummaries:     viper.GetBool("OverflowSummaries"),
+		SummaryStoreType:      viper.GetString("SummaryStoreType"),
+		SummaryDir:            viper.GetString("SummaryDir"),
+		SummaryBaseURL:        viper.GetString("SummaryBaseURL"),
+		SummaryFormat:         viper.GetString("SummaryFormat"),
+		SnoozeFile:            viper.GetString("SnoozeFile"),
+		SlackToken:            viper.GetString("SlackToken"),
+		SlackChannel:          viper.GetString("SlackChannel"),
+		EmailSMTP:             viper.GetString("EmailSMTP"),
+		EmailFrom:             viper.GetString("EmailFrom"),
+		EmailTo:               viper.GetString("EmailTo"),
+		ComplianceMode:        viper.GetBool("ComplianceMode"),
+		ComplianceMaxAge:      viper.GetDuration("ComplianceMaxAge"),
+		ComplianceSchedule:    viper.GetString("ComplianceSchedule"),
+		StaleReport:           viper.GetBool("StaleReport"),
+		StaleAfter:            viper.GetDuration("StaleAfter"),
+		StaleReportSchedule:   viper.GetString("StaleReportSchedule"),
+		ExitReportNotify:      viper.GetBool("ExitReportNotify"),
+		RepositoryType:        viper.GetString("RepositoryType"),
+		RepositorySecondary:   viper.GetString("RepositorySecondary"),
+		RepositoryCache:       viper.GetBool("RepositoryCache"),
+		RepositoryCacheTTL:    viper.GetDuration("RepositoryCacheTTL"),
+		RepositoryFallback:    viper.GetBool("RepositoryFallback"),
+		RepositoryRetryAfter:  viper.GetDuration("RepositoryRetryAfter"),
+		RepositoryQueueLimit:  viper.GetInt("RepositoryQueueLimit"),
+		MemoryMaxSources:      viper.GetInt("MemoryMaxSources"),
+		MemoryDumpFile:        viper.GetString("MemoryDumpFile"),
+		MemoryDumpInterval:    viper.GetDuration("MemoryDumpInterval"),
+		EncryptionKey:         viper.GetString("EncryptionKey"),
+		SnapshotRetention:     viper.GetInt("SnapshotRetention"),
+		RawContentRetention:   viper.GetInt("RawContentRetention"),
+		SnapshotMaxAge:        viper.GetDuration("SnapshotMaxAge"),
+		JobMaxAge:             viper.GetDuration("JobMaxAge"),
+		RetentionSchedule:     viper.GetString("RetentionSchedule"),
+		DataDir:               viper.GetString("DataDir"),
+		BootstrapDataset:      viper.GetString("BootstrapDataset"),
+		BootstrapSHA256:       strings.ToLower(viper.GetString("BootstrapSHA256")),
+		RawArchive:            viper.GetBool("RawArchive"),
+		RawArchiveType:        viper.GetString("RawArchiveType"),
+		RawArchiveDir:         viper.GetString("RawArchiveDir"),
+		SQLitePath:            viper.GetString("SQLitePath"),
+		BoltPath:              viper.GetString("BoltPath"),
+		RedisURL:              viper.GetString("RedisURL"),
+		RedisKeyPrefix:        viper.GetString("RedisKeyPrefix"),
+		RedisTTL:              viper.GetDuration("RedisTTL"),
+		S3Endpoint:            viper.GetString("S3Endpoint"),
+		S3Bucket:              viper.GetString("S3Bucket"),
+		S3Prefix:              viper.GetString("S3Prefix"),
+		S3Region:              viper.GetString("S3Region"),
+		S3AccessKey:           viper.GetString("S3AccessKey"),
+		S3SecretKey:           viper.GetString("S3SecretKey"),
+		S3UseSSL:              viper.GetBool("S3UseSSL"),
+		RenderMode:            strings.ToLower(viper.GetString("RenderMode")),
+		BrowserURL:            viper.GetString("BrowserURL"),
+		BrowserPages:          viper.GetInt("BrowserPages"),
+		BrowserPageRecycle:    viper.GetInt("BrowserPageRecycle"),
+		ScrollMax:             viper.GetInt("ScrollMax"),
+		ScrollIdle:            viper.GetDuration("ScrollIdle"),
+		Screenshots:           viper.GetBool("Screenshots"),
+		EnrichDetails:         viper.GetBool("EnrichDetails"),
+		EnrichWorkers:         viper.GetInt("EnrichWorkers"),
+		EnrichMaxJobs:         viper.GetInt("EnrichMaxJobs"),
+		ScrapeRetries:         viper.GetInt("ScrapeRetries"),
+		ScrapeRetryBackoff:    viper.GetDuration("ScrapeRetryBackoff"),
+		ScrapeRetryMaxBackoff: viper.GetDuration("ScrapeRetryMaxBackoff"),
+		HTTPTimeout:           viper.GetDuration("HTTPTimeout"),
+		HTTPProxy:             viper.GetString("HTTPProxy"),
+		HTTPProxies:           getStringList("HTTPProxies"),
+		HTTPMaxRetries:        viper.GetInt("HTTPMaxRetries"),
+		HTTPRetryBackoff:      viper.GetDuration("HTTPRetryBackoff"),
+		HostRateLimit:         viper.GetInt("HostRateLimit"),
+		HTTPMaxIdleConns:      viper.GetInt("HTTPMaxIdleConns"),
+		HTTPUserAgent:         viper.GetString("HTTPUserAgent"),
+		TLSCAFile:             viper.GetString("TLSCAFile"),
+		TLSCertFile:           viper.GetString("TLSCertFile"),
+		TLSKeyFile:            viper.GetString("TLSKeyFile"),
+		TLSInsecureSkipVerify: viper.GetBool("TLSInsecureSkipVerify"),
+		LogLevel:              viper.GetString("LogLevel"),
+		LogFormat:             viper.GetString("LogFormat"),
+		FilterQuery:           viper.GetString("FilterQuery"),
+		FilterLanguages:       getStringList("FilterLanguages"),
+		FilterTraceFile:       viper.GetString("FilterTraceFile"),
+		FilterTraceLimit:      viper.GetInt("FilterTraceLimit"),
+		DigestMode:            viper.GetBool("DigestMode"),
+		DigestSimilarity:      viper.GetFloat64("DigestSimilarity"),
+		CategorySimilarity:    viper.GetFloat64("CategorySimilarity"),
+		ExtractSalary:         viper.GetBool("ExtractSalary"),
+		JobIdentity:           getStringList("JobIdentity"),
+		Translator:            strings.ToLower(viper.GetString("Translator")),
+		TranslatorURL:         viper.GetString("TranslatorURL"),
+		TranslatorAPIKey:      viper.GetString("TranslatorAPIKey"),
+		TranslateTo:           strings.ToLower(viper.GetString("TranslateTo")),
+		SalaryCurrency:        viper.GetString("SalaryCurrency"),
+		SalaryRatesURL:        viper.GetString("SalaryRatesURL"),
+		SalaryRatesTTL:        viper.GetDuration("SalaryRatesTTL"),
+		FilterSalaryMin:       viper.GetFloat64("FilterSalaryMin"),
+		FilterSalaryMax:       viper.GetFloat64("FilterSalaryMax"),
+		FilterSalaryRequired:  viper.GetBool("FilterSalaryRequired"),
+		SelectorRegistryURL:   viper.GetString("SelectorRegistryURL"),
+		SelectorRegistryKey:   viper.GetString("SelectorRegistryKey"),
+		SelectorRegistryPin:   viper.GetString("SelectorRegistryPin"),
+		SelectorCacheFile:     viper.GetString("SelectorCacheFile"),
+		SelectorRefresh:       viper.GetString("SelectorRefresh"),
+		PDFTextCommand:        viper.GetStringSlice("PDFTextCommand"),
+	}
+
+	if err := viper.UnmarshalKey("SalaryRates", &config.SalaryRates); err != nil {
+		return nil, err
+	}
+	if err := viper.UnmarshalKey("Watches", &config.Watches); err != nil {
+		return nil, err
+	}
+	if err := viper.UnmarshalKey("DiscordMentions", &config.DiscordMentions); err != nil {
+		return nil, err
+	}
+	if err := viper.UnmarshalKey("LocationAliases", &config.LocationAliases); err != nil {
+		return nil, err
+	}
+	if err := viper.UnmarshalKey("CategoryMappings", &config.CategoryMappings); err != nil {
+		return nil, err
+	}
+
 	// Parse URLs
-	urlsStr := viper.GetString("URLs")
-	if urlsStr != "" {
-		config.URLs = strings.Split(urlsStr, ",")
+	config.URLs = getStringList("URLs")
+	if err := viper.UnmarshalKey("Sources", &config.Sources); err != nil {
+		return nil, err
+	}
+	if err := viper.UnmarshalKey("Searches", &config.Searches); err != nil {
+		return nil, err
+	}
+	if err := viper.UnmarshalKey("Selectors", &config.Selectors); err != nil {
+		return nil, err
+	}
+	if err := viper.UnmarshalKey("Plugins", &config.Plugins); err != nil {
+		return nil, err
+	}
+	for _, source := range config.Sources {
+		if source.URL != "" && !slices.Contains(config.URLs, source.URL) {
+			config.URLs = append(config.URLs, source.URL)
+		}
+	}
+	for _, search := range config.Searches {
+		if u := search.URL(); u != "" && !slices.Contains(config.URLs, u) {
+			config.URLs = append(config.URLs, u)
+		}
 	}
-	
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
 	return config, nil
-}
\ No newline at end of file
+}
+
+// validate checks settings that can be verified without side effects
+func (c *Config) validate() error {
+	switch c.StartupRun {
+	case StartupRunOff, StartupRunAlways, StartupRunIfStale:
+	default:
+		return fmt.Errorf("StartupRun: must be %s, %s or %s, got %q", StartupRunOff, StartupRunAlways, StartupRunIfStale, c.StartupRun)
+	}
+
+	switch c.WebhookMode {
+	case WebhookModeDiff, WebhookModePerJob:
+	default:
+		return fmt.Errorf("WebhookMode: must be %s or %s, got %q", WebhookModeDiff, WebhookModePerJob, c.WebhookMode)
+	}
+
+	switch c.SummaryFormat {
+	case SummaryFormatHTML, SummaryFormatMarkdown:
+	default:
+		return fmt.Errorf("SummaryFormat: must be %s or %s, got %q", SummaryFormatHTML, SummaryFormatMarkdown, c.SummaryFormat)
+	}
+	if c.OverflowSummaries && c.SummaryBaseURL == "" {
+		return fmt.Errorf("SummaryBaseURL: required for overflow summaries")
+	}
+	if c.OverflowSummaries && c.SummaryStoreType == "s3" && c.S3Bucket == "" {
+		return fmt.Errorf("S3Bucket: required for the s3 summary store")
+	}
+
+	if c.ScrapeConcurrency < 1 {
+		return fmt.Errorf("ScrapeConcurrency: must be at least 1, got %d", c.ScrapeConcurrency)
+	}
+	if c.HostConcurrency < 0 {
+		return fmt.Errorf("HostConcurrency: must not be negative, got %d", c.HostConcurrency)
+	}
+	if c.FailureBackoffLimit < 0 {
+		return fmt.Errorf("FailureBackoffLimit: must not be negative, got %d", c.FailureBackoffLimit)
+	}
+	if c.ScheduleJitter < 0 {
+		return fmt.Errorf("ScheduleJitter: must not be negative, got %s", c.ScheduleJitter)
+	}
+	if c.JobTimeout < 0 {
+		return fmt.Errorf("JobTimeout: must not be negative, got %s", c.JobTimeout)
+	}
+	if _, err := c.ScheduleLocation(); err != nil {
+		return err
+	}
+	switch c.LeaderElection {
+	case LeaderElectionOff, LeaderElectionRedis, LeaderElectionKubernetes:
+	default:
+		return fmt.Errorf("LeaderElection: must be empty, %s or %s, got %q", LeaderElectionRedis, LeaderElectionKubernetes, c.LeaderElection)
+	}
+	if c.LeaderElection != LeaderElectionOff {
+		if c.LeaderLease == "" {
+			return fmt.Errorf("LeaderLease: required for leader election")
+		}
+		if c.LeaderLeaseTTL < 3*time.Second {
+			return fmt.Errorf("LeaderLeaseTTL: must be at least 3s, got %s", c.LeaderLeaseTTL)
+		}
+	}
+
+	if c.StaleReport && c.StaleAfter <= 0 {
+		return fmt.Errorf("StaleAfter: must be positive, got %s", c.StaleAfter)
+	}
+
+	if c.HTTPProxy != "" && !validProxy(c.HTTPProxy) {
+		return fmt.Errorf("HTTPProxy: must be an http(s) or socks5 URL, got %q", c.HTTPProxy)
+	}
+	for i, proxy := range c.HTTPProxies {
+		if !validProxy(proxy) {
+			return fmt.Errorf("HTTPProxies[%d]: must be an http(s) or socks5 URL, got %q", i, proxy)
+		}
+	}
+
+	plugins := make(map[string]bool, len(c.Plugins))
+	for i, plugin := range c.Plugins {
+		switch {
+		case plugin.Name == "":
+			return fmt.Errorf("Plugins[%d]: Name is required", i)
+		case plugins[plugin.Name]:
+			return fmt.Errorf("Plugins[%d]: duplicate name %q", i, plugin.Name)
+		case len(plugin.Command) == 0 && plugin.Endpoint == "":
+			return fmt.Errorf("Plugins[%d]: Command or Endpoint is required", i)
+		case len(plugin.Command) > 0 && plugin.Endpoint != "":
+			return fmt.Errorf("Plugins[%d]: set either Command or Endpoint, not both", i)
+		case plugin.Timeout < 0:
+			return fmt.Errorf("Plugins[%d].Timeout: must not be negative, got %s", i, plugin.Timeout)
+		}
+		if plugin.Endpoint != "" {
+			if u, err := url.Parse(plugin.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("Plugins[%d].Endpoint: must be an http(s) URL, got %q", i, plugin.Endpoint)
+			}
+		}
+		plugins[plugin.Name] = true
+	}
+
+	for i, source := range c.Sources {
+		if source.URL == "" {
+			return fmt.Errorf("Sources[%d]: URL is required", i)
+		}
+		if source.Plugin != "" && !plugins[source.Plugin] {
+			return fmt.Errorf("Sources[%d].Plugin: no plugin named %q", i, source.Plugin)
+		}
+		if source.Plugin != "" && source.Sitemap != "" {
+			return fmt.Errorf("Sources[%d]: set either Plugin or Sitemap, not both", i)
+		}
+		switch source.Precheck {
+		case "", "head", "get":
+		default:
+			return fmt.Errorf("Sources[%d].Precheck: must be head or get, got %q", i, source.Precheck)
+		}
+		switch source.Render {
+		case "", RenderModeBrowser, RenderModeHTTP:
+		default:
+			return fmt.Errorf("Sources[%d].Render: must be %s or %s, got %q", i, RenderModeBrowser, RenderModeHTTP, source.Render)
+		}
+		if source.Proxy != "" && !validProxy(source.Proxy) {
+			return fmt.Errorf("Sources[%d].Proxy: must be an http(s) or socks5 URL, got %q", i, source.Proxy)
+		}
+		if source.Sitemap != "" {
+			if u, err := url.Parse(source.Sitemap); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("Sources[%d].Sitemap: must be an http(s) URL, got %q", i, source.Sitemap)
+			}
+		} else if source.SitemapPattern != "" {
+			return fmt.Errorf("Sources[%d].SitemapPattern: requires Sitemap", i)
+		}
+		if _, err := regexp.Compile(source.SitemapPattern); err != nil {
+			return fmt.Errorf("Sources[%d].SitemapPattern: %w", i, err)
+		}
+		if source.SitemapMaxPages < 0 {
+			return fmt.Errorf("Sources[%d].SitemapMaxPages: must not be negative, got %d", i, source.SitemapMaxPages)
+		}
+		if source.PDFPattern != "" {
+			pattern, err := regexp.Compile(source.PDFPattern)
+			if err != nil {
+				return fmt.Errorf("Sources[%d].PDFPattern: %w", i, err)
+			}
+			if pattern.SubexpIndex("title") < 0 {
+				return fmt.Errorf("Sources[%d].PDFPattern: must have a title group, e.g. (?P<title>...)", i)
+			}
+		}
+		if _, err := regexp.Compile(source.PDFSeparator); err != nil {
+			return fmt.Errorf("Sources[%d].PDFSeparator: %w", i, err)
+		}
+		names := make(map[string]bool)
+		for j, field := range source.Fields {
+			name := strings.ToLower(field.Name)
+			switch {
+			case name == "":
+				return fmt.Errorf("Sources[%d].Fields[%d]: Name is required", i, j)
+			case names[name]:
+				return fmt.Errorf("Sources[%d].Fields[%d]: duplicate name %q", i, j, field.Name)
+			case field.Selector == "" && field.Attr == "":
+				return fmt.Errorf("Sources[%d].Fields[%d]: Selector or Attr is required", i, j)
+			}
+			names[name] = true
+		}
+		for j, cookie := range source.Cookies {
+			if cookie.Name == "" {
+				return fmt.Errorf("Sources[%d].Cookies[%d]: Name is required", i, j)
+			}
+		}
+		if login := source.Login; login != nil {
+			if u, err := url.Parse(login.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("Sources[%d].Login.URL: must be an http(s) URL, got %q", i, login.URL)
+			}
+			if len(login.Steps) == 0 {
+				return fmt.Errorf("Sources[%d].Login.Steps: at least one step is required", i)
+			}
+			for j, step := range login.Steps {
+				switch step.Action {
+				case "fill", "click":
+					if step.Selector == "" {
+						return fmt.Errorf("Sources[%d].Login.Steps[%d]: Selector is required to %s", i, j, step.Action)
+					}
+				case "wait":
+				default:
+					return fmt.Errorf("Sources[%d].Login.Steps[%d].Action: must be fill, click or wait, got %q", i, j, step.Action)
+				}
+			}
+		}
+		if (len(source.Cookies) > 0 || source.Login != nil) && (source.Render == RenderModeHTTP || (source.Render == "" && c.RenderMode == RenderModeHTTP)) {
+			return fmt.Errorf("Sources[%d]: Cookies and Login require the browser, but the source is rendered over %s", i, RenderModeHTTP)
+		}
+		if intercept := source.Intercept; intercept != nil {
+			if intercept.URLPattern == "" {
+				return fmt.Errorf("Sources[%d].Intercept.URLPattern: is required", i)
+			}
+			if _, err := regexp.Compile(intercept.URLPattern); err != nil {
+				return fmt.Errorf("Sources[%d].Intercept.URLPattern: %w", i, err)
+			}
+			if source.Render == RenderModeHTTP || (source.Render == "" && c.RenderMode == RenderModeHTTP) {
+				return fmt.Errorf("Sources[%d]: Intercept requires the browser, but the source is rendered over %s", i, RenderModeHTTP)
+			}
+		}
+		if source.Owner != nil {
+			if source.Owner.Name == "" && source.Owner.Contact == "" {
+				return fmt.Errorf("Sources[%d].Owner: Name or Contact is required", i)
+			}
+			if source.Owner.Target != "" && source.Owner.Notifier == "" {
+				return fmt.Errorf("Sources[%d].Owner.Target: requires Notifier", i)
+			}
+		}
+	}
+
+	for i, search := range c.Searches {
+		switch search.Board {
+		case SearchBoardIndeed, SearchBoardLinkedIn:
+		default:
+			return fmt.Errorf("Searches[%d].Board: must be %s or %s, got %q", i, SearchBoardIndeed, SearchBoardLinkedIn, search.Board)
+		}
+		if strings.TrimSpace(search.Query) == "" {
+			return fmt.Errorf("Searches[%d]: Query is required", i)
+		}
+		if search.MaxPages < 0 {
+			return fmt.Errorf("Searches[%d].MaxPages: must not be negative, got %d", i, search.MaxPages)
+		}
+	}
+
+	rules := make(map[string]int)
+	for i, rule := range c.Selectors {
+		key := "url " + rule.URL
+		switch {
+		case rule.URL == "" && rule.Domain == "":
+			return fmt.Errorf("Selectors[%d]: URL or Domain is required", i)
+		case rule.URL != "" && rule.Domain != "":
+			return fmt.Errorf("Selectors[%d]: set either URL or Domain, not both", i)
+		case rule.Container == "" && rule.Title == "" && (rule.WaitFor != "" || rule.WaitIdle):
+			// only a wait strategy, the page is parsed as without a rule
+		case rule.Container == "":
+			return fmt.Errorf("Selectors[%d]: Container is required", i)
+		case rule.Title == "":
+			return fmt.Errorf("Selectors[%d]: Title is required", i)
+		}
+		if rule.WaitTimeout < 0 {
+			return fmt.Errorf("Selectors[%d].WaitTimeout: must not be negative, got %s", i, rule.WaitTimeout)
+		}
+		if rule.Domain != "" {
+			key = "domain " + strings.ToLower(rule.Domain)
+		}
+		if j, seen := rules[key]; seen {
+			return fmt.Errorf("Selectors[%d]: same %s as Selectors[%d]", i, key, j)
+		}
+		rules[key] = i
+		for name, selector := range map[string]string{
+			"Container": rule.Container, "Title": rule.Title, "Location": rule.Location,
+			"Department": rule.Department, "Description": rule.Description,
+			"Link": rule.Link, "ApplyLink": rule.ApplyLink, "WaitFor": rule.WaitFor,
+		} {
+			if selector == "" {
+				continue
+			}
+			if _, err := cascadia.Compile(selector); err != nil {
+				return fmt.Errorf("Selectors[%d].%s: invalid CSS selector %q: %w", i, name, selector, err)
+			}
+		}
+	}
+
+	if c.SelectorRegistryURL != "" {
+		if _, err := c.SelectorRegistryPublicKey(); err != nil {
+			return err
+		}
+	}
+
+	if c.RepositoryFallback && c.RepositoryRetryAfter <= 0 {
+		return fmt.Errorf("RepositoryRetryAfter: must be positive, got %s", c.RepositoryRetryAfter)
+	}
+	if c.RepositoryFallback && c.RepositoryQueueLimit <= 0 {
+		return fmt.Errorf("RepositoryQueueLimit: must be positive, got %d", c.RepositoryQueueLimit)
+	}
+
+	if c.MemoryMaxSources < 0 {
+		return fmt.Errorf("MemoryMaxSources: must not be negative, got %d", c.MemoryMaxSources)
+	}
+	if c.MemoryMaxSources > 0 && c.MemoryMaxSources < len(c.URLs) {
+		return fmt.Errorf("MemoryMaxSources: must be at least the %d configured URLs, got %d", len(c.URLs), c.MemoryMaxSources)
+	}
+	if c.MemoryDumpInterval < 0 {
+		return fmt.Errorf("MemoryDumpInterval: must not be negative, got %s", c.MemoryDumpInterval)
+	}
+
+	if c.SnapshotRetention < 0 {
+		return fmt.Errorf("SnapshotRetention: must not be negative, got %d", c.SnapshotRetention)
+	}
+	if c.RawContentRetention < 0 {
+		return fmt.Errorf("RawContentRetention: must not be negative, got %d", c.RawContentRetention)
+	}
+
+	if c.EncryptionKey != "" {
+		if _, err := c.RepositoryKey(); err != nil {
+			return err
+		}
+		if c.ComplianceMode {
+			return fmt.Errorf("ComplianceMode: not supported with EncryptionKey, since personal data can't be purged from encrypted collections")
+		}
+	}
+
+	if c.BootstrapSHA256 != "" {
+		if sum, err := hex.DecodeString(c.BootstrapSHA256); err != nil || len(sum) != sha256.Size {
+			return fmt.Errorf("BootstrapSHA256: must be %d hex characters, got %q", 2*sha256.Size, c.BootstrapSHA256)
+		}
+	}
+
+	if c.SnapshotMaxAge < 0 {
+		return fmt.Errorf("SnapshotMaxAge: must not be negative, got %s", c.SnapshotMaxAge)
+	}
+	if c.JobMaxAge < 0 {
+		return fmt.Errorf("JobMaxAge: must not be negative, got %s", c.JobMaxAge)
+	}
+
+	switch c.RenderMode {
+	case RenderModeBrowser, RenderModeHTTP:
+	default:
+		return fmt.Errorf("RenderMode: must be %s or %s, got %q", RenderModeBrowser, RenderModeHTTP, c.RenderMode)
+	}
+	if c.ScrollMax < 0 {
+		return fmt.Errorf("ScrollMax: must not be negative, got %d", c.ScrollMax)
+	}
+	if c.ScrollMax > 0 && c.ScrollIdle <= 0 {
+		return fmt.Errorf("ScrollIdle: must be positive, got %s", c.ScrollIdle)
+	}
+	if c.ScrapeRetries < 0 {
+		return fmt.Errorf("ScrapeRetries: must not be negative, got %d", c.ScrapeRetries)
+	}
+	if c.ScrapeRetries > 0 && c.ScrapeRetryBackoff <= 0 {
+		return fmt.Errorf("ScrapeRetryBackoff: must be positive, got %s", c.ScrapeRetryBackoff)
+	}
+	if c.ScrapeRetries > 0 && c.ScrapeRetryMaxBackoff < c.ScrapeRetryBackoff {
+		return fmt.Errorf("ScrapeRetryMaxBackoff: must be at least ScrapeRetryBackoff, got %s", c.ScrapeRetryMaxBackoff)
+	}
+	if c.BrowserURL != "" {
+		u, err := url.Parse(c.BrowserURL)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("BrowserURL: must be a URL with a host, got %q", c.BrowserURL)
+		}
+		switch u.Scheme {
+		case "ws", "wss", "http", "https":
+		default:
+			return fmt.Errorf("BrowserURL: must be ws, wss, http or https, got %q", u.Scheme)
+		}
+	}
+	if c.BrowserPages < 0 {
+		return fmt.Errorf("BrowserPages: must not be negative, got %d", c.BrowserPages)
+	}
+	if c.BrowserPageRecycle < 0 {
+		return fmt.Errorf("BrowserPageRecycle: must not be negative, got %d", c.BrowserPageRecycle)
+	}
+
+	if c.HostRateLimit < 0 {
+		return fmt.Errorf("HostRateLimit: must not be negative, got %d", c.HostRateLimit)
+	}
+
+	if c.RepositorySecondary != "" && c.RepositorySecondary == c.RepositoryType {
+		return fmt.Errorf("RepositorySecondary: must differ from RepositoryType, got %s", c.RepositorySecondary)
+	}
+	if (c.RepositoryType == "s3" || c.RepositorySecondary == "s3") && c.S3Bucket == "" {
+		return fmt.Errorf("S3Bucket: required for the s3 repository")
+	}
+	if c.EnrichDetails && c.EnrichWorkers < 1 {
+		return fmt.Errorf("EnrichWorkers: must be at least 1, got %d", c.EnrichWorkers)
+	}
+	if c.EnrichMaxJobs < 0 {
+		return fmt.Errorf("EnrichMaxJobs: must not be negative, got %d", c.EnrichMaxJobs)
+	}
+	if _, err := normalize.ParseJobIdentity(c.JobIdentity); err != nil {
+		return fmt.Errorf("JobIdentity: %w", err)
+	}
+	for i, name := range c.JobIdentity {
+		if slices.Contains(c.JobIdentity[:i], name) {
+			return fmt.Errorf("JobIdentity: %s is listed twice", name)
+		}
+	}
+	switch c.Translator {
+	case "":
+	case TranslatorDeepL:
+		if c.TranslatorAPIKey == "" {
+			return fmt.Errorf("TranslatorAPIKey: required for the %s translator", c.Translator)
+		}
+	case TranslatorLibreTranslate:
+		if c.TranslatorURL == "" {
+			return fmt.Errorf("TranslatorURL: required for the %s translator", c.Translator)
+		}
+	default:
+		return fmt.Errorf("Translator: must be %s or %s, got %q", TranslatorDeepL, TranslatorLibreTranslate, c.Translator)
+	}
+	if c.TranslatorURL != "" {
+		if u, err := url.Parse(c.TranslatorURL); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("TranslatorURL: must be an http(s) URL, got %q", c.TranslatorURL)
+		}
+	}
+	if c.Translator != "" && len(c.TranslateTo) != 2 {
+		return fmt.Errorf("TranslateTo: must be an ISO 639-1 language code, got %q", c.TranslateTo)
+	}
+	if c.Screenshots && !c.RawArchive && !c.DiscordScreenshots {
+		return fmt.Errorf("Screenshots: requires RawArchive or DiscordScreenshots to keep them")
+	}
+	if c.DiscordScreenshots && !c.Screenshots {
+		return fmt.Errorf("DiscordScreenshots: requires Screenshots")
+	}
+	if c.RawArchive && c.RawArchiveType == "s3" && c.S3Bucket == "" {
+		return fmt.Errorf("S3Bucket: required for the s3 raw archive")
+	}
+
+	for name, token := range c.AdminAPITokens {
+		if token == "" {
+			return fmt.Errorf("AdminAPITokens.%s: must not be empty", name)
+		}
+	}
+	for name, severity := range c.NotifierMinSeverity {
+		if _, err := domain.ParseSeverity(severity); err != nil {
+			return fmt.Errorf("NotifierMinSeverity.%s: %w", name, err)
+		}
+	}
+
+	if c.FilterQuery != "" {
+		if _, err := query.Parse(c.FilterQuery); err != nil {
+			return fmt.Errorf("FilterQuery: %w", err)
+		}
+	}
+
+	for _, w := range c.Watches {
+		if w.Query == "" {
+			continue
+		}
+		if _, err := query.Parse(w.Query); err != nil {
+			return fmt.Errorf("watch %q: %w", w.Name, err)
+		}
+	}
+
+	for i, alias := range c.LocationAliases {
+		if alias.Canonical == "" {
+			return fmt.Errorf("LocationAliases[%d]: canonical location is required", i)
+		}
+	}
+
+	for i, mapping := range c.CategoryMappings {
+		if mapping.Category == "" {
+			return fmt.Errorf("CategoryMappings[%d]: category is required", i)
+		}
+	}
+	if c.CategorySimilarity <= 0 || c.CategorySimilarity > 1 {
+		return fmt.Errorf("CategorySimilarity: must be in (0, 1], got %g", c.CategorySimilarity)
+	}
+
+	return nil
+}
+
+// validProxy reports whether proxy is a URL of a proxy the HTTP client can
+// connect through
+func validProxy(proxy string) bool {
+	u, err := url.Parse(proxy)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+		return true
+	}
+	return false
+}
+
+// getStringList reads a list setting given either as a YAML list or as a
+// comma separated string (the only form available from environment variables)
+func getStringList(key string) []string {
+	var values []string
+	if raw, ok := viper.Get(key).(string); ok {
+		values = strings.Split(raw, ",")
+	} else {
+		values = viper.GetStringSlice(key)
+	}
+
+	var list []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// SelectorRegistryPublicKey decodes SelectorRegistryKey, which must be the
+// base64 encoding of a 32 byte ed25519 public key. A registry can't be used
+// without one, so an empty key is an error.
+func (c *Config) SelectorRegistryPublicKey() (ed25519.PublicKey, error) {
+	if c.SelectorRegistryKey == "" {
+		return nil, fmt.Errorf("SelectorRegistryKey: required to verify the selector registry")
+	}
+	key, err := base64.StdEncoding.DecodeString(c.SelectorRegistryKey)
+	if err != nil {
+		return nil, fmt.Errorf("SelectorRegistryKey: must be base64: %w", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("SelectorRegistryKey: must decode to %d bytes, got %d", ed25519.PublicKeySize, len(key))
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// ScheduleLocation returns the timezone of Timezone, the local time of the
+// machine if it is empty
+func (c *Config) ScheduleLocation() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("Timezone: must be an IANA timezone such as Europe/Berlin, got %q", c.Timezone)
+	}
+	return location, nil
+}
+
+// RepositoryKey decodes EncryptionKey, which must be the base64 encoding of a
+// 16, 24 or 32 byte AES key. It returns nil if encryption is disabled.
+func (c *Config) RepositoryKey() ([]byte, error) {
+	if c.EncryptionKey == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(c.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("EncryptionKey: must be base64: %w", err)
+	}
+	if len(key) != 16 && len(key) != 24 && len(key) != 32 {
+		return nil, fmt.Errorf("EncryptionKey: must decode to 16, 24 or 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
@@ -0,0 +1,128 @@
+// internal/core/currency/converter.go
+package currency
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// refreshTimeout bounds how long a rates refresh may take
+const refreshTimeout = 10 * time.Second
+
+// refreshRetry is how long conversions use the previous rates, or fail for
+// lack of any, after a failed refresh before the next one is attempted
+const refreshRetry = time.Minute
+
+// Converter converts amounts between currencies using rates from a RateSource.
+// Rates are cached and refreshed once they are older than the configured TTL.
+// A failed refresh is retried after refreshRetry at the earliest, or the TTL if
+// shorter, so an outage of the source doesn't stall every conversion.
+type Converter struct {
+	source    ports.RateSource
+	ttl       time.Duration
+	refresh   sync.Mutex // held while refreshing, so concurrent conversions wait for one refresh
+	mu        sync.Mutex
+	base      string
+	rates     map[string]float64
+	fetchedAt time.Time
+	failedAt  time.Time // of the last refresh, if it failed
+}
+
+// NewConverter creates a new Converter. A zero ttl caches rates forever.
+func NewConverter(source ports.RateSource, ttl time.Duration) *Converter {
+	return &Converter{
+		source: source,
+		ttl:    ttl,
+	}
+}
+
+// Refresh fetches the latest rates from the source
+func (c *Converter) Refresh(ctx context.Context) error {
+	base, rates, err := c.source.Rates(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch exchange rates: %w", err)
+	}
+
+	normalized := make(map[string]float64, len(rates)+1)
+	for code, rate := range rates {
+		normalized[strings.ToUpper(code)] = rate
+	}
+	base = strings.ToUpper(base)
+	normalized[base] = 1
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.base = base
+	c.rates = normalized
+	c.fetchedAt = time.Now()
+	c.failedAt = time.Time{}
+	return nil
+}
+
+// Convert converts amount from one currency into another
+func (c *Converter) Convert(amount float64, from, to string) (float64, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to {
+		return amount, nil
+	}
+
+	rates := c.currentRates()
+	fromRate, ok := rates[from]
+	if !ok || fromRate == 0 {
+		return 0, fmt.Errorf("no exchange rate for %s", from)
+	}
+	toRate, ok := rates[to]
+	if !ok {
+		return 0, fmt.Errorf("no exchange rate for %s", to)
+	}
+
+	return amount / fromRate * toRate, nil
+}
+
+// currentRates returns the cached rates, refreshing them when stale
+func (c *Converter) currentRates() map[string]float64 {
+	if c.due() {
+		c.refresh.Lock()
+		if c.due() {
+			ctx, cancel := context.WithTimeout(context.Background(), refreshTimeout)
+			if err := c.Refresh(ctx); err != nil {
+				// Keep using the previous rates, if any, until the retry
+				log.Printf("Failed to refresh exchange rates, retrying in %s: %v", c.retryDelay(), err)
+				c.mu.Lock()
+				c.failedAt = time.Now()
+				c.mu.Unlock()
+			}
+			cancel()
+		}
+		c.refresh.Unlock()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.rates
+}
+
+// due reports whether the rates are stale and no failed refresh is awaiting
+// its retry
+func (c *Converter) due() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.failedAt.IsZero() && time.Since(c.failedAt) < c.retryDelay() {
+		return false
+	}
+	return c.rates == nil || (c.ttl > 0 && time.Since(c.fetchedAt) > c.ttl)
+}
+
+// retryDelay returns how long to wait after a failed refresh
+func (c *Converter) retryDelay() time.Duration {
+	if c.ttl > 0 {
+		return min(refreshRetry, c.ttl)
+	}
+	return refreshRetry
+}
@@ -0,0 +1,68 @@
+// internal/core/domain/audit.go
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry records an administrative action that changed state
+type AuditEntry struct {
+	ID       int64     `json:"id"`
+	At       time.Time `json:"at"`
+	Actor    string    `json:"actor"`
+	Action   string    `json:"action"`             // e.g. "source.delete"
+	Target   string    `json:"target"`             // what was acted on, e.g. a source URL
+	Previous string    `json:"previous,omitempty"` // JSON encoded value before the action
+	Current  string    `json:"current,omitempty"`  // JSON encoded value after the action
+}
+
+// NewAuditEntry creates an audit entry, encoding previous and current as JSON.
+// Nil values are left empty.
+func NewAuditEntry(actor, action, target string, previous, current interface{}) AuditEntry {
+	return AuditEntry{
+		At:       time.Now(),
+		Actor:    actor,
+		Action:   action,
+		Target:   target,
+		Previous: auditValue(previous),
+		Current:  auditValue(current),
+	}
+}
+
+// auditValue encodes a value for the audit log
+func auditValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// AuditFilter selects audit entries. Zero fields match everything.
+type AuditFilter struct {
+	Actor  string
+	Action string
+	Target string
+	Since  time.Time
+	Limit  int // maximum number of entries, newest first; 0 for all
+}
+
+// Matches reports whether the entry satisfies the filter, ignoring Limit
+func (f AuditFilter) Matches(entry AuditEntry) bool {
+	switch {
+	case f.Actor != "" && entry.Actor != f.Actor:
+		return false
+	case f.Action != "" && entry.Action != f.Action:
+		return false
+	case f.Target != "" && entry.Target != f.Target:
+		return false
+	case !f.Since.IsZero() && entry.At.Before(f.Since):
+		return false
+	default:
+		return true
+	}
+}
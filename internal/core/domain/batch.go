@@ -0,0 +1,13 @@
+// internal/core/domain/batch.go
+package domain
+
+// BatchResult is the outcome of processing one job collection submitted by an
+// external collector. A source's first collection becomes its baseline and
+// reports no changes.
+type BatchResult struct {
+	SourceURL   string `json:"source_url"`
+	NewJobs     int    `json:"new_jobs"`
+	UpdatedJobs int    `json:"updated_jobs"`
+	RemovedJobs int    `json:"removed_jobs"`
+	Error       string `json:"error,omitempty"`
+}
@@ -0,0 +1,72 @@
+// internal/core/domain/bulk_import.go
+package domain
+
+import "time"
+
+// ImportProgress tracks the throttled first import of a large board: the
+// detail page of every job listed at the baseline is fetched and archived,
+// a few at a time, and the progress is saved so an interrupted import
+// resumes where it stopped
+type ImportProgress struct {
+	SourceURL  string       `json:"source_url"`
+	StartedAt  time.Time    `json:"started_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+	BaselineAt time.Time    `json:"baseline_at"` // scrape time of the collection whose jobs are imported
+	Items      []ImportItem `json:"items"`
+}
+
+// ImportItem is the import state of a single job
+type ImportItem struct {
+	JobID      string    `json:"job_id"`
+	URL        string    `json:"url"`
+	ArchivedAt time.Time `json:"archived_at,omitempty"` // zero while pending
+	Attempts   int       `json:"attempts,omitempty"`
+	Error      string    `json:"error,omitempty"` // of the last failed attempt
+}
+
+// NewImportProgress starts the import of the jobs of a collection. Jobs
+// without a URL of their own have no detail page and are left out.
+func NewImportProgress(collection JobCollection, now time.Time) ImportProgress {
+	progress := ImportProgress{
+		SourceURL:  collection.SourceURL,
+		StartedAt:  now,
+		UpdatedAt:  now,
+		BaselineAt: collection.ScrapedAt,
+	}
+	seen := make(map[string]bool)
+	for _, job := range collection.Jobs {
+		if job.URL == "" || job.URL == collection.SourceURL || seen[job.URL] {
+			continue
+		}
+		seen[job.URL] = true
+		progress.Items = append(progress.Items, ImportItem{JobID: job.ID, URL: job.URL})
+	}
+	return progress
+}
+
+// Archived reports whether the detail page of the job was archived
+func (i ImportItem) Archived() bool {
+	return !i.ArchivedAt.IsZero()
+}
+
+// Counts returns how many jobs were archived, failed at their last attempt
+// and were not attempted yet
+func (p ImportProgress) Counts() (archived, failed, pending int) {
+	for _, item := range p.Items {
+		switch {
+		case item.Archived():
+			archived++
+		case item.Error != "":
+			failed++
+		default:
+			pending++
+		}
+	}
+	return archived, failed, pending
+}
+
+// Complete reports whether the detail page of every job was archived
+func (p ImportProgress) Complete() bool {
+	archived, _, _ := p.Counts()
+	return archived == len(p.Items)
+}
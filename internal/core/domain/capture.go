@@ -0,0 +1,118 @@
+// internal/core/domain/capture.go
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// CaptureKind says which notifier method a captured notification came from
+type CaptureKind string
+
+const (
+	// CaptureKindJobs is the diff of one source, see Notifier
+	CaptureKindJobs CaptureKind = "jobs"
+
+	// CaptureKindDigest is the digest of a run, see DigestNotifier
+	CaptureKindDigest CaptureKind = "digest"
+
+	// CaptureKindMessage is a standalone notification, see MessageNotifier
+	CaptureKindMessage CaptureKind = "message"
+)
+
+// CapturedNotification is a notification recorded by the capture notifier
+// instead of being sent. Exactly one of Diff, Digest and Notification is set,
+// according to Kind.
+type CapturedNotification struct {
+	ID           int64         `json:"id"`
+	CapturedAt   time.Time     `json:"captured_at"`
+	Kind         CaptureKind   `json:"kind"`
+	Diff         *DiffResult   `json:"diff,omitempty"`
+	Digest       *Digest       `json:"digest,omitempty"`
+	Notification *Notification `json:"notification,omitempty"`
+}
+
+// Summary describes the captured notification in one line
+func (c CapturedNotification) Summary() string {
+	switch {
+	case c.Diff != nil:
+		return fmt.Sprintf("%s: %d new, %d updated, %d removed jobs",
+			c.Diff.CompanyName, len(c.Diff.NewJobs), len(c.Diff.UpdatedJobs), len(c.Diff.RemovedJobs))
+	case c.Digest != nil:
+		return fmt.Sprintf("Digest of %d career pages", len(c.Digest.Diffs))
+	case c.Notification != nil:
+		if c.Notification.CompanyName != "" {
+			return fmt.Sprintf("%s: %s", c.Notification.CompanyName, c.Notification.Title)
+		}
+		return c.Notification.Title
+	default:
+		return string(c.Kind)
+	}
+}
+
+// Concerns reports whether the capture says anything about the source url
+func (c CapturedNotification) Concerns(url string) bool {
+	return notificationConcerns(c.Diff, c.Digest, c.Notification, url)
+}
+
+// WithoutSource returns the capture without what it says about the source
+// url, and whether anything is left of it. Only a digest covers several
+// sources, so only a digest can be left with the others.
+func (c CapturedNotification) WithoutSource(url string) (CapturedNotification, bool) {
+	var left bool
+	c.Diff, c.Digest, c.Notification, left = notificationWithoutSource(c.Diff, c.Digest, c.Notification, url)
+	return c, left
+}
+
+// notificationConcerns reports whether the diff, digest or standalone
+// notification a notification consists of says anything about url
+func notificationConcerns(diff *DiffResult, digest *Digest, notification *Notification, url string) bool {
+	switch {
+	case diff != nil:
+		return diff.SourceURL == url
+	case notification != nil:
+		return notification.SourceURL == url
+	case digest == nil:
+		return false
+	}
+	for _, d := range digest.Diffs {
+		if d.SourceURL == url {
+			return true
+		}
+	}
+	return false
+}
+
+// notificationWithoutSource removes what the diff, digest or standalone
+// notification a notification consists of says about url, reporting whether
+// anything is left
+func notificationWithoutSource(diff *DiffResult, digest *Digest, notification *Notification, url string) (*DiffResult, *Digest, *Notification, bool) {
+	if !notificationConcerns(diff, digest, notification, url) {
+		return diff, digest, notification, true
+	}
+	if digest == nil {
+		return diff, digest, notification, false
+	}
+
+	kept := *digest
+	kept.Diffs = nil
+	for _, d := range digest.Diffs {
+		if d.SourceURL != url {
+			kept.Diffs = append(kept.Diffs, d)
+		}
+	}
+	kept.Clusters = nil
+	for _, cluster := range digest.Clusters {
+		var entries []DigestEntry
+		for _, entry := range cluster.Entries {
+			if entry.SourceURL != url {
+				entries = append(entries, entry)
+			}
+		}
+		if len(entries) > 0 {
+			cluster.Entries = entries
+			kept.Clusters = append(kept.Clusters, cluster)
+		}
+	}
+	return diff, &kept, notification, len(kept.Diffs) > 0
+}
@@ -0,0 +1,74 @@
+// internal/core/domain/checksum.go
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrSnapshotCorrupted is matched by errors.Is for any CorruptSnapshotError
+var ErrSnapshotCorrupted = errors.New("snapshot corrupted")
+
+// CorruptSnapshotError is returned by repositories when a stored job collection
+// fails its integrity check, e.g. after a truncated write or a bad migration
+type CorruptSnapshotError struct {
+	SourceURL string
+	Reason    string
+}
+
+// Error implements the error interface
+func (e *CorruptSnapshotError) Error() string {
+	return fmt.Sprintf("snapshot for %s is corrupted: %s", e.SourceURL, e.Reason)
+}
+
+// Is makes errors.Is(err, ErrSnapshotCorrupted) match
+func (e *CorruptSnapshotError) Is(target error) bool {
+	return target == ErrSnapshotCorrupted
+}
+
+// ComputeChecksum returns a SHA-256 checksum over the source URL and jobs of the
+// collection. The raw content is not covered since it may be archived separately.
+func (c JobCollection) ComputeChecksum() string {
+	jobs := c.Jobs
+	if jobs == nil {
+		jobs = []Job{} // stores may not distinguish nil from empty
+	}
+
+	data, err := json.Marshal(struct {
+		SourceURL string `json:"source_url"`
+		Jobs      []Job  `json:"jobs"`
+	}{c.SourceURL, jobs})
+	if err != nil {
+		// Jobs only contain plain data, so marshalling cannot fail in practice
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// WithChecksum returns a copy of the collection with its checksum set
+func (c JobCollection) WithChecksum() JobCollection {
+	c.Checksum = c.ComputeChecksum()
+	return c
+}
+
+// VerifyChecksum checks the stored checksum against the collection contents.
+// Collections persisted before checksums existed have none and are accepted.
+func (c JobCollection) VerifyChecksum() error {
+	if c.Checksum == "" {
+		return nil
+	}
+
+	if actual := c.ComputeChecksum(); actual != c.Checksum {
+		return &CorruptSnapshotError{
+			SourceURL: c.SourceURL,
+			Reason:    fmt.Sprintf("checksum mismatch (stored %.12s, computed %.12s)", c.Checksum, actual),
+		}
+	}
+
+	return nil
+}
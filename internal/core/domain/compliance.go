@@ -0,0 +1,177 @@
+// internal/core/domain/compliance.go
+package domain
+
+// StripPersonalData returns a copy of the collection without the fields that
+// may carry personal data: job descriptions (which often name recruiters and
+// their contact details) and the raw page content. The checksum is updated.
+func (c JobCollection) StripPersonalData() JobCollection {
+	jobs := make([]Job, len(c.Jobs))
+	for i, job := range c.Jobs {
+		job.Description = ""
+		jobs[i] = job
+	}
+
+	c.Jobs = jobs
+	c.RawContent = ""
+	if c.Checksum != "" {
+		c = c.WithChecksum()
+	}
+	return c
+}
+
+// StripPersonalData returns a copy of the record without the descriptions of
+// the jobs its diff lists, as StripPersonalData does for collections
+func (r DiffRecord) StripPersonalData() DiffRecord {
+	r.Diff = r.Diff.StripPersonalData()
+	return r
+}
+
+// HasPersonalData reports whether the diff of the record still lists job
+// descriptions
+func (r DiffRecord) HasPersonalData() bool {
+	return r.Diff.HasPersonalData()
+}
+
+// StripPersonalData returns a copy of the diff without the descriptions of
+// the jobs it lists
+func (d DiffResult) StripPersonalData() DiffResult {
+	d.NewJobs = stripDescriptions(d.NewJobs)
+	d.RemovedJobs = stripDescriptions(d.RemovedJobs)
+	d.UpdatedJobs = stripDescriptions(d.UpdatedJobs)
+	if d.PreviousJobs != nil {
+		previous := make(map[string]Job, len(d.PreviousJobs))
+		for id, job := range d.PreviousJobs {
+			job.Description = ""
+			previous[id] = job
+		}
+		d.PreviousJobs = previous
+	}
+	if d.Alerts != nil {
+		alerts := make([]WatchAlert, len(d.Alerts))
+		for i, alert := range d.Alerts {
+			alert.Job.Description = ""
+			alerts[i] = alert
+		}
+		d.Alerts = alerts
+	}
+	return d
+}
+
+// HasPersonalData reports whether the diff still lists job descriptions
+func (d DiffResult) HasPersonalData() bool {
+	for _, jobs := range [][]Job{d.NewJobs, d.RemovedJobs, d.UpdatedJobs} {
+		for _, job := range jobs {
+			if job.Description != "" {
+				return true
+			}
+		}
+	}
+	for _, job := range d.PreviousJobs {
+		if job.Description != "" {
+			return true
+		}
+	}
+	for _, alert := range d.Alerts {
+		if alert.Job.Description != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// StripPersonalData returns a copy of the capture without the descriptions
+// of the jobs it lists. The payload of a standalone notification, which may
+// list jobs too, is dropped.
+func (c CapturedNotification) StripPersonalData() CapturedNotification {
+	c.Diff, c.Digest, c.Notification = stripNotificationPersonalData(c.Diff, c.Digest, c.Notification)
+	return c
+}
+
+// HasPersonalData reports whether the capture still lists job descriptions
+func (c CapturedNotification) HasPersonalData() bool {
+	return notificationHasPersonalData(c.Diff, c.Digest, c.Notification)
+}
+
+// StripPersonalData returns a copy of the record without the descriptions
+// of the jobs it lists, as StripPersonalData does for captures
+func (r NotificationRecord) StripPersonalData() NotificationRecord {
+	r.Diff, r.Digest, r.Notification = stripNotificationPersonalData(r.Diff, r.Digest, r.Notification)
+	return r
+}
+
+// HasPersonalData reports whether the record still lists job descriptions
+func (r NotificationRecord) HasPersonalData() bool {
+	return notificationHasPersonalData(r.Diff, r.Digest, r.Notification)
+}
+
+// stripNotificationPersonalData strips the personal data of the diff, digest
+// or standalone notification a notification consists of
+func stripNotificationPersonalData(diff *DiffResult, digest *Digest, notification *Notification) (*DiffResult, *Digest, *Notification) {
+	if diff != nil {
+		stripped := diff.StripPersonalData()
+		diff = &stripped
+	}
+	if digest != nil {
+		stripped := *digest
+		stripped.Diffs = make([]DiffResult, len(digest.Diffs))
+		for i, d := range digest.Diffs {
+			stripped.Diffs[i] = d.StripPersonalData()
+		}
+		stripped.Clusters = make([]TitleCluster, len(digest.Clusters))
+		for i, cluster := range digest.Clusters {
+			entries := make([]DigestEntry, len(cluster.Entries))
+			for j, entry := range cluster.Entries {
+				entry.Job.Description = ""
+				entries[j] = entry
+			}
+			cluster.Entries = entries
+			stripped.Clusters[i] = cluster
+		}
+		digest = &stripped
+	}
+	if notification != nil {
+		stripped := *notification
+		stripped.Payload = nil
+		notification = &stripped
+	}
+	return diff, digest, notification
+}
+
+// notificationHasPersonalData reports whether the diff, digest or standalone
+// notification a notification consists of still lists job descriptions
+func notificationHasPersonalData(diff *DiffResult, digest *Digest, notification *Notification) bool {
+	switch {
+	case diff != nil && diff.HasPersonalData():
+		return true
+	case notification != nil && notification.Payload != nil:
+		return true
+	case digest == nil:
+		return false
+	}
+	for _, d := range digest.Diffs {
+		if d.HasPersonalData() {
+			return true
+		}
+	}
+	for _, cluster := range digest.Clusters {
+		for _, entry := range cluster.Entries {
+			if entry.Job.Description != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stripDescriptions returns a copy of jobs without their descriptions
+func stripDescriptions(jobs []Job) []Job {
+	if jobs == nil {
+		return nil
+	}
+	stripped := make([]Job, len(jobs))
+	for i, job := range jobs {
+		job.Description = ""
+		stripped[i] = job
+	}
+	return stripped
+}
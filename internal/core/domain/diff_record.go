@@ -0,0 +1,23 @@
+// internal/core/domain/diff_record.go
+package domain
+
+import "time"
+
+// DiffRecord is a diff computed by a scrape run, kept so what the scraper
+// believed changed can be audited regardless of whether it was notified
+type DiffRecord struct {
+	RunID      string     `json:"run_id"`
+	ComputedAt time.Time  `json:"computed_at"`
+	Diff       DiffResult `json:"diff"`
+}
+
+// FilterDiffRecords returns the records computed at or after since, oldest first
+func FilterDiffRecords(records []DiffRecord, since time.Time) []DiffRecord {
+	var filtered []DiffRecord
+	for _, record := range records {
+		if !record.ComputedAt.Before(since) {
+			filtered = append(filtered, record)
+		}
+	}
+	return filtered
+}
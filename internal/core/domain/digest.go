@@ -0,0 +1,143 @@
+// internal/core/domain/digest.go
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/textutil"
+)
+
+// DefaultClusterSimilarity is the title similarity above which jobs are clustered
+const DefaultClusterSimilarity = 0.85
+
+// titleAbbreviations expands common abbreviations before titles are compared
+var titleAbbreviations = map[string]string{
+	"sr":  "senior",
+	"jr":  "junior",
+	"eng": "engineer",
+	"dev": "developer",
+	"mgr": "manager",
+}
+
+// Digest groups the diffs of a whole scrape run into a single notification
+type Digest struct {
+	CreatedAt  time.Time      `json:"created_at"`
+	Diffs      []DiffResult   `json:"diffs"`
+	Clusters   []TitleCluster `json:"clusters"`   // new jobs grouped by similar title
+	Similarity float64        `json:"similarity"` // threshold used for clustering
+}
+
+// DigestEntry is a job together with the company it was found at
+type DigestEntry struct {
+	CompanyName string `json:"company_name"`
+	SourceURL   string `json:"source_url"`
+	Job         Job    `json:"job"`
+}
+
+// TitleCluster groups jobs with near-identical titles
+type TitleCluster struct {
+	Title     string        `json:"title"`
+	Entries   []DigestEntry `json:"entries"`
+	Locations []string      `json:"locations,omitempty"` // distinct canonical locations of the entries
+}
+
+// NewDigest creates a digest for diffs, clustering new jobs whose titles are at
+// least threshold similar
+func NewDigest(diffs []DiffResult, threshold float64) Digest {
+	return Digest{
+		CreatedAt:  time.Now(),
+		Diffs:      diffs,
+		Clusters:   ClusterNewJobs(diffs, threshold),
+		Similarity: threshold,
+	}
+}
+
+// Severity returns the highest severity of the digest's diffs
+func (d Digest) Severity() Severity {
+	severity := SeverityInfo
+	for _, diff := range d.Diffs {
+		severity = severity.Max(diff.Severity)
+	}
+	return severity
+}
+
+// NewJobCount returns the number of new jobs across all diffs
+func (d Digest) NewJobCount() int {
+	count := 0
+	for _, diff := range d.Diffs {
+		count += len(diff.NewJobs)
+	}
+	return count
+}
+
+// ClusterNewJobs groups the new jobs of diffs by fuzzy title similarity.
+// Clusters are ordered by size, largest first.
+func ClusterNewJobs(diffs []DiffResult, threshold float64) []TitleCluster {
+	if threshold <= 0 {
+		threshold = DefaultClusterSimilarity
+	}
+
+	var clusters []TitleCluster
+	var keys []string
+
+	for _, diff := range diffs {
+		for _, job := range diff.NewJobs {
+			entry := DigestEntry{
+				CompanyName: diff.CompanyName,
+				SourceURL:   diff.SourceURL,
+				Job:         job,
+			}
+			key := clusterKey(job.Title)
+
+			matched := false
+			for i := range clusters {
+				if textutil.Similarity(keys[i], key) >= threshold {
+					clusters[i].Entries = append(clusters[i].Entries, entry)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				clusters = append(clusters, TitleCluster{Title: job.Title, Entries: []DigestEntry{entry}})
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	for i := range clusters {
+		clusters[i].Locations = clusterLocations(clusters[i].Entries)
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i].Entries) > len(clusters[j].Entries)
+	})
+
+	return clusters
+}
+
+// clusterLocations returns the distinct canonical locations of entries, in order of appearance
+func clusterLocations(entries []DigestEntry) []string {
+	var locations []string
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		location := entry.Job.CanonicalLocation()
+		if location != "" && !seen[location] {
+			seen[location] = true
+			locations = append(locations, location)
+		}
+	}
+	return locations
+}
+
+// clusterKey normalizes a title for similarity comparison
+func clusterKey(title string) string {
+	words := strings.Fields(textutil.NormalizeKey(title))
+	for i, word := range words {
+		if expanded, ok := titleAbbreviations[word]; ok {
+			words[i] = expanded
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,26 @@
+// internal/core/domain/errors.go
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned by repositories when nothing is stored for the requested key
+var ErrNotFound = errors.New("not found")
+
+// ErrNotModified is returned by scrapers when a page is unchanged since the
+// previous scrape, so parsing and diffing it can be skipped
+var ErrNotModified = errors.New("not modified")
+
+// ScrapeBlocked is returned by scrapers when a site answers with a bot
+// protection or CAPTCHA interstitial instead of its career page, so the
+// scrape fails instead of reporting that the jobs are gone
+type ScrapeBlocked struct {
+	SourceURL string
+	Reason    string // what gave the interstitial away, e.g. "Cloudflare challenge"
+}
+
+func (e *ScrapeBlocked) Error() string {
+	return fmt.Sprintf("%s is blocking the scraper: %s", e.SourceURL, e.Reason)
+}
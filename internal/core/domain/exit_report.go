@@ -0,0 +1,59 @@
+// internal/core/domain/exit_report.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExitReport sums up a session of the scheduler when it shuts down, so
+// operators can tell whether stopping the instance loses any work
+type ExitReport struct {
+	StartedAt           time.Time       `json:"started_at"`
+	StoppedAt           time.Time       `json:"stopped_at"`
+	RunsCompleted       int             `json:"runs_completed"`
+	RunsInProgress      int             `json:"runs_in_progress"` // runs the shutdown interrupted
+	NotificationsSent   int             `json:"notifications_sent"`
+	NotificationsFailed int             `json:"notifications_failed"`
+	PendingWrites       int             `json:"pending_writes"` // repository writes queued during an outage
+	FailingSources      []TrackedSource `json:"failing_sources"`
+}
+
+// SafeToStop reports whether nothing of the session is lost by stopping:
+// no run was interrupted and no write is still waiting for the repository
+func (r ExitReport) SafeToStop() bool {
+	return r.RunsInProgress == 0 && r.PendingWrites == 0
+}
+
+// Message describes the report in a few lines
+func (r ExitReport) Message() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Uptime: %s\n", r.StoppedAt.Sub(r.StartedAt).Round(time.Second))
+	fmt.Fprintf(&b, "Runs completed: %d, interrupted: %d\n", r.RunsCompleted, r.RunsInProgress)
+	fmt.Fprintf(&b, "Notifications sent: %d, failed: %d\n", r.NotificationsSent, r.NotificationsFailed)
+	fmt.Fprintf(&b, "Pending repository writes: %d\n", r.PendingWrites)
+	fmt.Fprintf(&b, "Sources in failure state: %d", len(r.FailingSources))
+	for _, source := range r.FailingSources {
+		fmt.Fprintf(&b, "\n%s: %s", source.SourceURL, source.LastError)
+	}
+	return b.String()
+}
+
+// CreateExitReportNotification creates a notification of a shutdown, of high
+// severity if stopping lost work
+func CreateExitReportNotification(report ExitReport) Notification {
+	notification := Notification{
+		Type:      NotificationTypeExitReport,
+		Severity:  SeverityInfo,
+		Title:     "Career Scraper Stopped",
+		Message:   report.Message(),
+		CreatedAt: report.StoppedAt,
+		Payload:   report,
+	}
+	if !report.SafeToStop() {
+		notification.Severity = SeverityHigh
+		notification.Title = "Career Scraper Stopped With Unfinished Work"
+	}
+	return notification
+}
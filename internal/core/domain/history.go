@@ -0,0 +1,253 @@
+// internal/core/domain/history.go
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// JobVersion is a job's fields as first seen by a scrape
+type JobVersion struct {
+	SeenAt time.Time `json:"seen_at"`
+	Job    Job       `json:"job"`
+}
+
+// JobHistory is the timeline of a job ID on a source, built from snapshots.
+// Its versions only reach back as far as the snapshots the repository
+// retains; its sightings and removal also come from the jobs the newest
+// snapshot tracks, see TrackSeen, so lifetimes outlast the retention.
+type JobHistory struct {
+	SourceURL   string       `json:"source_url"`
+	CompanyName string       `json:"company_name"`
+	JobID       string       `json:"job_id"`
+	FirstSeenAt time.Time    `json:"first_seen_at"`
+	LastSeenAt  time.Time    `json:"last_seen_at"`
+	RemovedAt   time.Time    `json:"removed_at"` // first scrape without the job after LastSeenAt, zero if still listed
+	Versions    []JobVersion `json:"versions"`   // oldest first, one per change of the job's fields
+}
+
+// Open reports whether the job is still listed
+func (h JobHistory) Open() bool {
+	return h.RemovedAt.IsZero()
+}
+
+// OpenFor returns how long the job has been listed, up to now if still open
+func (h JobHistory) OpenFor(now time.Time) time.Duration {
+	if h.Open() {
+		return now.Sub(h.FirstSeenAt)
+	}
+	return h.RemovedAt.Sub(h.FirstSeenAt)
+}
+
+// BuildJobHistories returns the histories of all jobs found in the snapshots
+// of a source, or among the departed jobs of the newest one, ordered by when
+// they were first seen. Snapshots may be given in any order.
+func BuildJobHistories(snapshots []JobCollection) []JobHistory {
+	ordered := make([]JobCollection, len(snapshots))
+	copy(ordered, snapshots)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].ScrapedAt.Before(ordered[j].ScrapedAt)
+	})
+
+	histories := make(map[string]*JobHistory)
+	var order []string
+	for _, snapshot := range ordered {
+		listed := make(map[string]bool, len(snapshot.Jobs))
+		for _, job := range snapshot.Jobs {
+			listed[job.ID] = true
+
+			h, exists := histories[job.ID]
+			if !exists {
+				h = &JobHistory{
+					SourceURL: snapshot.SourceURL,
+					JobID:     job.ID,
+				}
+				histories[job.ID] = h
+				order = append(order, job.ID)
+			}
+			if h.FirstSeenAt.IsZero() {
+				h.FirstSeenAt = snapshot.ScrapedAt
+			}
+			h.CompanyName = snapshot.CompanyName
+			h.LastSeenAt = snapshot.ScrapedAt
+			h.RemovedAt = time.Time{} // relisted
+
+			if n := len(h.Versions); n == 0 || jobFieldsChanged(h.Versions[n-1].Job, job) {
+				h.Versions = append(h.Versions, JobVersion{SeenAt: snapshot.ScrapedAt, Job: job})
+			}
+		}
+
+		for id, h := range histories {
+			if !listed[id] && h.RemovedAt.IsZero() {
+				h.RemovedAt = snapshot.ScrapedAt
+			}
+		}
+	}
+
+	if len(ordered) > 0 {
+		order = addSightings(histories, order, ordered[len(ordered)-1])
+	}
+
+	result := make([]JobHistory, 0, len(order))
+	for _, id := range order {
+		result = append(result, *histories[id])
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].FirstSeenAt.Before(result[j].FirstSeenAt)
+	})
+	return result
+}
+
+// addSightings completes the histories with the sightings tracked by the
+// newest snapshot, which reach back before the oldest snapshot retained,
+// adding the departed jobs no snapshot lists. It returns the IDs of the
+// histories in order.
+func addSightings(histories map[string]*JobHistory, order []string, newest JobCollection) []string {
+	for _, job := range newest.Jobs {
+		if h, ok := histories[job.ID]; ok && !job.FirstSeenAt.IsZero() && job.FirstSeenAt.Before(h.FirstSeenAt) {
+			h.FirstSeenAt = job.FirstSeenAt
+		}
+	}
+	for _, sighting := range newest.Departed {
+		if h, ok := histories[sighting.ID]; ok {
+			if sighting.FirstSeenAt.Before(h.FirstSeenAt) {
+				h.FirstSeenAt = sighting.FirstSeenAt
+			}
+			continue
+		}
+		histories[sighting.ID] = &JobHistory{
+			SourceURL:   newest.SourceURL,
+			CompanyName: newest.CompanyName,
+			JobID:       sighting.ID,
+			FirstSeenAt: sighting.FirstSeenAt,
+			LastSeenAt:  sighting.LastSeenAt,
+			RemovedAt:   sighting.RemovedAt,
+			Versions: []JobVersion{{
+				SeenAt: sighting.FirstSeenAt,
+				Job:    Job{ID: sighting.ID, Title: sighting.Title, Location: sighting.Location},
+			}},
+		}
+		order = append(order, sighting.ID)
+	}
+	return order
+}
+
+// BuildJobHistory returns the history of one job ID from the snapshots of its
+// source, false if the job isn't in any of them nor departed from the newest
+func BuildJobHistory(snapshots []JobCollection, jobID string) (JobHistory, bool) {
+	var own []JobCollection
+	for _, snapshot := range snapshots {
+		var jobs []Job
+		for _, job := range snapshot.Jobs {
+			if job.ID == jobID {
+				jobs = append(jobs, job)
+			}
+		}
+		var departed []JobSighting
+		for _, sighting := range snapshot.Departed {
+			if sighting.ID == jobID {
+				departed = append(departed, sighting)
+			}
+		}
+		snapshot.Jobs = jobs
+		snapshot.Departed = departed
+		own = append(own, snapshot)
+	}
+
+	for _, h := range BuildJobHistories(own) {
+		return h, true
+	}
+	return JobHistory{}, false
+}
+
+// jobFieldsChanged compares the fields a scrape reports as updates. A
+// description removed by a personal data purge isn't a change.
+func jobFieldsChanged(previous, current Job) bool {
+	if previous.Description != "" && current.Description != "" && previous.Description != current.Description {
+		return true
+	}
+	return previous.Title != current.Title ||
+		previous.Location != current.Location ||
+		previous.Department != current.Department ||
+		previous.URL != current.URL
+}
+
+// MedianOpenFor returns the median time closed jobs stayed listed and how many
+// were closed. Jobs still open are left out, since their lifetime is unknown.
+func MedianOpenFor(histories []JobHistory) (time.Duration, int) {
+	var durations []time.Duration
+	for _, h := range histories {
+		if !h.Open() {
+			durations = append(durations, h.RemovedAt.Sub(h.FirstSeenAt))
+		}
+	}
+	if len(durations) == 0 {
+		return 0, 0
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	mid := len(durations) / 2
+	if len(durations)%2 == 0 {
+		return (durations[mid-1] + durations[mid]) / 2, len(durations)
+	}
+	return durations[mid], len(durations)
+}
+
+// CollectionAt reconstructs the jobs of a source as they were listed at a
+// point in time: the newest snapshot scraped at or before at. It returns
+// false if every snapshot is newer.
+func CollectionAt(snapshots []JobCollection, at time.Time) (JobCollection, bool) {
+	var found JobCollection
+	ok := false
+	for _, snapshot := range snapshots {
+		if snapshot.ScrapedAt.After(at) {
+			continue
+		}
+		if !ok || snapshot.ScrapedAt.After(found.ScrapedAt) {
+			found, ok = snapshot, true
+		}
+	}
+	return found, ok
+}
+
+// ListedAt reconstructs the jobs of a source listed at a point in time before
+// its oldest retained snapshot from the sightings newest tracks: the jobs
+// first seen by then and not removed yet, as newest lists them, and the
+// departed jobs with the title and location they had. Gaps of jobs taken
+// down and listed again are not known. It returns false if no job was seen by
+// then.
+func ListedAt(newest JobCollection, at time.Time) (JobCollection, bool) {
+	listed := JobCollection{
+		CompanyName: newest.CompanyName,
+		SourceURL:   newest.SourceURL,
+		ScrapedAt:   newest.ScrapedAt,
+	}
+	for _, job := range newest.Jobs {
+		if !firstSeen(job, newest).After(at) {
+			listed.Jobs = append(listed.Jobs, job)
+		}
+	}
+	for _, sighting := range newest.Departed {
+		if !sighting.FirstSeenAt.After(at) && sighting.RemovedAt.After(at) {
+			listed.Jobs = append(listed.Jobs, Job{ID: sighting.ID, Title: sighting.Title, Location: sighting.Location})
+		}
+	}
+	return listed, len(listed.Jobs) > 0
+}
+
+// FirstSighting returns the earliest time a job of the collection, listed or
+// departed, was first seen, its scrape time if none was seen earlier
+func (c JobCollection) FirstSighting() time.Time {
+	first := c.ScrapedAt
+	for _, job := range c.Jobs {
+		if seen := firstSeen(job, c); seen.Before(first) {
+			first = seen
+		}
+	}
+	for _, sighting := range c.Departed {
+		if !sighting.FirstSeenAt.IsZero() && sighting.FirstSeenAt.Before(first) {
+			first = sighting.FirstSeenAt
+		}
+	}
+	return first
+}
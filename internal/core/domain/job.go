@@ -1,18 +1,87 @@
 // internal/core/domain/job.go
 package domain
 
-import "time"
+import (
+	"sort"
+	"strings"
+	"time"
+)
 
 // Job represents a job listing from a career page
 type Job struct {
-	ID          string
-	Title       string
-	Description string
-	Location    string
-	Department  string
-	URL         string
-	PostedDate  time.Time
-	ScrapedAt   time.Time
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Location    string `json:"location,omitempty"`
+	// NormalizedLocation is Location mapped to canonical values, used by filters
+	NormalizedLocation string `json:"normalized_location,omitempty"`
+	Department         string `json:"department,omitempty"`
+	// Category is Department mapped to a standard taxonomy, e.g. "Engineering"
+	Category string `json:"category,omitempty"`
+	URL      string `json:"url,omitempty"`
+	// ApplyURL links straight to the application form when the source exposes one
+	ApplyURL string  `json:"apply_url,omitempty"`
+	Salary   *Salary `json:"salary,omitempty"`
+	// Language is the ISO 639-1 code of the posting language, empty if unknown
+	Language string `json:"language,omitempty"`
+	// TranslatedTitle is Title translated for notifications when the job is
+	// posted in another language, see DisplayTitle
+	TranslatedTitle string `json:"translated_title,omitempty"`
+	// Metadata holds custom fields extracted per source, e.g. "visa"
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	PostedDate time.Time         `json:"posted_date"`
+	// Deadline is the last day applications are accepted, zero if unknown
+	Deadline  time.Time `json:"deadline"`
+	ScrapedAt time.Time `json:"scraped_at"`
+	// FirstSeenAt and LastSeenAt are the first and latest scrapes listing the
+	// job, see TrackSeen
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+// CanonicalLocation returns the normalized location, falling back to the scraped one
+func (j Job) CanonicalLocation() string {
+	if j.NormalizedLocation != "" {
+		return j.NormalizedLocation
+	}
+	return j.Location
+}
+
+// ListedFor returns how long the job has been listed as of its last sighting,
+// 0 if unknown. For a job in DiffResult.NewJobs anything above 0 means it was
+// re-posted after being taken down.
+func (j Job) ListedFor() time.Duration {
+	if j.FirstSeenAt.IsZero() || j.LastSeenAt.Before(j.FirstSeenAt) {
+		return 0
+	}
+	return j.LastSeenAt.Sub(j.FirstSeenAt)
+}
+
+// DisplayTitle returns the title followed by its translation, e.g.
+// "バックエンドエンジニア (Backend Engineer)", or the title alone if it has none
+func (j Job) DisplayTitle() string {
+	if j.TranslatedTitle == "" || j.TranslatedTitle == j.Title {
+		return j.Title
+	}
+	return j.Title + " (" + j.TranslatedTitle + ")"
+}
+
+// MetadataKeys returns the keys of the job's metadata in sorted order
+func (j Job) MetadataKeys() []string {
+	keys := make([]string, 0, len(j.Metadata))
+	for key := range j.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DirectApplyURL returns the application link if it differs from the listing URL
+func (j Job) DirectApplyURL() string {
+	if j.ApplyURL == j.URL {
+		return ""
+	}
+	return j.ApplyURL
 }
 
 // JobCollection represents a collection of jobs from a career page
@@ -21,14 +90,65 @@ type JobCollection struct {
 	SourceURL   string
 	ScrapedAt   time.Time
 	Jobs        []Job
-	RawContent  string // Raw HTML content for debugging
+	RawContent  string        // Raw HTML content for debugging
+	Checksum    string        // Integrity checksum set by repositories on save
+	ChangedAt   time.Time     // Last scrape that found different jobs, see TrackActivity
+	EmptySince  time.Time     // First of the consecutive scrapes without jobs, zero if jobs were found
+	Departed    []JobSighting // Jobs listed before but not anymore, see TrackSeen
+	Screenshot  []byte        `json:"-"` // Full-page PNG of the rendered page for debugging, never saved with the collection
 }
 
 // DiffResult represents the difference between two job collections
 type DiffResult struct {
-	CompanyName string
-	SourceURL   string
-	NewJobs     []Job
-	RemovedJobs []Job
-	UpdatedJobs []Job
-}
\ No newline at end of file
+	CompanyName  string         `json:"company_name"`
+	SourceURL    string         `json:"source_url"`
+	NewJobs      []Job          `json:"new_jobs"`
+	RemovedJobs  []Job          `json:"removed_jobs"`
+	UpdatedJobs  []Job          `json:"updated_jobs"`
+	PreviousJobs map[string]Job `json:"previous_jobs,omitempty"` // versions of UpdatedJobs before the change, by ID
+	Alerts       []WatchAlert   `json:"alerts,omitempty"`
+	Severity     Severity       `json:"severity"`
+	Screenshot   []byte         `json:"-"` // screenshot of the scrape that found the changes, if one was taken
+}
+
+// WatchAlert marks a job in a diff that matched a watch rule. Watches of the
+// same owner matching one job share an alert listing all of them.
+type WatchAlert struct {
+	Watch   string   `json:"watch"` // first matched watch
+	Watches []string `json:"watches,omitempty"`
+	Owner   string   `json:"owner,omitempty"`
+	Job     Job      `json:"job"`
+}
+
+// WatchNames lists the watches that matched the job
+func (a WatchAlert) WatchNames() string {
+	if len(a.Watches) == 0 {
+		return a.Watch
+	}
+	return strings.Join(a.Watches, ", ")
+}
+
+// ComputeSeverity returns high for diffs with new jobs or watch alerts and info otherwise
+func (d DiffResult) ComputeSeverity() Severity {
+	if len(d.NewJobs) > 0 || len(d.Alerts) > 0 {
+		return SeverityHigh
+	}
+	return SeverityInfo
+}
+
+// Reposted returns the new jobs that had been listed before, under the same
+// ID or as the same role
+func (d DiffResult) Reposted() []Job {
+	var reposted []Job
+	for _, job := range d.NewJobs {
+		if job.ListedFor() > 0 {
+			reposted = append(reposted, job)
+		}
+	}
+	return reposted
+}
+
+// HasChanges reports whether the diff contains any new, updated or removed jobs
+func (d DiffResult) HasChanges() bool {
+	return len(d.NewJobs) > 0 || len(d.UpdatedJobs) > 0 || len(d.RemovedJobs) > 0
+}
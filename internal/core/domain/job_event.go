@@ -0,0 +1,74 @@
+// internal/core/domain/job_event.go
+package domain
+
+// JobEventType is the kind of change a JobEvent describes
+type JobEventType string
+
+const (
+	// JobEventCreated indicates a job was listed for the first time
+	JobEventCreated JobEventType = "job.created"
+	// JobEventUpdated indicates a listed job changed
+	JobEventUpdated JobEventType = "job.updated"
+	// JobEventRemoved indicates a job is no longer listed
+	JobEventRemoved JobEventType = "job.removed"
+)
+
+// JobEvent is a change of a single job. Before is nil for created jobs and
+// After is nil for removed ones.
+type JobEvent struct {
+	Type        JobEventType `json:"event"`
+	CompanyName string       `json:"company_name"`
+	SourceURL   string       `json:"source_url"`
+	JobID       string       `json:"job_id"`
+	Before      *Job         `json:"before"`
+	After       *Job         `json:"after"`
+	Watches     []string     `json:"watches,omitempty"` // watch rules the job matched
+	Severity    Severity     `json:"severity"`
+}
+
+// JobEvents splits the diff into one event per changed job: created jobs
+// first, then updated and removed ones, each in diff order. Updated jobs
+// whose earlier version is unknown have a nil Before.
+func (d DiffResult) JobEvents() []JobEvent {
+	watches := make(map[string][]string, len(d.Alerts))
+	for _, alert := range d.Alerts {
+		if len(alert.Watches) > 0 {
+			watches[alert.Job.ID] = alert.Watches
+		} else {
+			watches[alert.Job.ID] = []string{alert.Watch}
+		}
+	}
+
+	event := func(eventType JobEventType, id string, before, after *Job) JobEvent {
+		severity := SeverityInfo
+		if eventType == JobEventCreated || len(watches[id]) > 0 {
+			severity = SeverityHigh
+		}
+		return JobEvent{
+			Type:        eventType,
+			CompanyName: d.CompanyName,
+			SourceURL:   d.SourceURL,
+			JobID:       id,
+			Before:      before,
+			After:       after,
+			Watches:     watches[id],
+			Severity:    severity,
+		}
+	}
+
+	events := make([]JobEvent, 0, len(d.NewJobs)+len(d.UpdatedJobs)+len(d.RemovedJobs))
+	for _, job := range d.NewJobs {
+		events = append(events, event(JobEventCreated, job.ID, nil, &job))
+	}
+	for _, job := range d.UpdatedJobs {
+		var before *Job
+		if previous, ok := d.PreviousJobs[job.ID]; ok {
+			before = &previous
+		}
+		events = append(events, event(JobEventUpdated, job.ID, before, &job))
+	}
+	for _, job := range d.RemovedJobs {
+		events = append(events, event(JobEventRemoved, job.ID, &job, nil))
+	}
+	return events
+}
@@ -0,0 +1,27 @@
+// internal/core/domain/language.go
+package domain
+
+import "github.com/fuzztobread/job-scheduler/internal/core/textutil"
+
+// maxDetectionText bounds how much of the description is used for language detection
+const maxDetectionText = 1000
+
+// DetectJobLanguage detects the posting language from the job title and description.
+// It returns an ISO 639-1 code, or an empty string when unknown.
+func DetectJobLanguage(job Job) string {
+	text := job.Description
+	if len(text) > maxDetectionText {
+		text = text[:maxDetectionText]
+	}
+
+	return textutil.DetectLanguage(job.Title + " " + text)
+}
+
+// PostingLanguage returns the language of the job, detected from its text if
+// it has none set
+func (j Job) PostingLanguage() string {
+	if j.Language != "" {
+		return j.Language
+	}
+	return DetectJobLanguage(j)
+}
@@ -1,7 +1,10 @@
 // internal/core/domain/notification.go
 package domain
 
-import "time"
+import (
+	"strconv"
+	"time"
+)
 
 // NotificationType defines the type of notification
 type NotificationType string
@@ -18,18 +21,33 @@ const (
 	
 	// NotificationTypeError indicates an error occurred during scraping
 	NotificationTypeError NotificationType = "error"
+	
+	// NotificationTypeBlocked indicates a site answered a scrape with a bot check
+	NotificationTypeBlocked NotificationType = "blocked"
+	
+	// NotificationTypeStaleSources reports sources that look abandoned
+	NotificationTypeStaleSources NotificationType = "stale_sources"
+	
+	// NotificationTypeSummary links to the hosted summary of changes too
+	// large to show in a message
+	NotificationTypeSummary NotificationType = "summary"
+	
+	// NotificationTypeExitReport sums up a session on shutdown
+	NotificationTypeExitReport NotificationType = "exit_report"
 )
 
 // Notification represents a notification to be sent
 type Notification struct {
 	ID          string           `json:"id"`
 	Type        NotificationType `json:"type"`
+	Severity    Severity         `json:"severity"`
 	CompanyName string           `json:"company_name"`
 	SourceURL   string           `json:"source_url"`
 	Title       string           `json:"title"`
 	Message     string           `json:"message"`
 	CreatedAt   time.Time        `json:"created_at"`
 	Payload     interface{}      `json:"payload,omitempty"`
+	Owner       *SourceOwner     `json:"owner,omitempty"` // owner of the source, set on failure alerts
 }
 
 // NotificationHistory represents a record of sent notifications
@@ -42,6 +60,7 @@ type NotificationHistory struct {
 func CreateNewJobsNotification(diff DiffResult) Notification {
 	return Notification{
 		Type:        NotificationTypeNewJobs,
+		Severity:    SeverityHigh,
 		CompanyName: diff.CompanyName,
 		SourceURL:   diff.SourceURL,
 		Title:       "New Job Listings",
@@ -55,6 +74,7 @@ func CreateNewJobsNotification(diff DiffResult) Notification {
 func CreateUpdatedJobsNotification(diff DiffResult) Notification {
 	return Notification{
 		Type:        NotificationTypeUpdatedJobs,
+		Severity:    SeverityInfo,
 		CompanyName: diff.CompanyName,
 		SourceURL:   diff.SourceURL,
 		Title:       "Updated Job Listings",
@@ -68,6 +88,7 @@ func CreateUpdatedJobsNotification(diff DiffResult) Notification {
 func CreateRemovedJobsNotification(diff DiffResult) Notification {
 	return Notification{
 		Type:        NotificationTypeRemovedJobs,
+		Severity:    SeverityInfo,
 		CompanyName: diff.CompanyName,
 		SourceURL:   diff.SourceURL,
 		Title:       "Removed Job Listings",
@@ -81,6 +102,7 @@ func CreateRemovedJobsNotification(diff DiffResult) Notification {
 func CreateErrorNotification(companyName, sourceURL, errMsg string) Notification {
 	return Notification{
 		Type:        NotificationTypeError,
+		Severity:    SeverityCritical,
 		CompanyName: companyName,
 		SourceURL:   sourceURL,
 		Title:       "Scraping Error",
@@ -89,6 +111,33 @@ func CreateErrorNotification(companyName, sourceURL, errMsg string) Notification
 	}
 }
 
+// CreateBlockedNotification creates a notification about a site blocking the
+// scraper with a bot protection or CAPTCHA
+func CreateBlockedNotification(companyName string, blocked *ScrapeBlocked) Notification {
+	return Notification{
+		Type:        NotificationTypeBlocked,
+		Severity:    SeverityHigh,
+		CompanyName: companyName,
+		SourceURL:   blocked.SourceURL,
+		Title:       "Site Is Blocking the Scraper",
+		Message:     "The site answered with a bot check (" + blocked.Reason + ") instead of its career page, so its jobs are not updated. A proxy, cookies of a signed-in session or a slower schedule may get the scraper through.",
+		CreatedAt:   time.Now(),
+	}
+}
+
+// CreateStaleSourcesNotification creates a notification suggesting to remove stale sources
+func CreateStaleSourcesNotification(stale []StaleSource) Notification {
+	now := time.Now()
+	return Notification{
+		Type:      NotificationTypeStaleSources,
+		Severity:  SeverityInfo,
+		Title:     "Consider Removing " + strconv.Itoa(len(stale)) + " Stale Sources",
+		Message:   StaleSourcesMessage(stale, now),
+		CreatedAt: now,
+		Payload:   stale,
+	}
+}
+
 // createJobsMessage creates a human-readable message about job changes
 func createJobsMessage(jobs []Job, changeType string) string {
 	if len(jobs) == 0 {
@@ -99,7 +148,7 @@ func createJobsMessage(jobs []Job, changeType string) string {
 		return "1 " + changeType + " job: " + jobs[0].Title
 	}
 	
-	return string(len(jobs)) + " " + changeType + " jobs found."
+	return strconv.Itoa(len(jobs)) + " " + changeType + " jobs found."
 }
 
 // NotificationDeliveryStatus represents the delivery status of a notification
@@ -0,0 +1,123 @@
+// internal/core/domain/notification_history.go
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// NotificationRecord is a notification handed to the configured notifiers,
+// together with the attempts to deliver it through each of them. Exactly one
+// of Diff, Digest and Notification is set, according to Kind.
+type NotificationRecord struct {
+	ID           int64             `json:"id"`
+	CreatedAt    time.Time         `json:"created_at"`
+	Kind         CaptureKind       `json:"kind"`
+	Severity     Severity          `json:"severity"`
+	Diff         *DiffResult       `json:"diff,omitempty"`
+	Digest       *Digest           `json:"digest,omitempty"`
+	Notification *Notification     `json:"notification,omitempty"`
+	Attempts     []DeliveryAttempt `json:"attempts"`
+}
+
+// DeliveryAttempt is the outcome of delivering a notification through one notifier
+type DeliveryAttempt struct {
+	Notifier string        `json:"notifier"`
+	At       time.Time     `json:"at"`
+	Duration time.Duration `json:"duration"`
+	Skipped  bool          `json:"skipped,omitempty"` // below the minimum severity of the notifier
+	Error    string        `json:"error,omitempty"`
+}
+
+// Failed reports whether the attempt returned an error
+func (a DeliveryAttempt) Failed() bool {
+	return a.Error != ""
+}
+
+// Failed reports whether any delivery attempt of the notification failed
+func (r NotificationRecord) Failed() bool {
+	for _, attempt := range r.Attempts {
+		if attempt.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// Sources returns the company name by source URL of every source the
+// notification is about
+func (r NotificationRecord) Sources() map[string]string {
+	sources := make(map[string]string)
+	switch {
+	case r.Diff != nil:
+		sources[r.Diff.SourceURL] = r.Diff.CompanyName
+	case r.Digest != nil:
+		for _, diff := range r.Digest.Diffs {
+			sources[diff.SourceURL] = diff.CompanyName
+		}
+	case r.Notification != nil && r.Notification.SourceURL != "":
+		sources[r.Notification.SourceURL] = r.Notification.CompanyName
+	}
+	return sources
+}
+
+// Concerns reports whether the notification says anything about the source url
+func (r NotificationRecord) Concerns(url string) bool {
+	return notificationConcerns(r.Diff, r.Digest, r.Notification, url)
+}
+
+// WithoutSource returns the record without what its notification says about
+// the source url, and whether anything is left of it, see
+// CapturedNotification.WithoutSource
+func (r NotificationRecord) WithoutSource(url string) (NotificationRecord, bool) {
+	var left bool
+	r.Diff, r.Digest, r.Notification, left = notificationWithoutSource(r.Diff, r.Digest, r.Notification, url)
+	return r, left
+}
+
+// Summary describes the notification in one line
+func (r NotificationRecord) Summary() string {
+	return CapturedNotification{Kind: r.Kind, Diff: r.Diff, Digest: r.Digest, Notification: r.Notification}.Summary()
+}
+
+// NotificationFilter selects notification records. Zero fields match everything.
+type NotificationFilter struct {
+	Company   string // company name, ignoring case
+	SourceURL string
+	Notifier  string // attempted through this notifier
+	Since     time.Time
+	Failed    bool // only notifications with a failed attempt
+	Limit     int  // maximum number of records, newest first; 0 for all
+}
+
+// Matches reports whether the record satisfies the filter, ignoring Limit
+func (f NotificationFilter) Matches(record NotificationRecord) bool {
+	switch {
+	case !f.Since.IsZero() && record.CreatedAt.Before(f.Since):
+		return false
+	case f.Failed && !record.Failed():
+		return false
+	case f.Notifier != "" && !record.attempted(f.Notifier):
+		return false
+	}
+
+	if f.Company == "" && f.SourceURL == "" {
+		return true
+	}
+	for url, company := range record.Sources() {
+		if (f.SourceURL == "" || url == f.SourceURL) && (f.Company == "" || strings.EqualFold(company, f.Company)) {
+			return true
+		}
+	}
+	return false
+}
+
+// attempted reports whether delivery through the named notifier was attempted
+func (r NotificationRecord) attempted(notifier string) bool {
+	for _, attempt := range r.Attempts {
+		if attempt.Notifier == notifier && !attempt.Skipped {
+			return true
+		}
+	}
+	return false
+}
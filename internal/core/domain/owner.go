@@ -0,0 +1,28 @@
+// internal/core/domain/owner.go
+package domain
+
+// SourceOwner is the person responsible for a source in team deployments.
+// Failure alerts of the source name them and escalate to their notifier.
+type SourceOwner struct {
+	Name     string `json:"name"`
+	Contact  string `json:"contact,omitempty"`  // e.g. an email address or a Discord mention such as <@123>
+	Notifier string `json:"notifier,omitempty"` // notifier failure alerts escalate to, empty for the shared notifiers
+}
+
+// String returns the owner as "Name (Contact)"
+func (o SourceOwner) String() string {
+	if o.Contact == "" {
+		return o.Name
+	}
+	if o.Name == "" {
+		return o.Contact
+	}
+	return o.Name + " (" + o.Contact + ")"
+}
+
+// AssignOwner names the owner of its source in a notification
+func AssignOwner(notification Notification, owner SourceOwner) Notification {
+	notification.Owner = &owner
+	notification.Message += "\nOwner: " + owner.String()
+	return notification
+}
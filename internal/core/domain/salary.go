@@ -0,0 +1,58 @@
+// internal/core/domain/salary.go
+package domain
+
+// SalaryPeriod defines the period a salary amount is paid for
+type SalaryPeriod string
+
+const (
+	// SalaryPeriodYear indicates an annual salary
+	SalaryPeriodYear SalaryPeriod = "year"
+
+	// SalaryPeriodMonth indicates a monthly salary
+	SalaryPeriodMonth SalaryPeriod = "month"
+
+	// SalaryPeriodWeek indicates a weekly salary
+	SalaryPeriodWeek SalaryPeriod = "week"
+
+	// SalaryPeriodDay indicates a daily rate
+	SalaryPeriodDay SalaryPeriod = "day"
+
+	// SalaryPeriodHour indicates an hourly rate
+	SalaryPeriodHour SalaryPeriod = "hour"
+)
+
+// periodsPerYear converts a salary period into its yearly multiplier
+var periodsPerYear = map[SalaryPeriod]float64{
+	SalaryPeriodYear:  1,
+	SalaryPeriodMonth: 12,
+	SalaryPeriodWeek:  52,
+	SalaryPeriodDay:   260,
+	SalaryPeriodHour:  2080,
+}
+
+// Salary represents the compensation advertised for a job
+type Salary struct {
+	Currency string       `json:"currency"` // ISO 4217 code, e.g. "USD"
+	Min      float64      `json:"min,omitempty"`
+	Max      float64      `json:"max,omitempty"`
+	Period   SalaryPeriod `json:"period,omitempty"`
+}
+
+// AnnualRange returns the salary range normalized to a yearly amount. A salary
+// with only one bound returns it as both minimum and maximum.
+func (s Salary) AnnualRange() (float64, float64) {
+	multiplier, ok := periodsPerYear[s.Period]
+	if !ok {
+		multiplier = 1
+	}
+
+	min, max := s.Min, s.Max
+	if min == 0 {
+		min = max
+	}
+	if max == 0 {
+		max = min
+	}
+
+	return min * multiplier, max * multiplier
+}
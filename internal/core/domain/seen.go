@@ -0,0 +1,157 @@
+// internal/core/domain/seen.go
+package domain
+
+import (
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/textutil"
+)
+
+// maxDepartedJobs bounds the jobs a collection remembers after they were
+// taken down; the longest gone are forgotten first
+const maxDepartedJobs = 10000
+
+// JobSighting records when a job that is no longer listed was, so first
+// sightings and lifetimes outlive the snapshots a repository retains
+type JobSighting struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Location    string    `json:"location"` // canonical location
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+	RemovedAt   time.Time `json:"removed_at"` // first scrape without the job
+}
+
+// TrackSeen returns a copy of the collection with FirstSeenAt and LastSeenAt
+// of every job set. LastSeenAt is this scrape. FirstSeenAt is carried over
+// from the same job in previous; for jobs not listed there it comes from the
+// earliest sighting of the same ID or the same title and location among the
+// departed jobs of previous and the history snapshots, so re-posted roles
+// keep their original date. A role still listed under another ID is a second
+// opening rather than a re-post. The jobs of previous that are gone are added
+// to Departed, which is carried over from save to save independently of the
+// snapshots retained; history is only needed for collections saved before
+// it. Pass a zero previous and no history for the first scrape of a source.
+func (c JobCollection) TrackSeen(previous JobCollection, history []JobCollection) JobCollection {
+	carried := make(map[string]time.Time, len(previous.Jobs))
+	for _, job := range previous.Jobs {
+		carried[job.ID] = firstSeen(job, previous)
+	}
+	listed := make(map[string]bool)
+	for _, job := range c.Jobs {
+		if _, ok := carried[job.ID]; ok {
+			listed[roleKey(job)] = true
+		}
+	}
+
+	byID, byRole := earliestSightings(history, previous.Departed)
+
+	jobs := make([]Job, len(c.Jobs))
+	for i, job := range c.Jobs {
+		job.LastSeenAt = c.ScrapedAt
+		if first, ok := carried[job.ID]; ok {
+			job.FirstSeenAt = first
+		} else {
+			job.FirstSeenAt = c.ScrapedAt
+			if first, ok := byID[job.ID]; ok && first.Before(job.FirstSeenAt) {
+				job.FirstSeenAt = first
+			}
+			if first, ok := byRole[roleKey(job)]; ok && !listed[roleKey(job)] && first.Before(job.FirstSeenAt) {
+				job.FirstSeenAt = first
+			}
+		}
+		jobs[i] = job
+	}
+
+	c.Jobs = jobs
+	c.Departed = departedJobs(previous, c)
+	return c
+}
+
+// departedJobs returns the departed jobs of previous and its jobs that
+// current doesn't list anymore, dropping those listed again, newest removal
+// last
+func departedJobs(previous, current JobCollection) []JobSighting {
+	listed := make(map[string]bool, len(current.Jobs))
+	for _, job := range current.Jobs {
+		listed[job.ID] = true
+	}
+
+	var departed []JobSighting
+	for _, sighting := range previous.Departed {
+		if !listed[sighting.ID] {
+			departed = append(departed, sighting)
+		}
+	}
+	for _, job := range previous.Jobs {
+		if listed[job.ID] {
+			continue
+		}
+		lastSeen := job.LastSeenAt
+		if lastSeen.IsZero() {
+			lastSeen = previous.ScrapedAt
+		}
+		departed = append(departed, JobSighting{
+			ID:          job.ID,
+			Title:       job.Title,
+			Location:    job.CanonicalLocation(),
+			FirstSeenAt: firstSeen(job, previous),
+			LastSeenAt:  lastSeen,
+			RemovedAt:   current.ScrapedAt,
+		})
+	}
+	if len(departed) > maxDepartedJobs {
+		departed = departed[len(departed)-maxDepartedJobs:]
+	}
+	return departed
+}
+
+// earliestSightings returns when each job ID and each role was first seen in
+// the snapshots, including their departed jobs, and the departed jobs given
+func earliestSightings(snapshots []JobCollection, departed []JobSighting) (map[string]time.Time, map[string]time.Time) {
+	byID := make(map[string]time.Time)
+	byRole := make(map[string]time.Time)
+	keep := func(m map[string]time.Time, key string, at time.Time) {
+		if seen, ok := m[key]; !ok || at.Before(seen) {
+			m[key] = at
+		}
+	}
+
+	for _, snapshot := range snapshots {
+		for _, job := range snapshot.Jobs {
+			first := firstSeen(job, snapshot)
+			keep(byID, job.ID, first)
+			keep(byRole, roleKey(job), first)
+		}
+		departed = append(departed, snapshot.Departed...)
+	}
+	for _, sighting := range departed {
+		keep(byID, sighting.ID, sighting.FirstSeenAt)
+		keep(byRole, sighting.roleKey(), sighting.FirstSeenAt)
+	}
+	return byID, byRole
+}
+
+// firstSeen returns when a job of a stored collection was first seen. Jobs
+// stored before sightings were tracked fall back to their scrape time.
+func firstSeen(job Job, collection JobCollection) time.Time {
+	switch {
+	case !job.FirstSeenAt.IsZero():
+		return job.FirstSeenAt
+	case !job.ScrapedAt.IsZero():
+		return job.ScrapedAt
+	default:
+		return collection.ScrapedAt
+	}
+}
+
+// roleKey identifies a role across postings: its title and location,
+// regardless of case and punctuation
+func roleKey(job Job) string {
+	return textutil.NormalizeKey(job.Title) + "\x00" + textutil.NormalizeKey(job.CanonicalLocation())
+}
+
+// roleKey identifies the role of a departed job, see roleKey
+func (s JobSighting) roleKey() string {
+	return roleKey(Job{Title: s.Title, Location: s.Location})
+}
@@ -0,0 +1,51 @@
+// internal/core/domain/severity.go
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity defines how important a notification is
+type Severity string
+
+const (
+	// SeverityInfo is used for routine changes such as updated or removed jobs
+	SeverityInfo Severity = "info"
+
+	// SeverityHigh is used for new jobs that passed the filters
+	SeverityHigh Severity = "high"
+
+	// SeverityCritical is used for errors that need attention
+	SeverityCritical Severity = "critical"
+)
+
+// severityRanks orders the severities from least to most important
+var severityRanks = map[Severity]int{
+	SeverityInfo:     0,
+	SeverityHigh:     1,
+	SeverityCritical: 2,
+}
+
+// ParseSeverity parses a severity name, case-insensitively
+func ParseSeverity(s string) (Severity, error) {
+	severity := Severity(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := severityRanks[severity]; !ok {
+		return "", fmt.Errorf("unknown severity %q (expected info, high or critical)", s)
+	}
+	return severity, nil
+}
+
+// AtLeast reports whether s is as important as min or more.
+// An unset severity is treated as info.
+func (s Severity) AtLeast(min Severity) bool {
+	return severityRanks[s] >= severityRanks[min]
+}
+
+// Max returns the more important of two severities
+func (s Severity) Max(other Severity) Severity {
+	if other.AtLeast(s) {
+		return other
+	}
+	return s
+}
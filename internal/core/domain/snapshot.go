@@ -0,0 +1,13 @@
+// internal/core/domain/snapshot.go
+package domain
+
+import "time"
+
+// RawSnapshot is an archived page of a source as it was fetched by a scrape run
+type RawSnapshot struct {
+	SourceURL  string    `json:"source_url"`
+	Version    int64     `json:"version"` // identifies the snapshot within its source
+	ScrapedAt  time.Time `json:"scraped_at"`
+	JobCount   int       `json:"job_count"` // jobs currently stored for the snapshot
+	RawContent string    `json:"-"`
+}
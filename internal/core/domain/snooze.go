@@ -0,0 +1,70 @@
+// internal/core/domain/snooze.go
+package domain
+
+import "time"
+
+// Snooze silences notifications about a source, or a single job of it, until
+// a point in time. A snooze with a notifier only applies to the notifier
+// configured under that name, so one channel can mute postings its readers
+// already reviewed while others keep being notified.
+type Snooze struct {
+	ID        string    `json:"id"`
+	Notifier  string    `json:"notifier,omitempty"` // empty snoozes every notifier
+	SourceURL string    `json:"source_url"`
+	JobID     string    `json:"job_id,omitempty"` // empty snoozes the whole source
+	Until     time.Time `json:"until"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Active reports whether the snooze is still in effect at a time
+func (s Snooze) Active(at time.Time) bool {
+	return at.Before(s.Until)
+}
+
+// AppliesTo reports whether the snooze silences a notifier
+func (s Snooze) AppliesTo(notifier string) bool {
+	return s.Notifier == "" || s.Notifier == notifier
+}
+
+// SnoozeDiff returns the diff without the changes silenced by the active
+// snoozes of a notifier. A snoozed source leaves nothing; a snoozed job is
+// dropped from the new, updated and removed jobs and from the alerts.
+func SnoozeDiff(diff DiffResult, snoozes []Snooze, notifier string, at time.Time) DiffResult {
+	jobs := make(map[string]bool)
+	for _, snooze := range snoozes {
+		if snooze.SourceURL != diff.SourceURL || !snooze.AppliesTo(notifier) || !snooze.Active(at) {
+			continue
+		}
+		if snooze.JobID == "" {
+			return DiffResult{CompanyName: diff.CompanyName, SourceURL: diff.SourceURL, Severity: diff.Severity}
+		}
+		jobs[snooze.JobID] = true
+	}
+	if len(jobs) == 0 {
+		return diff
+	}
+
+	keep := func(list []Job) []Job {
+		var kept []Job
+		for _, job := range list {
+			if !jobs[job.ID] {
+				kept = append(kept, job)
+			}
+		}
+		return kept
+	}
+	diff.NewJobs = keep(diff.NewJobs)
+	diff.UpdatedJobs = keep(diff.UpdatedJobs)
+	diff.RemovedJobs = keep(diff.RemovedJobs)
+
+	var alerts []WatchAlert
+	for _, alert := range diff.Alerts {
+		if !jobs[alert.Job.ID] {
+			alerts = append(alerts, alert)
+		}
+	}
+	diff.Alerts = alerts
+	return diff
+}
@@ -0,0 +1,72 @@
+// internal/core/domain/source.go
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// ScrapeFailure is a failed attempt to scrape a source
+type ScrapeFailure struct {
+	SourceURL string    `json:"source_url"`
+	FailedAt  time.Time `json:"failed_at"`
+	Error     string    `json:"error"`
+}
+
+// TrackedSource is the state of a source known to a repository
+type TrackedSource struct {
+	SourceURL     string    `json:"source_url"`
+	CompanyName   string    `json:"company_name,omitempty"`
+	LastScrapedAt time.Time `json:"last_scraped_at"` // zero if never scraped successfully
+	JobCount      int       `json:"job_count"`
+	LastError     string    `json:"last_error,omitempty"`
+	LastErrorAt   time.Time `json:"last_error_at"`
+}
+
+// SourceBackoff is the state of a source whose scrapes keep failing, while
+// the scheduled runs back off from it
+type SourceBackoff struct {
+	SourceURL           string `json:"source_url"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	SkippedRuns         int    `json:"skipped_runs"` // scheduled runs left to sit out
+}
+
+// Failing reports whether the last scrape of the source failed
+func (s TrackedSource) Failing() bool {
+	return !s.LastErrorAt.IsZero() && s.LastErrorAt.After(s.LastScrapedAt)
+}
+
+// TrackCollection returns the state of a source as of its latest collection
+func TrackCollection(collection JobCollection) TrackedSource {
+	return TrackedSource{
+		SourceURL:     collection.SourceURL,
+		CompanyName:   collection.CompanyName,
+		LastScrapedAt: collection.ScrapedAt,
+		JobCount:      len(collection.Jobs),
+	}
+}
+
+// MergeScrapeFailures adds the last failures of sources to their states,
+// adding states for sources that never scraped successfully, and orders the
+// result by URL
+func MergeScrapeFailures(sources []TrackedSource, failures []ScrapeFailure) []TrackedSource {
+	index := make(map[string]int, len(sources))
+	for i, source := range sources {
+		index[source.SourceURL] = i
+	}
+	for _, failure := range failures {
+		i, ok := index[failure.SourceURL]
+		if !ok {
+			i = len(sources)
+			index[failure.SourceURL] = i
+			sources = append(sources, TrackedSource{SourceURL: failure.SourceURL})
+		}
+		sources[i].LastError = failure.Error
+		sources[i].LastErrorAt = failure.FailedAt
+	}
+
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].SourceURL < sources[j].SourceURL
+	})
+	return sources
+}
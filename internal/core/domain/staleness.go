@@ -0,0 +1,89 @@
+// internal/core/domain/staleness.go
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StaleReason explains why a source looks abandoned
+type StaleReason string
+
+const (
+	// StaleReasonEmpty means the page has returned no jobs for a long time
+	StaleReasonEmpty StaleReason = "no jobs"
+
+	// StaleReasonUnchanged means the page has returned the same jobs for a long time
+	StaleReasonUnchanged StaleReason = "unchanged"
+)
+
+// StaleSource is a source that may have moved to another career platform
+type StaleSource struct {
+	SourceURL     string      `json:"source_url"`
+	CompanyName   string      `json:"company_name"`
+	Reason        StaleReason `json:"reason"`
+	Since         time.Time   `json:"since"`
+	LastScrapedAt time.Time   `json:"last_scraped_at"`
+}
+
+// TrackActivity returns a copy of the collection with ChangedAt and EmptySince
+// carried forward from previous. changed tells whether the jobs differ from
+// the previous scrape; pass a zero previous for the first scrape of a source.
+func (c JobCollection) TrackActivity(previous JobCollection, changed bool) JobCollection {
+	if changed || previous.ChangedAt.IsZero() {
+		c.ChangedAt = c.ScrapedAt
+	} else {
+		c.ChangedAt = previous.ChangedAt
+	}
+
+	switch {
+	case len(c.Jobs) > 0:
+		c.EmptySince = time.Time{}
+	case !previous.EmptySince.IsZero():
+		c.EmptySince = previous.EmptySince
+	default:
+		c.EmptySince = c.ScrapedAt
+	}
+
+	return c
+}
+
+// Staleness reports whether the collection has been empty or unchanged for at
+// least after as of now
+func (c JobCollection) Staleness(now time.Time, after time.Duration) (StaleSource, bool) {
+	stale := StaleSource{
+		SourceURL:     c.SourceURL,
+		CompanyName:   c.CompanyName,
+		LastScrapedAt: c.ScrapedAt,
+	}
+
+	switch {
+	case !c.EmptySince.IsZero() && now.Sub(c.EmptySince) >= after:
+		stale.Reason, stale.Since = StaleReasonEmpty, c.EmptySince
+	case !c.ChangedAt.IsZero() && now.Sub(c.ChangedAt) >= after:
+		stale.Reason, stale.Since = StaleReasonUnchanged, c.ChangedAt
+	default:
+		return StaleSource{}, false
+	}
+
+	return stale, true
+}
+
+// SortStaleSources orders stale sources by how long they have been stale
+func SortStaleSources(stale []StaleSource) {
+	sort.Slice(stale, func(i, j int) bool {
+		return stale[i].Since.Before(stale[j].Since)
+	})
+}
+
+// StaleSourcesMessage describes stale sources as one line each
+func StaleSourcesMessage(stale []StaleSource, now time.Time) string {
+	var b strings.Builder
+	for _, s := range stale {
+		days := int(now.Sub(s.Since).Hours() / 24)
+		fmt.Fprintf(&b, "%s: %s for %d days\n", s.SourceURL, s.Reason, days)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,14 @@
+// internal/core/domain/trace.go
+package domain
+
+import "time"
+
+// FilterRejection records that a filter rule dropped a job during a run
+type FilterRejection struct {
+	RunAt     time.Time `json:"run_at"`
+	SourceURL string    `json:"source_url"`
+	JobID     string    `json:"job_id"`
+	Title     string    `json:"title"`
+	Rule      string    `json:"rule"`
+	Reason    string    `json:"reason"`
+}
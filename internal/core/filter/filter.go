@@ -0,0 +1,75 @@
+// internal/core/filter/filter.go
+package filter
+
+import (
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// Rule decides whether a job should be kept
+type Rule interface {
+	// Name identifies the rule in logs and traces
+	Name() string
+	// Allow reports whether the job passes the rule and, if not, why
+	Allow(job domain.Job) (bool, string)
+}
+
+// Rejection records a job dropped by a rule
+type Rejection struct {
+	Job    domain.Job
+	Rule   string
+	Reason string
+}
+
+// Filter keeps the jobs that pass all of its rules
+type Filter struct {
+	rules []Rule
+}
+
+// New creates a new Filter from the given rules
+func New(rules ...Rule) *Filter {
+	return &Filter{
+		rules: rules,
+	}
+}
+
+// Empty reports whether the filter has no rules
+func (f *Filter) Empty() bool {
+	return f == nil || len(f.rules) == 0
+}
+
+// Allow reports whether the job passes every rule. When it doesn't, the
+// returned Rejection names the first rule that dropped it.
+func (f *Filter) Allow(job domain.Job) (bool, *Rejection) {
+	if f == nil {
+		return true, nil
+	}
+
+	for _, rule := range f.rules {
+		if ok, reason := rule.Allow(job); !ok {
+			return false, &Rejection{
+				Job:    job,
+				Rule:   rule.Name(),
+				Reason: reason,
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// Apply splits jobs into the ones that pass the filter and the rejections
+func (f *Filter) Apply(jobs []domain.Job) ([]domain.Job, []Rejection) {
+	var kept []domain.Job
+	var rejected []Rejection
+
+	for _, job := range jobs {
+		ok, rejection := f.Allow(job)
+		if !ok {
+			rejected = append(rejected, *rejection)
+			continue
+		}
+		kept = append(kept, job)
+	}
+
+	return kept, rejected
+}
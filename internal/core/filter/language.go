@@ -0,0 +1,49 @@
+// internal/core/filter/language.go
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// LanguageRule keeps jobs whose detected posting language is in an allow list.
+// Jobs whose language cannot be detected are kept.
+type LanguageRule struct {
+	languages map[string]bool
+}
+
+// NewLanguageRule creates a rule allowing the given ISO 639-1 language codes
+func NewLanguageRule(languages []string) *LanguageRule {
+	allowed := make(map[string]bool)
+	for _, lang := range languages {
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		if lang != "" {
+			allowed[lang] = true
+		}
+	}
+
+	return &LanguageRule{
+		languages: allowed,
+	}
+}
+
+// Name returns the rule name
+func (r *LanguageRule) Name() string {
+	return "language"
+}
+
+// Allow reports whether the job's detected language is allowed
+func (r *LanguageRule) Allow(job domain.Job) (bool, string) {
+	if len(r.languages) == 0 {
+		return true, ""
+	}
+
+	lang := job.PostingLanguage()
+	if lang == "" || r.languages[lang] {
+		return true, ""
+	}
+
+	return false, fmt.Sprintf("detected language %q is not allowed", lang)
+}
@@ -0,0 +1,34 @@
+// internal/core/filter/query.go
+package filter
+
+import (
+	"fmt"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/query"
+)
+
+// QueryRule keeps jobs matching a query
+type QueryRule struct {
+	query *query.Query
+}
+
+// NewQueryRule creates a new QueryRule
+func NewQueryRule(q *query.Query) *QueryRule {
+	return &QueryRule{
+		query: q,
+	}
+}
+
+// Name returns the rule name
+func (r *QueryRule) Name() string {
+	return "query"
+}
+
+// Allow reports whether the job matches the query
+func (r *QueryRule) Allow(job domain.Job) (bool, string) {
+	if r.query.Match(job) {
+		return true, ""
+	}
+	return false, fmt.Sprintf("does not match %s", r.query)
+}
@@ -0,0 +1,92 @@
+// internal/core/filter/salary.go
+package filter
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/currency"
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// SalaryRule keeps jobs whose annual salary range overlaps a target range.
+// Salaries in other currencies are converted before comparing. Jobs without
+// a (convertible) salary are kept unless the rule requires one.
+type SalaryRule struct {
+	currency  string
+	min       float64
+	max       float64
+	required  bool
+	converter *currency.Converter
+}
+
+// NewSalaryRule creates a rule for the annual target range [min, max] in the given
+// currency. A zero max leaves the range unbounded; converter may be nil when all
+// salaries are expected in the target currency.
+func NewSalaryRule(targetCurrency string, min, max float64, required bool, converter *currency.Converter) *SalaryRule {
+	if max <= 0 {
+		max = math.Inf(1)
+	}
+
+	return &SalaryRule{
+		currency:  strings.ToUpper(targetCurrency),
+		min:       min,
+		max:       max,
+		required:  required,
+		converter: converter,
+	}
+}
+
+// Name returns the rule name
+func (r *SalaryRule) Name() string {
+	return "salary"
+}
+
+// Allow reports whether the job's salary overlaps the target range
+func (r *SalaryRule) Allow(job domain.Job) (bool, string) {
+	if job.Salary == nil {
+		if r.required {
+			return false, "no salary advertised"
+		}
+		return true, ""
+	}
+
+	min, max, err := r.annualRange(*job.Salary)
+	if err != nil {
+		if r.required {
+			return false, err.Error()
+		}
+		return true, ""
+	}
+
+	if max < r.min || min > r.max {
+		return false, fmt.Sprintf("salary %.0f-%.0f %s/year is outside the target range", min, max, r.currency)
+	}
+
+	return true, ""
+}
+
+// annualRange returns the yearly salary range converted to the target currency
+func (r *SalaryRule) annualRange(salary domain.Salary) (float64, float64, error) {
+	min, max := salary.AnnualRange()
+
+	from := strings.ToUpper(salary.Currency)
+	if from == "" || from == r.currency {
+		return min, max, nil
+	}
+	if r.converter == nil {
+		return 0, 0, fmt.Errorf("cannot compare %s salary without exchange rates", from)
+	}
+
+	convertedMin, err := r.converter.Convert(min, from, r.currency)
+	if err != nil {
+		return 0, 0, err
+	}
+	convertedMax, err := r.converter.Convert(max, from, r.currency)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return convertedMin, convertedMax, nil
+}
@@ -0,0 +1,49 @@
+// internal/core/filter/watch.go
+package filter
+
+import (
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// Watch raises an alert for jobs that pass all of its rules. Unlike a Filter
+// it never drops jobs, it only highlights them.
+type Watch struct {
+	name  string
+	owner string
+	rules []Rule
+}
+
+// NewWatch creates a new named Watch. Alerts of watches with the same
+// non-empty owner are collapsed when they match the same job.
+func NewWatch(name, owner string, rules ...Rule) *Watch {
+	return &Watch{
+		name:  name,
+		owner: owner,
+		rules: rules,
+	}
+}
+
+// Name returns the watch name
+func (w *Watch) Name() string {
+	return w.name
+}
+
+// Owner returns the identity of the user the watch belongs to, empty if none
+func (w *Watch) Owner() string {
+	return w.owner
+}
+
+// Matches reports whether the job passes every rule of the watch
+func (w *Watch) Matches(job domain.Job) bool {
+	if len(w.rules) == 0 {
+		return false
+	}
+
+	for _, rule := range w.rules {
+		if ok, _ := rule.Allow(job); !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,88 @@
+// internal/core/normalize/category.go
+package normalize
+
+import (
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/textutil"
+)
+
+// DefaultCategorySimilarity is the similarity above which a department is
+// fuzzily matched to a category alias
+const DefaultCategorySimilarity = 0.8
+
+// CategoryNormalizer maps scraped departments to a standard taxonomy and stores
+// the result in Job.Category
+type CategoryNormalizer struct {
+	aliases    map[string]string // normalized alias -> category
+	similarity float64
+}
+
+// NewCategoryNormalizer creates a normalizer from the built-in taxonomy extended
+// with extra, which maps categories to additional aliases. Departments matching
+// no alias are fuzzily matched if they are at least similarity alike.
+func NewCategoryNormalizer(extra map[string][]string, similarity float64) *CategoryNormalizer {
+	n := &CategoryNormalizer{
+		aliases:    make(map[string]string),
+		similarity: similarity,
+	}
+	for category, aliases := range defaultCategoryAliases {
+		n.add(category, aliases)
+	}
+	for category, aliases := range extra {
+		n.add(category, aliases)
+	}
+	return n
+}
+
+// add registers aliases for a category; the category name is an alias of itself
+func (n *CategoryNormalizer) add(category string, aliases []string) {
+	n.aliases[textutil.NormalizeKey(category)] = category
+	for _, alias := range aliases {
+		if key := textutil.NormalizeKey(alias); key != "" {
+			n.aliases[key] = category
+		}
+	}
+}
+
+// Normalize sets the category of the job
+func (n *CategoryNormalizer) Normalize(job domain.Job) domain.Job {
+	job.Category = n.Category(job.Department)
+	return job
+}
+
+// Category returns the standard category of a department, or "" if it can't
+// be mapped. Exact aliases win over aliases contained in the department as
+// whole words (the longest one), which win over fuzzy matches.
+func (n *CategoryNormalizer) Category(department string) string {
+	key := textutil.NormalizeKey(department)
+	if key == "" {
+		return ""
+	}
+	if category, ok := n.aliases[key]; ok {
+		return category
+	}
+
+	padded := " " + key + " "
+	var contained string
+	for alias := range n.aliases {
+		longer := len(alias) > len(contained) || (len(alias) == len(contained) && alias < contained)
+		if longer && strings.Contains(padded, " "+alias+" ") {
+			contained = alias
+		}
+	}
+	if contained != "" {
+		return n.aliases[contained]
+	}
+
+	var best string
+	bestScore := n.similarity
+	for alias, category := range n.aliases {
+		// Ties are broken by name so the result doesn't depend on map order
+		if score := textutil.Similarity(key, alias); score > bestScore || (score == bestScore && best != "" && category < best) {
+			best, bestScore = category, score
+		}
+	}
+	return best
+}
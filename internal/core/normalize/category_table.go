@@ -0,0 +1,26 @@
+// internal/core/normalize/category_table.go
+package normalize
+
+// defaultCategoryAliases maps the standard job categories to department names
+// used by career pages. Entries of the config CategoryMappings setting are
+// merged into it.
+var defaultCategoryAliases = map[string][]string{
+	"Engineering": {
+		"engineering", "software engineering", "software development", "development", "r&d",
+		"research and development", "tech", "technology", "platform", "infrastructure", "devops",
+		"sre", "site reliability", "backend", "frontend", "mobile", "qa", "quality assurance",
+		"developers", "ingeniería", "entwicklung", "développement",
+	},
+	"Design":           {"design", "ux", "ui", "product design", "user experience", "creative", "diseño"},
+	"Product":          {"product", "product management", "product manager", "produkt"},
+	"Data":             {"data", "data science", "analytics", "machine learning", "ml", "ai", "business intelligence", "bi"},
+	"Sales":            {"sales", "business development", "account management", "partnerships", "ventas", "vertrieb"},
+	"Marketing":        {"marketing", "growth", "communications", "brand", "content", "pr", "public relations"},
+	"Customer Success": {"customer success", "customer support", "support", "customer service", "customer experience", "cx"},
+	"Operations":       {"operations", "ops", "business operations", "logistics", "supply chain", "administration"},
+	"Finance":          {"finance", "accounting", "controlling", "treasury", "finanzen"},
+	"People":           {"people", "hr", "human resources", "talent", "recruiting", "talent acquisition", "people operations", "personal"},
+	"Legal":            {"legal", "compliance", "legal and compliance", "recht"},
+	"IT":               {"it", "information technology", "it operations", "internal it", "helpdesk"},
+	"Security":         {"security", "information security", "infosec", "cybersecurity", "cyber security"},
+}
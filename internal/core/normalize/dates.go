@@ -0,0 +1,189 @@
+// internal/core/normalize/dates.go
+package normalize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// listingDate matches a written date: ISO, numeric (day first), or with the
+// month named before or after the day, the year being optional then
+const listingDate = `(\d{4}-\d{1,2}-\d{1,2}|\d{1,2}[./-]\d{1,2}[./-]\d{2,4}|[a-z]{3,9}\.? \d{1,2}(?:st|nd|rd|th)?,? \d{4}|\d{1,2}(?:st|nd|rd|th)?\.? [a-z]{3,9}\.?,? \d{4}|[a-z]{3,9}\.? \d{1,2}(?:st|nd|rd|th)?\b|\d{1,2}(?:st|nd|rd|th)?\.? [a-z]{3,9}\b)`
+
+// postedOn matches an absolute posting date, e.g. "Posted on: Apr 12, 2024"
+var postedOn = regexp.MustCompile(`(?i)\b(?:posted|published|listed|date posted|veröffentlicht|publié|publicado)(?: on| am| le| el)?\s?:?\s?` + listingDate)
+
+// deadlineOn matches an absolute application deadline, e.g. "Deadline: Apr 20"
+var deadlineOn = regexp.MustCompile(`(?i)\b(?:deadline|apply by|apply before|closing date|closes|closes on|expires|expires on|expiry date|last date(?: to apply)?|valid until|bewerbungsfrist|date limite|fecha límite)\s?:?\s?` + listingDate)
+
+// postedAgo matches a relative posting date, e.g. "Posted 3 days ago" or
+// "vor 2 Wochen"
+var postedAgo = regexp.MustCompile(`(?i)\b(?:(\d+|an?|one)\s(minute|hour|day|week|month|year)s?\sago|(?:vor|hace|il y a)\s(\d+|einem|einer|un|une)\s(minuten?|stunden?|tag(?:en)?|wochen?|monat(?:en)?|jahr(?:en)?|minutos?|horas?|días?|semanas?|mes(?:es)?|años?|minutes?|heures?|jours?|semaines?|mois|ans?))\b`)
+
+// postedToday matches the postings of today and yesterday
+var postedToday = regexp.MustCompile(`(?i)\b(?:posted\s)?(today|just now|just posted|yesterday|heute|gestern|hoy|ayer|aujourd'hui|hier)\b`)
+
+// deadlineIn matches a relative deadline, e.g. "5 days left" or "closes in 3 days"
+var deadlineIn = regexp.MustCompile(`(?i)\b(?:(\d+)\s(day|week|month)s?\s(?:left|remaining|to go)|(?:closes|expires|ends|deadline)\sin\s(\d+)\s(day|week|month)s?)\b`)
+
+// dateUnits maps the units of relative dates to days; hours and minutes are 0
+var dateUnits = map[string]int{
+	"minute": 0, "minuten": 0, "minuto": 0, "minutos": 0, "minutes": 0,
+	"hour": 0, "stunde": 0, "stunden": 0, "hora": 0, "horas": 0, "heure": 0, "heures": 0,
+	"day": 1, "tag": 1, "tagen": 1, "día": 1, "días": 1, "jour": 1, "jours": 1,
+	"week": 7, "woche": 7, "wochen": 7, "semana": 7, "semanas": 7, "semaine": 7, "semaines": 7,
+	"month": 30, "monat": 30, "monaten": 30, "mes": 30, "meses": 30, "mois": 30,
+	"year": 365, "jahr": 365, "jahren": 365, "año": 365, "años": 365, "an": 365, "ans": 365,
+}
+
+// numericLayouts parse numeric dates, day first, once their separators are
+// slashes
+var numericLayouts = []string{"2/1/2006", "2/1/06"}
+
+// namedLayouts parse dates with a named month once ordinals, commas and dots
+// are removed
+var namedLayouts = []string{"Jan 2 2006", "January 2 2006", "2 Jan 2006", "2 January 2006"}
+
+// yearlessLayouts parse dates with a named month but without a year
+var yearlessLayouts = []string{"Jan 2", "January 2", "2 Jan", "2 January"}
+
+// dateOrdinal matches the ordinal suffix of a day, e.g. the "th" of "20th"
+var dateOrdinal = regexp.MustCompile(`(?i)(\d)(st|nd|rd|th)\b`)
+
+// DateExtractor fills the posting date and deadline of jobs whose source
+// doesn't provide them from their metadata and description, e.g. "Posted 3
+// days ago" or "Deadline: Apr 20". Relative dates count from the scrape.
+type DateExtractor struct{}
+
+// NewDateExtractor creates a normalizer extracting dates from the text of jobs
+func NewDateExtractor() *DateExtractor {
+	return &DateExtractor{}
+}
+
+// Normalize sets the posting date and deadline of the job if it has none
+// and its text names them
+func (e *DateExtractor) Normalize(job domain.Job) domain.Job {
+	texts := make([]string, 0, len(job.Metadata)+1)
+	for _, key := range job.MetadataKeys() {
+		texts = append(texts, job.Metadata[key])
+	}
+	return ListingDates(job, append(texts, job.Description)...)
+}
+
+// ListingDates fills the zero posting date and deadline of job from the
+// first of texts naming them, relative to when the job was scraped
+func ListingDates(job domain.Job, texts ...string) domain.Job {
+	now := job.ScrapedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+	for _, text := range texts {
+		if job.PostedDate.IsZero() {
+			job.PostedDate = ParsePostedDate(text, now)
+		}
+		if job.Deadline.IsZero() {
+			job.Deadline = ParseDeadline(text, now)
+		}
+	}
+	return job
+}
+
+// ParsePostedDate returns the posting date named in text, relative to now,
+// or the zero time if text names none. Dates are days, at midnight in the
+// location of now.
+func ParsePostedDate(text string, now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if match := postedOn.FindStringSubmatch(text); match != nil {
+		if date, ok := parseListingDate(match[1], today, false); ok {
+			return date
+		}
+	}
+	if match := postedAgo.FindStringSubmatch(text); match != nil {
+		count, unit := match[1]+match[3], strings.ToLower(match[2]+match[4])
+		return today.AddDate(0, 0, -relativeCount(count)*dateUnits[unit])
+	}
+	if match := postedToday.FindStringSubmatch(text); match != nil {
+		switch strings.ToLower(match[1]) {
+		case "yesterday", "gestern", "ayer", "hier":
+			return today.AddDate(0, 0, -1)
+		default:
+			return today
+		}
+	}
+	return time.Time{}
+}
+
+// ParseDeadline returns the application deadline named in text, relative
+// to now, or the zero time if text names none
+func ParseDeadline(text string, now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if match := deadlineOn.FindStringSubmatch(text); match != nil {
+		if date, ok := parseListingDate(match[1], today, true); ok {
+			return date
+		}
+	}
+	if match := deadlineIn.FindStringSubmatch(text); match != nil {
+		count, unit := match[1]+match[3], strings.ToLower(match[2]+match[4])
+		return today.AddDate(0, 0, relativeCount(count)*dateUnits[unit])
+	}
+	return time.Time{}
+}
+
+// relativeCount parses the count of a relative date, "a" or "one" being 1
+func relativeCount(count string) int {
+	if n, err := strconv.Atoi(count); err == nil {
+		return n
+	}
+	return 1
+}
+
+// parseListingDate parses a date matched by listingDate. Dates without a
+// year are taken in the year that puts them closest to today: in the past
+// for postings, in the future for deadlines, as long as they are within half
+// a year.
+func parseListingDate(text string, today time.Time, future bool) (time.Time, bool) {
+	text = strings.TrimSpace(dateOrdinal.ReplaceAllString(text, "$1"))
+	if strings.IndexFunc(text, unicode.IsLetter) < 0 {
+		if date, err := time.ParseInLocation("2006-1-2", text, today.Location()); err == nil {
+			return date, true
+		}
+		numeric := strings.NewReplacer(".", "/", "-", "/").Replace(text)
+		for _, layout := range numericLayouts {
+			if date, err := time.ParseInLocation(layout, numeric, today.Location()); err == nil {
+				return date, true
+			}
+		}
+		return time.Time{}, false
+	}
+
+	text = strings.Join(strings.Fields(strings.NewReplacer(",", " ", ".", " ").Replace(text)), " ")
+	for _, layout := range namedLayouts {
+		if date, err := time.ParseInLocation(layout, text, today.Location()); err == nil {
+			return date, true
+		}
+	}
+	for _, layout := range yearlessLayouts {
+		date, err := time.ParseInLocation(layout, text, today.Location())
+		if err != nil {
+			continue
+		}
+		date = date.AddDate(today.Year(), 0, 0)
+		switch {
+		case future && date.Before(today.AddDate(0, -6, 0)):
+			date = date.AddDate(1, 0, 0)
+		case !future && date.After(today.AddDate(0, 0, 1)):
+			date = date.AddDate(-1, 0, 0)
+		}
+		return date, true
+	}
+	return time.Time{}, false
+}
+
+var (
+	_ Normalizer = (*DateExtractor)(nil) // Ensure interface compliance
+)
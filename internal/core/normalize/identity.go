@@ -0,0 +1,210 @@
+// internal/core/normalize/identity.go
+package normalize
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/textutil"
+)
+
+// Identity strategy names, see ParseJobIdentity
+const (
+	IdentitySourceID      = "id"             // the ID given by the source, e.g. data-job-id
+	IdentityURL           = "url"            // the canonical URL of the posting
+	IdentityTitleLocation = "title-location" // a hash of the normalized title and location
+)
+
+// DefaultJobIdentity is the order strategies are tried in unless configured
+var DefaultJobIdentity = []string{IdentitySourceID, IdentityURL, IdentityTitleLocation}
+
+// trackingParams are query parameters dropped from canonical URLs, as they
+// tell where a visitor came from rather than which posting they see
+var trackingParams = regexp.MustCompile(`(?i)^(utm_.*|gclid|fbclid|msclkid|mc_cid|mc_eid|_ga|ref|referrer|source|src|gh_src|lever-origin|lever-source(\[\])?|trk|trackingid)$`)
+
+// sessionPathParam matches a session ID in the path of a URL, e.g. ";jsessionid=..."
+var sessionPathParam = regexp.MustCompile(`(?i);(jsessionid|sid|phpsessid)=[^/?#]*`)
+
+// JobIdentity derives the ID a job is tracked by across scrapes. Jobs with
+// the same ID are the same posting, so the ID must survive cosmetic changes
+// of the listing; it is "" if the job can't be identified.
+type JobIdentity interface {
+	JobID(job domain.Job) string
+}
+
+// SourceIdentity identifies jobs by the ID their source gives them: the ID
+// of an ATS API, a feed GUID or the data-job-id of a listing
+type SourceIdentity struct{}
+
+// JobID returns the ID the job was scraped with
+func (SourceIdentity) JobID(job domain.Job) string {
+	return strings.TrimSpace(job.ID)
+}
+
+// URLIdentity identifies jobs by the canonical URL of their posting, see
+// CanonicalURL
+type URLIdentity struct{}
+
+// JobID returns the canonical URL of the job, "" if it has none
+func (URLIdentity) JobID(job domain.Job) string {
+	return CanonicalURL(job.URL)
+}
+
+// TitleLocationIdentity identifies jobs by a hash of their title and
+// location, compared regardless of case, punctuation and spacing. Postings
+// of the same title in the same place share an ID, so it comes last.
+type TitleLocationIdentity struct{}
+
+// JobID returns a hash of the normalized title and location of the job, ""
+// if it has no title
+func (TitleLocationIdentity) JobID(job domain.Job) string {
+	title := textutil.NormalizeKey(job.Title)
+	if title == "" {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(title + "\n" + textutil.NormalizeKey(job.Location)))
+	return hex.EncodeToString(hash[:])
+}
+
+// IdentityChain identifies jobs with the first of its strategies that can
+type IdentityChain []JobIdentity
+
+// JobID returns the first ID derived by the strategies of the chain
+func (c IdentityChain) JobID(job domain.Job) string {
+	for _, identity := range c {
+		if id := identity.JobID(job); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// ParseJobIdentity returns the chain of the named strategies, the Identity*
+// constants, tried in order
+func ParseJobIdentity(names []string) (JobIdentity, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no identity strategy given")
+	}
+	chain := make(IdentityChain, 0, len(names))
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case IdentitySourceID:
+			chain = append(chain, SourceIdentity{})
+		case IdentityURL:
+			chain = append(chain, URLIdentity{})
+		case IdentityTitleLocation:
+			chain = append(chain, TitleLocationIdentity{})
+		default:
+			return nil, fmt.Errorf("unknown identity strategy %q", name)
+		}
+	}
+	return chain, nil
+}
+
+// CanonicalURL returns rawURL without the parts that change between visits
+// of the same posting: the fragment, tracking parameters, session IDs, a
+// default port and a trailing slash. Scheme and host are lowercased and the
+// remaining parameters sorted. It returns "" for relative or invalid URLs.
+func CanonicalURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if port := u.Port(); (port == "80" && u.Scheme == "http") || (port == "443" && u.Scheme == "https") {
+		u.Host = u.Hostname()
+	}
+	u.User = nil
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.Path = sessionPathParam.ReplaceAllString(u.Path, "")
+	u.RawPath = ""
+	if len(u.Path) > 1 {
+		u.Path = strings.TrimSuffix(u.Path, "/")
+	} else {
+		u.Path = ""
+	}
+
+	query := u.Query()
+	for key := range query {
+		if trackingParams.MatchString(key) {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// IdentityNormalizer sets the ID of jobs with a JobIdentity, so that
+// listings whose text changes keep their ID. It runs after the other
+// normalizers. Jobs it can't identify keep the ID they were scraped with.
+type IdentityNormalizer struct {
+	identity JobIdentity
+}
+
+// NewIdentityNormalizer creates a normalizer identifying jobs with identity
+func NewIdentityNormalizer(identity JobIdentity) *IdentityNormalizer {
+	return &IdentityNormalizer{
+		identity: identity,
+	}
+}
+
+// Normalize sets the ID of the job
+func (n *IdentityNormalizer) Normalize(job domain.Job) domain.Job {
+	if id := n.identity.JobID(job); id != "" {
+		job.ID = id
+	}
+	return job
+}
+
+// Rekey gives the jobs of previous whose ID no job of current has the ID
+// identity derives for them without it, when a job of current has that ID
+// and no job of previous does. Stored jobs identified by an earlier version,
+// e.g. by a hash of their listing text, or by another strategy thus match
+// their current listing instead of showing as removed and new.
+func Rekey(previous, current domain.JobCollection, identity JobIdentity) domain.JobCollection {
+	currentIDs := make(map[string]bool, len(current.Jobs))
+	for _, job := range current.Jobs {
+		currentIDs[job.ID] = true
+	}
+	taken := make(map[string]bool, len(previous.Jobs))
+	for _, job := range previous.Jobs {
+		taken[job.ID] = true
+	}
+
+	var jobs []domain.Job
+	for i, job := range previous.Jobs {
+		if currentIDs[job.ID] {
+			continue
+		}
+		stripped := job
+		stripped.ID = ""
+		id := identity.JobID(stripped)
+		if id == "" || !currentIDs[id] || taken[id] {
+			continue
+		}
+		if jobs == nil {
+			jobs = append([]domain.Job(nil), previous.Jobs...)
+		}
+		jobs[i].ID = id
+		taken[id] = true
+	}
+	if jobs != nil {
+		previous.Jobs = jobs
+	}
+	return previous
+}
+
+var (
+	_ JobIdentity = SourceIdentity{} // Ensure interface compliance
+	_ JobIdentity = URLIdentity{}
+	_ JobIdentity = TitleLocationIdentity{}
+	_ JobIdentity = IdentityChain(nil)
+	_ Normalizer  = (*IdentityNormalizer)(nil)
+)
@@ -0,0 +1,30 @@
+// internal/core/normalize/language.go
+package normalize
+
+import (
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// LanguageDetector sets the language of jobs from their title and
+// description, see domain.DetectJobLanguage, so filters, queries and
+// translation share one guess. Jobs whose language cannot be told keep the
+// one they have; a source may provide it.
+type LanguageDetector struct{}
+
+// NewLanguageDetector creates a normalizer detecting the language of jobs
+func NewLanguageDetector() *LanguageDetector {
+	return &LanguageDetector{}
+}
+
+// Normalize sets the language of the job if its text tells it. Enriched jobs
+// are detected again with their full description.
+func (d *LanguageDetector) Normalize(job domain.Job) domain.Job {
+	if language := domain.DetectJobLanguage(job); language != "" {
+		job.Language = language
+	}
+	return job
+}
+
+var (
+	_ Normalizer = (*LanguageDetector)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,88 @@
+// internal/core/normalize/location.go
+package normalize
+
+import (
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/textutil"
+)
+
+// locationSeparators split multi-part locations such as "Remoto / Madrid"
+var locationSeparators = []string{",", "/", "|", ";", " - ", " · ", "、"}
+
+// LocationNormalizer maps localized location strings to canonical values and
+// stores the result in Job.NormalizedLocation
+type LocationNormalizer struct {
+	aliases map[string]string // normalized alias -> canonical location
+}
+
+// NewLocationNormalizer creates a normalizer from the built-in tables extended
+// with extra, which maps canonical locations to additional aliases
+func NewLocationNormalizer(extra map[string][]string) *LocationNormalizer {
+	n := &LocationNormalizer{
+		aliases: make(map[string]string),
+	}
+	for canonical, aliases := range defaultLocationAliases {
+		n.add(canonical, aliases)
+	}
+	for canonical, aliases := range extra {
+		n.add(canonical, aliases)
+	}
+	return n
+}
+
+// add registers aliases for a canonical location; the canonical name is an alias of itself
+func (n *LocationNormalizer) add(canonical string, aliases []string) {
+	n.aliases[textutil.NormalizeKey(canonical)] = canonical
+	for _, alias := range aliases {
+		if key := textutil.NormalizeKey(alias); key != "" {
+			n.aliases[key] = canonical
+		}
+	}
+}
+
+// Normalize sets the canonical location of the job
+func (n *LocationNormalizer) Normalize(job domain.Job) domain.Job {
+	job.NormalizedLocation = n.Canonical(job.Location)
+	return job
+}
+
+// Canonical returns the canonical form of a location. Parts of multi-part
+// locations are mapped individually; unknown parts are kept as written.
+func (n *LocationNormalizer) Canonical(location string) string {
+	location = strings.TrimSpace(location)
+	if location == "" {
+		return ""
+	}
+	if canonical, ok := n.aliases[textutil.NormalizeKey(location)]; ok {
+		return canonical
+	}
+
+	parts := []string{location}
+	for _, sep := range locationSeparators {
+		var split []string
+		for _, part := range parts {
+			split = append(split, strings.Split(part, sep)...)
+		}
+		parts = split
+	}
+
+	var canonical []string
+	seen := make(map[string]bool)
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if mapped, ok := n.aliases[textutil.NormalizeKey(part)]; ok {
+			part = mapped
+		}
+		if !seen[part] {
+			seen[part] = true
+			canonical = append(canonical, part)
+		}
+	}
+
+	return strings.Join(canonical, ", ")
+}
@@ -0,0 +1,40 @@
+// internal/core/normalize/location_table.go
+package normalize
+
+// defaultLocationAliases maps canonical locations to localized or alternative
+// spellings. Keys of the config LocationAliases setting are merged into it.
+var defaultLocationAliases = map[string][]string{
+	"Remote": {
+		"remote", "fully remote", "remote first", "anywhere", "work from home", "wfh",
+		"remoto", "en remoto", "teletrabajo", "à distance", "télétravail", "teletravail",
+		"home office", "homeoffice", "fernarbeit", "mobiles arbeiten", "lavoro da remoto",
+		"op afstand", "zdalnie", "praca zdalna", "リモート", "在宅勤務", "远程", "遠端", "재택근무",
+		"रिमोट",
+	},
+	"Hybrid":     {"hybrid", "híbrido", "hibrido", "hybride", "ibrido", "ハイブリッド"},
+	"Kathmandu":  {"kathmandu", "ktm", "kathmandu nepal", "kathmandu valley", "काठमाडौं", "काठमाडौँ", "काठमाण्डौ"},
+	"Lalitpur":   {"lalitpur", "patan", "ललितपुर"},
+	"Bhaktapur":  {"bhaktapur", "भक्तपुर"},
+	"Pokhara":    {"pokhara", "पोखरा"},
+	"Nepal":      {"nepal", "नेपाल"},
+	"Munich":     {"munich", "münchen", "muenchen"},
+	"Cologne":    {"cologne", "köln", "koeln"},
+	"Vienna":     {"vienna", "wien"},
+	"Zurich":     {"zurich", "zürich", "zuerich"},
+	"Geneva":     {"geneva", "genève", "genf"},
+	"Lisbon":     {"lisbon", "lisboa"},
+	"Prague":     {"prague", "praha", "prag"},
+	"Warsaw":     {"warsaw", "warszawa", "warschau"},
+	"Copenhagen": {"copenhagen", "københavn", "kobenhavn"},
+	"Brussels":   {"brussels", "bruxelles", "brussel"},
+	"Milan":      {"milan", "milano"},
+	"Rome":       {"rome", "roma"},
+	"Tokyo":      {"tokyo", "東京", "東京都"},
+	"Osaka":      {"osaka", "大阪"},
+	"Seoul":      {"seoul", "서울"},
+	"Beijing":    {"beijing", "北京"},
+	"Shanghai":   {"shanghai", "上海"},
+	"Bangalore":  {"bangalore", "bengaluru"},
+	"Mumbai":     {"mumbai", "bombay"},
+	"New Delhi":  {"new delhi", "delhi", "नई दिल्ली"},
+}
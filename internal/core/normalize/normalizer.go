@@ -0,0 +1,29 @@
+// internal/core/normalize/normalizer.go
+package normalize
+
+import (
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// Normalizer transforms a scraped job before it is filtered and diffed
+type Normalizer interface {
+	Normalize(job domain.Job) domain.Job
+}
+
+// Apply runs every normalizer over every job of the collection, in order
+func Apply(collection domain.JobCollection, normalizers ...Normalizer) domain.JobCollection {
+	if len(normalizers) == 0 {
+		return collection
+	}
+
+	jobs := make([]domain.Job, len(collection.Jobs))
+	for i, job := range collection.Jobs {
+		for _, n := range normalizers {
+			job = n.Normalize(job)
+		}
+		jobs[i] = job
+	}
+
+	collection.Jobs = jobs
+	return collection
+}
@@ -0,0 +1,203 @@
+// internal/core/normalize/salary.go
+package normalize
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// salaryAmount matches an amount as written in postings: with lakh grouping
+// ("1,20,000"), thousands separators ("120,000", "80.000", "80 000") and
+// optional cents, or plain
+const salaryAmount = `(?:\d{1,2}(?:,\d{2})+,\d{3}|\d{1,3}(?:[.,' ]\d{3})+(?:[.,]\d{1,2})?|\d+(?:[.,]\d{1,2})?)`
+
+// salaryCurrency matches a currency symbol or ISO 4217 code
+const salaryCurrency = `(?:US\$|CA\$|C\$|AU\$|A\$|NZ\$|S\$|HK\$|\$|€|£|₹|₨|¥|\bRs\.?|\b(?:USD|EUR|GBP|INR|NPR|PKR|LKR|BDT|CAD|AUD|NZD|CHF|SEK|NOK|DKK|PLN|CZK|HUF|JPY|CNY|SGD|HKD|ZAR|BRL|MXN|AED)\b)`
+
+// salaryRange matches a salary or salary range, e.g. "$120k–$150k",
+// "NPR 80,000" or "50.000 - 60.000 €"
+var salaryRange = regexp.MustCompile(`(?i)(` + salaryCurrency + `)?\s?(` + salaryAmount + `)\s?(k\b)?\s?(` + salaryCurrency + `)?` +
+	`(?:\s?(?:-|–|—|to|bis|à)\s?(` + salaryCurrency + `)?\s?(` + salaryAmount + `)\s?(k\b)?\s?(` + salaryCurrency + `)?)?`)
+
+// salaryPeriodAfter matches the period following a salary, e.g. "/month" or
+// "per annum"
+var salaryPeriodAfter = regexp.MustCompile(`(?i)^\s?(?:(?:/|per|an?|each)\s?(year|yr|annum|month|mo|week|wk|day|hour|hr)\b|(p\.\s?a\.?|annual(?:ly)?|yearly|monthly|weekly|daily|hourly)(?:\W|$))`)
+
+// salaryPeriodBefore matches the period of a salary named before it, e.g.
+// "Monthly salary:"
+var salaryPeriodBefore = regexp.MustCompile(`(?i)\b(annual|yearly|monthly|weekly|daily|hourly)\b`)
+
+// salaryContext matches the words introducing a salary in the text before it
+var salaryContext = regexp.MustCompile(`(?i)(salary|compensation|\bpay\b|wage|\bbase\b|\bote\b|\bctc\b|package|remuneration|gehalt|vergütung|salaire|salario|stipendio)`)
+
+// salaryNotPay matches what the text after an amount names when the amount is
+// no salary, e.g. "$5M in funding" or "€500 learning budget"
+var salaryNotPay = regexp.MustCompile(`(?i)^\W{0,2}\s?(?:m\b|mm\b|million|bn\b|billion|.{0,30}\b(?:budget|stipend|allowance|bonus|funding|raised|revenue|valuation|equity|referral|relocation|signing|per employee|users|customers)\b)`)
+
+// salaryCurrencies maps currency symbols to ISO 4217 codes; the dollar is
+// taken for USD and rupees for INR unless the code is written
+var salaryCurrencies = map[string]string{
+	"$": "USD", "US$": "USD", "CA$": "CAD", "C$": "CAD", "AU$": "AUD", "A$": "AUD",
+	"NZ$": "NZD", "S$": "SGD", "HK$": "HKD", "€": "EUR", "£": "GBP", "₹": "INR",
+	"₨": "INR", "RS": "INR", "RS.": "INR", "¥": "JPY",
+}
+
+// salaryPeriods maps the period words of postings to salary periods
+var salaryPeriods = map[string]domain.SalaryPeriod{
+	"year": domain.SalaryPeriodYear, "yr": domain.SalaryPeriodYear, "annum": domain.SalaryPeriodYear,
+	"annual": domain.SalaryPeriodYear, "annually": domain.SalaryPeriodYear, "yearly": domain.SalaryPeriodYear,
+	"month": domain.SalaryPeriodMonth, "mo": domain.SalaryPeriodMonth, "monthly": domain.SalaryPeriodMonth,
+	"week": domain.SalaryPeriodWeek, "wk": domain.SalaryPeriodWeek, "weekly": domain.SalaryPeriodWeek,
+	"day": domain.SalaryPeriodDay, "daily": domain.SalaryPeriodDay,
+	"hour": domain.SalaryPeriodHour, "hr": domain.SalaryPeriodHour, "hourly": domain.SalaryPeriodHour,
+}
+
+// SalaryExtractor fills the salary of jobs whose source doesn't provide one
+// from the salary written in their metadata, title or description, e.g.
+// "$120k–$150k" or "NPR 80,000/month". Detail pages read by enrichment are
+// scanned too, as enriched jobs are normalized again.
+type SalaryExtractor struct{}
+
+// NewSalaryExtractor creates a normalizer extracting salaries from the text of jobs
+func NewSalaryExtractor() *SalaryExtractor {
+	return &SalaryExtractor{}
+}
+
+// Normalize sets the salary of the job if it has none and its text names one
+func (e *SalaryExtractor) Normalize(job domain.Job) domain.Job {
+	if job.Salary != nil {
+		return job
+	}
+	texts := make([]string, 0, len(job.Metadata)+2)
+	for _, key := range job.MetadataKeys() {
+		texts = append(texts, job.Metadata[key])
+	}
+	texts = append(texts, job.Title, job.Description)
+	for _, text := range texts {
+		if salary, ok := ParseSalary(text); ok {
+			job.Salary = &salary
+			return job
+		}
+	}
+	return job
+}
+
+// ParseSalary returns the first salary named in text. Amounts count as a
+// salary if they have a currency and are a range, have a period or follow a
+// word like "salary"; amounts of funding, budgets and the like don't.
+func ParseSalary(text string) (domain.Salary, bool) {
+	for _, match := range salaryRange.FindAllStringSubmatchIndex(text, -1) {
+		group := func(i int) string {
+			if match[2*i] < 0 {
+				return ""
+			}
+			return text[match[2*i]:match[2*i+1]]
+		}
+		currency := salaryCurrencyCode(group(1), group(4), group(5), group(8))
+		if currency == "" {
+			continue
+		}
+		after := text[match[1]:]
+		if salaryNotPay.MatchString(after) {
+			continue
+		}
+
+		min, ok := parseSalaryAmount(group(2), group(3) != "")
+		if !ok {
+			continue
+		}
+		max := min
+		ranged := group(6) != ""
+		if ranged {
+			if max, ok = parseSalaryAmount(group(6), group(7) != ""); !ok {
+				continue
+			}
+			if group(3) == "" && group(7) != "" && min < 1000 {
+				min *= 1000 // "$120-150k"
+			}
+			if max < min {
+				continue
+			}
+		}
+
+		before := text[max0(match[0]-40):match[0]]
+		period := salaryPeriodOf(after, before)
+		if !ranged && period == "" && !salaryContext.MatchString(before) {
+			continue
+		}
+		salary := domain.Salary{Currency: currency, Min: min, Period: period}
+		if ranged {
+			salary.Max = max
+		}
+		return salary, true
+	}
+	return domain.Salary{}, false
+}
+
+// salaryCurrencyCode returns the ISO 4217 code of the first currency written,
+// "" if none is
+func salaryCurrencyCode(written ...string) string {
+	for _, currency := range written {
+		if currency == "" {
+			continue
+		}
+		currency = strings.ToUpper(currency)
+		if code, ok := salaryCurrencies[currency]; ok {
+			return code
+		}
+		return currency
+	}
+	return ""
+}
+
+// parseSalaryAmount parses an amount matched by salaryAmount, in thousands
+// if thousands is set, e.g. for "120k"
+func parseSalaryAmount(amount string, thousands bool) (float64, bool) {
+	cents := ""
+	if i := strings.LastIndexAny(amount, ".,"); i >= 0 && len(amount)-i-1 <= 2 {
+		amount, cents = amount[:i], amount[i+1:]
+	}
+	amount = strings.NewReplacer(",", "", ".", "", "'", "", " ", "").Replace(amount)
+	if cents != "" {
+		amount += "." + cents
+	}
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil || value <= 0 {
+		return 0, false
+	}
+	if thousands {
+		value *= 1000
+	}
+	return value, true
+}
+
+// salaryPeriodOf returns the period of a salary from the text after it, else
+// the text before it, "" if neither names one
+func salaryPeriodOf(after, before string) domain.SalaryPeriod {
+	if match := salaryPeriodAfter.FindStringSubmatch(after); match != nil {
+		word := strings.ToLower(match[1] + match[2])
+		if strings.HasPrefix(word, "p") {
+			return domain.SalaryPeriodYear // p.a.
+		}
+		return salaryPeriods[word]
+	}
+	if matches := salaryPeriodBefore.FindAllString(before, -1); len(matches) > 0 {
+		return salaryPeriods[strings.ToLower(matches[len(matches)-1])]
+	}
+	return ""
+}
+
+// max0 returns i, or 0 if i is negative
+func max0(i int) int {
+	if i < 0 {
+		return 0
+	}
+	return i
+}
+
+var (
+	_ Normalizer = (*SalaryExtractor)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,179 @@
+// internal/core/normalize/text.go
+package normalize
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// invisibleRunes are dropped from scraped text: zero-width characters, soft
+// hyphens and byte order marks, which change strings without showing
+var invisibleRunes = strings.NewReplacer("\u200b", "", "\u200c", "", "\u200d", "", "\u2060", "", "\u00ad", "", "\ufeff", "")
+
+// titleBoilerplate matches the labels, badges and calls to action that
+// listings wrap titles in, e.g. "Job title: ..." or "... Apply now"
+var titleBoilerplate = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)^(job title|title|position|role|job)\s*:\s*`),
+	regexp.MustCompile(`(?i)^(new|hot|featured|urgent)\s*[!:|•·-]+\s*`),
+	regexp.MustCompile(`(?i)\s*(\s[|•·–—-]\s*|\()?(apply now|view job|view details|learn more|read more|more info)\)?[!.]?$`),
+	regexp.MustCompile(`(?i)\s*([|•·–—-]\s*|\()(new|hot|featured|apply)\)?!?$`),
+}
+
+// locationBoilerplate matches the label of locations, e.g. "Location: Berlin"
+var locationBoilerplate = regexp.MustCompile(`(?i)^(job )?(location|locations|office|based in|standort|lieu|ubicación)\s*:\s*`)
+
+// edgePunctuation is trimmed from both ends of single-line fields, left over
+// from separators between a field and its neighbors
+const edgePunctuation = "|•·–—-:;,"
+
+// shoutedMinorWords are lowercased when an all-caps field is recased, instead
+// of being kept as acronyms
+var shoutedMinorWords = map[string]bool{
+	"and": true, "or": true, "of": true, "for": true, "the": true, "in": true,
+	"at": true, "to": true, "on": true, "with": true, "a": true, "an": true,
+	"und": true, "der": true, "die": true, "das": true, "de": true, "la": true,
+	"le": true, "et": true, "en": true, "del": true, "y": true,
+}
+
+// TextNormalizer cleans up the text fields of scraped jobs: HTML entities
+// are decoded, Unicode is composed (NFC), invisible characters are dropped,
+// whitespace is collapsed and labels, badges and calls to action around
+// titles and locations are trimmed. All-caps titles, locations and
+// departments are recased. Cosmetic differences between scrapes of a job
+// thus don't show up as updates. It runs before the other normalizers.
+type TextNormalizer struct{}
+
+// NewTextNormalizer creates a normalizer of the text fields of jobs
+func NewTextNormalizer() *TextNormalizer {
+	return &TextNormalizer{}
+}
+
+// Normalize cleans up the text fields of the job
+func (n *TextNormalizer) Normalize(job domain.Job) domain.Job {
+	job.Title = recase(trimBoilerplate(Line(job.Title), titleBoilerplate...))
+	job.Location = recase(trimBoilerplate(Line(job.Location), locationBoilerplate))
+	job.Department = recase(Line(job.Department))
+	job.Category = Line(job.Category)
+	job.Description = Paragraphs(job.Description)
+	job.URL = strings.TrimSpace(job.URL)
+	job.ApplyURL = strings.TrimSpace(job.ApplyURL)
+	if len(job.Metadata) > 0 {
+		metadata := make(map[string]string, len(job.Metadata))
+		for key, value := range job.Metadata {
+			metadata[key] = Line(value)
+		}
+		job.Metadata = metadata
+	}
+	return job
+}
+
+// Line returns s as a single line of clean text: entities decoded, composed,
+// without invisible characters and with its whitespace collapsed
+func Line(s string) string {
+	return strings.Join(strings.Fields(clean(s)), " ")
+}
+
+// Paragraphs cleans up multi-line text like Line, keeping line breaks and at
+// most one blank line between paragraphs
+func Paragraphs(s string) string {
+	lines := strings.Split(clean(s), "\n")
+	kept := lines[:0]
+	blank := false
+	for _, line := range lines {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			blank = len(kept) > 0
+			continue
+		}
+		if blank {
+			kept = append(kept, "")
+			blank = false
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// clean decodes entities, twice for doubly escaped ones such as "&amp;amp;",
+// composes the text and drops invisible characters
+func clean(s string) string {
+	if strings.Contains(s, "&") {
+		s = html.UnescapeString(html.UnescapeString(s))
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return invisibleRunes.Replace(norm.NFC.String(s))
+}
+
+// trimBoilerplate removes the matches of patterns and the separators left at
+// the ends of s, keeping s if nothing else would remain
+func trimBoilerplate(s string, patterns ...*regexp.Regexp) string {
+	trimmed := s
+	for _, pattern := range patterns {
+		trimmed = pattern.ReplaceAllString(trimmed, "")
+	}
+	trimmed = strings.TrimSpace(strings.Trim(strings.TrimSpace(trimmed), edgePunctuation))
+	if trimmed == "" {
+		return s
+	}
+	return trimmed
+}
+
+// recase turns an all-caps text of several words, e.g. "SENIOR ENGINEER, QA",
+// into title case, keeping words of up to three letters, which are mostly
+// acronyms, unless they are minor words. Other texts are returned as is.
+func recase(s string) string {
+	letters, upper := 0, 0
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	if letters < 4 || upper != letters || !strings.Contains(s, " ") {
+		return s
+	}
+
+	words := strings.Fields(s)
+	for i, word := range words {
+		lower := strings.ToLower(word)
+		switch {
+		case i > 0 && shoutedMinorWords[strings.Trim(lower, edgePunctuation+"()")]:
+			words[i] = lower
+		case len([]rune(strings.Trim(word, edgePunctuation+"()"))) <= 3:
+			// an acronym, e.g. QA or AWS
+		default:
+			words[i] = capitalize(lower)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// capitalize uppercases the first letter of each part of a lowercase word,
+// e.g. "full-stack" becomes "Full-Stack"
+func capitalize(word string) string {
+	runes := []rune(word)
+	start := true
+	for i, r := range runes {
+		if unicode.IsLetter(r) {
+			if start {
+				runes[i] = unicode.ToUpper(r)
+			}
+			start = false
+		} else {
+			start = r == '-' || r == '/' || r == '(' || r == '&'
+		}
+	}
+	return string(runes)
+}
+
+var (
+	_ Normalizer = (*TextNormalizer)(nil) // Ensure interface compliance
+)
@@ -0,0 +1,45 @@
+// internal/core/ports/archive.go
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// SnapshotArchive is implemented by repositories that keep the raw page of
+// every scrape run, so historical job data can be rebuilt from it
+type SnapshotArchive interface {
+	// ListRawSnapshots returns the snapshots of url scraped at or after since,
+	// oldest first. RawContent is empty for snapshots whose raw page was
+	// purged or moved to a RawContentStore.
+	ListRawSnapshots(ctx context.Context, url string, since time.Time) ([]domain.RawSnapshot, error)
+	// ReplaceSnapshotJobs overwrites the jobs parsed from a snapshot
+	ReplaceSnapshotJobs(ctx context.Context, url string, version int64, jobs []domain.Job) error
+}
+
+// RawContentStore archives the raw pages of scrapes apart from job data,
+// keyed by source URL and scrape time. Raw pages are personal data as much
+// as the descriptions they were parsed into, so the archive can be purged,
+// pruned and cleared of a source like the repository.
+type RawContentStore interface {
+	PutRawContent(ctx context.Context, url string, scrapedAt time.Time, content string) error
+	// GetRawContent returns domain.ErrNotFound if no page of that scrape was archived
+	GetRawContent(ctx context.Context, url string, scrapedAt time.Time) (string, error)
+	// ListRawContent returns the scrape times of the archived pages of url, newest first
+	ListRawContent(ctx context.Context, url string) ([]time.Time, error)
+	// PruneRawContent removes the pages and screenshots of scrapes before
+	// cutoff and returns how many were removed. PurgePersonalData does the
+	// same for the compliance purge.
+	PruneRawContent(ctx context.Context, cutoff time.Time) (int, error)
+	PersonalDataPurger
+	// DeleteSource removes the pages and screenshots of a URL
+	SourceDeleter
+}
+
+// ScreenshotStore is implemented by raw archives that also keep the
+// screenshots of rendered pages, next to the raw page of the same scrape
+type ScreenshotStore interface {
+	PutScreenshot(ctx context.Context, url string, scrapedAt time.Time, png []byte) error
+}
@@ -0,0 +1,16 @@
+// internal/core/ports/audit.go
+package ports
+
+import (
+	"context"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// AuditLog defines the interface for the append-only log of administrative
+// actions. Repositories that persist state implement it alongside JobRepository.
+type AuditLog interface {
+	AppendAudit(ctx context.Context, entry domain.AuditEntry) error
+	// QueryAudit returns the matching entries, newest first
+	QueryAudit(ctx context.Context, filter domain.AuditFilter) ([]domain.AuditEntry, error)
+}
@@ -0,0 +1,17 @@
+// internal/core/ports/batch.go
+package ports
+
+import (
+	"context"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// CollectionProcessor runs job collections gathered outside of the scraper,
+// e.g. by an external collector, through the diff and notify pipeline
+type CollectionProcessor interface {
+	// ProcessCollections diffs each collection against the stored collection
+	// of its source URL, notifies about changes and saves it, returning one
+	// result per collection in order
+	ProcessCollections(ctx context.Context, collections []domain.JobCollection) []domain.BatchResult
+}
@@ -0,0 +1,21 @@
+// internal/core/ports/bulk_import.go
+package ports
+
+import (
+	"context"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// PageFetcher downloads single pages, such as the detail pages of jobs
+type PageFetcher interface {
+	FetchPage(ctx context.Context, url string) (string, error)
+}
+
+// ImportProgressStore persists the progress of bulk imports so they can be
+// resumed after an interruption
+type ImportProgressStore interface {
+	// LoadImportProgress returns domain.ErrNotFound if no import of url was started
+	LoadImportProgress(ctx context.Context, url string) (domain.ImportProgress, error)
+	SaveImportProgress(ctx context.Context, progress domain.ImportProgress) error
+}
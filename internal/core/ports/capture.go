@@ -0,0 +1,19 @@
+// internal/core/ports/capture.go
+package ports
+
+import (
+	"context"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// CaptureStore defines the interface for keeping notifications captured
+// instead of sent. Repositories that persist state implement it alongside
+// JobRepository; they keep a bounded number of captures, which their
+// PurgePersonalData, DeleteSource and PruneSnapshots cover like snapshots.
+type CaptureStore interface {
+	// SaveCapture stores a notification, assigning its ID
+	SaveCapture(ctx context.Context, capture domain.CapturedNotification) error
+	// ListCaptures returns up to limit captured notifications, newest first, or all if limit <= 0
+	ListCaptures(ctx context.Context, limit int) ([]domain.CapturedNotification, error)
+}
@@ -0,0 +1,24 @@
+// internal/core/ports/compliance.go
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// PersonalDataPurger is implemented by stores that can strip personal data
+// from old snapshots to comply with data retention policies
+type PersonalDataPurger interface {
+	// PurgePersonalData strips personal-data-bearing fields and raw content from
+	// snapshots scraped before cutoff, and job descriptions from diffs computed
+	// and notifications captured or sent before cutoff, and returns how many
+	// snapshots, diffs and notifications were purged
+	PurgePersonalData(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// SourceDeleter is implemented by stores that can forget a source entirely
+type SourceDeleter interface {
+	// DeleteSource removes everything stored about the source URL and returns
+	// the number of records deleted
+	DeleteSource(ctx context.Context, url string) (int, error)
+}
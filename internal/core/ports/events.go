@@ -0,0 +1,14 @@
+// internal/core/ports/events.go
+package ports
+
+import (
+	"context"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// JobEventPublisher receives the job events of every diff computed by a run,
+// whether or not a notification goes out, e.g. to stream them to clients
+type JobEventPublisher interface {
+	PublishJobEvents(ctx context.Context, events []domain.JobEvent)
+}
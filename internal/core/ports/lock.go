@@ -0,0 +1,16 @@
+// internal/core/ports/lock.go
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// Locker defines the interface for named locks shared between instances.
+// Locks expire after their TTL so a crashed owner can't hold them forever.
+type Locker interface {
+	// TryLock acquires key for owner if it is free, or extends it if owner already holds it
+	TryLock(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// Unlock releases key if owner holds it
+	Unlock(ctx context.Context, key, owner string) error
+}
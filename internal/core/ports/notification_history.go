@@ -0,0 +1,20 @@
+// internal/core/ports/notification_history.go
+package ports
+
+import (
+	"context"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// NotificationHistory defines the interface for keeping the notifications
+// sent and their delivery attempts. Repositories that persist state
+// implement it alongside JobRepository; they keep a bounded number of
+// records, which their PurgePersonalData, DeleteSource and PruneSnapshots
+// cover like snapshots.
+type NotificationHistory interface {
+	// RecordNotification stores a notification with its delivery attempts, assigning its ID
+	RecordNotification(ctx context.Context, record domain.NotificationRecord) error
+	// QueryNotifications returns the matching notifications, newest first
+	QueryNotifications(ctx context.Context, filter domain.NotificationFilter) ([]domain.NotificationRecord, error)
+}
@@ -9,4 +9,29 @@ import (
 // Notifier defines the interface for sending notifications
 type Notifier interface {
 	NotifyNewJobs(ctx context.Context, diff domain.DiffResult) error
-}
\ No newline at end of file
+}
+
+// DigestNotifier is implemented by notifiers that can deliver all changes of a
+// scrape run as a single digest instead of one message per source
+type DigestNotifier interface {
+	NotifyDigest(ctx context.Context, digest domain.Digest) error
+}
+
+// MessageNotifier is implemented by notifiers that can deliver standalone
+// notifications such as scraping errors
+type MessageNotifier interface {
+	Notify(ctx context.Context, notification domain.Notification) error
+}
+
+// Prober is implemented by notifiers that can verify their configuration (for
+// example webhook URLs or credentials) without sending a real notification
+type Prober interface {
+	Probe(ctx context.Context) error
+}
+
+// SizeLimited is implemented by notifiers whose platform caps the size of a
+// message, reporting whether changes can be shown in full
+type SizeLimited interface {
+	FitsDiff(diff domain.DiffResult) bool
+	FitsDigest(digest domain.Digest) bool
+}
@@ -0,0 +1,12 @@
+// internal/core/ports/rates.go
+package ports
+
+import (
+	"context"
+)
+
+// RateSource defines the interface for retrieving currency exchange rates.
+// Rates are expressed as units of each currency per one unit of base.
+type RateSource interface {
+	Rates(ctx context.Context) (base string, rates map[string]float64, err error)
+}
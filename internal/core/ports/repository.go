@@ -2,12 +2,58 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/fuzztobread/job-scheduler/internal/core/domain"
 )
 
-// JobRepository defines the interface for storing and retrieving job data
+// JobRepository defines the interface for storing and retrieving job data.
+// GetLatestJobCollection returns domain.ErrNotFound if the URL was never saved.
+// Every saved collection is also kept as a snapshot; repositories retain the
+// newest snapshots of each URL up to their configured limit.
 type JobRepository interface {
 	SaveJobCollection(ctx context.Context, jobs domain.JobCollection) error
 	GetLatestJobCollection(ctx context.Context, url string) (domain.JobCollection, error)
+	// SaveSnapshot adds a collection to the history of its URL without making
+	// it the latest collection, e.g. when importing history
+	SaveSnapshot(ctx context.Context, jobs domain.JobCollection) error
+	// ListSnapshots returns up to limit snapshots of a URL, newest first, or
+	// all retained snapshots if limit <= 0
+	ListSnapshots(ctx context.Context, url string, limit int) ([]domain.JobCollection, error)
+	// GetJobHistory returns the timeline of a job ID on a URL from the retained
+	// snapshots and the departed jobs of the newest, or domain.ErrNotFound if
+	// none of them lists the job
+	GetJobHistory(ctx context.Context, url, jobID string) (domain.JobHistory, error)
+	// SaveScrapeFailure records a failed scrape, replacing the previous
+	// failure of its URL
+	SaveScrapeFailure(ctx context.Context, failure domain.ScrapeFailure) error
+	// ListTrackedSources returns the state of every URL with a saved
+	// collection or a recorded failure
+	ListTrackedSources(ctx context.Context) ([]domain.TrackedSource, error)
+	// SaveDiff records a diff computed for the URL of record.Diff
+	SaveDiff(ctx context.Context, record domain.DiffRecord) error
+	// GetDiffHistory returns the diffs recorded for a URL at or after since,
+	// oldest first
+	GetDiffHistory(ctx context.Context, url string, since time.Time) ([]domain.DiffRecord, error)
+}
+
+// PortSupporter is implemented by repository decorators, which have the
+// methods of the optional repository ports such as AuditLog but can only
+// serve the ports their backends serve
+type PortSupporter interface {
+	// SupportsPort reports whether the repository serves the port given as a
+	// nil pointer to it, e.g. (*ports.AuditLog)(nil)
+	SupportsPort(port any) bool
+}
+
+// Optional returns repo as the optional port P, e.g. AuditLog, if it serves
+// it. Use it instead of a type assertion, which also matches decorators
+// whose backends don't serve the port.
+func Optional[P any](repo JobRepository) (P, bool) {
+	port, ok := repo.(P)
+	if supporter, decorated := repo.(PortSupporter); ok && decorated && !supporter.SupportsPort((*P)(nil)) {
+		var none P
+		return none, false
+	}
+	return port, ok
 }
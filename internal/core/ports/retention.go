@@ -0,0 +1,19 @@
+// internal/core/ports/retention.go
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// RetentionPruner is implemented by stores that can delete data by age to
+// keep persistent storage from growing forever
+type RetentionPruner interface {
+	// PruneSnapshots deletes snapshots scraped before cutoff, never the latest
+	// collection of a source, and returns how many were deleted. Diffs and
+	// captured and sent notifications from before cutoff are deleted too.
+	PruneSnapshots(ctx context.Context, cutoff time.Time) (int, error)
+	// PruneSources deletes everything stored about sources last scraped before
+	// cutoff, e.g. ones removed from the configuration, and returns their URLs
+	PruneSources(ctx context.Context, cutoff time.Time) ([]string, error)
+}
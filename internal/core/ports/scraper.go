@@ -11,3 +11,22 @@ import (
 type Scraper interface {
 	Scrape(ctx context.Context, url string) (domain.JobCollection, error)
 }
+
+// JobParser defines the interface for extracting jobs from a fetched page, so
+// archived pages can be parsed again after selectors were fixed
+type JobParser interface {
+	ParseJobs(html, sourceURL string) ([]domain.Job, error)
+}
+
+// JobEnricher completes a job from its detail page, as listing pages rarely
+// show full descriptions, posted dates or salaries
+type JobEnricher interface {
+	EnrichJob(ctx context.Context, job domain.Job) (domain.Job, error)
+}
+
+// ChangeDetector cheaply fingerprints a page before it is scraped, so the
+// expensive browser scrape can be skipped while the fingerprint is unchanged.
+// An empty fingerprint means the URL has no precheck and is always scraped.
+type ChangeDetector interface {
+	Fingerprint(ctx context.Context, url string) (string, error)
+}
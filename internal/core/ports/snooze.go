@@ -0,0 +1,20 @@
+// internal/core/ports/snooze.go
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// SnoozeStore keeps the snoozes silencing notifications about sources and jobs
+type SnoozeStore interface {
+	// SaveSnooze adds a snooze and returns it with its ID assigned
+	SaveSnooze(ctx context.Context, snooze domain.Snooze) (domain.Snooze, error)
+	// ListSnoozes returns the snoozes still active at a time, ending soonest first
+	ListSnoozes(ctx context.Context, at time.Time) ([]domain.Snooze, error)
+	// DeleteSnooze removes a snooze, returning domain.ErrNotFound if there is
+	// none with the ID
+	DeleteSnooze(ctx context.Context, id string) error
+}
@@ -0,0 +1,24 @@
+// internal/core/ports/summary.go
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// SummaryStore hosts pages summarizing changes too large for a notification.
+// The pages list jobs with their descriptions, so the store can be purged,
+// pruned and cleared of a source like the repository. A page summarizing
+// several sources is deleted with any of them.
+type SummaryStore interface {
+	// PutSummary stores a page about the given source URLs under name and
+	// returns the URL it is served at
+	PutSummary(ctx context.Context, name, contentType string, content []byte, sources []string) (string, error)
+	// PruneSummaries removes the pages stored before cutoff and returns how
+	// many were removed. PurgePersonalData does the same for the compliance
+	// purge.
+	PruneSummaries(ctx context.Context, cutoff time.Time) (int, error)
+	PersonalDataPurger
+	// DeleteSource removes the pages about a URL
+	SourceDeleter
+}
@@ -0,0 +1,17 @@
+// internal/core/ports/trace.go
+package ports
+
+import (
+	"context"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// FilterTraceStore defines the interface for recording and querying the jobs
+// dropped by filters, so users can find out why a posting never showed up
+type FilterTraceStore interface {
+	RecordRejections(ctx context.Context, rejections []domain.FilterRejection) error
+	// FindRejections returns the rejections for a source URL (all sources if empty)
+	// whose job title contains title, case-insensitively
+	FindRejections(ctx context.Context, url, title string) ([]domain.FilterRejection, error)
+}
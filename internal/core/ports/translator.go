@@ -0,0 +1,13 @@
+// internal/core/ports/translator.go
+package ports
+
+import (
+	"context"
+)
+
+// Translator defines the interface for machine translation, used to show the
+// titles of jobs posted in other languages in the language of the user.
+// Languages are ISO 639-1 codes; texts are translated in order.
+type Translator interface {
+	Translate(ctx context.Context, texts []string, source, target string) ([]string, error)
+}
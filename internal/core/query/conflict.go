@@ -0,0 +1,170 @@
+// internal/core/query/conflict.go
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Conflict reports two terms of a query that no job can satisfy at the same
+// time, such as department = "Sales" AND department != "Sales", meaning the
+// query never matches. Only terms that must all hold, i.e. not under an OR,
+// are compared, so a reported conflict is certain but not every query that
+// never matches is detected.
+func Conflict(q *Query) (string, string, bool) {
+	terms := conjuncts(q.root, false)
+	for i, a := range terms {
+		for _, b := range terms[i+1:] {
+			if excludes(a, b) {
+				return describe(a), describe(b), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// ConflictBetween reports a term of a and a term of b that no job can satisfy
+// at the same time, meaning no job matches both queries. Like Conflict it
+// only compares terms not under an OR.
+func ConflictBetween(a, b *Query) (string, string, bool) {
+	for _, termA := range conjuncts(a.root, false) {
+		for _, termB := range conjuncts(b.root, false) {
+			if excludes(termA, termB) {
+				return describe(termA), describe(termB), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// conjuncts returns the comparisons that must all hold for n to be satisfied,
+// negated if negate is set. Disjunctions contribute nothing.
+func conjuncts(n node, negate bool) []node {
+	switch n := n.(type) {
+	case andNode:
+		if negate {
+			return nil
+		}
+		return append(conjuncts(n.left, false), conjuncts(n.right, false)...)
+	case orNode:
+		if !negate {
+			return nil
+		}
+		// NOT (a OR b) holds when both NOT a and NOT b do
+		return append(conjuncts(n.left, true), conjuncts(n.right, true)...)
+	case notNode:
+		return conjuncts(n.operand, !negate)
+	case equalNode:
+		n.negate = n.negate != negate
+		return []node{n}
+	case matchNode:
+		n.negate = n.negate != negate
+		return []node{n}
+	case inNode:
+		n.negate = n.negate != negate
+		return []node{n}
+	}
+	return nil
+}
+
+// excludes reports whether no field value satisfies both comparisons
+func excludes(a, b node) bool {
+	if fieldName(a) != fieldName(b) {
+		return false
+	}
+
+	// A comparison allowing a fixed set of values excludes another one if
+	// none of them satisfies it
+	if values, ok := allowedValues(a); ok {
+		return !anyAccepted(b, values)
+	}
+	if values, ok := allowedValues(b); ok {
+		return !anyAccepted(a, values)
+	}
+
+	// Otherwise only a pattern and its own negation are known to conflict
+	ma, okA := a.(matchNode)
+	mb, okB := b.(matchNode)
+	return okA && okB && ma.negate != mb.negate && ma.re.String() == mb.re.String()
+}
+
+// allowedValues returns the only values a comparison accepts, if finite
+func allowedValues(n node) ([]string, bool) {
+	switch n := n.(type) {
+	case equalNode:
+		return []string{n.value}, !n.negate
+	case inNode:
+		return n.values, !n.negate
+	}
+	return nil, false
+}
+
+// anyAccepted reports whether the comparison accepts one of the values
+func anyAccepted(n node, values []string) bool {
+	for _, value := range values {
+		if accepts(n, strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// accepts reports whether the comparison holds for a field value
+func accepts(n node, value string) bool {
+	switch n := n.(type) {
+	case equalNode:
+		return strings.EqualFold(value, n.value) != n.negate
+	case matchNode:
+		return n.re.MatchString(value) != n.negate
+	case inNode:
+		for _, candidate := range n.values {
+			if strings.EqualFold(value, candidate) {
+				return !n.negate
+			}
+		}
+		return n.negate
+	}
+	return true
+}
+
+// fieldName returns the field a comparison applies to
+func fieldName(n node) string {
+	switch n := n.(type) {
+	case equalNode:
+		return n.name
+	case matchNode:
+		return n.name
+	case inNode:
+		return n.name
+	}
+	return ""
+}
+
+// describe formats a comparison in query syntax
+func describe(n node) string {
+	switch n := n.(type) {
+	case equalNode:
+		op := "="
+		if n.negate {
+			op = "!="
+		}
+		return fmt.Sprintf("%s %s %q", n.name, op, n.value)
+	case matchNode:
+		op := "~"
+		if n.negate {
+			op = "!~"
+		}
+		return fmt.Sprintf("%s %s %q", n.name, op, strings.TrimPrefix(n.re.String(), "(?i)"))
+	case inNode:
+		op := "IN"
+		if n.negate {
+			op = "NOT IN"
+		}
+		quoted := make([]string, len(n.values))
+		for i, value := range n.values {
+			quoted[i] = fmt.Sprintf("%q", value)
+		}
+		return fmt.Sprintf("%s %s (%s)", n.name, op, strings.Join(quoted, ", "))
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+// internal/core/query/fields.go
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// fieldFunc extracts the value of a field from a job
+type fieldFunc func(job domain.Job) string
+
+// fields maps the field names usable in queries to their extractors
+var fields = map[string]fieldFunc{
+	"id":           func(job domain.Job) string { return job.ID },
+	"title":        func(job domain.Job) string { return job.Title },
+	"description":  func(job domain.Job) string { return job.Description },
+	"location":     func(job domain.Job) string { return job.CanonicalLocation() },
+	"raw_location": func(job domain.Job) string { return job.Location },
+	"department":   func(job domain.Job) string { return job.Department },
+	"category":     func(job domain.Job) string { return job.Category },
+	"url":          func(job domain.Job) string { return job.URL },
+	"language":     domain.Job.PostingLanguage,
+}
+
+// metadataPrefix selects a custom field of Job.Metadata, e.g. metadata.visa
+const metadataPrefix = "metadata."
+
+// lookupField returns the extractor for a field name
+func lookupField(name string) (fieldFunc, error) {
+	name = strings.ToLower(name)
+	if key, ok := strings.CutPrefix(name, metadataPrefix); ok && key != "" {
+		return metadataField(key), nil
+	}
+
+	field, ok := fields[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q", name)
+	}
+	return field, nil
+}
+
+// metadataField extracts a metadata value, matching its key case-insensitively
+func metadataField(key string) fieldFunc {
+	return func(job domain.Job) string {
+		if value, ok := job.Metadata[key]; ok {
+			return value
+		}
+		for k, value := range job.Metadata {
+			if strings.EqualFold(k, key) {
+				return value
+			}
+		}
+		return ""
+	}
+}
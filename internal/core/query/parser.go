@@ -0,0 +1,178 @@
+// internal/core/query/parser.go
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// parser is a recursive descent parser over query tokens
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// peek returns the current token without consuming it
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// next consumes and returns the current token
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// keyword consumes the current token if it is the given keyword
+func (p *parser) keyword(word string) bool {
+	tok := p.peek()
+	if tok.kind == tokenIdent && strings.EqualFold(tok.value, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// parseOr parses: and ("OR" and)*
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseAnd parses: unary ("AND" unary)*
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.keyword("AND") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseUnary parses: "NOT" unary | "(" or ")" | comparison
+func (p *parser) parseUnary() (node, error) {
+	if p.keyword("NOT") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+
+	if p.peek().kind == tokenLParen {
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokenRParen {
+			return nil, fmt.Errorf("expected ) at position %d", tok.pos)
+		}
+		return expr, nil
+	}
+
+	return p.parseComparison()
+}
+
+// parseComparison parses: field op value | field ["NOT"] "IN" "(" value ("," value)* ")"
+func (p *parser) parseComparison() (node, error) {
+	tok := p.next()
+	if tok.kind != tokenIdent {
+		return nil, fmt.Errorf("expected field name at position %d", tok.pos)
+	}
+
+	field, err := lookupField(tok.value)
+	if err != nil {
+		return nil, fmt.Errorf("%w at position %d", err, tok.pos)
+	}
+
+	negate := p.keyword("NOT")
+	if p.keyword("IN") {
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return inNode{name: strings.ToLower(tok.value), field: field, values: values, negate: negate}, nil
+	}
+	if negate {
+		return nil, fmt.Errorf("expected IN after NOT at position %d", p.peek().pos)
+	}
+
+	op := p.next()
+	if op.kind != tokenOperator {
+		return nil, fmt.Errorf("expected operator after %s at position %d", tok.value, op.pos)
+	}
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.value {
+	case "=", "!=":
+		return equalNode{name: strings.ToLower(tok.value), field: field, value: value, negate: op.value == "!="}, nil
+	default:
+		re, err := regexp.Compile("(?i)" + value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q at position %d: %w", value, op.pos, err)
+		}
+		return matchNode{name: strings.ToLower(tok.value), field: field, re: re, negate: op.value == "!~"}, nil
+	}
+}
+
+// parseList parses: "(" value ("," value)* ")"
+func (p *parser) parseList() ([]string, error) {
+	if tok := p.next(); tok.kind != tokenLParen {
+		return nil, fmt.Errorf("expected ( after IN at position %d", tok.pos)
+	}
+
+	var values []string
+	for {
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		tok := p.next()
+		if tok.kind == tokenRParen {
+			return values, nil
+		}
+		if tok.kind != tokenComma {
+			return nil, fmt.Errorf("expected , or ) at position %d", tok.pos)
+		}
+	}
+}
+
+// parseValue parses a quoted string or a bare word
+func (p *parser) parseValue() (string, error) {
+	tok := p.next()
+	if tok.kind != tokenString && tok.kind != tokenIdent {
+		return "", fmt.Errorf("expected value at position %d", tok.pos)
+	}
+	return tok.value, nil
+}
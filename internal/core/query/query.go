@@ -0,0 +1,131 @@
+// internal/core/query/query.go
+//
+// Package query implements a small boolean query language over job fields, e.g.
+//
+//	title ~ "go|golang" AND location IN ("Remote", "Kathmandu") AND NOT department = "Sales"
+//
+// Comparisons are case-insensitive. Supported operators are = and != (equality),
+// ~ and !~ (regular expression search), IN and NOT IN (membership), combined with
+// AND, OR, NOT and parentheses. Custom fields extracted per source are named
+// metadata.<key>.
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// Query is a parsed and validated query
+type Query struct {
+	source string
+	root   node
+}
+
+// node is an expression of the query syntax tree
+type node interface {
+	eval(job domain.Job) bool
+}
+
+// Parse parses and validates a query
+func Parse(src string) (*Query, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, fmt.Errorf("invalid query: unexpected %q at position %d", tok.value, tok.pos)
+	}
+
+	return &Query{
+		source: src,
+		root:   root,
+	}, nil
+}
+
+// Match reports whether the job satisfies the query
+func (q *Query) Match(job domain.Job) bool {
+	return q.root.eval(job)
+}
+
+// String returns the query source
+func (q *Query) String() string {
+	return q.source
+}
+
+// andNode is satisfied when both operands are
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) eval(job domain.Job) bool {
+	return n.left.eval(job) && n.right.eval(job)
+}
+
+// orNode is satisfied when either operand is
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) eval(job domain.Job) bool {
+	return n.left.eval(job) || n.right.eval(job)
+}
+
+// notNode negates its operand
+type notNode struct {
+	operand node
+}
+
+func (n notNode) eval(job domain.Job) bool {
+	return !n.operand.eval(job)
+}
+
+// equalNode compares a field with a value
+type equalNode struct {
+	name   string // field name, for diagnostics
+	field  fieldFunc
+	value  string
+	negate bool
+}
+
+func (n equalNode) eval(job domain.Job) bool {
+	return strings.EqualFold(strings.TrimSpace(n.field(job)), n.value) != n.negate
+}
+
+// matchNode searches a field with a regular expression
+type matchNode struct {
+	name   string // field name, for diagnostics
+	field  fieldFunc
+	re     *regexp.Regexp
+	negate bool
+}
+
+func (n matchNode) eval(job domain.Job) bool {
+	return n.re.MatchString(n.field(job)) != n.negate
+}
+
+// inNode checks whether a field equals one of several values
+type inNode struct {
+	name   string // field name, for diagnostics
+	field  fieldFunc
+	values []string
+	negate bool
+}
+
+func (n inNode) eval(job domain.Job) bool {
+	value := strings.TrimSpace(n.field(job))
+	for _, candidate := range n.values {
+		if strings.EqualFold(value, candidate) {
+			return !n.negate
+		}
+	}
+	return n.negate
+}
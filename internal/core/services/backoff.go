@@ -0,0 +1,104 @@
+// internal/core/services/backoff.go
+package services
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+)
+
+// failureBackoff spaces out the scrapes of URLs that keep failing: after n
+// consecutive failures a URL sits out the next 2^(n-1)-1 runs, up to limit,
+// so the first retry is immediate and a long outage is polled ever more
+// rarely. A successful scrape resets it.
+type failureBackoff struct {
+	limit int
+	mu    sync.Mutex
+	urls  map[string]*backoffState
+}
+
+// backoffState is the backoff of a failing URL
+type backoffState struct {
+	failures int // consecutive failed scrapes
+	skip     int // runs left to sit out
+}
+
+// newFailureBackoff creates a backoff skipping at most limit runs in a row
+func newFailureBackoff(limit int) *failureBackoff {
+	return &failureBackoff{
+		limit: limit,
+		urls:  make(map[string]*backoffState),
+	}
+}
+
+// skip reports whether url sits out this run, counting the run if it does,
+// with its consecutive failures and the runs it sits out after this one
+func (b *failureBackoff) skip(url string) (bool, backoffState) {
+	if b == nil {
+		return false, backoffState{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, ok := b.urls[url]
+	if !ok || state.skip == 0 {
+		return false, backoffState{}
+	}
+	state.skip--
+	return true, *state
+}
+
+// failed counts a failed scrape of url and returns its new backoff
+func (b *failureBackoff) failed(url string) backoffState {
+	if b == nil {
+		return backoffState{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, ok := b.urls[url]
+	if !ok {
+		state = &backoffState{}
+		b.urls[url] = state
+	}
+	state.failures++
+	state.skip = b.limit
+	if state.failures <= 31 {
+		state.skip = min(1<<(state.failures-1)-1, b.limit)
+	}
+	return *state
+}
+
+// list returns the backoff of every failing URL, ordered by URL
+func (b *failureBackoff) list() []domain.SourceBackoff {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	backoffs := make([]domain.SourceBackoff, 0, len(b.urls))
+	for url, state := range b.urls {
+		backoffs = append(backoffs, domain.SourceBackoff{
+			SourceURL:           url,
+			ConsecutiveFailures: state.failures,
+			SkippedRuns:         state.skip,
+		})
+	}
+	sort.Slice(backoffs, func(i, j int) bool { return backoffs[i].SourceURL < backoffs[j].SourceURL })
+	return backoffs
+}
+
+// succeeded resets the backoff of url, returning the consecutive failures it
+// recovered from
+func (b *failureBackoff) succeeded(url string) int {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	state, ok := b.urls[url]
+	if !ok {
+		return 0
+	}
+	delete(b.urls, url)
+	return state.failures
+}
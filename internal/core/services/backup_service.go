@@ -0,0 +1,487 @@
+// internal/core/services/backup_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// Backups are newline-delimited JSON: a header record followed by one record
+// per snapshot, latest collection, recorded diff, scrape failure, audit entry,
+// captured notification, sent notification and snooze. Snapshots and diffs of
+// a source come oldest first and snapshots before its latest collection, so
+// that importing them in order recreates the same history. Version 2 added
+// diffs, version 3 captures, notifications and snoozes.
+const (
+	backupFormat  = "careerscraper-backup"
+	backupVersion = 3
+)
+
+// backupRecord kinds
+const (
+	backupHeader       = "header"
+	backupSnapshot     = "snapshot"
+	backupLatest       = "latest"
+	backupDiff         = "diff"
+	backupFailure      = "failure"
+	backupAudit        = "audit"
+	backupCapture      = "capture"
+	backupNotification = "notification"
+	backupSnooze       = "snooze"
+)
+
+// backupRecord is a line of a backup
+type backupRecord struct {
+	Kind         string                       `json:"kind"`
+	Format       string                       `json:"format,omitempty"`
+	Version      int                          `json:"version,omitempty"`
+	Collection   *domain.JobCollection        `json:"collection,omitempty"`
+	Diff         *domain.DiffRecord           `json:"diff,omitempty"`
+	Failure      *domain.ScrapeFailure        `json:"failure,omitempty"`
+	Audit        *domain.AuditEntry           `json:"audit,omitempty"`
+	Capture      *domain.CapturedNotification `json:"capture,omitempty"`
+	Notification *domain.NotificationRecord   `json:"notification,omitempty"`
+	Snooze       *domain.Snooze               `json:"snooze,omitempty"`
+}
+
+// BackupSummary counts the records of a backup
+type BackupSummary struct {
+	Sources       int
+	Snapshots     int // including latest collections
+	Diffs         int
+	Failures      int
+	AuditEntries  int
+	Captures      int
+	Notifications int
+	Snoozes       int
+	Skipped       int // records the repository already had, on import
+}
+
+// BackupService dumps the data of a repository to a portable archive and
+// restores it into any repository implementation, e.g. to migrate between
+// storage backends
+type BackupService struct {
+	repository ports.JobRepository
+	snoozes    ports.SnoozeStore
+}
+
+// NewBackupService creates a new BackupService instance. snoozes may be nil
+// if snoozing is disabled.
+func NewBackupService(repository ports.JobRepository, snoozes ports.SnoozeStore) *BackupService {
+	return &BackupService{
+		repository: repository,
+		snoozes:    snoozes,
+	}
+}
+
+// Export writes every tracked source with its snapshots, diffs and last failure,
+// followed by the audit log, captured and sent notifications the repository
+// keeps and the active snoozes
+func (s *BackupService) Export(ctx context.Context, w io.Writer) (BackupSummary, error) {
+	var summary BackupSummary
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(backupRecord{Kind: backupHeader, Format: backupFormat, Version: backupVersion}); err != nil {
+		return summary, fmt.Errorf("failed to write backup header: %w", err)
+	}
+
+	sources, err := s.repository.ListTrackedSources(ctx)
+	if err != nil {
+		return summary, fmt.Errorf("failed to list sources: %w", err)
+	}
+	for _, source := range sources {
+		if err := s.exportSource(ctx, encoder, source, &summary); err != nil {
+			return summary, err
+		}
+		summary.Sources++
+	}
+
+	if auditLog, ok := ports.Optional[ports.AuditLog](s.repository); ok {
+		entries, err := auditLog.QueryAudit(ctx, domain.AuditFilter{})
+		if err != nil {
+			return summary, fmt.Errorf("failed to read audit log: %w", err)
+		}
+		for i := len(entries) - 1; i >= 0; i-- {
+			if err := encoder.Encode(backupRecord{Kind: backupAudit, Audit: &entries[i]}); err != nil {
+				return summary, fmt.Errorf("failed to write audit entry: %w", err)
+			}
+			summary.AuditEntries++
+		}
+	}
+
+	if captures, ok := ports.Optional[ports.CaptureStore](s.repository); ok {
+		list, err := captures.ListCaptures(ctx, 0)
+		if err != nil {
+			return summary, fmt.Errorf("failed to list captured notifications: %w", err)
+		}
+		for i := len(list) - 1; i >= 0; i-- {
+			if err := encoder.Encode(backupRecord{Kind: backupCapture, Capture: &list[i]}); err != nil {
+				return summary, fmt.Errorf("failed to write captured notification: %w", err)
+			}
+			summary.Captures++
+		}
+	}
+
+	if history, ok := ports.Optional[ports.NotificationHistory](s.repository); ok {
+		records, err := history.QueryNotifications(ctx, domain.NotificationFilter{})
+		if err != nil {
+			return summary, fmt.Errorf("failed to read notification history: %w", err)
+		}
+		for i := len(records) - 1; i >= 0; i-- {
+			if err := encoder.Encode(backupRecord{Kind: backupNotification, Notification: &records[i]}); err != nil {
+				return summary, fmt.Errorf("failed to write notification: %w", err)
+			}
+			summary.Notifications++
+		}
+	}
+
+	if s.snoozes != nil {
+		snoozes, err := s.snoozes.ListSnoozes(ctx, time.Now())
+		if err != nil {
+			return summary, fmt.Errorf("failed to list snoozes: %w", err)
+		}
+		for i := range snoozes {
+			if err := encoder.Encode(backupRecord{Kind: backupSnooze, Snooze: &snoozes[i]}); err != nil {
+				return summary, fmt.Errorf("failed to write snooze: %w", err)
+			}
+			summary.Snoozes++
+		}
+	}
+
+	return summary, nil
+}
+
+// exportSource writes the snapshots, latest collection, diffs and last failure of a source
+func (s *BackupService) exportSource(ctx context.Context, encoder *json.Encoder, source domain.TrackedSource, summary *BackupSummary) error {
+	latest, err := s.repository.GetLatestJobCollection(ctx, source.SourceURL)
+	hasLatest := err == nil
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		// The snapshots are still exported; the latest one becomes an ordinary snapshot
+		log.Printf("Exporting %s without its latest collection: %v", source.SourceURL, err)
+	}
+
+	snapshots, err := s.repository.ListSnapshots(ctx, source.SourceURL, 0)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots of %s: %w", source.SourceURL, err)
+	}
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		snapshot := snapshots[i]
+		if hasLatest && snapshot.ScrapedAt.Equal(latest.ScrapedAt) {
+			continue // restored by saving the latest collection
+		}
+		if err := encoder.Encode(backupRecord{Kind: backupSnapshot, Collection: &snapshot}); err != nil {
+			return fmt.Errorf("failed to write snapshot: %w", err)
+		}
+		summary.Snapshots++
+	}
+
+	if hasLatest {
+		if err := encoder.Encode(backupRecord{Kind: backupLatest, Collection: &latest}); err != nil {
+			return fmt.Errorf("failed to write job collection: %w", err)
+		}
+		summary.Snapshots++
+	}
+
+	diffs, err := s.repository.GetDiffHistory(ctx, source.SourceURL, time.Time{})
+	if err != nil {
+		return fmt.Errorf("failed to read diffs of %s: %w", source.SourceURL, err)
+	}
+	for i := range diffs {
+		if err := encoder.Encode(backupRecord{Kind: backupDiff, Diff: &diffs[i]}); err != nil {
+			return fmt.Errorf("failed to write diff: %w", err)
+		}
+		summary.Diffs++
+	}
+
+	if !source.LastErrorAt.IsZero() {
+		failure := domain.ScrapeFailure{SourceURL: source.SourceURL, FailedAt: source.LastErrorAt, Error: source.LastError}
+		if err := encoder.Encode(backupRecord{Kind: backupFailure, Failure: &failure}); err != nil {
+			return fmt.Errorf("failed to write scrape failure: %w", err)
+		}
+		summary.Failures++
+	}
+	return nil
+}
+
+// Import restores a backup written by Export. Audit entries, captured and
+// sent notifications and snoozes are skipped if there is nowhere to keep
+// them. Records the repository already has are skipped too, so importing a
+// backup again adds nothing.
+func (s *BackupService) Import(ctx context.Context, r io.Reader) (BackupSummary, error) {
+	return s.restore(ctx, r, nil)
+}
+
+// Seed restores the snapshots, latest collections and diffs of the given
+// source URLs from a backup, e.g. a published dataset of public job boards,
+// so that a new instance diffs against real baselines from its first scrape.
+// Sources the repository already tracks are left alone, as are the scrape
+// failures, audit entries, notifications and snoozes of the backup.
+func (s *BackupService) Seed(ctx context.Context, r io.Reader, urls []string) (BackupSummary, error) {
+	untracked, err := s.UntrackedSources(ctx, urls)
+	if err != nil {
+		return BackupSummary{}, err
+	}
+	seed := make(map[string]bool, len(untracked))
+	for _, url := range untracked {
+		seed[url] = true
+	}
+	if len(seed) == 0 {
+		return BackupSummary{}, nil
+	}
+	return s.restore(ctx, r, func(url string) bool { return seed[url] })
+}
+
+// UntrackedSources returns the URLs the repository has no collection or
+// failure of
+func (s *BackupService) UntrackedSources(ctx context.Context, urls []string) ([]string, error) {
+	sources, err := s.repository.ListTrackedSources(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sources: %w", err)
+	}
+	tracked := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		tracked[source.SourceURL] = true
+	}
+
+	var untracked []string
+	for _, url := range urls {
+		if !tracked[url] {
+			untracked = append(untracked, url)
+		}
+	}
+	return untracked, nil
+}
+
+// restore writes the records of a backup to the repository. If keep is set,
+// only the collections and diffs of the sources it keeps are restored.
+func (s *BackupService) restore(ctx context.Context, r io.Reader, keep func(url string) bool) (BackupSummary, error) {
+	var summary BackupSummary
+	decoder := json.NewDecoder(r)
+
+	var header backupRecord
+	if err := decoder.Decode(&header); err != nil {
+		return summary, fmt.Errorf("failed to read backup header: %w", err)
+	}
+	if header.Kind != backupHeader || header.Format != backupFormat {
+		return summary, fmt.Errorf("not a backup file")
+	}
+	if header.Version > backupVersion {
+		return summary, fmt.Errorf("backup version %d is newer than the supported version %d", header.Version, backupVersion)
+	}
+
+	auditLog, _ := ports.Optional[ports.AuditLog](s.repository)
+	captures, _ := ports.Optional[ports.CaptureStore](s.repository)
+	history, _ := ports.Optional[ports.NotificationHistory](s.repository)
+	existing := &existingRecords{loaded: make(map[string]bool), keys: make(map[string]bool)}
+	sources := make(map[string]bool)
+	for line := 2; ; line++ {
+		var record backupRecord
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return summary, fmt.Errorf("failed to read backup record %d: %w", line, err)
+		}
+		if keep != nil && !keep(record.sourceURL()) {
+			continue
+		}
+		exists, err := s.exists(ctx, existing, record)
+		if err != nil {
+			return summary, fmt.Errorf("failed to restore backup record %d: %w", line, err)
+		}
+		if exists {
+			summary.Skipped++
+			continue
+		}
+
+		switch {
+		case record.Kind == backupSnapshot && record.Collection != nil:
+			err = s.repository.SaveSnapshot(ctx, *record.Collection)
+			sources[record.Collection.SourceURL] = true
+			summary.Snapshots++
+		case record.Kind == backupLatest && record.Collection != nil:
+			err = s.repository.SaveJobCollection(ctx, *record.Collection)
+			sources[record.Collection.SourceURL] = true
+			summary.Snapshots++
+		case record.Kind == backupDiff && record.Diff != nil:
+			err = s.repository.SaveDiff(ctx, *record.Diff)
+			sources[record.Diff.Diff.SourceURL] = true
+			summary.Diffs++
+		case record.Kind == backupFailure && record.Failure != nil:
+			err = s.repository.SaveScrapeFailure(ctx, *record.Failure)
+			sources[record.Failure.SourceURL] = true
+			summary.Failures++
+		case record.Kind == backupAudit && record.Audit != nil:
+			if auditLog == nil {
+				continue
+			}
+			err = auditLog.AppendAudit(ctx, *record.Audit)
+			summary.AuditEntries++
+		case record.Kind == backupCapture && record.Capture != nil:
+			if captures == nil {
+				continue
+			}
+			err = captures.SaveCapture(ctx, *record.Capture)
+			summary.Captures++
+		case record.Kind == backupNotification && record.Notification != nil:
+			if history == nil {
+				continue
+			}
+			err = history.RecordNotification(ctx, *record.Notification)
+			summary.Notifications++
+		case record.Kind == backupSnooze && record.Snooze != nil:
+			if s.snoozes == nil {
+				continue
+			}
+			_, err = s.snoozes.SaveSnooze(ctx, *record.Snooze)
+			summary.Snoozes++
+		default:
+			return summary, fmt.Errorf("backup record %d: unknown kind %q", line, record.Kind)
+		}
+		if err != nil {
+			return summary, fmt.Errorf("failed to restore backup record %d: %w", line, err)
+		}
+	}
+
+	summary.Sources = len(sources)
+	return summary, nil
+}
+
+// sourceURL returns the source URL of a collection or diff record, "" for
+// other records
+func (r backupRecord) sourceURL() string {
+	switch {
+	case r.Collection != nil && (r.Kind == backupSnapshot || r.Kind == backupLatest):
+		return r.Collection.SourceURL
+	case r.Diff != nil && r.Kind == backupDiff:
+		return r.Diff.Diff.SourceURL
+	}
+	return ""
+}
+
+// existingRecords are the keys of the records the repository has, loaded as
+// the backup refers to them
+type existingRecords struct {
+	loaded map[string]bool // sources and kinds whose records were loaded
+	keys   map[string]bool
+}
+
+// exists reports whether the repository already has a backup record. The
+// records of the source, or of the kind for records not of a source, are
+// loaded on first use, so only the records there before the import count.
+func (s *BackupService) exists(ctx context.Context, existing *existingRecords, record backupRecord) (bool, error) {
+	key := record.key()
+	if key == "" {
+		return false, nil
+	}
+	scope := record.sourceURL()
+	if scope == "" {
+		scope = record.Kind
+	}
+	if !existing.loaded[scope] {
+		records, err := s.existing(ctx, record)
+		if err != nil {
+			return false, err
+		}
+		for _, r := range records {
+			existing.keys[r.key()] = true
+		}
+		existing.loaded[scope] = true
+	}
+	return existing.keys[key], nil
+}
+
+// existing returns the records the repository has of the source or kind of
+// a backup record
+func (s *BackupService) existing(ctx context.Context, record backupRecord) ([]backupRecord, error) {
+	var records []backupRecord
+	switch record.Kind {
+	case backupSnapshot, backupLatest, backupDiff:
+		url := record.sourceURL()
+		snapshots, err := s.repository.ListSnapshots(ctx, url, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snapshots of %s: %w", url, err)
+		}
+		for i := range snapshots {
+			records = append(records, backupRecord{Kind: backupSnapshot, Collection: &snapshots[i]})
+		}
+		diffs, err := s.repository.GetDiffHistory(ctx, url, time.Time{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read diffs of %s: %w", url, err)
+		}
+		for i := range diffs {
+			records = append(records, backupRecord{Kind: backupDiff, Diff: &diffs[i]})
+		}
+	case backupAudit:
+		if auditLog, ok := ports.Optional[ports.AuditLog](s.repository); ok {
+			entries, err := auditLog.QueryAudit(ctx, domain.AuditFilter{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to read audit log: %w", err)
+			}
+			for i := range entries {
+				records = append(records, backupRecord{Kind: backupAudit, Audit: &entries[i]})
+			}
+		}
+	case backupCapture:
+		if captures, ok := ports.Optional[ports.CaptureStore](s.repository); ok {
+			list, err := captures.ListCaptures(ctx, 0)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list captured notifications: %w", err)
+			}
+			for i := range list {
+				records = append(records, backupRecord{Kind: backupCapture, Capture: &list[i]})
+			}
+		}
+	case backupNotification:
+		if history, ok := ports.Optional[ports.NotificationHistory](s.repository); ok {
+			list, err := history.QueryNotifications(ctx, domain.NotificationFilter{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to read notification history: %w", err)
+			}
+			for i := range list {
+				records = append(records, backupRecord{Kind: backupNotification, Notification: &list[i]})
+			}
+		}
+	case backupSnooze:
+		if s.snoozes != nil {
+			snoozes, err := s.snoozes.ListSnoozes(ctx, time.Time{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list snoozes: %w", err)
+			}
+			for i := range snoozes {
+				records = append(records, backupRecord{Kind: backupSnooze, Snooze: &snoozes[i]})
+			}
+		}
+	}
+	return records, nil
+}
+
+// key identifies a record by what a repository keeps of it, since IDs are
+// assigned anew on import; "" for records that overwrite rather than add,
+// like scrape failures. A latest collection matches the snapshot of the same
+// scrape.
+func (r backupRecord) key() string {
+	switch {
+	case r.Collection != nil && (r.Kind == backupSnapshot || r.Kind == backupLatest):
+		return fmt.Sprintln(backupSnapshot, r.Collection.SourceURL, r.Collection.ScrapedAt.UnixNano())
+	case r.Diff != nil && r.Kind == backupDiff:
+		return fmt.Sprintln(backupDiff, r.Diff.Diff.SourceURL, r.Diff.RunID, r.Diff.ComputedAt.UnixNano())
+	case r.Audit != nil && r.Kind == backupAudit:
+		return fmt.Sprintln(backupAudit, r.Audit.At.UnixNano(), r.Audit.Actor, r.Audit.Action, r.Audit.Target)
+	case r.Capture != nil && r.Kind == backupCapture:
+		return fmt.Sprintln(backupCapture, r.Capture.CapturedAt.UnixNano(), r.Capture.Kind, r.Capture.Summary())
+	case r.Notification != nil && r.Kind == backupNotification:
+		return fmt.Sprintln(backupNotification, r.Notification.CreatedAt.UnixNano(), r.Notification.Kind, r.Notification.Summary())
+	case r.Snooze != nil && r.Kind == backupSnooze:
+		return fmt.Sprintln(backupSnooze, r.Snooze.Notifier, r.Snooze.SourceURL, r.Snooze.JobID, r.Snooze.Until.UnixNano(), r.Snooze.CreatedAt.UnixNano())
+	}
+	return ""
+}
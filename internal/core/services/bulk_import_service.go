@@ -0,0 +1,187 @@
+// internal/core/services/bulk_import_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/normalize"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// BulkImportPace spreads the requests of a bulk import over time. With an
+// interval of 30s, a board of 500 jobs takes a little over four hours.
+type BulkImportPace struct {
+	Interval   time.Duration // wait between two detail pages
+	BatchSize  int           // detail pages fetched before pausing, 0 never pauses
+	BatchPause time.Duration // wait after every batch, on top of the interval
+}
+
+// BulkImportService onboards a large board without hammering it: the listing
+// is scraped once as the baseline, and the detail page of every job on it is
+// then fetched at the configured pace and kept in the raw page archive.
+// Progress is saved after every page, so an interrupted import picks up where
+// it stopped when run again.
+type BulkImportService struct {
+	scraper     ports.Scraper
+	fetcher     ports.PageFetcher
+	repository  ports.JobRepository
+	archive     ports.RawContentStore
+	progress    ports.ImportProgressStore
+	pace        BulkImportPace
+	normalizers []normalize.Normalizer
+}
+
+// NewBulkImportService creates a new BulkImportService instance. The baseline
+// goes through the same normalizers as regular scrapes.
+func NewBulkImportService(
+	scraper ports.Scraper,
+	fetcher ports.PageFetcher,
+	repository ports.JobRepository,
+	archive ports.RawContentStore,
+	progress ports.ImportProgressStore,
+	pace BulkImportPace,
+	normalizers ...normalize.Normalizer,
+) *BulkImportService {
+	return &BulkImportService{
+		scraper:     scraper,
+		fetcher:     fetcher,
+		repository:  repository,
+		archive:     archive,
+		progress:    progress,
+		pace:        pace,
+		normalizers: normalizers,
+	}
+}
+
+// Import starts or resumes the import of url. Every job not archived yet is
+// attempted once, including those that failed before; run it again to retry
+// them. It returns the progress saved last, also when ctx is cancelled.
+func (s *BulkImportService) Import(ctx context.Context, url string) (domain.ImportProgress, error) {
+	progress, err := s.progress.LoadImportProgress(ctx, url)
+	if errors.Is(err, domain.ErrNotFound) {
+		progress, err = s.start(ctx, url)
+	}
+	if err != nil {
+		return progress, err
+	}
+
+	archived, failed, pending := progress.Counts()
+	log.Printf("Importing %s: %d of %d jobs archived, %d failed, %d pending",
+		url, archived, len(progress.Items), failed, pending)
+
+	fetched := 0
+	for i := range progress.Items {
+		if progress.Items[i].Archived() {
+			continue
+		}
+		if fetched > 0 {
+			wait := s.pace.Interval
+			if s.pace.BatchSize > 0 && fetched%s.pace.BatchSize == 0 {
+				archived, _, _ := progress.Counts()
+				log.Printf("Archived %d of %d jobs of %s, pausing for %s", archived, len(progress.Items), url, s.pace.BatchPause)
+				wait += s.pace.BatchPause
+			}
+			if err := sleep(ctx, wait); err != nil {
+				return progress, err
+			}
+		}
+
+		s.archiveItem(ctx, &progress.Items[i])
+		fetched++
+		progress.UpdatedAt = time.Now()
+		if err := s.progress.SaveImportProgress(ctx, progress); err != nil {
+			return progress, fmt.Errorf("failed to save import progress: %w", err)
+		}
+	}
+
+	archived, failed, _ = progress.Counts()
+	log.Printf("Import of %s finished this pass: %d of %d jobs archived, %d failed", url, archived, len(progress.Items), failed)
+	return progress, nil
+}
+
+// start saves a baseline for url unless it already has one and creates the
+// progress of importing its jobs
+func (s *BulkImportService) start(ctx context.Context, url string) (domain.ImportProgress, error) {
+	baseline, err := s.repository.GetLatestJobCollection(ctx, url)
+	if errors.Is(err, domain.ErrNotFound) {
+		baseline, err = s.saveBaseline(ctx, url)
+	}
+	if err != nil {
+		return domain.ImportProgress{}, fmt.Errorf("failed to load baseline of %s: %w", url, err)
+	}
+
+	progress := domain.NewImportProgress(baseline, time.Now())
+	if err := s.progress.SaveImportProgress(ctx, progress); err != nil {
+		return progress, fmt.Errorf("failed to save import progress: %w", err)
+	}
+	log.Printf("Started import of %s with %d jobs", url, len(progress.Items))
+	return progress, nil
+}
+
+// saveBaseline scrapes url once and saves the result the way the first
+// regular scrape would, so no notifications go out for the imported jobs
+func (s *BulkImportService) saveBaseline(ctx context.Context, url string) (domain.JobCollection, error) {
+	collection, err := s.scraper.Scrape(ctx, url)
+	if err != nil {
+		return domain.JobCollection{}, fmt.Errorf("failed to scrape URL %s: %w", url, err)
+	}
+	collection = normalize.Apply(collection, s.normalizers...)
+	if screenshots, ok := s.archive.(ports.ScreenshotStore); ok && len(collection.Screenshot) > 0 {
+		if err := screenshots.PutScreenshot(ctx, url, collection.ScrapedAt, collection.Screenshot); err != nil {
+			log.Printf("Failed to archive screenshot of %s: %v", url, err)
+		}
+	}
+	collection.Screenshot = nil
+	if collection.RawContent != "" {
+		if err := s.archive.PutRawContent(ctx, url, collection.ScrapedAt, collection.RawContent); err != nil {
+			log.Printf("Failed to archive raw page of %s, keeping it with the collection: %v", url, err)
+		} else {
+			collection.RawContent = ""
+		}
+	}
+
+	baseline := collection.TrackSeen(domain.JobCollection{}, nil).TrackActivity(domain.JobCollection{}, true)
+	if err := s.repository.SaveJobCollection(ctx, baseline); err != nil {
+		return domain.JobCollection{}, fmt.Errorf("failed to save job collection: %w", err)
+	}
+	log.Printf("Saved baseline of %s with %d jobs", url, len(baseline.Jobs))
+	return baseline, nil
+}
+
+// archiveItem fetches and archives the detail page of a job, recording the
+// outcome in the item
+func (s *BulkImportService) archiveItem(ctx context.Context, item *domain.ImportItem) {
+	item.Attempts++
+	fetchedAt := time.Now()
+	content, err := s.fetcher.FetchPage(ctx, item.URL)
+	if err == nil {
+		err = s.archive.PutRawContent(ctx, item.URL, fetchedAt, content)
+	}
+	if err != nil {
+		log.Printf("Failed to archive detail page of job %s: %v", item.JobID, err)
+		item.Error = err.Error()
+		return
+	}
+	item.ArchivedAt = fetchedAt
+	item.Error = ""
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -3,19 +3,208 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"log"
+	"slices"
+	"sync"
+	"time"
 
 	"fmt"
 	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/filter"
+	"github.com/fuzztobread/job-scheduler/internal/core/normalize"
 	"github.com/fuzztobread/job-scheduler/internal/core/ports"
 )
 
 // CareerScraperService is responsible for orchestrating the scraping process
 type CareerScraperService struct {
-	scraper    ports.Scraper
-	notifier   ports.Notifier
-	repository ports.JobRepository
-	urls       []string
+	scraper     ports.Scraper
+	notifier    ports.Notifier
+	repository  ports.JobRepository
+	urls        []string
+	normalizers []normalize.Normalizer
+	identity    normalize.JobIdentity // rekeys stored jobs identified differently
+	filter      *filter.Filter
+	watches     []*filter.Watch
+	digest      bool
+	similarity  float64
+	notifyErrs  bool
+	trace       ports.FilterTraceStore
+	traceLimit  int
+	precheck    ports.ChangeDetector
+	fingerprint map[string]string // precheck fingerprint of the last successful scrape per URL
+	rawArchive  ports.RawContentStore
+	enricher    ports.JobEnricher
+	enrichers   int // jobs enriched concurrently
+	enrichLimit int // jobs enriched per scrape, 0 for no limit
+	translator  ports.Translator
+	translateTo string // language titles are translated to
+	workers     int           // URLs scraped concurrently
+	perHost     int           // URLs of the same effective host scraped concurrently, 0 for no cap
+	hostSpacing time.Duration // minimum time between the scrapes of the same effective host, 0 for none
+	events      ports.JobEventPublisher
+	owners      map[string]domain.SourceOwner // owner by source URL
+	escalations map[string]ports.Notifier     // notifier of the owner by source URL
+	backoff     *failureBackoff               // skips runs of failing URLs, nil to retry them every run
+	runsDone    int                           // runs completed since the service was created
+	runsActive  int                           // runs in progress
+	mu          sync.Mutex
+}
+
+// maxLoggedRejections bounds how many filter rejections are logged per source and run
+const maxLoggedRejections = 5
+
+// scrapeRun holds the state of a single ScrapeAndNotify invocation
+type scrapeRun struct {
+	id     string              // identifies the run in recorded diffs
+	forced bool                // scrapes backed off URLs too
+	diffs  []domain.DiffResult // changes collected for the digest
+	mu     sync.Mutex
+}
+
+// newRunID returns an ID for a run from its start time, with a random suffix
+// telling apart runs of several instances started at the same second
+func newRunID(startedAt time.Time) string {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return startedAt.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(suffix)
+}
+
+// addDiff collects the changes of a source for the digest
+func (r *scrapeRun) addDiff(diff domain.DiffResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.diffs = append(r.diffs, diff)
+}
+
+// Option configures optional behaviour of the CareerScraperService
+type Option func(*CareerScraperService)
+
+// WithNormalizers rewrites scraped jobs with each normalizer, in order, before
+// they are filtered, diffed and saved
+func WithNormalizers(normalizers ...normalize.Normalizer) Option {
+	return func(s *CareerScraperService) {
+		s.normalizers = append(s.normalizers, normalizers...)
+	}
+}
+
+// WithJobIdentity matches stored jobs identified otherwise than by identity,
+// e.g. by a hash of their listing text as earlier versions did, to their
+// current listing, see normalize.Rekey. Jobs are identified by a
+// normalize.IdentityNormalizer among the normalizers.
+func WithJobIdentity(identity normalize.JobIdentity) Option {
+	return func(s *CareerScraperService) {
+		s.identity = identity
+	}
+}
+
+// WithFilter drops jobs rejected by f from diffs before notifying
+func WithFilter(f *filter.Filter) Option {
+	return func(s *CareerScraperService) {
+		s.filter = f
+	}
+}
+
+// WithFilterTrace records up to limit filter rejections per source and run in
+// store, so they can be queried later (see the why-missing command)
+func WithFilterTrace(store ports.FilterTraceStore, limit int) Option {
+	return func(s *CareerScraperService) {
+		s.trace = store
+		s.traceLimit = limit
+	}
+}
+
+// WithWatches raises alerts for new or updated jobs matching any of the watches
+func WithWatches(watches ...*filter.Watch) Option {
+	return func(s *CareerScraperService) {
+		s.watches = append(s.watches, watches...)
+	}
+}
+
+// WithDigest collects the changes of a whole run and sends them as one digest
+// when the notifier supports it. New jobs whose titles are at least similarity
+// alike are clustered together.
+func WithDigest(similarity float64) Option {
+	return func(s *CareerScraperService) {
+		s.digest = true
+		s.similarity = similarity
+	}
+}
+
+// WithErrorNotifications sends a critical notification when a URL fails to
+// process, if the notifier supports standalone messages
+func WithErrorNotifications(enabled bool) Option {
+	return func(s *CareerScraperService) {
+		s.notifyErrs = enabled
+	}
+}
+
+// WithPrecheck asks detector for a cheap fingerprint of each page first and
+// skips the scrape while it matches the fingerprint of the last successful one
+func WithPrecheck(detector ports.ChangeDetector) Option {
+	return func(s *CareerScraperService) {
+		s.precheck = detector
+	}
+}
+
+// WithRawArchive moves the raw page of every scrape into store, keeping saved
+// collections lean. Pages that fail to archive are saved with the collection.
+// Screenshots are archived too if store is a ports.ScreenshotStore.
+func WithRawArchive(store ports.RawContentStore) Option {
+	return func(s *CareerScraperService) {
+		s.rawArchive = store
+	}
+}
+
+// WithConcurrency scrapes up to workers URLs at once, but at most perHost
+// URLs sharing an effective host such as greenhouse.io. Hosts take turns so
+// that one ATS with many sources doesn't delay all others. perHost 0 means
+// no per-host cap.
+func WithConcurrency(workers, perHost int) Option {
+	return func(s *CareerScraperService) {
+		s.workers = workers
+		s.perHost = perHost
+	}
+}
+
+// WithHostRateLimit starts at most perMinute scrapes of URLs sharing an
+// effective host per minute, spacing them out evenly. Other hosts are
+// scraped meanwhile. perMinute 0 means no limit.
+func WithHostRateLimit(perMinute int) Option {
+	return func(s *CareerScraperService) {
+		if perMinute > 0 {
+			s.hostSpacing = time.Minute / time.Duration(perMinute)
+		}
+	}
+}
+
+// WithJobEvents publishes the job events of every diff to publisher, before
+// notifications are sent
+func WithJobEvents(publisher ports.JobEventPublisher) Option {
+	return func(s *CareerScraperService) {
+		s.events = publisher
+	}
+}
+
+// WithSourceOwners names the owner of a source in its failure alerts and sends
+// the alerts to the escalation notifier of the source, by source URL, instead
+// of the shared notifier
+func WithSourceOwners(owners map[string]domain.SourceOwner, escalations map[string]ports.Notifier) Option {
+	return func(s *CareerScraperService) {
+		s.owners = owners
+		s.escalations = escalations
+	}
+}
+
+// WithFailureBackoff skips runs of URLs that keep failing, doubling the runs
+// sat out with every consecutive failure up to limit, until a scrape succeeds.
+// Runs started with ScrapeAllNow don't skip them.
+func WithFailureBackoff(limit int) Option {
+	return func(s *CareerScraperService) {
+		s.backoff = newFailureBackoff(limit)
+	}
 }
 
 // NewCareerScraperService creates a new instance of CareerScraperService
@@ -24,63 +213,367 @@ func NewCareerScraperService(
 	notifier ports.Notifier,
 	repository ports.JobRepository,
 	urls []string,
+	opts ...Option,
 ) *CareerScraperService {
-	return &CareerScraperService{
-		scraper:    scraper,
-		notifier:   notifier,
-		repository: repository,
-		urls:       urls,
+	s := &CareerScraperService{
+		scraper:     scraper,
+		notifier:    notifier,
+		repository:  repository,
+		urls:        urls,
+		fingerprint: make(map[string]string),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
 // ScrapeAndNotify scrapes the specified URLs and sends notifications for changes
 func (s *CareerScraperService) ScrapeAndNotify(ctx context.Context) error {
+	return s.scrapeAndNotify(ctx, false)
+}
+
+// ScrapeAllNow scrapes the specified URLs like ScrapeAndNotify, including
+// those backed off after failing, for a scrape requested on demand. The runs
+// they sit out are left for the scheduled runs.
+func (s *CareerScraperService) ScrapeAllNow(ctx context.Context) error {
+	return s.scrapeAndNotify(ctx, true)
+}
+
+// Backoffs returns the URLs the scheduled runs back off from
+func (s *CareerScraperService) Backoffs() []domain.SourceBackoff {
+	return s.backoff.list()
+}
+
+// scrapeAndNotify runs a scrape of all URLs, forced to include backed off ones
+func (s *CareerScraperService) scrapeAndNotify(ctx context.Context, forced bool) error {
 	log.Printf("Starting scrape job for %d URLs", len(s.urls))
+	s.mu.Lock()
+	s.runsActive++
+	s.mu.Unlock()
+	defer s.finishRun()
 	
-	for _, url := range s.urls {
-		log.Printf("Processing URL: %s", url)
-		if err := s.processSingleURL(ctx, url); err != nil {
-			log.Printf("Error processing URL %s: %v", url, err)
-			// Continue with other URLs instead of failing entirely
-			continue
+	run := &scrapeRun{id: newRunID(time.Now()), forced: forced}
+	if s.workers > 1 || s.hostSpacing > 0 {
+		s.processConcurrently(ctx, run)
+	} else {
+		for _, url := range s.urls {
+			s.processURL(ctx, run, url)
 		}
 	}
 	
+	if len(run.diffs) > 0 {
+		s.sendDigest(ctx, run.diffs)
+	}
+	
 	log.Printf("Completed scrape job for all URLs")
 	return nil
 }
 
+// ProcessCollections runs collections gathered outside of the scraper through
+// the same pipeline as scraped pages: they are normalized, diffed against the
+// stored collection of their source URL, filtered and notified about. In
+// digest mode the changes of the whole batch are sent as one digest.
+func (s *CareerScraperService) ProcessCollections(ctx context.Context, collections []domain.JobCollection) []domain.BatchResult {
+	log.Printf("Processing batch of %d submitted collections", len(collections))
+	
+	run := &scrapeRun{id: newRunID(time.Now())}
+	results := make([]domain.BatchResult, len(collections))
+	for i, collection := range collections {
+		results[i].SourceURL = collection.SourceURL
+		diff, err := s.processCollection(ctx, run, collection.SourceURL, collection)
+		if err != nil {
+			log.Printf("Failed to process submitted collection for %s: %v", collection.SourceURL, err)
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].NewJobs = len(diff.NewJobs)
+		results[i].UpdatedJobs = len(diff.UpdatedJobs)
+		results[i].RemovedJobs = len(diff.RemovedJobs)
+	}
+	
+	if len(run.diffs) > 0 {
+		s.sendDigest(ctx, run.diffs)
+	}
+	return results
+}
+
+// finishRun counts a run as completed
+func (s *CareerScraperService) finishRun() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.runsActive--
+	s.runsDone++
+}
+
+// RunCounts returns the number of runs completed since the service was
+// created and the number of runs in progress
+func (s *CareerScraperService) RunCounts() (completed, active int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.runsDone, s.runsActive
+}
+
+// processConcurrently processes the URLs with a pool of workers, interleaving
+// hosts fairly within their concurrency caps and rate limits
+func (s *CareerScraperService) processConcurrently(ctx context.Context, run *scrapeRun) {
+	queue := newHostQueue(s.urls, s.perHost, s.hostSpacing)
+	var wg sync.WaitGroup
+	for i := 0; i < min(max(s.workers, 1), len(s.urls)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for url, ok := queue.take(ctx); ok; url, ok = queue.take(ctx) {
+				s.processURL(ctx, run, url)
+				queue.done(url)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// processURL processes a single URL, reporting failures instead of returning them
+// and backing off URLs that keep failing
+func (s *CareerScraperService) processURL(ctx context.Context, run *scrapeRun, url string) {
+	if !run.forced {
+		if skipped, state := s.backoff.skip(url); skipped {
+			log.Printf("Backing off %s after %d consecutive failures, skipping %d more runs", url, state.failures, state.skip)
+			return
+		}
+	}
+	
+	log.Printf("Processing URL: %s", url)
+	if err := s.processSingleURL(ctx, run, url); err != nil {
+		log.Printf("Error processing URL %s: %v", url, err)
+		s.recordFailure(ctx, url, err)
+		s.notifyError(ctx, url, err)
+		if state := s.backoff.failed(url); state.skip > 0 {
+			log.Printf("URL %s failed %d times in a row, skipping its next %d runs", url, state.failures, state.skip)
+		}
+		return
+	}
+	if failures := s.backoff.succeeded(url); failures > 0 {
+		log.Printf("URL %s recovered after %d consecutive failures", url, failures)
+	}
+}
+
+// OldestScrapeAt returns the oldest of the latest scrape times of all URLs, or
+// the zero time if any URL has never been scraped
+func (s *CareerScraperService) OldestScrapeAt(ctx context.Context) (time.Time, error) {
+	var oldest time.Time
+	for _, url := range s.urls {
+		collection, err := s.repository.GetLatestJobCollection(ctx, url)
+		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrSnapshotCorrupted) {
+			return time.Time{}, nil
+		}
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to load job collection for %s: %w", url, err)
+		}
+		
+		if oldest.IsZero() || collection.ScrapedAt.Before(oldest) {
+			oldest = collection.ScrapedAt
+		}
+	}
+	return oldest, nil
+}
+
+// sendDigest delivers the changes collected during a run as a single digest,
+// falling back to one notification per source if the notifier can't do digests
+func (s *CareerScraperService) sendDigest(ctx context.Context, diffs []domain.DiffResult) {
+	digestNotifier, ok := s.notifier.(ports.DigestNotifier)
+	if !ok {
+		for _, diff := range diffs {
+			if err := s.notifier.NotifyNewJobs(ctx, diff); err != nil {
+				log.Printf("Failed to send notification for %s: %v", diff.SourceURL, err)
+			}
+		}
+		return
+	}
+	
+	log.Printf("Sending digest for %d sources", len(diffs))
+	digest := domain.NewDigest(diffs, s.similarity)
+	if err := digestNotifier.NotifyDigest(ctx, digest); err != nil {
+		log.Printf("Failed to send digest: %v", err)
+	} else {
+		log.Printf("Successfully sent digest")
+	}
+}
+
+// recordFailure saves a failed scrape so the source's state shows its last error
+func (s *CareerScraperService) recordFailure(ctx context.Context, url string, err error) {
+	failure := domain.ScrapeFailure{SourceURL: url, FailedAt: time.Now(), Error: err.Error()}
+	if saveErr := s.repository.SaveScrapeFailure(ctx, failure); saveErr != nil {
+		log.Printf("Failed to record scrape failure for %s: %v", url, saveErr)
+	}
+}
+
+// notifyError sends a critical notification about a failed URL, or a
+// notification that the site is blocking the scraper if it answered with a bot check
+func (s *CareerScraperService) notifyError(ctx context.Context, url string, err error) {
+	if !s.notifyErrs {
+		return
+	}
+	notification := domain.CreateErrorNotification("", url, err.Error())
+	var blocked *domain.ScrapeBlocked
+	if errors.As(err, &blocked) {
+		notification = domain.CreateBlockedNotification("", blocked)
+	}
+	target := s.notifier
+	if owner, ok := s.owners[url]; ok {
+		notification = domain.AssignOwner(notification, owner)
+		if escalation, ok := s.escalations[url]; ok {
+			target = escalation
+		}
+	}
+	messageNotifier, ok := target.(ports.MessageNotifier)
+	if !ok {
+		return
+	}
+	
+	if notifyErr := messageNotifier.Notify(ctx, notification); notifyErr != nil {
+		log.Printf("Failed to send error notification for %s: %v", url, notifyErr)
+	}
+}
+
 // processSingleURL handles the scraping and notification for a single URL
-func (s *CareerScraperService) processSingleURL(ctx context.Context, url string) error {
+func (s *CareerScraperService) processSingleURL(ctx context.Context, run *scrapeRun, url string) error {
+	// Skip the browser if a cheap precheck shows the page did not change
+	fingerprint, unchanged := s.checkUnchanged(ctx, url)
+	if unchanged {
+		log.Printf("Precheck shows no change at %s, skipping scrape", url)
+		return nil
+	}
+	
 	log.Printf("Starting to scrape URL: %s", url)
+	if err := s.scrapeURL(ctx, run, url); err != nil {
+		return err
+	}
+	
+	if fingerprint != "" {
+		s.mu.Lock()
+		s.fingerprint[url] = fingerprint
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// checkUnchanged fingerprints a page with the precheck, if any, and reports
+// whether it matches the last successful scrape. Precheck failures never
+// prevent a scrape.
+func (s *CareerScraperService) checkUnchanged(ctx context.Context, url string) (string, bool) {
+	if s.precheck == nil {
+		return "", false
+	}
 	
+	fingerprint, err := s.precheck.Fingerprint(ctx, url)
+	if err != nil {
+		log.Printf("Precheck failed for %s, scraping anyway: %v", url, err)
+		return "", false
+	}
+	if fingerprint == "" {
+		return "", false
+	}
+	
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fingerprint, s.fingerprint[url] == fingerprint
+}
+
+// scrapeURL scrapes a single URL, diffs it against the stored collection,
+// notifies about changes and saves the result
+func (s *CareerScraperService) scrapeURL(ctx context.Context, run *scrapeRun, url string) error {
 	// Scrape the career page
 	currentJobs, err := s.scraper.Scrape(ctx, url)
+	if errors.Is(err, domain.ErrNotModified) {
+		log.Printf("Page at %s is not modified, skipping parsing and diffing", url)
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to scrape URL %s: %w", url, err)
 	}
 	
 	log.Printf("Found %d jobs at %s", len(currentJobs.Jobs), url)
+	_, err = s.processCollection(ctx, run, url, currentJobs)
+	return err
+}
+
+// processCollection diffs a collection found at url against the stored
+// collection, notifies about changes and saves the result. It returns the
+// diff, empty if the collection became the baseline.
+func (s *CareerScraperService) processCollection(ctx context.Context, run *scrapeRun, url string, currentJobs domain.JobCollection) (domain.DiffResult, error) {
+	currentJobs = normalize.Apply(currentJobs, s.normalizers...)
+	screenshot := currentJobs.Screenshot
+	currentJobs = s.archiveRawContent(ctx, currentJobs)
 	
 	// Get the previous job collection
 	previousJobs, err := s.repository.GetLatestJobCollection(ctx, url)
+	if errors.Is(err, domain.ErrSnapshotCorrupted) {
+		// Diffing against a damaged baseline would produce garbage notifications
+		log.Printf("Stored job collection for %s failed its integrity check, re-baselining: %v", url, err)
+		baseline := currentJobs.TrackSeen(domain.JobCollection{}, s.sightings(ctx, url)).TrackActivity(domain.JobCollection{}, true)
+		return domain.DiffResult{}, s.repository.SaveJobCollection(ctx, baseline)
+	}
+	if errors.Is(err, domain.ErrNotFound) {
+		log.Printf("No previous job data found for %s, saving baseline", url)
+		// If it's the first time, just save and don't notify
+		baseline := currentJobs.TrackSeen(domain.JobCollection{}, nil).TrackActivity(domain.JobCollection{}, true)
+		return domain.DiffResult{}, s.repository.SaveJobCollection(ctx, baseline)
+	}
 	if err != nil {
-		log.Printf("No previous job data found for %s: %v", url, err)
-		// If it's the first time or there was an error, just save and don't notify
-		return s.repository.SaveJobCollection(ctx, currentJobs)
+		return domain.DiffResult{}, fmt.Errorf("failed to load previous job collection: %w", err)
 	}
 	
 	log.Printf("Retrieved previous job collection with %d jobs", len(previousJobs.Jobs))
 	
+	// Normalize the stored jobs too, so they differ from the current ones in
+	// actual changes only, not in how they were normalized when saved
+	previousJobs = normalize.Apply(previousJobs, s.normalizers...)
+	if s.identity != nil {
+		previousJobs = normalize.Rekey(previousJobs, currentJobs, s.identity)
+	}
+	
+	// Carry first sightings forward; jobs that look new may be re-posted
+	var history []domain.JobCollection
+	if hasUnlistedJobs(previousJobs, currentJobs) {
+		history = s.sightings(ctx, url)
+	}
+	currentJobs = currentJobs.TrackSeen(previousJobs, history)
+	
+	// Complete new jobs from their detail pages before they are notified
+	currentJobs = s.enrichJobs(ctx, previousJobs, currentJobs)
+	
+	// Translate the titles of jobs posted in other languages
+	currentJobs = s.translateJobs(ctx, previousJobs, currentJobs)
+	
 	// Compare and find differences
 	diff := s.compareScrapeResults(previousJobs, currentJobs)
+	diff.Screenshot = screenshot
+	currentJobs = currentJobs.TrackActivity(previousJobs, diff.HasChanges())
+	
+	// Drop jobs the user is not interested in
+	diff = s.applyFilter(ctx, diff)
+	
+	// Highlight jobs matching watch rules
+	diff = s.applyWatches(diff)
+	diff.Severity = diff.ComputeSeverity()
 	
 	// Log the diff results
-	log.Printf("Diff results for %s: %d new, %d updated, %d removed", 
-		url, len(diff.NewJobs), len(diff.UpdatedJobs), len(diff.RemovedJobs))
+	log.Printf("Diff results for %s: %d new (%d re-posted), %d updated, %d removed", 
+		url, len(diff.NewJobs), len(diff.Reposted()), len(diff.UpdatedJobs), len(diff.RemovedJobs))
+	
+	// Keep what was found for auditing, whether or not the notification goes out
+	if diff.HasChanges() {
+		s.recordDiff(ctx, run, diff)
+		if s.events != nil {
+			s.events.PublishJobEvents(ctx, diff.JobEvents())
+		}
+	}
 	
 	// If there are changes, send notifications
-	if len(diff.NewJobs) > 0 || len(diff.RemovedJobs) > 0 || len(diff.UpdatedJobs) > 0 {
+	if diff.HasChanges() && s.digest {
+		log.Printf("Adding changes at %s to the digest", url)
+		run.addDiff(diff)
+	} else if diff.HasChanges() {
 		log.Printf("Sending notification for changes at %s", url)
 		if err := s.notifier.NotifyNewJobs(ctx, diff); err != nil {
 			log.Printf("Failed to send notification: %v", err)
@@ -95,11 +588,77 @@ func (s *CareerScraperService) processSingleURL(ctx context.Context, url string)
 	// Save the current results
 	log.Printf("Saving current job collection for %s", url)
 	if err := s.repository.SaveJobCollection(ctx, currentJobs); err != nil {
-		return fmt.Errorf("failed to save job collection: %w", err)
+		return diff, fmt.Errorf("failed to save job collection: %w", err)
 	}
 	
 	log.Printf("Successfully processed URL: %s", url)
-	return nil
+	return diff, nil
+}
+
+// recordDiff saves a diff computed by the run. Errors are logged; the
+// notification is sent regardless.
+func (s *CareerScraperService) recordDiff(ctx context.Context, run *scrapeRun, diff domain.DiffResult) {
+	record := domain.DiffRecord{RunID: run.id, ComputedAt: time.Now(), Diff: diff}
+	if err := s.repository.SaveDiff(ctx, record); err != nil {
+		log.Printf("Failed to record diff for %s: %v", diff.SourceURL, err)
+	}
+}
+
+// sightings returns the retained snapshots of a URL to look up earlier
+// listings of jobs in that the departed jobs of the stored collection don't
+// cover, e.g. from before they were tracked. Errors are logged; jobs then
+// count as first seen now.
+func (s *CareerScraperService) sightings(ctx context.Context, url string) []domain.JobCollection {
+	snapshots, err := s.repository.ListSnapshots(ctx, url, 0)
+	if err != nil {
+		log.Printf("Failed to load the history of %s to detect re-posted jobs: %v", url, err)
+		return nil
+	}
+	return snapshots
+}
+
+// hasUnlistedJobs reports whether current lists jobs that previous doesn't
+func hasUnlistedJobs(previous, current domain.JobCollection) bool {
+	listed := make(map[string]bool, len(previous.Jobs))
+	for _, job := range previous.Jobs {
+		listed[job.ID] = true
+	}
+	for _, job := range current.Jobs {
+		if !listed[job.ID] {
+			return true
+		}
+	}
+	return false
+}
+
+// archiveRawContent moves the raw page of a collection to the raw archive, if
+// there is one
+func (s *CareerScraperService) archiveRawContent(ctx context.Context, collection domain.JobCollection) domain.JobCollection {
+	collection = s.archiveScreenshot(ctx, collection)
+	if s.rawArchive == nil || collection.RawContent == "" {
+		return collection
+	}
+	
+	err := s.rawArchive.PutRawContent(ctx, collection.SourceURL, collection.ScrapedAt, collection.RawContent)
+	if err != nil {
+		log.Printf("Failed to archive raw page of %s, keeping it with the collection: %v", collection.SourceURL, err)
+		return collection
+	}
+	collection.RawContent = ""
+	return collection
+}
+
+// archiveScreenshot moves the screenshot of a collection to the raw archive,
+// if it keeps screenshots. Screenshots are never saved with the collection.
+func (s *CareerScraperService) archiveScreenshot(ctx context.Context, collection domain.JobCollection) domain.JobCollection {
+	screenshots, ok := s.rawArchive.(ports.ScreenshotStore)
+	if ok && len(collection.Screenshot) > 0 {
+		if err := screenshots.PutScreenshot(ctx, collection.SourceURL, collection.ScrapedAt, collection.Screenshot); err != nil {
+			log.Printf("Failed to archive screenshot of %s: %v", collection.SourceURL, err)
+		}
+	}
+	collection.Screenshot = nil
+	return collection
 }
 
 // compareScrapeResults compares two job collections and returns the differences
@@ -119,6 +678,12 @@ func (s *CareerScraperService) compareScrapeResults(
 		prevJobMap[job.ID] = job
 	}
 	
+	// The compliance purge strips every description from a stored collection;
+	// descriptions aren't compared then, or all its jobs would look updated
+	compareDescriptions := slices.ContainsFunc(previous.Jobs, func(job domain.Job) bool {
+		return job.Description != ""
+	})
+	
 	for _, job := range current.Jobs {
 		currJobMap[job.ID] = job
 		
@@ -127,11 +692,15 @@ func (s *CareerScraperService) compareScrapeResults(
 			// New job
 			result.NewJobs = append(result.NewJobs, job)
 		} else if job.Title != prevJob.Title || 
-				 job.Description != prevJob.Description || 
+				 (compareDescriptions && job.Description != prevJob.Description) || 
 				 job.Location != prevJob.Location || 
 				 job.Department != prevJob.Department {
 			// Updated job
 			result.UpdatedJobs = append(result.UpdatedJobs, job)
+			if result.PreviousJobs == nil {
+				result.PreviousJobs = make(map[string]domain.Job)
+			}
+			result.PreviousJobs[job.ID] = prevJob
 		}
 	}
 	
@@ -143,4 +712,96 @@ func (s *CareerScraperService) compareScrapeResults(
 	}
 	
 	return result
-}
\ No newline at end of file
+}
+
+// applyFilter removes jobs rejected by the configured filter from the diff
+func (s *CareerScraperService) applyFilter(ctx context.Context, diff domain.DiffResult) domain.DiffResult {
+	if s.filter.Empty() {
+		return diff
+	}
+	
+	var rejected, r []filter.Rejection
+	diff.NewJobs, r = s.filter.Apply(diff.NewJobs)
+	rejected = append(rejected, r...)
+	diff.UpdatedJobs, r = s.filter.Apply(diff.UpdatedJobs)
+	rejected = append(rejected, r...)
+	diff.RemovedJobs, r = s.filter.Apply(diff.RemovedJobs)
+	rejected = append(rejected, r...)
+	
+	if len(rejected) > 0 {
+		log.Printf("Filtered out %d jobs for %s", len(rejected), diff.SourceURL)
+		s.traceRejections(ctx, diff.SourceURL, rejected)
+	}
+	
+	return diff
+}
+
+// traceRejections logs and records filter rejections, bounded per source and run
+func (s *CareerScraperService) traceRejections(ctx context.Context, url string, rejected []filter.Rejection) {
+	for i, rejection := range rejected {
+		if i == maxLoggedRejections {
+			log.Printf("... and %d more filtered jobs for %s", len(rejected)-i, url)
+			break
+		}
+		log.Printf("Filtered out job %q by %s rule: %s", rejection.Job.Title, rejection.Rule, rejection.Reason)
+	}
+	
+	if s.trace == nil {
+		return
+	}
+	
+	if s.traceLimit > 0 && len(rejected) > s.traceLimit {
+		log.Printf("Filter trace limit reached for %s, %d rejections not recorded", url, len(rejected)-s.traceLimit)
+		rejected = rejected[:s.traceLimit]
+	}
+	
+	now := time.Now()
+	records := make([]domain.FilterRejection, 0, len(rejected))
+	for _, rejection := range rejected {
+		records = append(records, domain.FilterRejection{
+			RunAt:     now,
+			SourceURL: url,
+			JobID:     rejection.Job.ID,
+			Title:     rejection.Job.Title,
+			Rule:      rejection.Rule,
+			Reason:    rejection.Reason,
+		})
+	}
+	
+	if err := s.trace.RecordRejections(ctx, records); err != nil {
+		log.Printf("Failed to record filter trace for %s: %v", url, err)
+	}
+}
+
+// applyWatches records an alert for every new or updated job matching a
+// watch. Matches of watches with the same owner are collapsed into one alert.
+func (s *CareerScraperService) applyWatches(diff domain.DiffResult) domain.DiffResult {
+	for _, jobs := range [][]domain.Job{diff.NewJobs, diff.UpdatedJobs} {
+		for _, job := range jobs {
+			owned := make(map[string]int) // owner -> index of their alert for this job
+			for _, watch := range s.watches {
+				if !watch.Matches(job) {
+					continue
+				}
+				if i, exists := owned[watch.Owner()]; exists && watch.Owner() != "" {
+					diff.Alerts[i].Watches = append(diff.Alerts[i].Watches, watch.Name())
+					continue
+				}
+				
+				owned[watch.Owner()] = len(diff.Alerts)
+				diff.Alerts = append(diff.Alerts, domain.WatchAlert{
+					Watch:   watch.Name(),
+					Watches: []string{watch.Name()},
+					Owner:   watch.Owner(),
+					Job:     job,
+				})
+			}
+		}
+	}
+	
+	if len(diff.Alerts) > 0 {
+		log.Printf("%d watch alerts for %s", len(diff.Alerts), diff.SourceURL)
+	}
+	
+	return diff
+}
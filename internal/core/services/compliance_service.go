@@ -0,0 +1,100 @@
+// internal/core/services/compliance_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// complianceActor is recorded in the audit log for automatic purges
+const complianceActor = "compliance"
+
+// ComplianceService enforces data retention policies: it strips personal data
+// from snapshots older than maxAge and forgets sources on request
+type ComplianceService struct {
+	maxAge   time.Duration
+	audit    ports.AuditLog
+	purgers  []ports.PersonalDataPurger
+	deleters []ports.SourceDeleter
+}
+
+// NewComplianceService creates a new ComplianceService instance. audit may be
+// nil; stores not supporting purges or deletion can be left out of the lists.
+func NewComplianceService(
+	maxAge time.Duration,
+	audit ports.AuditLog,
+	purgers []ports.PersonalDataPurger,
+	deleters []ports.SourceDeleter,
+) *ComplianceService {
+	return &ComplianceService{
+		maxAge:   maxAge,
+		audit:    audit,
+		purgers:  purgers,
+		deleters: deleters,
+	}
+}
+
+// Purge strips personal data from all snapshots older than the maximum age
+func (s *ComplianceService) Purge(ctx context.Context) error {
+	cutoff := time.Now().Add(-s.maxAge)
+
+	var errs []error
+	purged := 0
+	for _, purger := range s.purgers {
+		n, err := purger.PurgePersonalData(ctx, cutoff)
+		purged += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if purged > 0 {
+		log.Printf("Compliance purge removed personal data from %d snapshots, archived pages and hosted summaries from before %s", purged, cutoff.Format(time.RFC3339))
+		s.record(ctx, domain.NewAuditEntry(complianceActor, "compliance.purge", "snapshots",
+			nil, map[string]interface{}{"purged": purged, "cutoff": cutoff}))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to purge personal data: %w", err)
+	}
+	return nil
+}
+
+// DeleteSource removes everything stored about a source URL on behalf of actor
+// and returns the number of records deleted
+func (s *ComplianceService) DeleteSource(ctx context.Context, actor, url string) (int, error) {
+	var errs []error
+	deleted := 0
+	for _, deleter := range s.deleters {
+		n, err := deleter.DeleteSource(ctx, url)
+		deleted += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	log.Printf("Deleted %d records about %s", deleted, url)
+	s.record(ctx, domain.NewAuditEntry(actor, "source.delete", url,
+		map[string]int{"records": deleted}, nil))
+
+	if err := errors.Join(errs...); err != nil {
+		return deleted, fmt.Errorf("failed to delete source %s: %w", url, err)
+	}
+	return deleted, nil
+}
+
+// record appends an entry to the audit log, if there is one
+func (s *ComplianceService) record(ctx context.Context, entry domain.AuditEntry) {
+	if s.audit == nil {
+		return
+	}
+	if err := s.audit.AppendAudit(ctx, entry); err != nil {
+		log.Printf("Failed to record audit entry for %s: %v", entry.Action, err)
+	}
+}
@@ -0,0 +1,116 @@
+// internal/core/services/enrichment.go
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// WithEnrichment completes the jobs new to a source from their detail pages
+// with enricher before they are diffed and notified, up to workers at once
+// and at most maxJobs per scrape, 0 for all. Jobs listed before keep what
+// was enriched when they were new. Baselines are not enriched.
+func WithEnrichment(enricher ports.JobEnricher, workers, maxJobs int) Option {
+	return func(s *CareerScraperService) {
+		s.enricher = enricher
+		s.enrichers = workers
+		s.enrichLimit = maxJobs
+	}
+}
+
+// enrichJobs enriches the jobs of current not listed in previous and carries
+// the enriched fields of the others over from previous
+func (s *CareerScraperService) enrichJobs(ctx context.Context, previous, current domain.JobCollection) domain.JobCollection {
+	if s.enricher == nil {
+		return current
+	}
+	stored := make(map[string]domain.Job, len(previous.Jobs))
+	for _, job := range previous.Jobs {
+		stored[job.ID] = job
+	}
+
+	jobs := make([]domain.Job, len(current.Jobs))
+	var fresh []int
+	for i, job := range current.Jobs {
+		if before, ok := stored[job.ID]; ok {
+			jobs[i] = carryEnriched(before, job)
+			continue
+		}
+		jobs[i] = job
+		fresh = append(fresh, i)
+	}
+	if s.enrichLimit > 0 && len(fresh) > s.enrichLimit {
+		log.Printf("Enriching %d of the %d new jobs at %s", s.enrichLimit, len(fresh), current.SourceURL)
+		fresh = fresh[:s.enrichLimit]
+	}
+
+	workers := s.enrichers
+	if workers <= 0 {
+		workers = 1
+	}
+	slots := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for _, i := range fresh {
+		if ctx.Err() != nil {
+			break
+		}
+		slots <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			enriched, err := s.enricher.EnrichJob(ctx, jobs[i])
+			if err != nil {
+				log.Printf("Failed to enrich job %s at %s from its detail page: %v", jobs[i].ID, current.SourceURL, err)
+				return
+			}
+			for _, normalizer := range s.normalizers {
+				enriched = normalizer.Normalize(enriched)
+			}
+			jobs[i] = enriched
+		}(i)
+	}
+	wg.Wait()
+
+	current.Jobs = jobs
+	return current
+}
+
+// carryEnriched fills the fields of a listed job the listing leaves empty
+// from its stored version, so enriched fields don't show up as changes
+func carryEnriched(stored, job domain.Job) domain.Job {
+	if job.Description == "" {
+		job.Description = stored.Description
+	}
+	if job.PostedDate.IsZero() {
+		job.PostedDate = stored.PostedDate
+	}
+	if job.Deadline.IsZero() {
+		job.Deadline = stored.Deadline
+	}
+	if job.Salary == nil {
+		job.Salary = stored.Salary
+	}
+	if job.Location == "" {
+		job.Location = stored.Location
+		job.NormalizedLocation = stored.NormalizedLocation
+	}
+	if job.ApplyURL == "" {
+		job.ApplyURL = stored.ApplyURL
+	}
+	if len(stored.Metadata) > 0 {
+		metadata := make(map[string]string, len(stored.Metadata)+len(job.Metadata))
+		for key, value := range stored.Metadata {
+			metadata[key] = value
+		}
+		for key, value := range job.Metadata {
+			metadata[key] = value
+		}
+		job.Metadata = metadata
+	}
+	return job
+}
@@ -0,0 +1,81 @@
+// internal/core/services/exit_report_service.go
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// ExitReportService completes the report of a session on shutdown with the
+// state of its sources, logs it and optionally sends it
+type ExitReportService struct {
+	repository ports.JobRepository
+	notifier   ports.Notifier
+	urls       []string
+}
+
+// NewExitReportService creates a new ExitReportService instance. Only the
+// given URLs are checked for failures. notifier may be nil to only log.
+func NewExitReportService(repository ports.JobRepository, notifier ports.Notifier, urls []string) *ExitReportService {
+	return &ExitReportService{
+		repository: repository,
+		notifier:   notifier,
+		urls:       urls,
+	}
+}
+
+// Report adds the sources in failure state to the report, logs it as JSON
+// and sends it if a notifier is configured. The report is logged even if the
+// sources can't be listed.
+func (s *ExitReportService) Report(ctx context.Context, report domain.ExitReport) error {
+	failing, listErr := s.failingSources(ctx)
+	report.FailingSources = failing
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal exit report: %w", err)
+	}
+	log.Printf("Exit report: %s", data)
+	if report.SafeToStop() {
+		log.Printf("Safe to stop: no interrupted runs or pending writes")
+	} else {
+		log.Printf("Not safe to stop: %d interrupted runs, %d pending repository writes", report.RunsInProgress, report.PendingWrites)
+	}
+	if listErr != nil {
+		log.Printf("Exit report is missing the failing sources: %v", listErr)
+	}
+
+	messageNotifier, ok := s.notifier.(ports.MessageNotifier)
+	if !ok {
+		return nil
+	}
+	if err := messageNotifier.Notify(ctx, domain.CreateExitReportNotification(report)); err != nil {
+		return fmt.Errorf("failed to send exit report: %w", err)
+	}
+	return nil
+}
+
+// failingSources returns the configured sources whose last scrape failed
+func (s *ExitReportService) failingSources(ctx context.Context) ([]domain.TrackedSource, error) {
+	sources, err := s.repository.ListTrackedSources(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tracked sources: %w", err)
+	}
+
+	configured := make(map[string]bool, len(s.urls))
+	for _, url := range s.urls {
+		configured[url] = true
+	}
+	failing := []domain.TrackedSource{}
+	for _, source := range sources {
+		if configured[source.SourceURL] && source.Failing() {
+			failing = append(failing, source)
+		}
+	}
+	return failing, nil
+}
@@ -0,0 +1,125 @@
+// internal/core/services/host_queue.go
+package services
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// hostQueue hands out URLs to scrape workers, interleaving hosts round-robin
+// and never running more than perHost URLs of the same host at once, nor
+// starting them less than spacing apart, so many sources on one ATS don't
+// fire at its API in a burst
+type hostQueue struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	hosts   []string             // round-robin order, by first appearance
+	pending map[string][]string  // host -> URLs not handed out yet
+	active  map[string]int       // host -> URLs being scraped
+	started map[string]time.Time // host -> when its last URL was handed out
+	left    int                  // URLs not handed out yet
+	perHost int                  // 0 means no cap
+	spacing time.Duration        // 0 means no spacing
+	next    int                  // index in hosts to look at first
+}
+
+// newHostQueue groups urls by effective host
+func newHostQueue(urls []string, perHost int, spacing time.Duration) *hostQueue {
+	q := &hostQueue{
+		pending: make(map[string][]string),
+		active:  make(map[string]int),
+		started: make(map[string]time.Time),
+		left:    len(urls),
+		perHost: perHost,
+		spacing: spacing,
+	}
+	q.cond = sync.NewCond(&q.mu)
+	for _, u := range urls {
+		host := effectiveHost(u)
+		if _, seen := q.pending[host]; !seen {
+			q.hosts = append(q.hosts, host)
+		}
+		q.pending[host] = append(q.pending[host], u)
+	}
+	return q
+}
+
+// take returns the next URL whose host is below its cap and spacing, waiting
+// for a slot if necessary, or false once every URL has been handed out or
+// ctx is done
+func (q *hostQueue) take(ctx context.Context) (string, bool) {
+	stop := context.AfterFunc(ctx, q.wake)
+	defer stop()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.left > 0 && ctx.Err() == nil {
+		now := time.Now()
+		var soonest time.Duration // until a host held back by its spacing is due
+		for i := range q.hosts {
+			index := (q.next + i) % len(q.hosts)
+			host := q.hosts[index]
+			if len(q.pending[host]) == 0 || (q.perHost > 0 && q.active[host] >= q.perHost) {
+				continue
+			}
+			if wait := q.started[host].Add(q.spacing).Sub(now); q.spacing > 0 && wait > 0 {
+				if soonest == 0 || wait < soonest {
+					soonest = wait
+				}
+				continue
+			}
+
+			u := q.pending[host][0]
+			q.pending[host] = q.pending[host][1:]
+			q.active[host]++
+			q.started[host] = now
+			q.left--
+			q.next = index + 1
+			return u, true
+		}
+		if soonest > 0 {
+			timer := time.AfterFunc(soonest, q.wake)
+			q.cond.Wait()
+			timer.Stop()
+		} else {
+			q.cond.Wait()
+		}
+	}
+	return "", false
+}
+
+// wake makes the waiting takes look at the hosts again
+func (q *hostQueue) wake() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.cond.Broadcast()
+}
+
+// done releases the host slot of a URL returned by take
+func (q *hostQueue) done(u string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.active[effectiveHost(u)]--
+	q.cond.Broadcast()
+}
+
+// effectiveHost returns the registrable domain of a URL, so that e.g.
+// boards.greenhouse.io and job-boards.greenhouse.io count as one host
+func effectiveHost(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return raw
+	}
+	host := strings.ToLower(u.Hostname())
+	if domain, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return domain
+	}
+	return host
+}
@@ -0,0 +1,133 @@
+// internal/core/services/leader_election.go
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// LeaderElection elects one of the instances sharing a lock as the leader,
+// which runs the scheduled jobs while the others stand by. The leader holds
+// the lock for ttl and renews it every third of ttl; when it stops renewing,
+// e.g. because it crashed, the lock expires and a standby takes over.
+type LeaderElection struct {
+	locker ports.Locker
+	key    string
+	owner  string
+	ttl    time.Duration
+	mu     sync.Mutex
+	leader bool
+	term   chan struct{} // closed when this instance stops leading
+}
+
+// NewLeaderElection creates an election of the instances locking key, owner
+// identifying this instance among them
+func NewLeaderElection(locker ports.Locker, key, owner string, ttl time.Duration) *LeaderElection {
+	return &LeaderElection{
+		locker: locker,
+		key:    key,
+		owner:  owner,
+		ttl:    ttl,
+	}
+}
+
+// IsLeader reports whether this instance leads
+func (e *LeaderElection) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leader
+}
+
+// Lead returns a context derived from ctx for work only the leader may do,
+// which is canceled once this instance stops leading, or false if it doesn't
+// lead. cancel must be called once the work is done.
+func (e *LeaderElection) Lead(ctx context.Context) (context.Context, context.CancelFunc, bool) {
+	e.mu.Lock()
+	leader, term := e.leader, e.term
+	e.mu.Unlock()
+	if !leader {
+		return ctx, func() {}, false
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-term:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel, true
+}
+
+// setLeader records whether this instance leads, starting or ending its term,
+// and returns whether it led before
+func (e *LeaderElection) setLeader(leader bool) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	was := e.leader
+	switch {
+	case leader && !was:
+		e.term = make(chan struct{})
+	case !leader && was:
+		close(e.term)
+	}
+	e.leader = leader
+	return was
+}
+
+// Campaign tries once to acquire or renew the leadership, logging a change
+// of leadership. An instance that can't reach the lock steps down, since it
+// can't tell whether its lease still holds.
+func (e *LeaderElection) Campaign(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, e.ttl/3)
+	defer cancel()
+	leader, err := e.locker.TryLock(ctx, e.key, e.owner, e.ttl)
+	if err != nil {
+		log.Printf("Failed to renew leadership of %s: %v", e.key, err)
+		leader = false
+	}
+	if was := e.setLeader(leader); was != leader {
+		if leader {
+			log.Printf("Elected leader of %s as %s, running scheduled jobs", e.key, e.owner)
+		} else {
+			log.Printf("No longer leader of %s, standing by", e.key)
+		}
+	}
+	return leader
+}
+
+// Run campaigns for the leadership until ctx is done, then hands it over by
+// releasing the lock, so a standby takes over without waiting for it to
+// expire
+func (e *LeaderElection) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			e.resign()
+			return
+		case <-ticker.C:
+			e.Campaign(ctx)
+		}
+	}
+}
+
+// resign releases the leadership if this instance holds it
+func (e *LeaderElection) resign() {
+	if !e.setLeader(false) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), e.ttl/3)
+	defer cancel()
+	if err := e.locker.Unlock(ctx, e.key, e.owner); err != nil {
+		log.Printf("Failed to release leadership of %s: %v", e.key, err)
+		return
+	}
+	log.Printf("Released leadership of %s", e.key)
+}
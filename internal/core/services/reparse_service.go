@@ -0,0 +1,107 @@
+// internal/core/services/reparse_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/normalize"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// ReparseResult summarizes a re-parse of archived snapshots
+type ReparseResult struct {
+	Snapshots  int // snapshots re-parsed
+	Skipped    int // snapshots without a raw page, e.g. purged
+	JobsBefore int // jobs stored for them before
+	JobsAfter  int // jobs parsed from them now
+}
+
+// ReparseService rebuilds historical job data by parsing archived raw pages
+// again, e.g. after a selector fix. Pages are read from the snapshots, or
+// from the raw page archive for snapshots whose pages were moved there.
+type ReparseService struct {
+	archive     ports.SnapshotArchive
+	pages       ports.RawContentStore
+	parser      ports.JobParser
+	normalizers []normalize.Normalizer
+}
+
+// NewReparseService creates a new ReparseService instance. pages is the raw
+// page archive, nil if disabled. Parsed jobs go through the same normalizers
+// as freshly scraped ones.
+func NewReparseService(
+	archive ports.SnapshotArchive,
+	pages ports.RawContentStore,
+	parser ports.JobParser,
+	normalizers ...normalize.Normalizer,
+) *ReparseService {
+	return &ReparseService{
+		archive:     archive,
+		pages:       pages,
+		parser:      parser,
+		normalizers: normalizers,
+	}
+}
+
+// Reparse re-parses every archived snapshot of url scraped since the given
+// time and replaces the jobs stored for it
+func (s *ReparseService) Reparse(ctx context.Context, url string, since time.Time) (ReparseResult, error) {
+	var result ReparseResult
+
+	snapshots, err := s.archive.ListRawSnapshots(ctx, url, since)
+	if err != nil {
+		return result, err
+	}
+
+	for _, snapshot := range snapshots {
+		content, err := s.rawContent(ctx, snapshot)
+		if err != nil {
+			return result, err
+		}
+		if content == "" {
+			result.Skipped++
+			continue
+		}
+
+		jobs, err := s.parser.ParseJobs(content, url)
+		if err != nil {
+			return result, fmt.Errorf("failed to parse snapshot %d of %s: %w", snapshot.Version, url, err)
+		}
+		for i := range jobs {
+			jobs[i].ScrapedAt = snapshot.ScrapedAt
+		}
+		collection := normalize.Apply(domain.JobCollection{SourceURL: url, Jobs: jobs}, s.normalizers...)
+
+		if err := s.archive.ReplaceSnapshotJobs(ctx, url, snapshot.Version, collection.Jobs); err != nil {
+			return result, fmt.Errorf("failed to store re-parsed snapshot %d of %s: %w", snapshot.Version, url, err)
+		}
+
+		result.Snapshots++
+		result.JobsBefore += snapshot.JobCount
+		result.JobsAfter += len(collection.Jobs)
+		log.Printf("Re-parsed snapshot %d of %s from %s: %d -> %d jobs",
+			snapshot.Version, url, snapshot.ScrapedAt.Format(time.RFC3339), snapshot.JobCount, len(collection.Jobs))
+	}
+
+	return result, nil
+}
+
+// rawContent returns the raw page of a snapshot, empty if it has none
+func (s *ReparseService) rawContent(ctx context.Context, snapshot domain.RawSnapshot) (string, error) {
+	if snapshot.RawContent != "" || s.pages == nil {
+		return snapshot.RawContent, nil
+	}
+	content, err := s.pages.GetRawContent(ctx, snapshot.SourceURL, snapshot.ScrapedAt)
+	if errors.Is(err, domain.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read archived page of snapshot %d of %s: %w", snapshot.Version, snapshot.SourceURL, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,143 @@
+// internal/core/services/retention_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// retentionActor is recorded in the audit log for automatic pruning
+const retentionActor = "retention"
+
+// RetentionService enforces the retention policy of persistent storage: it
+// deletes snapshots older than snapshotMaxAge and sources that haven't been
+// scraped for jobMaxAge. A zero age keeps that data forever. The raw pages
+// of deleted snapshots and sources are deleted from the raw archive too, and
+// hosted summaries as old as the snapshots or about deleted sources from the
+// summary store.
+type RetentionService struct {
+	snapshotMaxAge time.Duration
+	jobMaxAge      time.Duration
+	audit          ports.AuditLog
+	pruners        []ports.RetentionPruner
+	archive        ports.RawContentStore
+	summaries      ports.SummaryStore
+}
+
+// NewRetentionService creates a new RetentionService instance. audit,
+// archive and summaries may be nil.
+func NewRetentionService(
+	snapshotMaxAge, jobMaxAge time.Duration,
+	audit ports.AuditLog,
+	pruners []ports.RetentionPruner,
+	archive ports.RawContentStore,
+	summaries ports.SummaryStore,
+) *RetentionService {
+	return &RetentionService{
+		snapshotMaxAge: snapshotMaxAge,
+		jobMaxAge:      jobMaxAge,
+		audit:          audit,
+		pruners:        pruners,
+		archive:        archive,
+		summaries:      summaries,
+	}
+}
+
+// Prune deletes the data that is older than the retention policy allows
+func (s *RetentionService) Prune(ctx context.Context) error {
+	now := time.Now()
+
+	var errs []error
+	snapshots := 0
+	var sources []string
+	for _, pruner := range s.pruners {
+		if s.jobMaxAge > 0 {
+			urls, err := pruner.PruneSources(ctx, now.Add(-s.jobMaxAge))
+			sources = append(sources, urls...)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if s.snapshotMaxAge > 0 {
+			n, err := pruner.PruneSnapshots(ctx, now.Add(-s.snapshotMaxAge))
+			snapshots += n
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	pages := 0
+	if s.archive != nil {
+		for _, url := range sources {
+			n, err := s.archive.DeleteSource(ctx, url)
+			pages += n
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if s.snapshotMaxAge > 0 {
+			n, err := s.archive.PruneRawContent(ctx, now.Add(-s.snapshotMaxAge))
+			pages += n
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	pruned := 0
+	if s.summaries != nil {
+		for _, url := range sources {
+			n, err := s.summaries.DeleteSource(ctx, url)
+			pruned += n
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if s.snapshotMaxAge > 0 {
+			n, err := s.summaries.PruneSummaries(ctx, now.Add(-s.snapshotMaxAge))
+			pruned += n
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	for _, url := range sources {
+		log.Printf("Retention pruning deleted %s, not scraped for %s", url, s.jobMaxAge)
+		s.record(ctx, domain.NewAuditEntry(retentionActor, "retention.prune_source", url,
+			nil, map[string]interface{}{"max_age": s.jobMaxAge.String()}))
+	}
+	if snapshots > 0 {
+		log.Printf("Retention pruning deleted %d snapshots older than %s", snapshots, s.snapshotMaxAge)
+		s.record(ctx, domain.NewAuditEntry(retentionActor, "retention.prune_snapshots", "snapshots",
+			nil, map[string]interface{}{"pruned": snapshots, "max_age": s.snapshotMaxAge.String()}))
+	}
+	if pages > 0 {
+		log.Printf("Retention pruning deleted %d archived raw pages and screenshots", pages)
+	}
+	if pruned > 0 {
+		log.Printf("Retention pruning deleted %d hosted summaries", pruned)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to prune old data: %w", err)
+	}
+	return nil
+}
+
+// record appends an entry to the audit log, if there is one
+func (s *RetentionService) record(ctx context.Context, entry domain.AuditEntry) {
+	if s.audit == nil {
+		return
+	}
+	if err := s.audit.AppendAudit(ctx, entry); err != nil {
+		log.Printf("Failed to record audit entry for %s: %v", entry.Action, err)
+	}
+}
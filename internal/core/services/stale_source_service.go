@@ -0,0 +1,87 @@
+// internal/core/services/stale_source_service.go
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// StaleSourceService finds sources whose pages have returned no jobs or the
+// same jobs for a long time, e.g. because the company changed career platforms
+type StaleSourceService struct {
+	repository ports.JobRepository
+	notifier   ports.Notifier
+	urls       []string
+	after      time.Duration
+}
+
+// NewStaleSourceService creates a new StaleSourceService instance. Sources are
+// stale once they have been empty or unchanged for after. notifier may be nil.
+func NewStaleSourceService(
+	repository ports.JobRepository,
+	notifier ports.Notifier,
+	urls []string,
+	after time.Duration,
+) *StaleSourceService {
+	return &StaleSourceService{
+		repository: repository,
+		notifier:   notifier,
+		urls:       urls,
+		after:      after,
+	}
+}
+
+// Find returns the stale sources, longest stale first. Sources that were never
+// scraped are skipped.
+func (s *StaleSourceService) Find(ctx context.Context) ([]domain.StaleSource, error) {
+	now := time.Now()
+	var stale []domain.StaleSource
+	for _, url := range s.urls {
+		collection, err := s.repository.GetLatestJobCollection(ctx, url)
+		if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrSnapshotCorrupted) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load job collection for %s: %w", url, err)
+		}
+
+		if source, ok := collection.Staleness(now, s.after); ok {
+			stale = append(stale, source)
+		}
+	}
+
+	domain.SortStaleSources(stale)
+	return stale, nil
+}
+
+// Report sends a "consider removing these sources" notification if any
+// sources are stale, or logs them if the notifier can't send messages
+func (s *StaleSourceService) Report(ctx context.Context) error {
+	stale, err := s.Find(ctx)
+	if err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		log.Printf("No stale sources")
+		return nil
+	}
+
+	notification := domain.CreateStaleSourcesNotification(stale)
+	messageNotifier, ok := s.notifier.(ports.MessageNotifier)
+	if !ok {
+		log.Printf("%s:\n%s", notification.Title, notification.Message)
+		return nil
+	}
+
+	if err := messageNotifier.Notify(ctx, notification); err != nil {
+		return fmt.Errorf("failed to send stale source report: %w", err)
+	}
+	log.Printf("Sent stale source report for %d sources", len(stale))
+	return nil
+}
@@ -0,0 +1,76 @@
+// internal/core/services/translation.go
+package services
+
+import (
+	"context"
+	"log"
+
+	"github.com/fuzztobread/job-scheduler/internal/core/domain"
+	"github.com/fuzztobread/job-scheduler/internal/core/ports"
+)
+
+// WithTranslation translates the titles of the jobs new to a source, or
+// retitled, to target when they are posted in another language, so
+// notifications can show them in both. Jobs whose title is unchanged keep
+// their translation; the jobs of a baseline are translated with the next
+// scrape.
+func WithTranslation(translator ports.Translator, target string) Option {
+	return func(s *CareerScraperService) {
+		s.translator = translator
+		s.translateTo = target
+	}
+}
+
+// translateJobs translates the titles of the jobs of current written in
+// another language than the target, one request per language, and carries
+// the translations of the others over from previous. Jobs whose translation
+// fails are notified untranslated.
+func (s *CareerScraperService) translateJobs(ctx context.Context, previous, current domain.JobCollection) domain.JobCollection {
+	if s.translator == nil {
+		return current
+	}
+	stored := make(map[string]domain.Job, len(previous.Jobs))
+	for _, job := range previous.Jobs {
+		stored[job.ID] = job
+	}
+
+	jobs := make([]domain.Job, len(current.Jobs))
+	pending := make(map[string][]int) // jobs to translate by language
+	var languages []string
+	for i, job := range current.Jobs {
+		jobs[i] = job
+		language := job.PostingLanguage()
+		if language == "" || language == s.translateTo {
+			continue
+		}
+		if before, ok := stored[job.ID]; ok && before.Title == job.Title && before.TranslatedTitle != "" {
+			jobs[i].TranslatedTitle = before.TranslatedTitle
+			continue
+		}
+		if _, ok := pending[language]; !ok {
+			languages = append(languages, language)
+		}
+		pending[language] = append(pending[language], i)
+	}
+
+	for _, language := range languages {
+		if ctx.Err() != nil {
+			break
+		}
+		titles := make([]string, len(pending[language]))
+		for n, i := range pending[language] {
+			titles[n] = jobs[i].Title
+		}
+		translations, err := s.translator.Translate(ctx, titles, language, s.translateTo)
+		if err != nil {
+			log.Printf("Failed to translate %d job titles at %s from %s: %v", len(titles), current.SourceURL, language, err)
+			continue
+		}
+		for n, i := range pending[language] {
+			jobs[i].TranslatedTitle = translations[n]
+		}
+	}
+
+	current.Jobs = jobs
+	return current
+}
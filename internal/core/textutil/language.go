@@ -0,0 +1,137 @@
+// internal/core/textutil/language.go
+package textutil
+
+import (
+	"strings"
+	"unicode"
+)
+
+// latinStopwords holds frequent words (and common job-posting vocabulary) used to
+// tell apart languages written in the Latin script
+var latinStopwords = map[string][]string{
+	"en": {"the", "and", "for", "with", "you", "our", "we", "are", "of", "to", "in", "is",
+		"engineer", "developer", "manager", "senior", "junior", "lead", "intern", "remote", "team"},
+	"de": {"und", "der", "die", "das", "für", "mit", "wir", "sie", "ist", "ein", "eine", "bei",
+		"entwickler", "mitarbeiter", "leiter", "m/w/d", "w/m/d", "stelle"},
+	"fr": {"le", "la", "les", "et", "des", "pour", "avec", "nous", "vous", "une", "est", "du",
+		"développeur", "ingénieur", "chef", "stage", "h/f", "poste"},
+	"es": {"el", "los", "las", "para", "con", "una", "por", "del", "es", "en",
+		"desarrollador", "ingeniero", "remoto", "puesto"},
+	"pt": {"os", "as", "para", "com", "uma", "não", "da", "do", "em",
+		"desenvolvedor", "engenheiro", "vaga"},
+	"it": {"il", "gli", "per", "con", "una", "della", "di", "che",
+		"sviluppatore", "ingegnere"},
+	"nl": {"de", "het", "en", "voor", "met", "een", "wij", "jij", "van",
+		"ontwikkelaar", "medewerker", "vacature"},
+}
+
+// DetectLanguage makes a best-effort guess of the language of text and returns
+// its ISO 639-1 code, or an empty string when the language cannot be determined
+func DetectLanguage(text string) string {
+	scripts := make(map[string]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Devanagari, r):
+			scripts["devanagari"]++
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			scripts["kana"]++
+		case unicode.Is(unicode.Han, r):
+			scripts["han"]++
+		case unicode.Is(unicode.Hangul, r):
+			scripts["hangul"]++
+		case unicode.Is(unicode.Cyrillic, r):
+			scripts["cyrillic"]++
+		case unicode.Is(unicode.Arabic, r):
+			scripts["arabic"]++
+		case unicode.Is(unicode.Thai, r):
+			scripts["thai"]++
+		case unicode.Is(unicode.Greek, r):
+			scripts["greek"]++
+		case unicode.Is(unicode.Hebrew, r):
+			scripts["hebrew"]++
+		case unicode.Is(unicode.Latin, r):
+			scripts["latin"]++
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+
+	dominant, count := "", 0
+	for script, n := range scripts {
+		if n > count {
+			dominant, count = script, n
+		}
+	}
+
+	switch dominant {
+	case "devanagari":
+		// Hindi and Nepali share the script; a few auxiliaries tell them apart
+		if strings.Contains(text, "है") || strings.Contains(text, " और ") {
+			return "hi"
+		}
+		return "ne"
+	case "kana":
+		return "ja"
+	case "han":
+		// Japanese text mixes kanji with kana
+		if scripts["kana"] > 0 {
+			return "ja"
+		}
+		return "zh"
+	case "hangul":
+		return "ko"
+	case "cyrillic":
+		return "ru"
+	case "arabic":
+		return "ar"
+	case "thai":
+		return "th"
+	case "greek":
+		return "el"
+	case "hebrew":
+		return "he"
+	case "latin":
+		return detectLatinLanguage(text)
+	}
+
+	return ""
+}
+
+// detectLatinLanguage scores Latin-script text against per-language stopword lists
+func detectLatinLanguage(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '/'
+	})
+
+	scores := make(map[string]int)
+	for _, word := range words {
+		for lang, stopwords := range latinStopwords {
+			for _, stopword := range stopwords {
+				if word == stopword {
+					scores[lang]++
+				}
+			}
+		}
+	}
+
+	best, bestScore, tie := "", 0, false
+	for lang, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, tie = lang, score, false
+		case score == bestScore:
+			tie = true
+		}
+	}
+	if bestScore == 0 || tie {
+		return ""
+	}
+
+	return best
+}